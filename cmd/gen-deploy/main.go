@@ -0,0 +1,299 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// gen-deploy generates a Dockerfile and Kubernetes DaemonSet and ConfigMap
+// manifests for the reference plugins, from shared templates and the plugin
+// metadata in plugins.go. The command line arguments of each plugin are
+// checked against the common plugin flags and the flags the plugin itself
+// registers, so that the manifests stay in sync with the plugins.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/containerd/nri/pkg/plugin/cli"
+)
+
+const (
+	// configDir is the directory the plugin configuration is mounted to.
+	configDir = "/etc/nri"
+)
+
+type generator struct {
+	root      string
+	output    string
+	registry  string
+	tag       string
+	namespace string
+	goVersion string
+	check     bool
+	templates *template.Template
+}
+
+// manifest is the data the templates are executed with.
+type manifest struct {
+	*plugin
+	Image        string
+	Namespace    string
+	GoVersion    string
+	Args         []string
+	SelfTestArgs []string
+	ConfigData   string
+}
+
+func main() {
+	g := &generator{}
+
+	flag.StringVar(&g.root, "root", ".", "root of the NRI source tree")
+	flag.StringVar(&g.output, "output", "deployment", "directory to generate files into")
+	flag.StringVar(&g.registry, "registry", "ghcr.io/containerd/nri/plugins", "container image registry")
+	flag.StringVar(&g.tag, "tag", "latest", "container image tag")
+	flag.StringVar(&g.namespace, "namespace", "kube-system", "namespace to deploy plugins into")
+	flag.StringVar(&g.goVersion, "go-version", "", "Go version to build plugins with, from go.mod if empty")
+	flag.BoolVar(&g.check, "check", false, "check that generated files are up to date")
+	flag.Parse()
+
+	if err := g.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "gen-deploy: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (g *generator) run() error {
+	g.templates = template.New("").Funcs(template.FuncMap{
+		"quote":  strconv.Quote,
+		"indent": indent,
+	})
+	for name, text := range map[string]string{
+		"Dockerfile":     dockerfileTemplate,
+		"daemonset.yaml": daemonSetTemplate,
+		"configmap.yaml": configMapTemplate,
+	} {
+		if _, err := g.templates.New(name).Parse(text); err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+	}
+
+	var stale []string
+	for _, p := range plugins {
+		files, err := g.generate(p)
+		if err != nil {
+			return fmt.Errorf("plugin %s: %w", p.Name, err)
+		}
+		for _, name := range sortedKeys(files) {
+			path := filepath.Join(g.output, p.Name, name)
+			if g.check {
+				if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, files[name]) {
+					stale = append(stale, path)
+				}
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, files[name], 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("generated files out of date, rerun gen-deploy: %s",
+			strings.Join(stale, ", "))
+	}
+
+	return nil
+}
+
+// generate the files of a single plugin.
+func (g *generator) generate(p *plugin) (map[string][]byte, error) {
+	m := &manifest{
+		plugin:    p,
+		Image:     g.registry + "/" + p.Name + ":" + g.tag,
+		Namespace: g.namespace,
+		GoVersion: g.goVersion,
+	}
+
+	if m.GoVersion == "" {
+		v, err := goVersion(filepath.Join(g.root, "plugins", p.Name, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		m.GoVersion = v
+	}
+
+	if p.Idx != "" {
+		m.Args = append(m.Args, "-idx", p.Idx)
+	}
+	if p.Config != "" {
+		data, err := os.ReadFile(filepath.Join(g.root, "plugins", p.Name, p.Config))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration: %w", err)
+		}
+		m.ConfigData = string(data)
+		m.Args = append(m.Args, "-config", configDir+"/"+p.Name+"/config.yaml")
+	}
+	m.Args = append(m.Args, p.Args...)
+	m.SelfTestArgs = append(append([]string{}, m.Args...), "-nri-self-test")
+
+	if err := g.checkArgs(p, m.SelfTestArgs); err != nil {
+		return nil, err
+	}
+
+	names := []string{"Dockerfile", "daemonset.yaml"}
+	if p.Config != "" {
+		names = append(names, "configmap.yaml")
+	}
+
+	files := map[string][]byte{}
+	for _, name := range names {
+		buf := &bytes.Buffer{}
+		if err := g.templates.ExecuteTemplate(buf, name, m); err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+		files[name] = buf.Bytes()
+	}
+
+	return files, nil
+}
+
+// checkArgs checks that all arguments are flags known to the plugin.
+func (g *generator) checkArgs(p *plugin, args []string) error {
+	fs := flag.NewFlagSet(p.Name, flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	cli.NewOptions().AddFlags(fs)
+
+	names, err := pluginFlags(filepath.Join(g.root, "plugins", p.Name))
+	if err != nil {
+		return err
+	}
+	for name, isBool := range names {
+		if isBool {
+			fs.Bool(name, false, "")
+		} else {
+			fs.String(name, "", "")
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("invalid arguments %q: %w", args, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+
+	return nil
+}
+
+// pluginFlags returns the flags registered by the plugin in the given
+// directory, with whether they are boolean flags.
+func pluginFlags(dir string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plugin sources: %w", err)
+	}
+
+	flags := map[string]bool{}
+	for _, pkg := range pkgs {
+		ast.Inspect(pkg, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if id, ok := sel.X.(*ast.Ident); !ok || id.Name != "flag" {
+				return true
+			}
+
+			fn, arg := sel.Sel.Name, 0
+			if strings.HasSuffix(fn, "Var") {
+				fn, arg = strings.TrimSuffix(fn, "Var"), 1
+			}
+			if len(call.Args) <= arg {
+				return true
+			}
+			lit, ok := call.Args[arg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			name, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+
+			switch fn {
+			case "Bool":
+				flags[name] = true
+			case "String", "Int", "Int64", "Uint", "Uint64", "Float64", "Duration", "":
+				flags[name] = false
+			}
+			return true
+		})
+	}
+
+	return flags, nil
+}
+
+// goVersion returns the Go version declared in the given go.mod file.
+func goVersion(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, l := range strings.Split(string(data), "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(l), "go "); ok {
+			return strings.TrimSpace(v), nil
+		}
+	}
+	return "", fmt.Errorf("no Go version in %s", path)
+}
+
+func indent(n int, s string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
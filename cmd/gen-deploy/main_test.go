@@ -0,0 +1,52 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	g := &generator{
+		root:      "../..",
+		output:    "../../deployment",
+		registry:  "ghcr.io/containerd/nri/plugins",
+		tag:       "latest",
+		namespace: "kube-system",
+		check:     true,
+	}
+	if err := g.run(); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestInvalidPluginArgs(t *testing.T) {
+	g := &generator{
+		root: "../..",
+	}
+	p := &plugin{
+		Name: "logger",
+		Args: []string{"-no-such-flag"},
+	}
+	if err := g.checkArgs(p, p.Args); err == nil {
+		t.Fatalf("unknown flag of plugin %s not detected", p.Name)
+	}
+	p.Args = []string{"-events", "all", "-nri-self-test"}
+	if err := g.checkArgs(p, p.Args); err != nil {
+		t.Fatalf("valid flags of plugin %s rejected: %v", p.Name, err)
+	}
+}
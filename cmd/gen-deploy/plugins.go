@@ -0,0 +1,91 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+// plugin is the deployment metadata of a reference plugin.
+type plugin struct {
+	// Name of the plugin, also the name of its directory under plugins.
+	Name string
+	// Idx is the plugin index to register with, if the plugin takes one.
+	Idx string
+	// Description is a short description of the plugin.
+	Description string
+	// Args are the plugin-specific command line arguments.
+	Args []string
+	// Config is the path of a sample configuration, relative to the plugin
+	// directory, deployed as a ConfigMap and passed using -config.
+	Config string
+	// HostPaths are host directories mounted read-only into the plugin.
+	HostPaths []string
+	// Privileged is true if the plugin needs a privileged container.
+	Privileged bool
+	// HostNetwork is true if the plugin runs in the host network namespace.
+	HostNetwork bool
+}
+
+// plugins lists the reference plugins with deployment manifests.
+var plugins = []*plugin{
+	{
+		Name:        "device-injector",
+		Idx:         "10",
+		Description: "injects devices and mounts into containers based on pod annotations",
+	},
+	{
+		Name:        "hook-injector",
+		Idx:         "10",
+		Description: "injects OCI hooks into containers",
+		HostPaths: []string{
+			"/usr/share/containers/oci/hooks.d",
+			"/etc/containers/oci/hooks.d",
+		},
+	},
+	{
+		Name:        "network-device-injector",
+		Idx:         "10",
+		Description: "injects network devices into pods based on pod annotations",
+		HostPaths:   []string{"/var/run/netns"},
+		Privileged:  true,
+		HostNetwork: true,
+	},
+	{
+		Name:        "qos-class-registry",
+		Idx:         "20",
+		Description: "applies resource settings of QoS classes to containers",
+		Config:      "sample-qos-classes.yaml",
+	},
+	{
+		Name:        "ulimit-adjuster",
+		Idx:         "10",
+		Description: "adjusts rlimits of containers based on pod annotations",
+	},
+	{
+		Name:        "logger",
+		Idx:         "00",
+		Description: "logs pod and container lifecycle events",
+		Args:        []string{"-events", "all"},
+	},
+	{
+		Name:        "network-logger",
+		Idx:         "00",
+		Description: "logs pod network configuration",
+	},
+	{
+		Name:        "differ",
+		Description: "logs the changes made to containers by other plugins",
+		Args:        []string{"-indices", "0,99"},
+	},
+}
@@ -0,0 +1,129 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+const header = `# Code generated by gen-deploy. DO NOT EDIT.
+`
+
+const dockerfileTemplate = header + `
+FROM golang:{{ .GoVersion }} AS builder
+WORKDIR /go/src/github.com/containerd/nri
+COPY . .
+RUN cd plugins/{{ .Name }} && CGO_ENABLED=0 go build -o /nri-{{ .Name }} .
+
+FROM gcr.io/distroless/static
+COPY --from=builder /nri-{{ .Name }} /bin/nri-{{ .Name }}
+ENTRYPOINT ["/bin/nri-{{ .Name }}"]
+`
+
+const configMapTemplate = header + `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: nri-{{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: nri-{{ .Name }}
+data:
+  config.yaml: |
+{{ indent 4 .ConfigData }}
+`
+
+const daemonSetTemplate = header + `
+# NRI {{ .Name }} plugin: {{ .Description }}.
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: nri-{{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: nri-{{ .Name }}
+spec:
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: nri-{{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: nri-{{ .Name }}
+    spec:
+      nodeSelector:
+        kubernetes.io/os: linux
+      priorityClassName: system-node-critical
+{{- if .HostNetwork }}
+      hostNetwork: true
+{{- end }}
+      initContainers:
+      - name: self-test
+        image: {{ .Image }}
+        args:
+{{- range .SelfTestArgs }}
+        - {{ quote . }}
+{{- end }}
+{{- if .Config }}
+        volumeMounts:
+        - name: config
+          mountPath: /etc/nri/{{ .Name }}
+          readOnly: true
+{{- end }}
+      containers:
+      - name: plugin
+        image: {{ .Image }}
+        args:
+{{- range .Args }}
+        - {{ quote . }}
+{{- end }}
+        resources:
+          requests:
+            cpu: 10m
+            memory: 32Mi
+          limits:
+            memory: 128Mi
+{{- if .Privileged }}
+        securityContext:
+          privileged: true
+{{- end }}
+        volumeMounts:
+        - name: nri-socket
+          mountPath: /var/run/nri/nri.sock
+{{- if .Config }}
+        - name: config
+          mountPath: /etc/nri/{{ .Name }}
+          readOnly: true
+{{- end }}
+{{- range $i, $path := .HostPaths }}
+        - name: host-path-{{ $i }}
+          mountPath: {{ $path }}
+          readOnly: true
+{{- end }}
+      volumes:
+      - name: nri-socket
+        hostPath:
+          path: /var/run/nri/nri.sock
+          type: Socket
+{{- if .Config }}
+      - name: config
+        configMap:
+          name: nri-{{ .Name }}
+{{- end }}
+{{- range $i, $path := .HostPaths }}
+      - name: host-path-{{ $i }}
+        hostPath:
+          path: {{ $path }}
+          type: DirectoryOrCreate
+{{- end }}
+`
@@ -0,0 +1,110 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// a message, enum, service or oneof opening a block
+	blockRe = regexp.MustCompile(`^(message|enum|service|oneof)\s+(\w+)\s*\{`)
+	// a message field or enum value
+	fieldRe = regexp.MustCompile(`^(?:(?:repeated|optional)\s+)?(?:map\s*<[^>]*>\s+|[\w.]+\s+)?(\w+)\s*=\s*-?\d+`)
+)
+
+// parseComments collects the leading comments of messages, enums, fields
+// and enum values in a proto file, by their name relative to the package,
+// with fields and values qualified by the name of their message or enum.
+// Trailing comments are used for fields and values without leading ones.
+// The parser only understands the subset of the proto syntax used by NRI.
+func parseComments(src string) map[string]string {
+	var (
+		comments = map[string]string{}
+		scopes   []string // enclosing blocks, "" for non-message scopes
+		pending  []string
+	)
+
+	path := func(name string) string {
+		var parts []string
+		for _, s := range scopes {
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(append(parts, name), ".")
+	}
+	record := func(key, trailing string) {
+		switch {
+		case len(pending) > 0:
+			comments[key] = joinComment(pending)
+		case trailing != "":
+			comments[key] = trailing
+		}
+	}
+
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "//") {
+			pending = append(pending, strings.TrimSpace(strings.TrimPrefix(line, "//")))
+			continue
+		}
+
+		code, trailing, _ := strings.Cut(line, "//")
+		code = strings.TrimSpace(code)
+		trailing = strings.TrimSpace(trailing)
+
+		switch m := blockRe.FindStringSubmatch(code); {
+		case m != nil:
+			switch m[1] {
+			case "message", "enum":
+				record(path(m[2]), trailing)
+				scopes = append(scopes, m[2])
+			default:
+				scopes = append(scopes, "")
+			}
+			if strings.HasSuffix(code, "}") {
+				scopes = scopes[:len(scopes)-1]
+			}
+		case strings.HasPrefix(code, "}"):
+			if len(scopes) > 0 {
+				scopes = scopes[:len(scopes)-1]
+			}
+		case len(scopes) > 0 && scopes[len(scopes)-1] != "" && !strings.HasPrefix(code, "rpc "):
+			if m := fieldRe.FindStringSubmatch(code); m != nil {
+				record(path(m[1]), trailing)
+			}
+		}
+
+		pending = nil
+	}
+
+	return comments
+}
+
+// joinComment joins the lines of a comment, keeping paragraph breaks.
+func joinComment(lines []string) string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.Join(lines, "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, strings.ReplaceAll(p, "\n", " "))
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
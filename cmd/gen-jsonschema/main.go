@@ -0,0 +1,210 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// gen-jsonschema generates JSON Schema documents for the NRI API, one for
+// each request and response of the NRI services and for container
+// adjustments, updates and evictions. The schemas describe the protobuf JSON
+// mapping of the messages. They are generated from the compiled protobuf
+// descriptors, with descriptions taken from the comments in the proto file.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const (
+	// protoFile is the proto file of the API, relative to the source tree.
+	protoFile = "pkg/api/api.proto"
+	// schemaSuffix is the suffix of generated schema files.
+	schemaSuffix = ".schema.json"
+)
+
+// extraRoots are the messages, other than the requests and responses of the
+// services, to generate schemas for.
+var extraRoots = []protoreflect.Name{
+	"ContainerAdjustment",
+	"ContainerUpdate",
+	"ContainerEviction",
+}
+
+type generator struct {
+	root   string
+	output string
+	check  bool
+}
+
+func main() {
+	g := &generator{}
+
+	flag.StringVar(&g.root, "root", ".", "root of the NRI source tree")
+	flag.StringVar(&g.output, "output", "schema", "directory to generate schemas into")
+	flag.BoolVar(&g.check, "check", false, "check that generated schemas are up to date")
+	flag.Parse()
+
+	if err := g.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "gen-jsonschema: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (g *generator) run() error {
+	files, err := g.generate()
+	if err != nil {
+		return err
+	}
+
+	if g.check {
+		return g.checkFiles(files)
+	}
+
+	if err := os.MkdirAll(g.output, 0755); err != nil {
+		return err
+	}
+	// remove schemas of messages which are no longer generated
+	existing, err := filepath.Glob(filepath.Join(g.output, "*"+schemaSuffix))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		if _, ok := files[filepath.Base(path)]; !ok {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		if err := os.WriteFile(filepath.Join(g.output, name), files[name], 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkFiles checks that the generated files match the ones in the output
+// directory, and that there are no others.
+func (g *generator) checkFiles(files map[string][]byte) error {
+	var stale []string
+
+	for _, name := range sortedKeys(files) {
+		path := filepath.Join(g.output, name)
+		if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, files[name]) {
+			stale = append(stale, path)
+		}
+	}
+	existing, err := filepath.Glob(filepath.Join(g.output, "*"+schemaSuffix))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		if _, ok := files[filepath.Base(path)]; !ok {
+			stale = append(stale, path)
+		}
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("generated schemas out of date, rerun gen-jsonschema: %s",
+			strings.Join(stale, ", "))
+	}
+
+	return nil
+}
+
+// generate the schema files, by file name.
+func (g *generator) generate() (map[string][]byte, error) {
+	src, err := os.ReadFile(filepath.Join(g.root, protoFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proto file: %w", err)
+	}
+
+	fd := api.File_pkg_api_api_proto
+	s := &schemaGenerator{
+		file:     fd,
+		comments: parseComments(string(src)),
+		version:  api.SchemaVersion,
+	}
+
+	files := map[string][]byte{}
+	mds, err := roots(fd)
+	if err != nil {
+		return nil, err
+	}
+	for _, md := range mds {
+		data, err := s.document(md)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema for %s: %w", md.Name(), err)
+		}
+		files[string(md.Name())+schemaSuffix] = data
+	}
+
+	return files, nil
+}
+
+// roots returns the messages to generate schema documents for: the requests
+// and responses of all services, and the extra roots.
+func roots(fd protoreflect.FileDescriptor) ([]protoreflect.MessageDescriptor, error) {
+	var (
+		seen   = map[protoreflect.FullName]bool{}
+		result []protoreflect.MessageDescriptor
+	)
+
+	add := func(md protoreflect.MessageDescriptor) {
+		if !seen[md.FullName()] {
+			seen[md.FullName()] = true
+			result = append(result, md)
+		}
+	}
+
+	for i := 0; i < fd.Services().Len(); i++ {
+		methods := fd.Services().Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			add(methods.Get(j).Input())
+			add(methods.Get(j).Output())
+		}
+	}
+	for _, name := range extraRoots {
+		md := fd.Messages().ByName(name)
+		if md == nil {
+			return nil, fmt.Errorf("message %s not found", name)
+		}
+		add(md)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+
+	return result, nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
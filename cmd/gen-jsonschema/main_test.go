@@ -0,0 +1,284 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+func TestGeneratedSchemasUpToDate(t *testing.T) {
+	g := &generator{
+		root:   "../..",
+		output: "../../schema",
+		check:  true,
+	}
+	if err := g.run(); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	comments := parseComments(`
+// Not a comment of Foo.
+
+// Foo is a message.
+//
+// It has two paragraphs.
+message Foo {
+  // Name of foo.
+  string name = 1;
+  map<string, string> labels = 2; // Labels of foo.
+  repeated Bar.Kind kinds = 3;
+  enum Kind {
+    // No kind.
+    NONE = 0;
+  }
+}
+message Empty {}
+service Svc {
+  // Call is not a field.
+  rpc Call(Foo) returns (Empty) {}
+}
+`)
+	expected := map[string]string{
+		"Foo":           "Foo is a message.\n\nIt has two paragraphs.",
+		"Foo.name":      "Name of foo.",
+		"Foo.labels":    "Labels of foo.",
+		"Foo.Kind.NONE": "No kind.",
+	}
+	if len(comments) != len(expected) {
+		t.Fatalf("got comments %v, expected %v", comments, expected)
+	}
+	for key, comment := range expected {
+		if comments[key] != comment {
+			t.Fatalf("got comment %q for %s, expected %q", comments[key], key, comment)
+		}
+	}
+}
+
+func TestSchemasMatchJSONMapping(t *testing.T) {
+	files, err := (&generator{root: "../.."}).generate()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	adjust := &api.ContainerAdjustment{}
+	adjust.AddAnnotation("key", "value")
+	adjust.AddMount(&api.Mount{Destination: "/data", Source: "/host/data", Type: "bind", Options: []string{"ro"}})
+	adjust.AddEnv("NAME", "value")
+	adjust.AddDevice(&api.LinuxDevice{Path: "/dev/foo", Type: "c", Major: 10, Minor: 1})
+	adjust.SetLinuxMemoryLimit(1 << 40)
+	adjust.SetLinuxCPUShares(512)
+	adjust.SetLinuxCPUSetCPUs("0-3")
+	adjust.AddRlimit("RLIMIT_NOFILE", 1024, 512)
+	adjust.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/dev=foo"})
+
+	pod := &api.PodSandbox{
+		Id:          "pod0",
+		Name:        "pod0",
+		Labels:      map[string]string{"app": "test"},
+		Generation:  1 << 62,
+		Annotations: map[string]string{"key": "value"},
+	}
+	ctr := &api.Container{
+		Id:            "ctr0",
+		PodSandboxId:  "pod0",
+		Name:          "ctr0",
+		State:         api.ContainerState_CONTAINER_RUNNING,
+		Args:          []string{"sleep", "inf"},
+		EventSequence: 3,
+		Linux: &api.LinuxContainer{
+			Resources: &api.LinuxResources{
+				Memory: &api.LinuxMemory{Limit: api.Int64(-1)},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		schema string
+		msg    proto.Message
+	}{
+		{"ContainerAdjustment", adjust},
+		{"CreateContainerRequest", &api.CreateContainerRequest{Pod: pod, Container: ctr, TimeBudget: 1000000000}},
+		{"CreateContainerResponse", &api.CreateContainerResponse{Adjust: adjust}},
+		{"StateChangeEvent", &api.StateChangeEvent{Event: api.Event_CREATE_CONTAINER_ROLLBACK, Pod: pod, Container: ctr, Adjust: adjust}},
+		{"ConfigureResponse", &api.ConfigureResponse{Events: int32(api.ValidEvents)}},
+	} {
+		for _, opts := range []protojson.MarshalOptions{{}, {EmitUnpopulated: true}, {UseEnumNumbers: true}} {
+			data, err := opts.Marshal(tc.msg)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if err := validate(files[tc.schema+schemaSuffix], data); err != nil {
+				t.Fatalf("%s %s does not match schema: %v", tc.schema, data, err)
+			}
+		}
+	}
+
+	for _, data := range []string{
+		`{"noSuchField": 1}`,
+		`{"pod": {"labels": {"app": 1}}}`,
+		`{"container": {"state": "NO_SUCH_STATE"}}`,
+		`{"timeBudget": "1.5"}`,
+	} {
+		if err := validate(files["CreateContainerRequest"+schemaSuffix], []byte(data)); err == nil {
+			t.Fatalf("invalid CreateContainerRequest %s matches schema", data)
+		}
+	}
+}
+
+// validate a JSON document against a schema, supporting only the subset of
+// JSON Schema used by the generated schemas.
+func validate(schema, data []byte) error {
+	var s, v map[string]interface{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return check(s, s, v, "")
+}
+
+func check(doc, s map[string]interface{}, v interface{}, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		def, ok := doc["$defs"].(map[string]interface{})[strings.TrimPrefix(ref, "#/$defs/")]
+		if !ok {
+			return fmt.Errorf("%s: unresolved reference %s", path, ref)
+		}
+		if err := check(doc, def.(map[string]interface{}), v, path); err != nil {
+			return err
+		}
+	}
+
+	if types, ok := s["type"]; ok {
+		if err := checkType(types, v); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if c, ok := s["const"]; ok && c != v {
+		return fmt.Errorf("%s: %v is not %v", path, v, c)
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == v
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, enum)
+		}
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if str, ok := v.(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			return fmt.Errorf("%s: %q does not match %s", path, str, pattern)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		var err error
+		for _, alt := range anyOf {
+			if err = check(doc, alt.(map[string]interface{}), v, path); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	switch val := v.(type) {
+	case []interface{}:
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, item := range val {
+				if err := check(doc, items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		props, _ := s["properties"].(map[string]interface{})
+		for key, item := range val {
+			if prop, ok := props[key]; ok {
+				if err := check(doc, prop.(map[string]interface{}), item, path+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			switch extra := s["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					return fmt.Errorf("%s: unknown property %s", path, key)
+				}
+			case map[string]interface{}:
+				if err := check(doc, extra, item, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkType(types interface{}, v interface{}) error {
+	var names []interface{}
+	switch t := types.(type) {
+	case string:
+		names = []interface{}{t}
+	case []interface{}:
+		names = t
+	}
+
+	for _, name := range names {
+		switch val := v.(type) {
+		case string:
+			if name == "string" {
+				return nil
+			}
+		case bool:
+			if name == "boolean" {
+				return nil
+			}
+		case float64:
+			if name == "number" || (name == "integer" && val == float64(int64(val))) {
+				return nil
+			}
+		case []interface{}:
+			if name == "array" {
+				return nil
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return nil
+			}
+		case nil:
+			if name == "null" {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%v is not of type %v", v, types)
+}
@@ -0,0 +1,297 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// metaSchema is the JSON Schema dialect of generated documents.
+	metaSchema = "https://json-schema.org/draft/2020-12/schema"
+	// idPrefix is the prefix of the IDs of generated documents.
+	idPrefix = "https://github.com/containerd/nri/schema/"
+)
+
+// object is a JSON object which keeps the order of its keys.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: map[string]interface{}{}}
+}
+
+// set a key, appending it if it is not set yet.
+func (o *object) set(key string, value interface{}) *object {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+	return o
+}
+
+// MarshalJSON marshals the object with its keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// schemaGenerator generates schema documents for the messages of a file.
+type schemaGenerator struct {
+	file     protoreflect.FileDescriptor
+	comments map[string]string
+	version  int
+}
+
+// document generates the schema document of a message. Messages and enums
+// are defined in $defs, by their name relative to the proto package.
+func (s *schemaGenerator) document(md protoreflect.MessageDescriptor) ([]byte, error) {
+	defs := map[string]*object{}
+	if err := s.define(md, defs); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ordered := newObject()
+	for _, name := range names {
+		ordered.set(name, defs[name])
+	}
+
+	doc := newObject().
+		set("$schema", metaSchema).
+		set("$id", idPrefix+string(md.Name())+schemaSuffix).
+		set("$comment", "Code generated by gen-jsonschema. DO NOT EDIT.").
+		set("title", string(md.Name()))
+	if d := s.description(md); d != "" {
+		doc.set("description", d)
+	}
+	doc.set("x-nri-package", string(s.file.Package())).
+		set("x-nri-schema-version", s.version).
+		set("$ref", s.ref(md)).
+		set("$defs", ordered)
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// define a message, and the messages and enums it refers to.
+func (s *schemaGenerator) define(md protoreflect.MessageDescriptor, defs map[string]*object) error {
+	name := s.name(md)
+	if _, ok := defs[name]; ok {
+		return nil
+	}
+	if md.ParentFile() != s.file {
+		return fmt.Errorf("message %s defined outside of %s", md.FullName(), s.file.Path())
+	}
+	if md.Oneofs().Len() > 0 {
+		return fmt.Errorf("message %s: oneof fields are not supported", md.FullName())
+	}
+
+	def := newObject().set("type", "object")
+	defs[name] = def
+	if d := s.description(md); d != "" {
+		def.set("description", d)
+	}
+
+	props := newObject()
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		prop, err := s.field(fd, defs)
+		if err != nil {
+			return err
+		}
+		if d := s.description(fd); d != "" {
+			prop.set("description", d)
+		}
+		props.set(fd.JSONName(), prop)
+	}
+	def.set("properties", props).
+		set("additionalProperties", false)
+
+	return nil
+}
+
+// field returns the schema of a field.
+func (s *schemaGenerator) field(fd protoreflect.FieldDescriptor, defs map[string]*object) (*object, error) {
+	switch {
+	case fd.IsMap():
+		value, err := s.value(fd.MapValue(), defs)
+		if err != nil {
+			return nil, err
+		}
+		o := newObject().set("type", "object")
+		if keys := s.mapKeys(fd.MapKey()); keys != nil {
+			o.set("propertyNames", keys)
+		}
+		return o.set("additionalProperties", value), nil
+
+	case fd.IsList():
+		item, err := s.value(fd, defs)
+		if err != nil {
+			return nil, err
+		}
+		return newObject().set("type", "array").set("items", item), nil
+
+	case fd.Kind() == protoreflect.MessageKind:
+		// unset messages are encoded as null with unpopulated fields emitted
+		value, err := s.value(fd, defs)
+		if err != nil {
+			return nil, err
+		}
+		return newObject().set("anyOf", []*object{value, newObject().set("type", "null")}), nil
+	}
+
+	return s.value(fd, defs)
+}
+
+// value returns the schema of a single value of a field.
+func (s *schemaGenerator) value(fd protoreflect.FieldDescriptor, defs map[string]*object) (*object, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return newObject().set("type", "boolean"), nil
+	case protoreflect.StringKind:
+		return newObject().set("type", "string"), nil
+	case protoreflect.BytesKind:
+		return newObject().set("type", "string").set("contentEncoding", "base64"), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return integer(math.MinInt32, math.MaxInt32), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return integer(0, math.MaxUint32), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are encoded as strings, but numbers are accepted
+		return newObject().set("type", []string{"string", "integer"}).set("pattern", "^-?[0-9]+$"), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return newObject().set("type", []string{"string", "integer"}).set("pattern", "^[0-9]+$").set("minimum", 0), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return newObject().set("anyOf", []*object{
+			newObject().set("type", "number"),
+			newObject().set("enum", []string{"NaN", "Infinity", "-Infinity"}),
+		}), nil
+	case protoreflect.EnumKind:
+		s.defineEnum(fd.Enum(), defs)
+		return newObject().set("$ref", s.ref(fd.Enum())), nil
+	case protoreflect.MessageKind:
+		if err := s.define(fd.Message(), defs); err != nil {
+			return nil, err
+		}
+		return newObject().set("$ref", s.ref(fd.Message())), nil
+	}
+
+	return nil, fmt.Errorf("field %s: unsupported kind %s", fd.FullName(), fd.Kind())
+}
+
+// mapKeys returns the schema of the keys of a map, or nil for string keys.
+func (s *schemaGenerator) mapKeys(fd protoreflect.FieldDescriptor) *object {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return newObject().set("enum", []string{"true", "false"})
+	case protoreflect.StringKind:
+		return nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return newObject().set("pattern", "^[0-9]+$")
+	}
+	return newObject().set("pattern", "^-?[0-9]+$")
+}
+
+// defineEnum defines an enum. Enum values are encoded by name, but their
+// numbers are accepted, including unknown ones.
+func (s *schemaGenerator) defineEnum(ed protoreflect.EnumDescriptor, defs map[string]*object) {
+	name := s.name(ed)
+	if _, ok := defs[name]; ok {
+		return
+	}
+
+	var (
+		def    = newObject()
+		values = ed.Values()
+		anyOf  []*object
+	)
+
+	if d := s.description(ed); d != "" {
+		def.set("description", d)
+	}
+	for i := 0; i < values.Len(); i++ {
+		vd := values.Get(i)
+		v := newObject().set("const", string(vd.Name()))
+		if d := s.description(vd); d != "" {
+			v.set("description", d)
+		}
+		anyOf = append(anyOf, v)
+	}
+	anyOf = append(anyOf, integer(math.MinInt32, math.MaxInt32))
+	defs[name] = def.set("anyOf", anyOf)
+}
+
+// name returns the name of a message or enum relative to the proto package.
+func (s *schemaGenerator) name(d protoreflect.Descriptor) string {
+	return strings.TrimPrefix(string(d.FullName()), string(s.file.Package())+".")
+}
+
+// ref returns a reference to the definition of a message or enum.
+func (s *schemaGenerator) ref(d protoreflect.Descriptor) string {
+	return "#/$defs/" + s.name(d)
+}
+
+// description returns the comment of a descriptor in the proto file.
+func (s *schemaGenerator) description(d protoreflect.Descriptor) string {
+	return s.comments[s.name(d)]
+}
+
+func integer(min, max int64) *object {
+	return newObject().set("type", "integer").set("minimum", min).set("maximum", max)
+}
@@ -0,0 +1,224 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// nri-plugin-gen generates the skeleton of a new plugin under plugins, with
+// handlers for the given events, the common command line flags, parsing of
+// a YAML configuration and a build target in the Makefile. The go.mod and
+// go.sum files of the new plugin are taken from the template plugin.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const (
+	// templatePlugin is the plugin the go.mod and go.sum files are taken from.
+	templatePlugin = "template"
+)
+
+var (
+	validName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+)
+
+type generator struct {
+	root     string
+	name     string
+	events   string
+	makefile bool
+	force    bool
+}
+
+// skeleton is the data the templates are executed with.
+type skeleton struct {
+	Name     string
+	Handlers []string
+}
+
+func main() {
+	g := &generator{}
+
+	flag.StringVar(&g.root, "root", ".", "root of the NRI source tree")
+	flag.StringVar(&g.name, "name", "", "name of the plugin to generate")
+	flag.StringVar(&g.events, "events", "all", "comma-separated list of events to handle")
+	flag.BoolVar(&g.makefile, "makefile", true, "add a build target for the plugin to the Makefile")
+	flag.BoolVar(&g.force, "force", false, "overwrite an existing plugin")
+	flag.Parse()
+
+	if err := g.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "nri-plugin-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (g *generator) run() error {
+	files, err := g.generate()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(g.root, "plugins", g.name)
+	if _, err := os.Stat(dir); err == nil && !g.force {
+		return fmt.Errorf("plugin %s already exists, use -force to overwrite it", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	if g.makefile {
+		if err := g.updateMakefile(filepath.Join(g.root, "Makefile")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generate the files of the plugin.
+func (g *generator) generate() (map[string][]byte, error) {
+	if !validName.MatchString(g.name) {
+		return nil, fmt.Errorf("invalid plugin name %q", g.name)
+	}
+
+	events, err := api.ParseEventMask(g.events)
+	if err != nil {
+		return nil, err
+	}
+	if events == 0 {
+		return nil, fmt.Errorf("no events to handle")
+	}
+
+	s := &skeleton{Name: g.name}
+	for _, h := range handlers {
+		if events.IsSet(h.event) {
+			s.Handlers = append(s.Handlers, h.code)
+		}
+	}
+
+	src, err := execute(pluginTemplate, s)
+	if err != nil {
+		return nil, err
+	}
+	if src, err = format.Source(src); err != nil {
+		return nil, fmt.Errorf("failed to format plugin source: %w", err)
+	}
+
+	files := map[string][]byte{
+		"plugin.go": src,
+	}
+
+	mod, err := os.ReadFile(filepath.Join(g.root, "plugins", templatePlugin, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod of template plugin: %w", err)
+	}
+	files["go.mod"] = bytes.Replace(mod,
+		[]byte("/plugins/"+templatePlugin+"\n"), []byte("/plugins/"+g.name+"\n"), 1)
+
+	if files["go.sum"], err = os.ReadFile(filepath.Join(g.root, "plugins", templatePlugin, "go.sum")); err != nil {
+		return nil, fmt.Errorf("failed to read go.sum of template plugin: %w", err)
+	}
+
+	return files, nil
+}
+
+// updateMakefile adds the plugin and a build target for it to the Makefile,
+// unless it is already there.
+func (g *generator) updateMakefile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read Makefile: %w", err)
+	}
+
+	mk, err := addMakefileTarget(string(data), g.name)
+	if err != nil {
+		return err
+	}
+	if mk == string(data) {
+		return nil
+	}
+
+	return os.WriteFile(path, []byte(mk), 0644)
+}
+
+// addMakefileTarget adds the given plugin to the list of plugins and a
+// build target for it after the one of the template plugin.
+func addMakefileTarget(mk, name string) (string, error) {
+	target := "$(BIN_PATH)/" + name
+	if strings.Contains(mk, "\n"+target+":") {
+		return mk, nil
+	}
+
+	lines := strings.Split(mk, "\n")
+	list, rule := -1, -1
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "PLUGINS :="):
+			list = i
+		case list >= 0 && rule < 0 && strings.HasPrefix(l, "\t$(BIN_PATH)/") && !strings.HasSuffix(l, "\\"):
+			// last entry of the plugin list
+			rule = i
+		}
+	}
+	if list < 0 || rule < 0 {
+		return "", fmt.Errorf("no plugin list in Makefile")
+	}
+	lines = append(lines[:rule], append([]string{"\t" + target + " \\"}, lines[rule:]...)...)
+	mk = strings.Join(lines, "\n")
+
+	buildRule, err := execute(makefileTemplate, &skeleton{Name: name})
+	if err != nil {
+		return "", err
+	}
+
+	anchor := "\n$(BIN_PATH)/" + templatePlugin + ":"
+	idx := strings.Index(mk, anchor)
+	if idx < 0 {
+		return "", fmt.Errorf("no build target for plugin %s in Makefile", templatePlugin)
+	}
+	end := strings.Index(mk[idx+1:], "\n\n")
+	if end < 0 {
+		return "", fmt.Errorf("malformed build target for plugin %s in Makefile", templatePlugin)
+	}
+	end += idx + 1
+
+	return mk[:end+1] + string(buildRule) + mk[end+1:], nil
+}
+
+func execute(text string, data interface{}) ([]byte, error) {
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
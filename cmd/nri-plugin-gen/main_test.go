@@ -0,0 +1,101 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePlugin(t *testing.T) {
+	g := &generator{
+		root:   "../..",
+		name:   "my-plugin",
+		events: "RunPodSandbox,CreateContainer,ContainerDied",
+	}
+	files, err := g.generate()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "plugin.go", files["plugin.go"], 0)
+	if err != nil {
+		t.Fatalf("generated plugin does not parse: %v", err)
+	}
+	var methods []string
+	for _, d := range f.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv != nil && ast.IsExported(fn.Name.Name) {
+			methods = append(methods, fn.Name.Name)
+		}
+	}
+	sort.Strings(methods)
+	expected := "Configure,ContainerDied,CreateContainer,RunPodSandbox,Shutdown,Synchronize"
+	if got := strings.Join(methods, ","); got != expected {
+		t.Fatalf("generated plugin has handlers %s, expected %s", got, expected)
+	}
+
+	if !strings.HasPrefix(string(files["go.mod"]), "module github.com/containerd/nri/plugins/my-plugin\n") {
+		t.Fatalf("unexpected module of generated plugin:\n%s", files["go.mod"])
+	}
+	if len(files["go.sum"]) == 0 {
+		t.Fatalf("generated plugin has no go.sum")
+	}
+}
+
+func TestInvalidPlugin(t *testing.T) {
+	for _, g := range []*generator{
+		{root: "../..", name: "", events: "all"},
+		{root: "../..", name: "My_Plugin", events: "all"},
+		{root: "../..", name: "my-plugin", events: "nosuchevent"},
+		{root: "../..", name: "my-plugin", events: ""},
+	} {
+		if _, err := g.generate(); err == nil {
+			t.Fatalf("invalid plugin %q with events %q not detected", g.name, g.events)
+		}
+	}
+}
+
+func TestAddMakefileTarget(t *testing.T) {
+	data, err := os.ReadFile("../../Makefile")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mk, err := addMakefileTarget(string(data), "my-plugin")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !strings.Contains(mk, "\t$(BIN_PATH)/my-plugin \\\n") {
+		t.Fatalf("plugin not added to the list of plugins")
+	}
+	if !strings.Contains(mk, "\n$(BIN_PATH)/my-plugin: $(wildcard plugins/my-plugin/*.go)\n") {
+		t.Fatalf("no build target added for plugin")
+	}
+
+	again, err := addMakefileTarget(mk, "my-plugin")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if again != mk {
+		t.Fatalf("build target of plugin added twice")
+	}
+}
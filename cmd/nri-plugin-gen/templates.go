@@ -0,0 +1,226 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/containerd/nri/pkg/api"
+)
+
+const license = `/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+`
+
+const pluginTemplate = license + `
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+// config is the configuration of the {{ .Name }} plugin.
+type config struct {
+	// Add the configuration parameters of the plugin here.
+}
+
+type plugin struct {
+	stub stub.Stub
+	cfg  config
+}
+
+var (
+	log *logrus.Logger
+)
+
+func (p *plugin) Configure(_ context.Context, config, runtime, version string) (stub.EventMask, error) {
+	log.Infof("Connected to %s/%s...", runtime, version)
+
+	if config == "" {
+		return 0, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(config), &p.cfg); err != nil {
+		return 0, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	log.Infof("Got configuration %+v...", p.cfg)
+
+	return 0, nil
+}
+
+func (p *plugin) Synchronize(_ context.Context, pods []*api.PodSandbox, containers []*api.Container) ([]*api.ContainerUpdate, error) {
+	log.Infof("Synchronized state with the runtime (%d pods, %d containers)...",
+		len(pods), len(containers))
+	return nil, nil
+}
+
+func (p *plugin) Shutdown(_ context.Context) {
+	log.Info("Runtime shutting down...")
+}
+{{ range .Handlers }}
+{{ . }}
+{{ end }}
+func (p *plugin) onClose() {
+	log.Infof("Connection to the runtime lost, exiting...")
+	os.Exit(0)
+}
+
+func main() {
+	var (
+		opts []stub.Option
+		err  error
+	)
+
+	log = logrus.StandardLogger()
+
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
+
+	p := &plugin{}
+	opts = append(opts, stub.WithOnClose(p.onClose))
+
+	if p.stub, err = stub.New(p, opts...); err != nil {
+		log.Fatalf("failed to create plugin stub: %v", err)
+	}
+
+	if err = p.stub.Run(context.Background()); err != nil {
+		log.Errorf("plugin exited (%v)", err)
+		os.Exit(1)
+	}
+}
+`
+
+const makefileTemplate = `
+$(BIN_PATH)/{{ .Name }}: $(wildcard plugins/{{ .Name }}/*.go)
+	$(Q)echo "Building $@..."; \
+	cd $(dir $<) && $(GO_BUILD) -o $@ .
+`
+
+// handlers are the event handlers of generated plugins, in the order they
+// are generated in.
+var handlers = []struct {
+	event api.Event
+	code  string
+}{
+	{api.Event_RUN_POD_SANDBOX, `func (p *plugin) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	log.Infof("Started pod %s/%s...", pod.GetNamespace(), pod.GetName())
+	return nil
+}`},
+	{api.Event_STOP_POD_SANDBOX, `func (p *plugin) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	log.Infof("Stopped pod %s/%s...", pod.GetNamespace(), pod.GetName())
+	return nil
+}`},
+	{api.Event_REMOVE_POD_SANDBOX, `func (p *plugin) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	log.Infof("Removed pod %s/%s...", pod.GetNamespace(), pod.GetName())
+	return nil
+}`},
+	{api.Event_CREATE_CONTAINER, `func (p *plugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	log.Infof("Creating container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+
+	//
+	// Adjust the container being created here. Take a look at the adjustment
+	// functions in pkg/api/adjustment.go to see the available controls. You
+	// can also update other existing containers, using the functions in
+	// pkg/api/update.go.
+	//
+
+	adjustment := &api.ContainerAdjustment{}
+	updates := []*api.ContainerUpdate{}
+
+	return adjustment, updates, nil
+}`},
+	{api.Event_CREATE_CONTAINER_ROLLBACK, `func (p *plugin) CreateContainerRollback(_ context.Context, pod *api.PodSandbox, ctr *api.Container, adjust *api.ContainerAdjustment, reason string) error {
+	log.Infof("Rolling back creation of container %s/%s/%s (%s)...", pod.GetNamespace(), pod.GetName(), ctr.GetName(), reason)
+
+	//
+	// Release any resources reserved for the container in CreateContainer
+	// here. The runtime failed to create the container, discarding adjust.
+	//
+
+	return nil
+}`},
+	{api.Event_POST_CREATE_CONTAINER, `func (p *plugin) PostCreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Created container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+	return nil
+}`},
+	{api.Event_START_CONTAINER, `func (p *plugin) StartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Starting container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+	return nil
+}`},
+	{api.Event_POST_START_CONTAINER, `func (p *plugin) PostStartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Started container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+	return nil
+}`},
+	{api.Event_UPDATE_CONTAINER, `func (p *plugin) UpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container, r *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	log.Infof("Updating container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+
+	//
+	// Alter the pending update or update other existing containers here.
+	// Take a look at the functions in pkg/api/update.go to see the available
+	// controls.
+	//
+
+	updates := []*api.ContainerUpdate{}
+
+	return updates, nil
+}`},
+	{api.Event_POST_UPDATE_CONTAINER, `func (p *plugin) PostUpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Updated container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+	return nil
+}`},
+	{api.Event_CONTAINER_DIED, `func (p *plugin) ContainerDied(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Container %s/%s/%s died (%+v)...", pod.GetNamespace(), pod.GetName(), ctr.GetName(), ctr.Exit())
+	return nil
+}`},
+	{api.Event_STOP_CONTAINER, `func (p *plugin) StopContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+	log.Infof("Stopped container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+
+	//
+	// Update any of the remaining running containers here. Take a look at
+	// the functions in pkg/api/update.go to see the available controls.
+	//
+
+	return []*api.ContainerUpdate{}, nil
+}`},
+	{api.Event_REMOVE_CONTAINER, `func (p *plugin) RemoveContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	log.Infof("Removed container %s/%s/%s...", pod.GetNamespace(), pod.GetName(), ctr.GetName())
+	return nil
+}`},
+}
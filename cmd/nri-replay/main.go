@@ -0,0 +1,179 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// nri-replay converts recorded CRI call traces to NRI events, and replays
+// them against plugins under test. Plugins connect to the socket of the
+// replayer, or are launched by it from a plugin directory. The results of
+// each replayed event, the adjustments, updates and evictions requested by
+// plugins, and any errors, are written as JSON, one event per line.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/replay"
+)
+
+const (
+	formatContainerd = "containerd"
+	formatCRI        = "cri"
+	formatNRI        = "nri"
+)
+
+type options struct {
+	format     string
+	convert    string
+	output     string
+	socketPath string
+	pluginPath string
+	plugins    int
+	wait       time.Duration
+}
+
+func main() {
+	o := &options{}
+
+	flag.StringVar(&o.format, "format", formatNRI,
+		"trace format, "+formatContainerd+" (debug log), "+formatCRI+" (JSON CRI calls) or "+formatNRI+" (converted events)")
+	flag.StringVar(&o.convert, "convert", "", "only convert the trace, writing NRI events to this file, - for stdout")
+	flag.StringVar(&o.output, "output", "-", "file to write replay results to, - for stdout")
+	flag.StringVar(&o.socketPath, "socket-path", filepath.Join(os.TempDir(), "nri-replay.sock"),
+		"socket for plugins under test to connect to")
+	flag.StringVar(&o.pluginPath, "plugin-path", "", "directory of plugins to launch")
+	flag.IntVar(&o.plugins, "plugins", 1, "number of plugins to wait for before replaying")
+	flag.DurationVar(&o.wait, "wait", time.Minute, "time to wait for plugins to get ready")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [options] <trace>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(o, flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(o *options, trace string) error {
+	steps, err := readTrace(o.format, trace)
+	if err != nil {
+		return err
+	}
+
+	if o.convert != "" {
+		return writeFile(o.convert, func(w io.Writer) error {
+			return replay.WriteSteps(w, steps)
+		})
+	}
+
+	opts := []nri.Option{
+		nri.WithSocketPath(o.socketPath),
+	}
+	if o.pluginPath != "" {
+		opts = append(opts, nri.WithPluginPath(o.pluginPath))
+	} else {
+		opts = append(opts, nri.WithPluginPath(filepath.Join(os.TempDir(), "nri-replay-no-plugins")))
+	}
+
+	r, err := replay.New(opts...)
+	if err != nil {
+		return err
+	}
+	if err := r.Start(); err != nil {
+		return fmt.Errorf("failed to start replayer: %w", err)
+	}
+	defer r.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.wait)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "waiting for %d plugin(s) on %s...\n", o.plugins, o.socketPath)
+	if err := r.WaitForPlugins(ctx, o.plugins); err != nil {
+		return fmt.Errorf("failed to wait for plugins: %w", err)
+	}
+
+	results := r.Replay(context.Background(), steps)
+
+	failed := 0
+	err = writeFile(o.output, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		for _, res := range results {
+			if res.Err != nil {
+				failed++
+			}
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "replayed %d events, %d failed, %d unsolicited updates\n",
+		len(results), failed, len(r.Unsolicited()))
+
+	return nil
+}
+
+func readTrace(format, path string) ([]*replay.Step, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case formatContainerd:
+		return replay.ParseContainerdLog(f)
+	case formatCRI:
+		return replay.ParseCRITrace(f)
+	case formatNRI:
+		return replay.ReadSteps(f)
+	}
+
+	return nil, fmt.Errorf("unknown trace format %q", format)
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,413 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// nrictl inspects and drives a live runtime over NRI. It connects to the
+// NRI socket as a plugin, then lists the pods and containers it gets
+// synchronized with, watches events as JSON, one event per line, or
+// requests unsolicited updates to containers.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// idleEvents are subscribed to by commands which don't watch events,
+	// since plugins must subscribe to at least one event. They are ignored.
+	idleEvents = api.EventMask(1 << (api.Event_REMOVE_POD_SANDBOX - 1))
+)
+
+var (
+	errConnectionClosed = errors.New("connection to runtime closed")
+)
+
+// options are the global command line options.
+type options struct {
+	socketPath string
+	name       string
+	idx        string
+	timeout    time.Duration
+	logLevel   string
+}
+
+// command is an nrictl subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(o *options, args []string, out io.Writer) error
+}
+
+var commands = []*command{
+	{
+		name:  "list",
+		usage: "list [-json] [pods|containers]: list pods and containers",
+		run:   list,
+	},
+	{
+		name:  "watch",
+		usage: "watch [-events <events>]: write events as JSON, one per line",
+		run:   watch,
+	},
+	{
+		name:  "update",
+		usage: "update [-file <updates>] [-container <id> <update flags>]: request container updates",
+		run:   update,
+	},
+}
+
+func main() {
+	o := &options{}
+
+	flag.StringVar(&o.socketPath, "socket-path", api.DefaultSocketPath, "NRI socket path to connect to")
+	flag.StringVar(&o.name, "name", "nrictl", "plugin name to register to NRI")
+	flag.StringVar(&o.idx, "idx", "99", "plugin index to register to NRI")
+	flag.DurationVar(&o.timeout, "timeout", 30*time.Second, "time to wait for connecting and synchronizing")
+	flag.StringVar(&o.logLevel, "log-level", logrus.WarnLevel.String(), "logging level (debug, info, warn, error)")
+	flag.Usage = func() {
+		w := flag.CommandLine.Output()
+		fmt.Fprintf(w, "usage: %s [options] <command> [command options]\n\ncommands:\n", os.Args[0])
+		for _, cmd := range commands {
+			fmt.Fprintf(w, "  %s\n", cmd.usage)
+		}
+		fmt.Fprintf(w, "\noptions:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	level, err := logrus.ParseLevel(o.logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid log level %q\n", o.logLevel)
+		os.Exit(2)
+	}
+	logrus.SetLevel(level)
+
+	for _, cmd := range commands {
+		if cmd.name != flag.Arg(0) {
+			continue
+		}
+		if err := cmd.run(o, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "error: unknown command %q\n", flag.Arg(0))
+	flag.Usage()
+	os.Exit(2)
+}
+
+// connect to the runtime as a plugin subscribed to the given events, and
+// wait for getting synchronized.
+func (o *options) connect(events api.EventMask, out io.Writer) (*plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+
+	p := newPlugin(events, out)
+	err := p.start(ctx,
+		stub.WithSocketPath(o.socketPath),
+		stub.WithPluginName(o.name),
+		stub.WithPluginIdx(o.idx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", o.socketPath, err)
+	}
+
+	return p, nil
+}
+
+// list the pods and containers of the runtime.
+func list(o *options, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "list as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var pods, ctrs bool
+	switch fs.Arg(0) {
+	case "":
+		pods, ctrs = true, true
+	case "pods", "pod":
+		pods = true
+	case "containers", "container", "ctrs":
+		ctrs = true
+	default:
+		return fmt.Errorf("can't list unknown objects %q", fs.Arg(0))
+	}
+
+	p, err := o.connect(idleEvents, nil)
+	if err != nil {
+		return err
+	}
+	defer p.stop()
+
+	if !pods {
+		p.pods = nil
+	}
+	if !ctrs {
+		p.ctrs = nil
+	}
+
+	if *asJSON {
+		return writeJSON(out, p.pods, p.ctrs)
+	}
+	return writeTable(out, p.pods, p.ctrs)
+}
+
+// writeJSON writes pods and containers as a single JSON object.
+func writeJSON(out io.Writer, pods []*api.PodSandbox, ctrs []*api.Container) error {
+	objects := struct {
+		Pods       []json.RawMessage `json:"pods,omitempty"`
+		Containers []json.RawMessage `json:"containers,omitempty"`
+	}{}
+	for _, pod := range pods {
+		objects.Pods = append(objects.Pods, marshal(pod))
+	}
+	for _, ctr := range ctrs {
+		objects.Containers = append(objects.Containers, marshal(ctr))
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(objects)
+}
+
+// writeTable writes pods and containers as tables.
+func writeTable(out io.Writer, pods []*api.PodSandbox, ctrs []*api.Container) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	podNames := map[string]string{}
+	if pods != nil {
+		fmt.Fprintf(w, "POD ID\tNAME\tNAMESPACE\tUID\n")
+	}
+	for _, pod := range pods {
+		podNames[pod.GetId()] = pod.GetName()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pod.GetId(), pod.GetName(), pod.GetNamespace(), pod.GetUid())
+	}
+
+	if ctrs != nil {
+		if pods != nil {
+			fmt.Fprintf(w, "\n")
+		}
+		fmt.Fprintf(w, "CONTAINER ID\tNAME\tPOD\tSTATE\n")
+	}
+	for _, ctr := range ctrs {
+		pod := podNames[ctr.GetPodSandboxId()]
+		if pod == "" {
+			pod = ctr.GetPodSandboxId()
+		}
+		state := strings.TrimPrefix(ctr.GetState().String(), "CONTAINER_")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ctr.GetId(), ctr.GetName(), pod, state)
+	}
+
+	return w.Flush()
+}
+
+// watch events in real time, until interrupted or the connection is closed.
+func watch(o *options, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	events := fs.String("events", "all", "comma-separated list of events to watch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mask, err := api.ParseEventMask(*events)
+	if err != nil {
+		return err
+	}
+	if mask == 0 {
+		return fmt.Errorf("no events to watch")
+	}
+
+	p, err := o.connect(mask, out)
+	if err != nil {
+		return err
+	}
+	defer p.stop()
+
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigC)
+
+	select {
+	case <-sigC:
+		return nil
+	case <-p.closed:
+		return errConnectionClosed
+	}
+}
+
+// update containers using updates read from a file or given on the command line.
+func update(o *options, args []string, _ io.Writer) error {
+	updates, err := parseUpdates(args)
+	if err != nil {
+		return err
+	}
+
+	p, err := o.connect(idleEvents, nil)
+	if err != nil {
+		return err
+	}
+	defer p.stop()
+
+	failed, err := p.stub.UpdateContainers(updates)
+	if err != nil {
+		return fmt.Errorf("failed to update containers: %w", err)
+	}
+	if len(failed) > 0 {
+		var ids []string
+		for _, u := range failed {
+			ids = append(ids, u.GetContainerId())
+		}
+		return fmt.Errorf("failed to update containers %s", strings.Join(ids, ", "))
+	}
+
+	fmt.Fprintf(os.Stderr, "requested %d container update(s)\n", len(updates))
+	return nil
+}
+
+// parseUpdates parses the arguments of the update command.
+func parseUpdates(args []string) ([]*api.ContainerUpdate, error) {
+	var (
+		fs = flag.NewFlagSet("update", flag.ContinueOnError)
+
+		file          = fs.String("file", "", "JSON file with a list of container updates, - for stdin")
+		id            = fs.String("container", "", "ID of the container to update")
+		cpuShares     = fs.Uint64("cpu-shares", 0, "CPU shares")
+		cpuQuota      = fs.Int64("cpu-quota", 0, "CPU CFS quota")
+		cpuPeriod     = fs.Int64("cpu-period", 0, "CPU CFS period")
+		cpusetCpus    = fs.String("cpuset-cpus", "", "cpuset CPUs")
+		cpusetMems    = fs.String("cpuset-mems", "", "cpuset memory nodes")
+		memoryLimit   = fs.Int64("memory-limit", 0, "memory limit in bytes")
+		rdtClass      = fs.String("rdt-class", "", "RDT class")
+		blockioClass  = fs.String("blockio-class", "", "block I/O class")
+		ignoreFailure = fs.Bool("ignore-failure", false, "ignore failure of the update")
+	)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if *file != "" {
+		if len(set) > 1 {
+			return nil, fmt.Errorf("can't combine -file with other update flags")
+		}
+		return readUpdates(*file)
+	}
+
+	if *id == "" {
+		return nil, fmt.Errorf("no container to update, use -container or -file")
+	}
+
+	u := &api.ContainerUpdate{}
+	u.SetContainerId(*id)
+	if set["cpu-shares"] {
+		u.SetLinuxCPUShares(*cpuShares)
+	}
+	if set["cpu-quota"] {
+		u.SetLinuxCPUQuota(*cpuQuota)
+	}
+	if set["cpu-period"] {
+		u.SetLinuxCPUPeriod(*cpuPeriod)
+	}
+	if set["cpuset-cpus"] {
+		u.SetLinuxCPUSetCPUs(*cpusetCpus)
+	}
+	if set["cpuset-mems"] {
+		u.SetLinuxCPUSetMems(*cpusetMems)
+	}
+	if set["memory-limit"] {
+		u.SetLinuxMemoryLimit(*memoryLimit)
+	}
+	if set["rdt-class"] {
+		u.SetLinuxRDTClass(*rdtClass)
+	}
+	if set["blockio-class"] {
+		u.SetLinuxBlockIOClass(*blockioClass)
+	}
+	if *ignoreFailure {
+		u.SetIgnoreFailure()
+	}
+
+	if u.GetLinux() == nil {
+		return nil, fmt.Errorf("nothing to update for container %s", *id)
+	}
+
+	return []*api.ContainerUpdate{u}, nil
+}
+
+// readUpdates reads a JSON list of container updates from the given file.
+func readUpdates(file string) ([]*api.ContainerUpdate, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read updates: %w", err)
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse updates: %w", err)
+	}
+
+	updates := make([]*api.ContainerUpdate, 0, len(list))
+	for i, raw := range list {
+		u := &api.ContainerUpdate{}
+		if err := protojson.Unmarshal(raw, u); err != nil {
+			return nil, fmt.Errorf("failed to parse update #%d: %w", i, err)
+		}
+		updates = append(updates, u)
+	}
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no updates in %s", file)
+	}
+
+	return updates, nil
+}
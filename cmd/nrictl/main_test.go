@@ -0,0 +1,209 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+)
+
+var (
+	testPod = &api.PodSandbox{Id: "pod0", Name: "pod0", Namespace: "default", Uid: "uid0"}
+	testCtr = &api.Container{Id: "ctr0", PodSandboxId: "pod0", Name: "ctr0",
+		State: api.ContainerState_CONTAINER_RUNNING}
+)
+
+// startRuntime starts a runtime with a single pod and container, returning
+// the options to connect to it. Updates requested by nrictl are recorded.
+func startRuntime(t *testing.T, updated *[]*nri.ContainerUpdate) (*nri.Adaptation, *options) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "nri.sock")
+
+	var lock sync.Mutex
+	r, err := nri.New("test-runtime", "0.0.1",
+		func(ctx context.Context, cb nri.SyncCB) error {
+			_, err := cb(ctx, []*api.PodSandbox{testPod}, []*api.Container{testCtr})
+			return err
+		},
+		func(_ context.Context, updates []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if updated != nil {
+				*updated = append(*updated, updates...)
+			}
+			return nil, nil
+		},
+		nri.WithPluginPath(filepath.Join(dir, "plugins")),
+		nri.WithPluginConfigPath(filepath.Join(dir, "conf.d")),
+		nri.WithSocketPath(socket),
+	)
+	if err != nil {
+		t.Fatalf("failed to create runtime: %v", err)
+	}
+	if err := r.Start(); err != nil {
+		t.Fatalf("failed to start runtime: %v", err)
+	}
+	t.Cleanup(r.Stop)
+
+	return r, &options{
+		socketPath: socket,
+		name:       "nrictl",
+		idx:        "99",
+		timeout:    10 * time.Second,
+	}
+}
+
+func TestList(t *testing.T) {
+	_, o := startRuntime(t, nil)
+
+	out := &bytes.Buffer{}
+	if err := list(o, nil, out); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	for _, expected := range []string{"pod0", "default", "uid0", "ctr0", "RUNNING"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("expected %q in list output:\n%s", expected, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := list(o, []string{"-json", "containers"}, out); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	objects := struct {
+		Pods       []json.RawMessage `json:"pods"`
+		Containers []struct {
+			ID string `json:"id"`
+		} `json:"containers"`
+	}{}
+	if err := json.Unmarshal(out.Bytes(), &objects); err != nil {
+		t.Fatalf("invalid JSON list output: %v", err)
+	}
+	if len(objects.Pods) != 0 || len(objects.Containers) != 1 || objects.Containers[0].ID != "ctr0" {
+		t.Fatalf("unexpected JSON list output:\n%s", out.String())
+	}
+}
+
+func TestWatch(t *testing.T) {
+	r, o := startRuntime(t, nil)
+
+	out := &syncBuffer{}
+	mask, err := api.ParseEventMask("pod")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	p, err := o.connect(mask, out)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer p.stop()
+
+	// the runtime only starts relaying events to the plugin once it has
+	// processed the response to synchronization, so retry for a while
+	pod := &api.PodSandbox{Id: "pod1", Name: "pod1", Namespace: "default", Uid: "uid1"}
+	for deadline := time.Now().Add(5 * time.Second); out.String() == "" && time.Now().Before(deadline); {
+		if err := r.RunPodSandbox(context.Background(), &nri.StateChangeEvent{Pod: pod}); err != nil {
+			t.Fatalf("RunPodSandbox failed: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	line, _, _ := strings.Cut(out.String(), "\n")
+	e := &event{}
+	if err := json.Unmarshal([]byte(line), e); err != nil {
+		t.Fatalf("invalid watch output %q: %v", out.String(), err)
+	}
+	if e.Event != "RunPodSandbox" || !strings.Contains(string(e.Pod), `"pod1"`) || e.Container != nil {
+		t.Fatalf("unexpected watched event %q", out.String())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var updated []*nri.ContainerUpdate
+	_, o := startRuntime(t, &updated)
+
+	err := update(o, []string{"-container", "ctr0", "-cpuset-cpus", "0-1", "-memory-limit", "1048576"}, nil)
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if len(updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(updated))
+	}
+	u := updated[0]
+	if u.GetContainerId() != "ctr0" ||
+		u.GetLinux().GetResources().GetCpu().GetCpus() != "0-1" ||
+		u.GetLinux().GetResources().GetMemory().GetLimit().GetValue() != 1048576 {
+		t.Fatalf("unexpected update %v", u)
+	}
+}
+
+func TestParseUpdates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "updates.json")
+	data := `[{"container_id": "ctr0", "linux": {"resources": {"rdt_class": {"value": "gold"}}}},
+	          {"container_id": "ctr1", "ignore_failure": true}]`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	updates, err := parseUpdates([]string{"-file", file})
+	if err != nil {
+		t.Fatalf("failed to parse updates: %v", err)
+	}
+	if len(updates) != 2 || updates[0].GetLinux().GetResources().GetRdtClass().GetValue() != "gold" ||
+		!updates[1].GetIgnoreFailure() {
+		t.Fatalf("unexpected updates %v", updates)
+	}
+
+	for _, args := range [][]string{
+		nil,
+		{"-container", "ctr0"},
+		{"-file", file, "-container", "ctr0"},
+		{"-cpu-shares", "512"},
+	} {
+		if _, err := parseUpdates(args); err == nil {
+			t.Fatalf("invalid update arguments %v not detected", args)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,203 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+// plugin is the plugin nrictl connects to the runtime as. It records the
+// pods and containers it is synchronized with, and writes the events it
+// is subscribed to as JSON, one event per line.
+type plugin struct {
+	stub   stub.Stub
+	events api.EventMask
+	synced chan struct{}
+	closed chan struct{}
+	pods   []*api.PodSandbox
+	ctrs   []*api.Container
+
+	sync.Mutex
+	out io.Writer
+}
+
+// event is a watched event, as written by nrictl watch.
+type event struct {
+	Time      time.Time       `json:"time"`
+	Event     string          `json:"event"`
+	Pod       json.RawMessage `json:"pod,omitempty"`
+	Container json.RawMessage `json:"container,omitempty"`
+	Resources json.RawMessage `json:"resources,omitempty"`
+}
+
+func newPlugin(events api.EventMask, out io.Writer) *plugin {
+	return &plugin{
+		events: events,
+		synced: make(chan struct{}),
+		closed: make(chan struct{}),
+		out:    out,
+	}
+}
+
+// start connects to the runtime and waits for the plugin to get synchronized.
+func (p *plugin) start(ctx context.Context, opts ...stub.Option) error {
+	opts = append(opts, stub.WithOnClose(func() { close(p.closed) }))
+
+	s, err := stub.New(p, opts...)
+	if err != nil {
+		return err
+	}
+	p.stub = s
+
+	if err := s.Start(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case <-p.synced:
+		return nil
+	case <-p.closed:
+		return errConnectionClosed
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
+
+func (p *plugin) stop() {
+	if p.stub != nil {
+		p.stub.Stop()
+	}
+}
+
+func (p *plugin) Configure(_ context.Context, _, _, _ string) (stub.EventMask, error) {
+	return p.events, nil
+}
+
+func (p *plugin) Synchronize(_ context.Context, pods []*api.PodSandbox, ctrs []*api.Container) ([]*api.ContainerUpdate, error) {
+	p.pods = pods
+	p.ctrs = ctrs
+	close(p.synced)
+
+	return nil, nil
+}
+
+func (p *plugin) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.write("RunPodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.write("StopPodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.write("RemovePodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.write("CreateContainer", pod, ctr, nil)
+	return nil, nil, nil
+}
+
+func (p *plugin) PostCreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("PostCreateContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) StartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("StartContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) PostStartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("PostStartContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) UpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.write("UpdateContainer", pod, ctr, res)
+	return nil, nil
+}
+
+func (p *plugin) PostUpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("PostUpdateContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) StopContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+	p.write("StopContainer", pod, ctr, nil)
+	return nil, nil
+}
+
+func (p *plugin) RemoveContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("RemoveContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) ContainerDied(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.write("ContainerDied", pod, ctr, nil)
+	return nil
+}
+
+// write an event, unless no output is set.
+func (p *plugin) write(name string, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.out == nil {
+		return
+	}
+
+	e := &event{
+		Time:      time.Now(),
+		Event:     name,
+		Pod:       marshal(pod),
+		Container: marshal(ctr),
+		Resources: marshal(res),
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	p.out.Write(append(data, '\n'))
+}
+
+// marshal a message to JSON, or nil for a nil message.
+func marshal(m proto.Message) json.RawMessage {
+	if !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -22,12 +22,16 @@ import (
 	"fmt"
 	"io/fs"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/api/merge"
+	"github.com/containerd/nri/pkg/debug"
 	"github.com/containerd/nri/pkg/log"
 	"github.com/containerd/ttrpc"
 )
@@ -39,6 +43,11 @@ const (
 	DefaultSocketPath = api.DefaultSocketPath
 	// PluginConfigDir is the drop-in directory for NRI-launched plugin configuration.
 	DefaultPluginConfigPath = "/etc/nri/conf.d"
+	// DefaultPluginSyncConcurrency is the default limit for concurrent plugin syncs.
+	DefaultPluginSyncConcurrency = 4
+	// DefaultSyncChunkSize is the default size limit for the chunks plugins
+	// are synchronized in. It is well below the default ttrpc message limit.
+	DefaultSyncChunkSize = 2 << 20
 )
 
 // SyncFn is a container runtime function for state synchronization.
@@ -64,9 +73,90 @@ type Adaptation struct {
 	clientOpts  []ttrpc.ClientOpts
 	serverOpts  []ttrpc.ServerOpt
 	listener    net.Listener
+	grpcSocket  string
+	grpcLsnr    net.Listener
 	plugins     []*plugin
 	syncLock    sync.RWMutex
 	wasmService *api.PluginPlugin
+	graceTime   time.Duration
+	snapshots   map[string]*pluginSnapshot
+	snapLock    sync.Mutex
+	syncLimit   int
+	dropUnsupp  bool
+	syncQueue   *syncBatch
+	syncBatch   *syncBatch
+	batchLock   sync.Mutex
+	debugAddr   string
+	debugTrk    *debug.Tracker
+	debugSrv    *debug.Server
+	eventSeq    map[string]uint64
+	seqLock     sync.Mutex
+	generation  uint64
+	podGen      map[string]uint64
+	ctrGen      map[string]uint64
+	healthIntv  time.Duration
+	healthFails int
+	healthFn    PluginHealthFn
+	healthStop  chan struct{}
+	enableIntv  time.Duration
+	enableStop  chan struct{}
+	configIntv  time.Duration
+	configStop  chan struct{}
+	configKick  chan struct{}
+	configFn    PluginConfigChangeFn
+	configs     *configStore
+	metrics     MetricsRecorder
+	disabled    map[string]struct{}
+	enableLock  sync.RWMutex
+	hdlrPrefix  []string
+	readyWait   time.Duration
+	annoLimits  *api.AnnotationLimits
+	defaults    *api.RuntimeDefaults
+	resolvers   map[FieldClass]ConflictResolver
+	cache       *stateCache
+	cacheLock   sync.Mutex
+	lifecycle   *lifecycleTracker
+	journal     *updateJournal
+	preview     createPreview
+	creating    createDispatch
+	orderPolicy PluginOrderPolicy
+	topology    *api.Topology
+	hugeSizes   []string
+	vetoers     []string
+	asyncQueue  int
+	queueSizes  map[string]int
+	evtQueues   map[*eventQueue]struct{}
+	queueLock   sync.Mutex
+	adjPolicies map[string]*AdjustmentPolicy
+	annoAllow   map[string][]string
+	visibility  map[string]Visibility
+	redactKey   []byte
+	statsRoot   string
+	userPolicy  *UserPolicy
+	verifier    PluginVerifier
+	authorizer  PluginAuthorizer
+	validators  []ContainerValidator
+	intercepts  []UpdateInterceptor
+	timeouts    map[string]map[Event]time.Duration
+	aaProfiles  []string
+	syncChunk   int
+	kaIntv      time.Duration
+	kaMisses    int
+}
+
+// syncBatch is a set of plugins synchronized using a single runtime snapshot.
+type syncBatch struct {
+	plugins []*plugin
+}
+
+// pluginSnapshot is the saved subscription of a disconnected plugin.
+type pluginSnapshot struct {
+	events    EventMask
+	filter    *EventFilter
+	config    string
+	runAfter  []string
+	runBefore []string
+	closed    time.Time
 }
 
 var (
@@ -109,6 +199,147 @@ func WithDisabledExternalConnections() Option {
 	}
 }
 
+// WithPluginReconnectGracePeriod returns an option to save the subscription
+// of disconnected external plugins for the given grace period. If a plugin
+// with the same name and index reconnects within this period and accepts a
+// restored subscription, it is registered without configuring and
+// synchronizing it again.
+func WithPluginReconnectGracePeriod(grace time.Duration) Option {
+	return func(r *Adaptation) error {
+		r.graceTime = grace
+		return nil
+	}
+}
+
+// WithDroppedUnsupportedAdjustments returns an option to drop container
+// adjustments which don't take effect with the runtime handler of the pod,
+// as indicated by the runtime in the pod's runtime handler capabilities.
+// By default such adjustments are only warned about.
+func WithDroppedUnsupportedAdjustments() Option {
+	return func(r *Adaptation) error {
+		r.dropUnsupp = true
+		return nil
+	}
+}
+
+// WithHandlerAnnotationPrefixes returns an option to allow plugins to set
+// runtime handler annotations with any of the given key prefixes. Handler
+// annotations with other keys are rejected. By default all are rejected.
+func WithHandlerAnnotationPrefixes(prefixes ...string) Option {
+	return func(r *Adaptation) error {
+		for _, prefix := range prefixes {
+			if prefix == "" {
+				return fmt.Errorf("invalid (empty) handler annotation prefix")
+			}
+		}
+		r.hdlrPrefix = append(r.hdlrPrefix, prefixes...)
+		return nil
+	}
+}
+
+// WithAnnotationLimits returns an option to limit the length of keys and
+// values, and the number of annotations plugins can inject into containers.
+// Adjustments exceeding the limits are rejected, unless the policy allows
+// truncating oversized values. Plugins are told the limits during their
+// configuration.
+func WithAnnotationLimits(limits *api.AnnotationLimits) Option {
+	return func(r *Adaptation) error {
+		r.annoLimits = limits
+		return nil
+	}
+}
+
+// WithRuntimeDefaults returns an option to pass the defaults the runtime
+// applies to containers to plugins during their configuration, so plugins
+// can compute adjustments relative to them. Runtimes can get the defaults
+// from their base OCI Spec using FromOCIRuntimeDefaults.
+func WithRuntimeDefaults(defaults *api.RuntimeDefaults) Option {
+	return func(r *Adaptation) error {
+		r.defaults = defaults
+		return nil
+	}
+}
+
+// WithCpusetValidation returns an option to validate the cpuset CPUs and
+// memory nodes set by plugins against the online CPUs and memory nodes of
+// the given topology. Plugin adjustments and updates with invalid values are
+// rejected. If topology is nil, the topology of the host is read from sysfs
+// when the option is applied.
+func WithCpusetValidation(topology *api.Topology) Option {
+	return func(r *Adaptation) error {
+		if topology == nil {
+			host, err := api.HostTopology()
+			if err != nil {
+				return fmt.Errorf("failed to get host topology for cpuset validation: %w", err)
+			}
+			topology = host
+		}
+		r.topology = topology
+		return nil
+	}
+}
+
+// WithHugepageSizeValidation returns an option to validate the page sizes
+// of the hugepage limits set by plugins against the given supported sizes.
+// Plugin adjustments and updates with unsupported or invalid sizes are
+// rejected. If no sizes are given, the sizes supported by the host are read
+// from sysfs when the option is applied. Valid page sizes are normalized
+// regardless of this option, invalid ones are otherwise kept as they are.
+func WithHugepageSizeValidation(sizes ...string) Option {
+	return func(r *Adaptation) error {
+		if len(sizes) == 0 {
+			host, err := api.HostHugepageSizes()
+			if err != nil {
+				return fmt.Errorf("failed to get host hugepage sizes for validation: %w", err)
+			}
+			sizes = host
+		}
+		for _, size := range sizes {
+			if _, err := api.NormalizeHugepageSize(size); err != nil {
+				return err
+			}
+		}
+		r.hugeSizes = sizes
+		return nil
+	}
+}
+
+// WithPluginSyncConcurrency returns an option to limit the number of plugins
+// synchronized concurrently using the same snapshot of the runtime state.
+func WithPluginSyncConcurrency(limit int) Option {
+	return func(r *Adaptation) error {
+		if limit < 1 {
+			return fmt.Errorf("invalid plugin sync concurrency %d", limit)
+		}
+		r.syncLimit = limit
+		return nil
+	}
+}
+
+// WithSyncChunkSize returns an option to set the size limit, in bytes, for
+// the chunks plugins are synchronized in. Runtimes with a larger ttrpc message
+// limit can raise it to synchronize plugins with fewer messages.
+func WithSyncChunkSize(size int) Option {
+	return func(r *Adaptation) error {
+		if size < 1 {
+			return fmt.Errorf("invalid sync chunk size %d", size)
+		}
+		r.syncChunk = size
+		return nil
+	}
+}
+
+// WithDebugListener returns an option to serve pprof profiles and a status
+// page on the given address. The status page lists plugin connections,
+// pending requests to and from plugins and the last failed requests. The
+// listener also serves dry-run validation of container creation.
+func WithDebugListener(addr string) Option {
+	return func(r *Adaptation) error {
+		r.debugAddr = addr
+		return nil
+	}
+}
+
 // WithTTRPCOptions sets extra client and server options to use for ttrpc.
 func WithTTRPCOptions(clientOpts []ttrpc.ClientOpts, serverOpts []ttrpc.ServerOpt) Option {
 	return func(r *Adaptation) error {
@@ -143,7 +374,15 @@ func New(name, version string, syncFn SyncFn, updateFn UpdateFn, opts ...Option)
 		dropinPath:  DefaultPluginConfigPath,
 		socketPath:  DefaultSocketPath,
 		syncLock:    sync.RWMutex{},
+		syncLimit:   DefaultPluginSyncConcurrency,
+		syncChunk:   DefaultSyncChunkSize,
 		wasmService: wasmPlugins,
+		eventSeq:    make(map[string]uint64),
+		podGen:      make(map[string]uint64),
+		ctrGen:      make(map[string]uint64),
+		cache:       newStateCache(),
+		metrics:     nopMetrics{},
+		lifecycle:   newLifecycleTracker(),
 	}
 
 	for _, o := range opts {
@@ -152,6 +391,17 @@ func New(name, version string, syncFn SyncFn, updateFn UpdateFn, opts ...Option)
 		}
 	}
 
+	r.configs = newConfigStore(r.dropinPath, r.pluginConfigChanged)
+	r.configKick = make(chan struct{}, 1)
+
+	if r.debugAddr != "" {
+		r.debugTrk = &debug.Tracker{}
+		r.clientOpts = append(r.clientOpts,
+			ttrpc.WithChainUnaryClientInterceptor(r.debugTrk.ClientInterceptor()))
+		r.serverOpts = append(r.serverOpts,
+			ttrpc.WithChainUnaryServerInterceptor(r.debugTrk.ServerInterceptor()))
+	}
+
 	log.Infof(noCtx, "runtime interface created")
 
 	return r, nil
@@ -164,6 +414,15 @@ func (r *Adaptation) Start() error {
 	r.Lock()
 	defer r.Unlock()
 
+	if err := r.startDebugListener(); err != nil {
+		return err
+	}
+
+	r.configs.start()
+	r.startEnablementWatch()
+	r.startHealthCheck()
+	r.startConfigWatch()
+
 	if err := r.startPlugins(); err != nil {
 		return err
 	}
@@ -184,6 +443,11 @@ func (r *Adaptation) Stop() {
 
 	r.stopListener()
 	r.stopPlugins()
+	r.stopEnablementWatch()
+	r.stopHealthCheck()
+	r.stopConfigWatch()
+	r.configs.stop()
+	r.stopDebugListener()
 }
 
 // RunPodSandbox relays the corresponding CRI event to plugins.
@@ -204,25 +468,108 @@ func (r *Adaptation) RemovePodSandbox(ctx context.Context, evt *StateChangeEvent
 	return r.StateChange(ctx, evt)
 }
 
-// CreateContainer relays the corresponding CRI request to plugins.
-func (r *Adaptation) CreateContainer(ctx context.Context, req *CreateContainerRequest) (*CreateContainerResponse, error) {
+// CreateContainer relays the corresponding CRI request to plugins. If the
+// pod of the container is stopped or removed meanwhile, the creation is
+// cancelled. Plugins which already got the request are notified, and the
+// collected adjustments are discarded.
+func (r *Adaptation) CreateContainer(ctx context.Context, req *CreateContainerRequest) (_ *CreateContainerResponse, retErr error) {
 	r.Lock()
 	defer r.Unlock()
 	defer r.removeClosedPlugins()
 
-	result := collectCreateContainerResult(req)
-	for _, plugin := range r.plugins {
+	relay, err := r.lifecycle.check(Event_CREATE_CONTAINER, req.Pod, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if !relay {
+		return &CreateContainerResponse{}, nil
+	}
+
+	defer func() {
+		if retErr != nil {
+			r.lifecycle.forgetContainer(req.Container)
+		}
+	}()
+
+	defer r.recordFanOut(Event_CREATE_CONTAINER, time.Now())
+
+	r.sequenceEvent(req.Container)
+	r.generateEvent(req.Pod, req.Container)
+	fillImage(req.Container)
+	r.cacheCreate(req)
+
+	result := merge.NewCreateContainerResult(req, r.mergeOptions()...)
+	r.preview.start(req.Container.Id, result)
+	defer r.preview.stop()
+
+	ctx, done := r.creating.start(ctx, req.GetPod().GetId())
+	defer done()
+
+	// pass the owners and the adjustment collected so far to each plugin
+	defer func() {
+		req.Owners, req.Adjust = nil, nil
+	}()
+
+	var relayed []*plugin
+	for _, plugin := range r.pluginsFor(Event_CREATE_CONTAINER) {
+		if isCancelled(ctx) {
+			return nil, r.cancelledCreate(ctx, req, relayed)
+		}
+		if r.isDisabled(plugin) {
+			continue
+		}
+		relayed = append(relayed, plugin)
+		req.Owners = result.Owners(req.Container.Id)
+		req.Adjust = result.Adjustment()
+		r.preview.setPlugin(plugin.name())
 		rpl, err := plugin.createContainer(ctx, req)
+		r.preview.setPlugin("")
 		if err != nil {
+			if isCancelled(ctx) {
+				return nil, r.cancelledCreate(ctx, req, relayed)
+			}
 			return nil, err
 		}
-		err = result.apply(rpl, plugin.name())
+		err = result.Apply(rpl, plugin.name())
 		if err != nil {
+			r.recordConflict(err)
+			var rejected *RejectedError
+			if errors.As(err, &rejected) {
+				rejected.Pod = req.GetPod().GetName()
+				rejected.Container = req.GetContainer().GetName()
+			}
 			return nil, err
 		}
 	}
 
-	return result.createContainerResponse(), nil
+	if isCancelled(ctx) {
+		return nil, r.cancelledCreate(ctx, req, relayed)
+	}
+
+	rpl := result.CreateContainerResponse()
+	if err := r.validateAdjustment(ctx, req, rpl, relayed); err != nil {
+		return nil, err
+	}
+	rpl.Update, err = r.interceptUpdates(ctx, &InterceptUpdatesRequest{
+		Event:     Event_CREATE_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+	}, rpl.Update, result.UpdatePlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpl, nil
+}
+
+// CreateContainerRollback relays to plugins that the runtime failed to create
+// a container, after the adjustments of plugins were collected for it. The
+// event should carry the adjustment the runtime got from CreateContainer, and
+// the error it failed with. Plugins can then release any resources they have
+// reserved for the container. The container is forgotten, as if removed.
+func (r *Adaptation) CreateContainerRollback(ctx context.Context, evt *StateChangeEvent) error {
+	evt.Event = Event_CREATE_CONTAINER_ROLLBACK
+	return r.StateChange(ctx, evt)
 }
 
 // PostCreateContainer relays the corresponding CRI event to plugins.
@@ -249,19 +596,62 @@ func (r *Adaptation) UpdateContainer(ctx context.Context, req *UpdateContainerRe
 	defer r.Unlock()
 	defer r.removeClosedPlugins()
 
-	result := collectUpdateContainerResult(req)
-	for _, plugin := range r.plugins {
+	relay, err := r.lifecycle.check(Event_UPDATE_CONTAINER, req.Pod, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if !relay {
+		return &UpdateContainerResponse{}, nil
+	}
+
+	defer r.recordFanOut(Event_UPDATE_CONTAINER, time.Now())
+
+	r.sequenceEvent(req.Container)
+	r.generateEvent(req.Pod, req.Container)
+	fillImage(req.Container)
+	if req.Stats == nil {
+		req.Stats = r.containerStats(req.Container)
+	}
+
+	var relayed []*plugin
+	result := merge.NewUpdateContainerResult(req, r.mergeOptions()...)
+	for _, plugin := range r.pluginsFor(Event_UPDATE_CONTAINER) {
+		if r.isDisabled(plugin) {
+			continue
+		}
+		relayed = append(relayed, plugin)
 		rpl, err := plugin.updateContainer(ctx, req)
 		if err != nil {
 			return nil, err
 		}
-		err = result.apply(rpl, plugin.name())
+		err = result.Apply(rpl, plugin.name())
 		if err != nil {
+			r.recordConflict(err)
 			return nil, err
 		}
 	}
 
-	return result.updateContainerResponse(), nil
+	rpl := result.UpdateContainerResponse()
+	err = r.validateUpdates(ctx, &ValidateContainerUpdatesRequest{
+		Event:     Event_UPDATE_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+		Update:    rpl.GetUpdate(),
+		Plugins:   pluginNames(relayed),
+	})
+	if err != nil {
+		return nil, err
+	}
+	rpl.Update, err = r.interceptUpdates(ctx, &InterceptUpdatesRequest{
+		Event:     Event_UPDATE_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+	}, rpl.Update, result.UpdatePlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpl, nil
 }
 
 // PostUpdateContainer relays the corresponding CRI event to plugins.
@@ -270,28 +660,90 @@ func (r *Adaptation) PostUpdateContainer(ctx context.Context, evt *StateChangeEv
 	return r.StateChange(ctx, evt)
 }
 
-// StopContainer relays the corresponding CRI request to plugins.
+// ContainerDied relays the death of a container, as observed by the runtime,
+// to plugins. The container should carry exit information, including whether
+// it was OOM-killed and the signal which terminated it. Missing information
+// is filled in from earlier events, or marked unknown. The container is then
+// expected to get stopped and removed as usual.
+func (r *Adaptation) ContainerDied(ctx context.Context, evt *StateChangeEvent) error {
+	evt.Event = Event_CONTAINER_DIED
+	return r.StateChange(ctx, evt)
+}
+
+// StopContainer relays the corresponding CRI request to plugins. If the
+// container is stopped, it carries exit information. Missing information is
+// filled in from earlier events, or marked unknown.
 func (r *Adaptation) StopContainer(ctx context.Context, req *StopContainerRequest) (*StopContainerResponse, error) {
 	r.Lock()
 	defer r.Unlock()
 	defer r.removeClosedPlugins()
 
-	result := collectStopContainerResult()
-	for _, plugin := range r.plugins {
+	relay, err := r.lifecycle.check(Event_STOP_CONTAINER, req.Pod, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if !relay {
+		return &StopContainerResponse{}, nil
+	}
+
+	defer r.recordFanOut(Event_STOP_CONTAINER, time.Now())
+
+	r.sequenceEvent(req.Container)
+	r.generateEvent(req.Pod, req.Container)
+	fillImage(req.Container)
+	r.fillExit(Event_STOP_CONTAINER, req.Container)
+	r.cacheEvent(&StateChangeEvent{
+		Event:     Event_STOP_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+	})
+	if req.Stats == nil {
+		req.Stats = r.containerStats(req.Container)
+	}
+
+	var relayed []*plugin
+	result := merge.NewStopContainerResult(r.mergeOptions()...)
+	for _, plugin := range r.pluginsFor(Event_STOP_CONTAINER) {
+		if r.isDisabled(plugin) {
+			continue
+		}
+		relayed = append(relayed, plugin)
 		rpl, err := plugin.stopContainer(ctx, req)
 		if err != nil {
 			return nil, err
 		}
-		err = result.apply(rpl, plugin.name())
+		err = result.Apply(rpl, plugin.name())
 		if err != nil {
+			r.recordConflict(err)
 			return nil, err
 		}
 	}
 
-	return result.stopContainerResponse(), nil
+	rpl := result.StopContainerResponse()
+	err = r.validateUpdates(ctx, &ValidateContainerUpdatesRequest{
+		Event:     Event_STOP_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+		Update:    rpl.GetUpdate(),
+		Plugins:   pluginNames(relayed),
+	})
+	if err != nil {
+		return nil, err
+	}
+	rpl.Update, err = r.interceptUpdates(ctx, &InterceptUpdatesRequest{
+		Event:     Event_STOP_CONTAINER,
+		Pod:       req.Pod,
+		Container: req.Container,
+	}, rpl.Update, result.UpdatePlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpl, nil
 }
 
-// RemoveContainer relays the corresponding CRI event to plugins.
+// RemoveContainer relays the corresponding CRI event to plugins. As with
+// StopContainer, stopped containers carry exit information.
 func (r *Adaptation) RemoveContainer(ctx context.Context, evt *StateChangeEvent) error {
 	evt.Event = Event_REMOVE_CONTAINER
 	return r.StateChange(ctx, evt)
@@ -303,11 +755,61 @@ func (r *Adaptation) StateChange(ctx context.Context, evt *StateChangeEvent) err
 		return errors.New("invalid (unset) event in state change notification")
 	}
 
+	switch evt.Event {
+	case Event_STOP_POD_SANDBOX, Event_REMOVE_POD_SANDBOX:
+		r.CancelCreateContainer(evt.GetPod().GetId())
+	}
+
 	r.Lock()
 	defer r.Unlock()
 	defer r.removeClosedPlugins()
 
-	for _, plugin := range r.plugins {
+	relay, err := r.lifecycle.check(evt.Event, evt.Pod, evt.Container)
+	if err != nil {
+		return err
+	}
+	if !relay {
+		return nil
+	}
+
+	defer r.recordFanOut(evt.Event, time.Now())
+
+	r.sequenceEvent(evt.Container)
+	r.generateEvent(evt.Pod, evt.Container)
+	fillImage(evt.Container)
+	r.fillExit(evt.Event, evt.Container)
+	switch evt.Event {
+	case Event_REMOVE_CONTAINER, Event_CREATE_CONTAINER_ROLLBACK:
+		defer r.forgetSequence(evt.Container)
+		defer r.forgetGeneration(nil, evt.Container)
+	case Event_REMOVE_POD_SANDBOX:
+		defer r.forgetGeneration(evt.Pod, nil)
+	}
+	r.cacheEvent(evt)
+
+	var (
+		async *StateChangeEvent
+		full  []*plugin
+	)
+	defer func() {
+		if len(full) > 0 {
+			waitQueueEvent(ctx, full, async)
+		}
+	}()
+
+	for _, plugin := range r.pluginsFor(evt.Event) {
+		if r.isDisabled(plugin) {
+			continue
+		}
+		if r.isAsyncEvent(plugin, evt.Event) {
+			if async == nil {
+				async = cloneEvent(evt)
+			}
+			if !plugin.queueEvent(ctx, async, false) {
+				full = append(full, plugin)
+			}
+			continue
+		}
 		err := plugin.StateChange(ctx, evt)
 		if err != nil {
 			return err
@@ -317,12 +819,180 @@ func (r *Adaptation) StateChange(ctx context.Context, evt *StateChangeEvent) err
 	return nil
 }
 
+// sequenceEvent assigns the next event sequence number to a container. All
+// events are relayed to plugins with the runtime lock held, so plugins see
+// the events of any single container in sequence number order.
+func (r *Adaptation) sequenceEvent(ctr *Container) {
+	if ctr == nil {
+		return
+	}
+
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	r.eventSeq[ctr.Id]++
+	ctr.EventSequence = r.eventSeq[ctr.Id]
+}
+
+// fillImage fills in missing image information of a container from its
+// annotations, for runtimes which don't provide it.
+func fillImage(ctr *Container) {
+	if ctr == nil || ctr.Image != nil {
+		return
+	}
+	ctr.Image = api.ImageFromAnnotations(ctr.Annotations)
+}
+
+// forgetSequence forgets the event sequence of a removed container.
+func (r *Adaptation) forgetSequence(ctr *Container) {
+	if ctr == nil {
+		return
+	}
+
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	delete(r.eventSeq, ctr.Id)
+}
+
+// sequenceSync sets the last event sequence numbers of containers for sync.
+func (r *Adaptation) sequenceSync(containers []*Container) {
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	for _, ctr := range containers {
+		ctr.EventSequence = r.eventSeq[ctr.Id]
+	}
+}
+
+// cacheCreate caches the pod and container of a container creation request.
+func (r *Adaptation) cacheCreate(req *CreateContainerRequest) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	r.cache.addPod(req.Pod)
+	r.cache.addContainer(req.Container)
+}
+
+// cacheEvent updates the cached pods and containers according to an event.
+func (r *Adaptation) cacheEvent(evt *StateChangeEvent) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	r.cache.update(evt)
+}
+
+// cacheSync resets the cached pods and containers to a sync snapshot.
+func (r *Adaptation) cacheSync(pods []*PodSandbox, containers []*Container) {
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+
+	r.cache.reset(pods, containers)
+}
+
+// generateEvent bumps the generation of the container, or of the pod for pod
+// events, to the next value of the generation counter. The pod of container
+// events carries the generation of its last pod event.
+func (r *Adaptation) generateEvent(pod *PodSandbox, ctr *Container) {
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	if ctr != nil {
+		r.generation++
+		r.ctrGen[ctr.Id] = r.generation
+		ctr.Generation = r.generation
+		if pod != nil {
+			pod.Generation = r.lastGeneration(r.podGen, pod.Id)
+		}
+		return
+	}
+
+	if pod != nil {
+		r.generation++
+		r.podGen[pod.Id] = r.generation
+		pod.Generation = r.generation
+	}
+}
+
+// forgetGeneration forgets the generation of a removed pod or container.
+func (r *Adaptation) forgetGeneration(pod *PodSandbox, ctr *Container) {
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	if pod != nil {
+		delete(r.podGen, pod.Id)
+	}
+	if ctr != nil {
+		delete(r.ctrGen, ctr.Id)
+	}
+}
+
+// generationSync sets the last generations of pods and containers for sync.
+func (r *Adaptation) generationSync(pods []*PodSandbox, containers []*Container) {
+	r.seqLock.Lock()
+	defer r.seqLock.Unlock()
+
+	for _, pod := range pods {
+		pod.Generation = r.lastGeneration(r.podGen, pod.Id)
+	}
+	for _, ctr := range containers {
+		ctr.Generation = r.lastGeneration(r.ctrGen, ctr.Id)
+	}
+}
+
+// lastGeneration returns the last generation of a pod or container, starting
+// a new one for those without any events seen yet.
+func (r *Adaptation) lastGeneration(generations map[string]uint64, id string) uint64 {
+	gen, ok := generations[id]
+	if !ok {
+		r.generation++
+		gen = r.generation
+		generations[id] = gen
+	}
+	return gen
+}
+
 // Perform a set of unsolicited container updates requested by a plugin.
-func (r *Adaptation) updateContainers(ctx context.Context, req []*ContainerUpdate) ([]*ContainerUpdate, error) {
+// Updates with a selector are expanded to updates of the selected containers,
+// then validated.
+func (r *Adaptation) updateContainers(ctx context.Context, p *plugin, req []*ContainerUpdate) ([]*ContainerUpdate, error) {
 	r.Lock()
 	defer r.Unlock()
 
-	return r.updateFn(ctx, req)
+	r.cacheLock.Lock()
+	updates, err := r.cache.expand(req)
+	r.cacheLock.Unlock()
+	if err != nil {
+		return req, err
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	err = r.validateUpdates(ctx, &ValidateContainerUpdatesRequest{
+		Unsolicited: true,
+		Update:      updates,
+		Plugins:     []string{p.name()},
+	})
+	if err != nil {
+		return req, err
+	}
+	updates, err = r.interceptUpdates(ctx, &InterceptUpdatesRequest{
+		Unsolicited: true,
+	}, updates, pluginsOf(p.name()))
+	if err != nil {
+		return req, err
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	if err := r.journal.record(updates); err != nil {
+		return req, err
+	}
+	defer r.journal.clear()
+
+	return r.updateFn(ctx, updates)
 }
 
 // Start up pre-installed plugins.
@@ -331,7 +1001,11 @@ func (r *Adaptation) startPlugins() (retErr error) {
 
 	log.Infof(noCtx, "starting plugins...")
 
-	ids, names, configs, err := r.discoverPlugins()
+	if err := r.journal.load(); err != nil {
+		log.Warnf(noCtx, "ignoring update journal: %v", err)
+	}
+
+	discovered, err := r.discoverPlugins()
 	if err != nil {
 		return err
 	}
@@ -344,22 +1018,44 @@ func (r *Adaptation) startPlugins() (retErr error) {
 		}
 	}()
 
-	for i, name := range names {
+	for _, d := range discovered {
+		name := d.base
 		log.Infof(noCtx, "starting pre-installed NRI plugin %q...", name)
 
-		id := ids[i]
-		p, err := r.newLaunchedPlugin(r.pluginPath, id, name, configs[i])
+		p, err := r.newLaunchedPlugin(d.path, d.idx, d.base, d.cfg)
 		if err != nil {
 			log.Warnf(noCtx, "failed to initialize pre-installed NRI plugin %q: %v", name, err)
 			continue
 		}
 
+		p.manifest = d.manifest
+
 		if err := p.start(r.name, r.version); err != nil {
 			log.Warnf(noCtx, "failed to start pre-installed NRI plugin %q: %v", name, err)
 			continue
 		}
 
+		if err := r.electLeader(p, plugins); err != nil {
+			log.Warnf(noCtx, "failed to elect leader for pre-installed NRI plugin %q: %v", name, err)
+			p.close()
+			p.stop()
+			continue
+		}
+
 		plugins = append(plugins, p)
+
+		for _, sub := range p.subs {
+			if err := sub.start(r.name, r.version); err != nil {
+				log.Warnf(noCtx, "failed to start sub-plugin of pre-installed NRI plugin %q: %v", name, err)
+				continue
+			}
+			if err := r.electLeader(sub, plugins); err != nil {
+				log.Warnf(noCtx, "failed to elect leader for sub-plugin %q: %v", sub.name(), err)
+				sub.close()
+				continue
+			}
+			plugins = append(plugins, sub)
+		}
 	}
 
 	// Although the error returned by syncPlugins may not be nil, r.syncFn could still ignores this error and returns a nil error.
@@ -367,23 +1063,23 @@ func (r *Adaptation) startPlugins() (retErr error) {
 	syncPlugins := func(ctx context.Context, pods []*PodSandbox, containers []*Container) (updates []*ContainerUpdate, err error) {
 		startedPlugins := plugins
 		plugins = make([]*plugin, 0, len(plugins))
-		for _, plugin := range startedPlugins {
-			us, err := plugin.synchronize(ctx, pods, containers)
-			if err != nil {
+		updates, errs := r.synchronizePlugins(ctx, startedPlugins, pods, containers)
+		for i, plugin := range startedPlugins {
+			if err := errs[i]; err != nil {
 				plugin.stop()
 				log.Warnf(noCtx, "failed to synchronize pre-installed NRI plugin %q: %v", plugin.name(), err)
 				continue
 			}
 
 			plugins = append(plugins, plugin)
-			updates = append(updates, us...)
 			log.Infof(noCtx, "pre-installed NRI plugin %q synchronization success", plugin.name())
 		}
-		return updates, nil
+		return append(updates, r.journal.replay(ctx, containers)...), nil
 	}
 	if err := r.syncFn(noCtx, syncPlugins); err != nil {
 		return fmt.Errorf("failed to synchronize pre-installed NRI Plugins: %w", err)
 	}
+	r.journal.clear()
 
 	r.plugins = plugins
 	r.sortPlugins()
@@ -418,6 +1114,60 @@ func (r *Adaptation) removeClosedPlugins() {
 		}()
 	}
 	r.plugins = active
+
+	for _, p := range closed {
+		if p.election && !p.isStandby() {
+			r.promoteStandby(p.name())
+		}
+	}
+}
+
+// electLeader sets the role of a plugin taking part in leader election. The
+// plugin becomes the leader unless another active instance already is one.
+func (r *Adaptation) electLeader(p *plugin, plugins []*plugin) error {
+	if !p.election {
+		return nil
+	}
+
+	leader := true
+	for _, o := range plugins {
+		if o != p && o.isLeaderOf(p.name()) {
+			leader = false
+			break
+		}
+	}
+
+	return p.setLeadership(noCtx, leader)
+}
+
+// promoteStandby promotes a standby plugin instance after its leader is gone.
+func (r *Adaptation) promoteStandby(name string) {
+	var standby *plugin
+	for _, p := range r.plugins {
+		if p.isLeaderOf(name) {
+			return
+		}
+		if standby == nil && p.election && p.name() == name && p.isStandby() {
+			standby = p
+		}
+	}
+
+	if standby == nil {
+		return
+	}
+
+	log.Infof(noCtx, "promoting standby plugin %q to leader", standby.qualifiedName())
+
+	standby.Lock()
+	standby.standby = false
+	standby.Unlock()
+
+	go func() {
+		if err := standby.setLeadership(noCtx, true); err != nil {
+			log.Errorf(noCtx, "failed to notify plugin %q about promotion: %v",
+				standby.qualifiedName(), err)
+		}
+	}()
 }
 
 func (r *Adaptation) startListener() error {
@@ -439,20 +1189,44 @@ func (r *Adaptation) startListener() error {
 		return fmt.Errorf("failed to create socket %q: %w", r.socketPath, err)
 	}
 
-	r.acceptPluginConnections(l)
+	r.listener = l
+	r.acceptPluginConnections(l, ttrpcTransport)
+
+	return r.startGRPCListener()
+}
+
+func (r *Adaptation) startDebugListener() error {
+	if r.debugTrk == nil || r.debugSrv != nil {
+		return nil
+	}
+
+	srv, err := debug.Listen(r.debugAddr, r.debugTrk,
+		debug.WithHandler(ValidateEndpoint, http.HandlerFunc(r.validateHandler)))
+	if err != nil {
+		return err
+	}
+
+	log.Infof(noCtx, "serving debug endpoints on %s", srv.Addr())
+	r.debugSrv = srv
 
 	return nil
 }
 
+func (r *Adaptation) stopDebugListener() {
+	if r.debugSrv != nil {
+		r.debugSrv.Close()
+		r.debugSrv = nil
+	}
+}
+
 func (r *Adaptation) stopListener() {
 	if r.listener != nil {
 		r.listener.Close()
 	}
+	r.stopGRPCListener()
 }
 
-func (r *Adaptation) acceptPluginConnections(l net.Listener) error {
-	r.listener = l
-
+func (r *Adaptation) acceptPluginConnections(l net.Listener, t transport) error {
 	ctx := context.Background()
 	go func() {
 		for {
@@ -462,7 +1236,7 @@ func (r *Adaptation) acceptPluginConnections(l net.Listener) error {
 				return
 			}
 
-			p, err := r.newExternalPlugin(conn)
+			p, err := r.newExternalPlugin(conn, t)
 			if err != nil {
 				log.Errorf(ctx, "failed to create external plugin: %v", err)
 				continue
@@ -473,31 +1247,256 @@ func (r *Adaptation) acceptPluginConnections(l net.Listener) error {
 				continue
 			}
 
-			r.requestPluginSync()
-
-			err = r.syncFn(ctx, p.synchronize)
-			if err != nil {
-				log.Infof(ctx, "failed to synchronize plugin: %v", err)
-			} else {
-				r.Lock()
-				r.plugins = append(r.plugins, p)
-				r.sortPlugins()
-				r.Unlock()
-				log.Infof(ctx, "plugin %q connected and synchronized", p.name())
+			if !r.addExternalPlugin(ctx, p) {
+				continue
 			}
 
-			r.finishedPluginSync()
+			for _, sub := range p.subs {
+				if err := sub.start(r.name, r.version); err != nil {
+					log.Errorf(ctx, "failed to start sub-plugin of plugin %q: %v", p.name(), err)
+					continue
+				}
+				r.addExternalPlugin(ctx, sub)
+			}
 		}
 	}()
 
 	return nil
 }
 
-func (r *Adaptation) discoverPlugins() ([]string, []string, []string, error) {
+// addExternalPlugin adds a started external plugin, either directly if its
+// subscription was restored, or by queuing it for synchronization.
+func (r *Adaptation) addExternalPlugin(ctx context.Context, p *plugin) bool {
+	plugins := r.pendingSyncPlugins()
+	r.Lock()
+	plugins = append(plugins, r.plugins...)
+	r.Unlock()
+
+	if err := r.electLeader(p, plugins); err != nil {
+		log.Errorf(ctx, "failed to elect leader for plugin %q: %v", p.name(), err)
+		p.close()
+		return false
+	}
+
+	if p.restored {
+		r.Lock()
+		r.plugins = append(r.plugins, p)
+		r.sortPlugins()
+		r.Unlock()
+		log.Infof(ctx, "plugin %q reconnected with restored subscription", p.name())
+		return true
+	}
+
+	r.queuePluginSync(ctx, p)
+	return true
+}
+
+// queuePluginSync queues a connected plugin for synchronization. Plugins
+// queued while an earlier synchronization is in progress or blocked are
+// synchronized together, using a single snapshot of the runtime state.
+func (r *Adaptation) queuePluginSync(ctx context.Context, p *plugin) {
+	r.batchLock.Lock()
+	defer r.batchLock.Unlock()
+
+	if b := r.syncQueue; b != nil {
+		b.plugins = append(b.plugins, p)
+		return
+	}
+
+	b := &syncBatch{
+		plugins: []*plugin{p},
+	}
+	r.syncQueue = b
+
+	go r.synchronizeBatch(ctx, b)
+}
+
+// synchronizeBatch synchronizes a batch of queued plugins.
+func (r *Adaptation) synchronizeBatch(ctx context.Context, b *syncBatch) {
+	r.requestPluginSync()
+	defer r.finishedPluginSync()
+
+	r.batchLock.Lock()
+	r.syncQueue = nil
+	r.syncBatch = b
+	r.batchLock.Unlock()
+
+	defer func() {
+		r.batchLock.Lock()
+		r.syncBatch = nil
+		r.batchLock.Unlock()
+	}()
+
+	var synced []*plugin
+
+	err := r.syncFn(ctx, func(ctx context.Context, pods []*PodSandbox, containers []*Container) ([]*ContainerUpdate, error) {
+		updates, errs := r.synchronizePlugins(ctx, b.plugins, pods, containers)
+		for i, p := range b.plugins {
+			if err := errs[i]; err != nil {
+				log.Infof(ctx, "failed to synchronize plugin %q: %v", p.name(), err)
+				p.close()
+				continue
+			}
+			synced = append(synced, p)
+		}
+		return updates, nil
+	})
+	if err != nil {
+		log.Infof(ctx, "failed to synchronize plugins: %v", err)
+		for _, p := range b.plugins {
+			p.close()
+		}
+		return
+	}
+
+	r.Lock()
+	r.plugins = append(r.plugins, synced...)
+	r.sortPlugins()
+	r.Unlock()
+
+	for _, p := range synced {
+		log.Infof(ctx, "plugin %q connected and synchronized", p.name())
+	}
+}
+
+// synchronizePlugins synchronizes the given plugins with the same snapshot
+// of pods and containers, running at most syncLimit of them concurrently.
+// The snapshot is shared by all plugins and must not be modified. Updates
+// are collected in plugin order, errors are returned per plugin.
+func (r *Adaptation) synchronizePlugins(ctx context.Context, plugins []*plugin, pods []*PodSandbox, containers []*Container) ([]*ContainerUpdate, []error) {
+	var (
+		updates = make([][]*ContainerUpdate, len(plugins))
+		errs    = make([]error, len(plugins))
+		limit   = make(chan struct{}, r.syncLimit)
+		wg      sync.WaitGroup
+	)
+
+	r.sequenceSync(containers)
+	r.generationSync(pods, containers)
+	r.cacheSync(pods, containers)
+	r.lifecycle.reset(pods, containers)
+	for _, ctr := range containers {
+		fillImage(ctr)
+	}
+
+	for i, p := range plugins {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, p *plugin) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			updates[i], errs[i] = p.synchronize(ctx, pods, containers)
+			if errs[i] == nil {
+				errs[i] = r.validateUpdates(ctx, &ValidateContainerUpdatesRequest{
+					Update:  updates[i],
+					Plugins: []string{p.name()},
+				})
+				if errs[i] == nil {
+					updates[i], errs[i] = r.interceptUpdates(ctx, &InterceptUpdatesRequest{},
+						updates[i], pluginsOf(p.name()))
+				}
+				if errs[i] != nil {
+					updates[i] = nil
+				}
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	var collected []*ContainerUpdate
+	for _, u := range updates {
+		collected = append(collected, u...)
+	}
+
+	return collected, errs
+}
+
+// pendingSyncPlugins returns the plugins queued or being synchronized.
+func (r *Adaptation) pendingSyncPlugins() []*plugin {
+	r.batchLock.Lock()
+	defer r.batchLock.Unlock()
+
+	var plugins []*plugin
+	for _, b := range []*syncBatch{r.syncBatch, r.syncQueue} {
+		if b != nil {
+			plugins = append(plugins, b.plugins...)
+		}
+	}
+
+	return plugins
+}
+
+// snapshotPlugin saves the subscription of a disconnected external plugin.
+func (r *Adaptation) snapshotPlugin(p *plugin) {
+	if r.graceTime <= 0 || !p.isExternal() || !p.isSynchronized() {
+		return
+	}
+
+	r.snapLock.Lock()
+	defer r.snapLock.Unlock()
+
+	if r.snapshots == nil {
+		r.snapshots = make(map[string]*pluginSnapshot)
+	}
+
+	r.snapshots[p.name()] = &pluginSnapshot{
+		events:    p.events,
+		filter:    p.filter,
+		config:    p.cfg,
+		runAfter:  p.runAfter,
+		runBefore: p.runBefore,
+		closed:    time.Now(),
+	}
+}
+
+// restorePlugin restores the saved subscription of a reconnecting plugin.
+// It returns false if the plugin has no saved subscription or if it has
+// already expired.
+func (r *Adaptation) restorePlugin(p *plugin) bool {
+	r.snapLock.Lock()
+	defer r.snapLock.Unlock()
+
+	for name, snap := range r.snapshots {
+		if time.Since(snap.closed) > r.graceTime {
+			delete(r.snapshots, name)
+		}
+	}
+
+	snap, ok := r.snapshots[p.name()]
+	if !ok {
+		return false
+	}
+	delete(r.snapshots, p.name())
+
+	p.events = snap.events
+	p.filter = snap.filter
+	p.cfg = snap.config
+	p.runAfter = snap.runAfter
+	p.runBefore = snap.runBefore
+	p.restored = true
+	p.markSynchronized()
+
+	return true
+}
+
+// discoveredPlugin is a pre-installed plugin found in the plugin path.
+type discoveredPlugin struct {
+	path     string
+	idx      string
+	base     string
+	cfg      string
+	manifest *PluginManifest
+}
+
+// discoverPlugins discovers pre-installed plugins. The index and name of a
+// plugin are taken from its manifest, if it has one, or its filename.
+func (r *Adaptation) discoverPlugins() ([]*discoveredPlugin, error) {
 	var (
-		plugins []string
-		indices []string
-		configs []string
+		plugins []*discoveredPlugin
+		names   = map[string]string{}
 		entries []os.DirEntry
 		info    fs.FileInfo
 		err     error
@@ -505,14 +1504,14 @@ func (r *Adaptation) discoverPlugins() ([]string, []string, []string, error) {
 
 	if entries, err = os.ReadDir(r.pluginPath); err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil, nil, nil
+			return nil, nil
 		}
-		return nil, nil, nil, fmt.Errorf("failed to discover plugins in %s: %w",
+		return nil, fmt.Errorf("failed to discover plugins in %s: %w",
 			r.pluginPath, err)
 	}
 
 	for _, e := range entries {
-		if e.IsDir() {
+		if e.IsDir() || api.IsPluginManifest(e.Name()) {
 			continue
 		}
 		if info, err = e.Info(); err != nil {
@@ -522,27 +1521,62 @@ func (r *Adaptation) discoverPlugins() ([]string, []string, []string, error) {
 			continue
 		}
 
-		name := e.Name()
-		idx, base, err := api.ParsePluginName(name)
+		d, err := r.discoverPlugin(e.Name())
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to discover plugins in %s: %w",
+			return nil, fmt.Errorf("failed to discover plugins in %s: %w",
 				r.pluginPath, err)
 		}
+		if d == nil {
+			continue
+		}
 
-		cfg, err := r.getPluginConfig(idx, base)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to discover plugins in %s: %w",
-				r.pluginPath, err)
+		name := d.idx + "-" + d.base
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("failed to discover plugins in %s: %s and %s are both plugin %q",
+				r.pluginPath, other, e.Name(), name)
 		}
+		names[name] = e.Name()
 
 		log.Infof(noCtx, "discovered plugin %s", name)
 
-		indices = append(indices, idx)
-		plugins = append(plugins, base)
-		configs = append(configs, cfg)
+		plugins = append(plugins, d)
+	}
+
+	return plugins, nil
+}
+
+// discoverPlugin discovers the pre-installed plugin with the given filename.
+// It returns nil if the plugin requires a newer API version than ours.
+func (r *Adaptation) discoverPlugin(file string) (*discoveredPlugin, error) {
+	d := &discoveredPlugin{
+		path: filepath.Join(r.pluginPath, file),
+	}
+
+	manifest, err := api.FindPluginManifest(d.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest != "" {
+		if d.manifest, err = api.ReadPluginManifest(manifest); err != nil {
+			return nil, err
+		}
+		if err = d.manifest.Supported(api.SchemaVersion); err != nil {
+			log.Warnf(noCtx, "skipping plugin %s: %v", file, err)
+			return nil, nil
+		}
+		d.idx, d.base = d.manifest.Index, d.manifest.Name
+	} else {
+		if d.idx, d.base, err = api.ParsePluginName(file); err != nil {
+			return nil, err
+		}
+	}
+
+	if d.cfg, err = r.getPluginConfig(d.idx, d.base); err != nil {
+		return nil, err
 	}
 
-	return indices, plugins, configs, nil
+	return d, nil
 }
 
 func (r *Adaptation) sortPlugins() {
@@ -550,6 +1584,7 @@ func (r *Adaptation) sortPlugins() {
 	sort.Slice(r.plugins, func(i, j int) bool {
 		return r.plugins[i].idx < r.plugins[j].idx
 	})
+	r.plugins = orderPlugins(r.plugins)
 	if len(r.plugins) > 0 {
 		log.Infof(noCtx, "plugin invocation order")
 		for i, p := range r.plugins {
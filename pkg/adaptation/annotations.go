@@ -0,0 +1,114 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// AnyPlugin names all plugins without an annotation passthrough list
+	// of their own in WithAnnotationPassthrough.
+	AnyPlugin = "*"
+)
+
+// WithAnnotationPassthrough returns an option to restrict the pod and
+// container annotations forwarded to a plugin to the ones with any of the
+// given key prefixes. Without prefixes no annotations are forwarded. The
+// plugin is given by its full (idx-name) or base name, or AnyPlugin to set
+// the restriction for all plugins without one of their own. By default all
+// annotations are forwarded to all plugins.
+func WithAnnotationPassthrough(plugin string, prefixes ...string) Option {
+	return func(r *Adaptation) error {
+		if plugin == "" {
+			return fmt.Errorf("invalid annotation passthrough list, no plugin given")
+		}
+		if r.annoAllow == nil {
+			r.annoAllow = map[string][]string{}
+		}
+		r.annoAllow[plugin] = append([]string{}, prefixes...)
+		return nil
+	}
+}
+
+// annotationPrefixes returns the prefixes of the annotations forwarded to
+// the plugin, and false if all annotations are forwarded.
+func (p *plugin) annotationPrefixes() ([]string, bool) {
+	for _, name := range []string{p.name(), p.base, AnyPlugin} {
+		if prefixes, ok := p.r.annoAllow[name]; ok {
+			return prefixes, true
+		}
+	}
+	return nil, false
+}
+
+// forwardAnnotations returns the pod and container with annotations not
+// forwarded to the plugin removed, and true if any were removed. The given
+// pod and container are not modified.
+func (p *plugin) forwardAnnotations(pod *PodSandbox, ctr *Container) (*PodSandbox, *Container, bool) {
+	prefixes, ok := p.annotationPrefixes()
+	if !ok {
+		return pod, ctr, false
+	}
+
+	stripped := false
+	if pod != nil && !allForwarded(pod.Annotations, prefixes) {
+		pod = proto.Clone(pod).(*PodSandbox)
+		pod.Annotations = forwarded(pod.Annotations, prefixes)
+		stripped = true
+	}
+	if ctr != nil && !allForwarded(ctr.Annotations, prefixes) {
+		ctr = proto.Clone(ctr).(*Container)
+		ctr.Annotations = forwarded(ctr.Annotations, prefixes)
+		stripped = true
+	}
+
+	return pod, ctr, stripped
+}
+
+// allForwarded returns true if all the annotations have a forwarded prefix.
+func allForwarded(annotations map[string]string, prefixes []string) bool {
+	for key := range annotations {
+		if !hasAnyPrefix(key, prefixes) {
+			return false
+		}
+	}
+	return true
+}
+
+// forwarded returns the annotations with a forwarded prefix.
+func forwarded(annotations map[string]string, prefixes []string) map[string]string {
+	kept := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if hasAnyPrefix(key, prefixes) {
+			kept[key] = value
+		}
+	}
+	return kept
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -27,15 +27,25 @@ import (
 // Aliased request/response/event types for api/api.proto.
 // nolint
 type (
-	RegisterPluginRequest    = api.RegisterPluginRequest
-	RegisterPluginResponse   = api.Empty
-	UpdateContainersRequest  = api.UpdateContainersRequest
-	UpdateContainersResponse = api.UpdateContainersResponse
-
-	ConfigureRequest    = api.ConfigureRequest
-	ConfigureResponse   = api.ConfigureResponse
-	SynchronizeRequest  = api.SynchronizeRequest
-	SynchronizeResponse = api.SynchronizeResponse
+	RegisterPluginRequest     = api.RegisterPluginRequest
+	RegisterPluginResponse    = api.RegisterPluginResponse
+	UpdateContainersRequest   = api.UpdateContainersRequest
+	UpdateContainersResponse  = api.UpdateContainersResponse
+	ReportStatusRequest       = api.ReportStatusRequest
+	PreviewAdjustmentRequest  = api.PreviewAdjustmentRequest
+	PreviewAdjustmentResponse = api.PreviewAdjustmentResponse
+	AdjustmentConflict        = api.AdjustmentConflict
+	GetPodsRequest            = api.GetPodsRequest
+	GetPodsResponse           = api.GetPodsResponse
+	GetContainersRequest      = api.GetContainersRequest
+	GetContainersResponse     = api.GetContainersResponse
+	KeepaliveRequest          = api.KeepaliveRequest
+
+	ConfigureRequest         = api.ConfigureRequest
+	ConfigureResponse        = api.ConfigureResponse
+	ReconfigurePluginRequest = api.ReconfigurePluginRequest
+	SynchronizeRequest       = api.SynchronizeRequest
+	SynchronizeResponse      = api.SynchronizeResponse
 
 	CreateContainerRequest  = api.CreateContainerRequest
 	CreateContainerResponse = api.CreateContainerResponse
@@ -45,6 +55,7 @@ type (
 	StopContainerResponse   = api.StopContainerResponse
 
 	StateChangeEvent            = api.StateChangeEvent
+	SetLeadershipRequest        = api.SetLeadershipRequest
 	StateChangeResponse         = api.StateChangeResponse
 	RunPodSandboxRequest        = api.RunPodSandboxRequest
 	StopPodSandboxRequest       = api.StopPodSandboxRequest
@@ -59,6 +70,11 @@ type (
 	PostStartContainerResponse  = api.PostStartContainerResponse
 	PostUpdateContainerRequest  = api.PostUpdateContainerRequest
 	PostUpdateContainerResponse = api.PostUpdateContainerResponse
+	ContainerDiedRequest        = api.ContainerDiedRequest
+	ContainerDiedResponse       = api.ContainerDiedResponse
+
+	CreateContainerRollbackRequest  = api.CreateContainerRollbackRequest
+	CreateContainerRollbackResponse = api.CreateContainerRollbackResponse
 
 	PodSandbox               = api.PodSandbox
 	LinuxPodSandbox          = api.LinuxPodSandbox
@@ -66,12 +82,34 @@ type (
 	ContainerAdjustment      = api.ContainerAdjustment
 	LinuxContainerAdjustment = api.LinuxContainerAdjustment
 	ContainerUpdate          = api.ContainerUpdate
+	ContainerSelector        = api.ContainerSelector
+	ContainerFilter          = api.ContainerFilter
+	ContainerImage           = api.ContainerImage
+	ContainerExit            = api.ContainerExit
+	PodNetwork               = api.PodNetwork
+	PodNetworkInterface      = api.PodNetworkInterface
+	PodNetworkRoute          = api.PodNetworkRoute
+	PodResourceSpec          = api.PodResourceSpec
+	ContainerResourceSpec    = api.ContainerResourceSpec
+	ResourceList             = api.ResourceList
+	QOSClass                 = api.QOSClass
+	Topology                 = api.Topology
+	CPUSet                   = api.CPUSet
 	LinuxContainerUpdate     = api.LinuxContainerUpdate
 	ContainerEviction        = api.ContainerEviction
 	ContainerState           = api.ContainerState
+	ContainerStats           = api.ContainerStats
+	MemoryStats              = api.MemoryStats
+	CPUStats                 = api.CPUStats
+	PSIStats                 = api.PSIStats
+	PSIData                  = api.PSIData
 	KeyValue                 = api.KeyValue
+	EnvFile                  = api.EnvFile
+	User                     = api.User
 	Mount                    = api.Mount
+	LinuxIDMapping           = api.LinuxIDMapping
 	LinuxContainer           = api.LinuxContainer
+	LinuxCapabilities        = api.LinuxCapabilities
 	LinuxNamespace           = api.LinuxNamespace
 	LinuxResources           = api.LinuxResources
 	LinuxCPU                 = api.LinuxCPU
@@ -83,8 +121,26 @@ type (
 	Hooks                    = api.Hooks
 	Hook                     = api.Hook
 	POSIXRlimit              = api.POSIXRlimit
-
-	EventMask = api.EventMask
+	TopologyHints            = api.TopologyHints
+	DeviceTopologyHint       = api.DeviceTopologyHint
+	SchedulingHints          = api.SchedulingHints
+	LinuxNetworkBandwidth    = api.LinuxNetworkBandwidth
+	SMTPolicy                = api.SMTPolicy
+	WindowsContainer         = api.WindowsContainer
+	WindowsHyperV            = api.WindowsHyperV
+	WindowsResources         = api.WindowsResources
+	WindowsMemory            = api.WindowsMemory
+	WindowsCPU               = api.WindowsCPU
+
+	WindowsContainerAdjustment = api.WindowsContainerAdjustment
+
+	RuntimeHandlerCapabilities = api.RuntimeHandlerCapabilities
+
+	PluginManifest = api.PluginManifest
+
+	Event       = api.Event
+	EventMask   = api.EventMask
+	EventFilter = api.EventFilter
 )
 
 // Aliased consts for api/api.proto.
@@ -102,14 +158,22 @@ const (
 	Event_POST_UPDATE_CONTAINER = api.Event_POST_UPDATE_CONTAINER
 	Event_STOP_CONTAINER        = api.Event_STOP_CONTAINER
 	Event_REMOVE_CONTAINER      = api.Event_REMOVE_CONTAINER
+	Event_CONTAINER_DIED        = api.Event_CONTAINER_DIED
 	ValidEvents                 = api.ValidEvents
 
+	Event_CREATE_CONTAINER_ROLLBACK = api.Event_CREATE_CONTAINER_ROLLBACK
+
 	ContainerState_CONTAINER_UNKNOWN = api.ContainerState_CONTAINER_UNKNOWN
 	ContainerState_CONTAINER_CREATED = api.ContainerState_CONTAINER_CREATED
 	ContainerState_CONTAINER_PAUSED  = api.ContainerState_CONTAINER_PAUSED
 	ContainerState_CONTAINER_RUNNING = api.ContainerState_CONTAINER_RUNNING
 	ContainerState_CONTAINER_STOPPED = api.ContainerState_CONTAINER_STOPPED
 	ContainerState_CONTAINER_EXITED  = api.ContainerState_CONTAINER_STOPPED
+
+	QOSClass_QOS_CLASS_UNKNOWN     = api.QOSClass_QOS_CLASS_UNKNOWN
+	QOSClass_QOS_CLASS_GUARANTEED  = api.QOSClass_QOS_CLASS_GUARANTEED
+	QOSClass_QOS_CLASS_BURSTABLE   = api.QOSClass_QOS_CLASS_BURSTABLE
+	QOSClass_QOS_CLASS_BEST_EFFORT = api.QOSClass_QOS_CLASS_BEST_EFFORT
 )
 
 // Aliased types for api/optional.go.
@@ -141,13 +205,26 @@ var (
 // Aliased functions for api/types.go.
 // nolint
 var (
-	FromOCIMounts          = api.FromOCIMounts
-	FromOCIHooks           = api.FromOCIHooks
-	FromOCILinuxNamespaces = api.FromOCILinuxNamespaces
-	FromOCILinuxDevices    = api.FromOCILinuxDevices
-	FromOCILinuxResources  = api.FromOCILinuxResources
-	DupStringSlice         = api.DupStringSlice
-	DupStringMap           = api.DupStringMap
-	IsMarkedForRemoval     = api.IsMarkedForRemoval
-	MarkForRemoval         = api.MarkForRemoval
+	FromOCIMounts             = api.FromOCIMounts
+	FromOCILinuxIDMappings    = api.FromOCILinuxIDMappings
+	FromOCIHooks              = api.FromOCIHooks
+	FromOCILinuxNamespaces    = api.FromOCILinuxNamespaces
+	FromOCILinuxDevices       = api.FromOCILinuxDevices
+	FromOCILinuxResources     = api.FromOCILinuxResources
+	FromOCIRuntimeDefaults    = api.FromOCIRuntimeDefaults
+	PodQOSClass               = api.PodQOSClass
+	QOSClassFromCgroupParent  = api.QOSClassFromCgroupParent
+	FromCRIContainerResources = api.FromCRIContainerResources
+	HostTopology              = api.HostTopology
+	ReadTopology              = api.ReadTopology
+	ParseCPUSet               = api.ParseCPUSet
+	NormalizeHugepageSize     = api.NormalizeHugepageSize
+	HostHugepageSizes         = api.HostHugepageSizes
+	ReadHugepageSizes         = api.ReadHugepageSizes
+	ReadContainerStats        = api.ReadContainerStats
+	DupStringSlice            = api.DupStringSlice
+	DupStringMap              = api.DupStringMap
+	IsMarkedForRemoval        = api.IsMarkedForRemoval
+	MarkForRemoval            = api.MarkForRemoval
+	StripRemovalMarker        = api.StripRemovalMarker
 )
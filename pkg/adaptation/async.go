@@ -0,0 +1,354 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// EventQueueRecorder records metrics about delivering events asynchronously
+// to plugins. A MetricsRecorder passed to WithMetricsRecorder can optionally
+// implement it to observe backpressure from slow plugins.
+type EventQueueRecorder interface {
+	// EventQueued records queuing an event for a plugin, with the depth of
+	// the queue of the plugin after queuing it and the time spent waiting
+	// for room in a full queue.
+	EventQueued(plugin string, event Event, depth int, blocked time.Duration)
+	// EventDropped records dropping an event for a plugin because there was
+	// no room in its queue before the runtime operation was done.
+	EventDropped(plugin string, event Event)
+}
+
+// EventQueueDepthRecorder records the depth of the event queues of plugins.
+// A MetricsRecorder passed to WithMetricsRecorder can optionally implement
+// it to track queue depths, for instance as a gauge per plugin. The depth
+// is recorded whenever it changes.
+type EventQueueDepthRecorder interface {
+	EventQueueDepth(plugin string, depth int)
+}
+
+// WithAsyncEventDelivery returns an option to deliver events which do not
+// affect the outcome of runtime operations, PostCreateContainer,
+// PostStartContainer and PostUpdateContainer, asynchronously to plugins.
+// Events are queued to a bounded queue of the given size per plugin and
+// delivered in order. Relaying an event blocks while the queue of a plugin
+// is full. Other events and requests to the plugin wait for its queue to
+// drain, so plugins still see all events in order. PostCreateContainer is
+// always delivered synchronously to plugins allowed to veto container starts.
+func WithAsyncEventDelivery(queueSize int) Option {
+	return func(r *Adaptation) error {
+		if queueSize <= 0 {
+			return fmt.Errorf("invalid async event queue size %d", queueSize)
+		}
+		r.asyncQueue = queueSize
+		return nil
+	}
+}
+
+// WithPluginEventQueueSize returns an option to override the size of the
+// event queue of a plugin, by full (idx-name) or base name. It also enables
+// asynchronous event delivery for the plugin if WithAsyncEventDelivery is
+// not used. This lets runtimes give more room to plugins known to handle
+// events in bursts, without raising the memory bound for all plugins.
+func WithPluginEventQueueSize(plugin string, queueSize int) Option {
+	return func(r *Adaptation) error {
+		if plugin == "" {
+			return fmt.Errorf("invalid (empty) plugin name for event queue size")
+		}
+		if queueSize <= 0 {
+			return fmt.Errorf("invalid event queue size %d for plugin %q", queueSize, plugin)
+		}
+		if r.queueSizes == nil {
+			r.queueSizes = map[string]int{}
+		}
+		r.queueSizes[plugin] = queueSize
+		return nil
+	}
+}
+
+// queueSize returns the size of the event queue of the plugin, or 0 if
+// events are delivered synchronously to it.
+func (r *Adaptation) queueSize(p *plugin) int {
+	if size, ok := r.queueSizes[p.name()]; ok {
+		return size
+	}
+	if size, ok := r.queueSizes[p.base]; ok {
+		return size
+	}
+	return r.asyncQueue
+}
+
+// EventQueueDepths returns the number of events queued for, or being
+// delivered to, each plugin with an event queue, by plugin name. It does
+// not wait for events being relayed, so it can be used to find plugins
+// holding up event delivery.
+func (r *Adaptation) EventQueueDepths() map[string]int {
+	r.queueLock.Lock()
+	defer r.queueLock.Unlock()
+
+	depths := map[string]int{}
+	for q := range r.evtQueues {
+		depths[q.p.name()] += int(q.depth.Load())
+	}
+
+	return depths
+}
+
+// isAsyncEvent returns true if the event is delivered asynchronously to the
+// plugin.
+func (r *Adaptation) isAsyncEvent(p *plugin, event Event) bool {
+	if r.queueSize(p) == 0 {
+		return false
+	}
+	switch event {
+	case Event_POST_CREATE_CONTAINER:
+		return !p.isAnyOf(r.vetoers)
+	case Event_POST_START_CONTAINER, Event_POST_UPDATE_CONTAINER:
+		return true
+	}
+	return false
+}
+
+// eventQueue delivers events asynchronously, in order, to a plugin.
+type eventQueue struct {
+	p      *plugin
+	events chan *queuedEvent
+	// events queued or being delivered
+	depth atomic.Int32
+	stopC chan struct{}
+}
+
+// queuedEvent is an event to deliver, or a barrier to close once all events
+// queued before it have been delivered.
+type queuedEvent struct {
+	evt   *StateChangeEvent
+	doneC chan struct{}
+}
+
+// cloneEvent returns a copy of an event for asynchronous delivery, which the
+// runtime is free to modify once the event has been relayed.
+func cloneEvent(evt *StateChangeEvent) *StateChangeEvent {
+	c := proto.Clone(evt).(*StateChangeEvent)
+	// there is no runtime operation waiting for the event to be handled
+	c.TimeBudget = 0
+	return c
+}
+
+// queue returns the event queue of the plugin, creating and starting it if
+// necessary. It returns nil for closed plugins.
+func (p *plugin) queue() *eventQueue {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	if p.evtQueue == nil {
+		p.evtQueue = &eventQueue{
+			p:      p,
+			events: make(chan *queuedEvent, p.r.queueSize(p)),
+			stopC:  make(chan struct{}),
+		}
+		p.r.queueLock.Lock()
+		if p.r.evtQueues == nil {
+			p.r.evtQueues = map[*eventQueue]struct{}{}
+		}
+		p.r.evtQueues[p.evtQueue] = struct{}{}
+		p.r.queueLock.Unlock()
+		go p.evtQueue.run()
+	}
+
+	return p.evtQueue
+}
+
+// queueEvent queues an event for asynchronous delivery to the plugin. If
+// the queue of the plugin is full, queueEvent waits for room if wait is set
+// and otherwise returns false without queuing the event.
+func (p *plugin) queueEvent(ctx context.Context, evt *StateChangeEvent, wait bool) bool {
+	if !p.events.IsSet(evt.Event) || p.isFiltered(evt.Pod) {
+		return true
+	}
+
+	q := p.queue()
+	if q == nil {
+		return true
+	}
+
+	var (
+		e       = &queuedEvent{evt: evt}
+		start   = time.Now()
+		blocked time.Duration
+	)
+
+	q.depth.Add(1)
+	select {
+	case q.events <- e:
+	default:
+		if !wait {
+			q.depth.Add(-1)
+			return false
+		}
+		log.Warnf(ctx, "event queue of plugin %s is full, waiting to queue %s",
+			p.name(), evt.Event)
+		select {
+		case q.events <- e:
+			blocked = time.Since(start)
+		case <-ctx.Done():
+			q.depth.Add(-1)
+			log.Errorf(ctx, "dropping %s for plugin %s: %v", evt.Event, p.name(), ctx.Err())
+			if m, ok := p.r.metrics.(EventQueueRecorder); ok {
+				m.EventDropped(p.name(), evt.Event)
+			}
+			return true
+		case <-q.stopC:
+			q.depth.Add(-1)
+			return true
+		}
+	}
+
+	depth := int(q.depth.Load())
+	if m, ok := p.r.metrics.(EventQueueRecorder); ok {
+		m.EventQueued(p.name(), evt.Event, depth, blocked)
+	}
+	q.recordDepth(depth)
+
+	return true
+}
+
+// waitQueueEvent queues an event for asynchronous delivery to plugins which
+// had no room in their queue when the event was relayed. It waits for room
+// in the queues in parallel, once the event has been relayed to all other
+// plugins, so a plugin slow to handle a burst of events does not hold up
+// delivering the event to the other plugins.
+func waitQueueEvent(ctx context.Context, plugins []*plugin, evt *StateChangeEvent) {
+	if len(plugins) == 1 {
+		plugins[0].queueEvent(ctx, evt, true)
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	for _, p := range plugins {
+		wg.Add(1)
+		go func(p *plugin) {
+			defer wg.Done()
+			p.queueEvent(ctx, evt, true)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// recordDepth records the depth of the queue.
+func (q *eventQueue) recordDepth(depth int) {
+	if m, ok := q.p.r.metrics.(EventQueueDepthRecorder); ok {
+		m.EventQueueDepth(q.p.name(), depth)
+	}
+}
+
+// drainEvents waits until all events queued for the plugin are delivered.
+func (p *plugin) drainEvents(ctx context.Context) error {
+	p.Lock()
+	q := p.evtQueue
+	p.Unlock()
+
+	if q == nil || q.depth.Load() == 0 {
+		return nil
+	}
+
+	doneC := make(chan struct{})
+	select {
+	case q.events <- &queuedEvent{doneC: doneC}:
+	case <-q.stopC:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain event queue of plugin %s: %w", p.name(), ctx.Err())
+	}
+
+	select {
+	case <-doneC:
+	case <-q.stopC:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain event queue of plugin %s: %w", p.name(), ctx.Err())
+	}
+
+	return nil
+}
+
+// stop stops delivering queued events.
+func (q *eventQueue) stop() {
+	close(q.stopC)
+
+	q.p.r.queueLock.Lock()
+	delete(q.p.r.evtQueues, q)
+	q.p.r.queueLock.Unlock()
+}
+
+// run delivers queued events until the queue is stopped.
+func (q *eventQueue) run() {
+	for {
+		select {
+		case <-q.stopC:
+			return
+		case e := <-q.events:
+			if e.doneC != nil {
+				close(e.doneC)
+				continue
+			}
+			q.deliver(e.evt)
+			q.recordDepth(int(q.depth.Add(-1)))
+		}
+	}
+}
+
+// deliver delivers a single event to the plugin.
+func (q *eventQueue) deliver(evt *StateChangeEvent) {
+	p := q.p
+	if p.isClosed() {
+		return
+	}
+
+	evt = p.forwardEvent(evt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.requestTimeout(evt.Event))
+	defer cancel()
+
+	start := time.Now()
+	err := p.impl.StateChange(ctx, evt)
+	p.recordRequest(ctx, evt.Event, start, err)
+	if err == nil {
+		return
+	}
+
+	switch _, veto := api.IsVeto(err); {
+	case veto:
+		log.Warnf(ctx, "ignoring veto of plugin %s in asynchronously delivered event %s",
+			p.name(), evt.Event)
+	case isFatalError(err):
+		log.Errorf(ctx, "closing plugin %s, failed to handle event %s: %v",
+			p.name(), evt.Event, err)
+		p.close()
+	default:
+		log.Errorf(ctx, "plugin %s failed to handle event %s: %v", p.name(), evt.Event, err)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// PluginPeer describes an external plugin registering with the runtime.
+type PluginPeer struct {
+	// Name is the base name the plugin registers with.
+	Name string
+	// Index is the index the plugin registers with.
+	Index string
+	// Pid, Uid and Gid are the process, user and group id of the process
+	// at the other end of the plugin connection, as reported by the kernel
+	// (SO_PEERCRED). Uid and Gid are -1 if they could not be determined.
+	Pid int
+	Uid int
+	Gid int
+	// Token is the token presented by the plugin, if any.
+	Token string
+}
+
+// PluginAuthorizer decides whether an external plugin may register. It
+// returns an error if the plugin is not allowed to register.
+type PluginAuthorizer func(context.Context, *PluginPeer) error
+
+// WithPluginAuthorizer returns an option to authorize external plugins when
+// they register. By default any process with access to the NRI socket can
+// attach a plugin. The authorizer gets the credentials of the process at the
+// other end of the connection, the name and index the plugin registers with,
+// and the token it presents. Plugins the authorizer rejects fail to register.
+// Plugins launched by the runtime and sub-plugins, which share the connection
+// of their host, are not authorized.
+func WithPluginAuthorizer(authorizer PluginAuthorizer) Option {
+	return func(r *Adaptation) error {
+		if authorizer == nil {
+			return fmt.Errorf("invalid (nil) plugin authorizer")
+		}
+		r.authorizer = authorizer
+		return nil
+	}
+}
+
+// TokenAuthorizer returns a PluginAuthorizer which allows plugins presenting
+// any of the given tokens to register. Tokens are compared in constant time.
+func TokenAuthorizer(tokens ...string) PluginAuthorizer {
+	return func(_ context.Context, peer *PluginPeer) error {
+		if peer.Token == "" {
+			return errors.New("missing token")
+		}
+		for _, token := range tokens {
+			if subtle.ConstantTimeCompare([]byte(peer.Token), []byte(token)) == 1 {
+				return nil
+			}
+		}
+		return errors.New("invalid token")
+	}
+}
+
+// authorize authorizes an external plugin to register, if necessary.
+func (p *plugin) authorize(ctx context.Context, token string) error {
+	if p.r.authorizer == nil || !p.isExternal() || p.host != nil {
+		return nil
+	}
+
+	peer := &PluginPeer{
+		Name:  p.base,
+		Index: p.idx,
+		Pid:   p.pid,
+		Uid:   p.uid,
+		Gid:   p.gid,
+		Token: token,
+	}
+	if err := p.r.authorizer(ctx, peer); err != nil {
+		return fmt.Errorf("plugin %s (pid %d, uid %d, gid %d) not authorized: %w",
+			p.name(), p.pid, p.uid, p.gid, err)
+	}
+
+	log.Infof(ctx, "authorized plugin %q (pid %d, uid %d, gid %d)", p.name(), p.pid, p.uid, p.gid)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// stateCache tracks the pods and containers known to the runtime, as seen
+// in synchronization and in relayed events. It is used to expand selectors
+// of unsolicited container updates to concrete containers, and to answer
+// plugin queries for the current pods and containers.
+type stateCache struct {
+	pods map[string]*PodSandbox
+	ctrs map[string]*Container
+}
+
+func newStateCache() *stateCache {
+	return &stateCache{
+		pods: make(map[string]*PodSandbox),
+		ctrs: make(map[string]*Container),
+	}
+}
+
+// reset the cache to the given runtime snapshot.
+func (c *stateCache) reset(pods []*PodSandbox, containers []*Container) {
+	c.pods = make(map[string]*PodSandbox, len(pods))
+	c.ctrs = make(map[string]*Container, len(containers))
+	for _, pod := range pods {
+		c.addPod(pod)
+	}
+	for _, ctr := range containers {
+		c.addContainer(ctr)
+	}
+}
+
+// addPod caches a copy of the pod, which the runtime is free to modify.
+func (c *stateCache) addPod(pod *PodSandbox) {
+	if pod == nil || pod.Id == "" {
+		return
+	}
+	c.pods[pod.Id] = proto.Clone(pod).(*PodSandbox)
+}
+
+// addContainer caches a copy of the container, which the runtime is free
+// to modify.
+func (c *stateCache) addContainer(ctr *Container) {
+	if ctr == nil || ctr.Id == "" {
+		return
+	}
+	c.ctrs[ctr.Id] = proto.Clone(ctr).(*Container)
+}
+
+func (c *stateCache) removePod(pod *PodSandbox) {
+	if pod == nil {
+		return
+	}
+	delete(c.pods, pod.Id)
+	for id, ctr := range c.ctrs {
+		if ctr.PodSandboxId == pod.Id {
+			delete(c.ctrs, id)
+		}
+	}
+}
+
+func (c *stateCache) removeContainer(ctr *Container) {
+	if ctr == nil {
+		return
+	}
+	delete(c.ctrs, ctr.Id)
+}
+
+// update the cache according to a relayed event.
+func (c *stateCache) update(evt *StateChangeEvent) {
+	switch evt.Event {
+	case Event_RUN_POD_SANDBOX:
+		c.addPod(evt.Pod)
+	case Event_REMOVE_POD_SANDBOX:
+		c.removePod(evt.Pod)
+	case Event_REMOVE_CONTAINER, Event_CREATE_CONTAINER_ROLLBACK:
+		c.removeContainer(evt.Container)
+	default:
+		c.addPod(evt.Pod)
+		c.addContainer(evt.Container)
+	}
+}
+
+// expand updates with selectors to updates of the selected containers.
+func (c *stateCache) expand(updates []*ContainerUpdate) ([]*ContainerUpdate, error) {
+	expanded := make([]*ContainerUpdate, 0, len(updates))
+	for _, u := range updates {
+		sel := u.GetSelector()
+		if sel == nil {
+			expanded = append(expanded, u)
+			continue
+		}
+		if u.ContainerId != "" {
+			return nil, fmt.Errorf("invalid update of container %q, both ID and selector set",
+				u.ContainerId)
+		}
+		if sel.PodUid == "" && len(sel.Labels) == 0 {
+			return nil, fmt.Errorf("invalid container update, empty selector")
+		}
+
+		ids := make([]string, 0)
+		for id, ctr := range c.ctrs {
+			pod, ok := c.pods[ctr.PodSandboxId]
+			if !ok || !sel.Matches(pod.Uid, pod.Labels) {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			cu := proto.Clone(u).(*ContainerUpdate)
+			cu.ContainerId = id
+			cu.Selector = nil
+			expanded = append(expanded, cu)
+		}
+	}
+	return expanded, nil
+}
+
+// getPods returns copies of the cached pods, sorted by ID.
+func (c *stateCache) getPods() []*PodSandbox {
+	pods := make([]*PodSandbox, 0, len(c.pods))
+	for _, pod := range c.pods {
+		pods = append(pods, proto.Clone(pod).(*PodSandbox))
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Id < pods[j].Id })
+	return pods
+}
+
+// getContainers returns copies of the cached containers matching the filter,
+// sorted by ID.
+func (c *stateCache) getContainers(filter *ContainerFilter) []*Container {
+	ctrs := make([]*Container, 0, len(c.ctrs))
+	for _, ctr := range c.ctrs {
+		if filter.Matches(c.pods[ctr.PodSandboxId], ctr) {
+			ctrs = append(ctrs, proto.Clone(ctr).(*Container))
+		}
+	}
+	sort.Slice(ctrs, func(i, j int) bool { return ctrs[i].Id < ctrs[j].Id })
+	return ctrs
+}
@@ -0,0 +1,137 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrCreateCancelled is returned by CreateContainer if the creation was
+	// cancelled because the pod of the container was stopped or removed.
+	ErrCreateCancelled = errors.New("container creation cancelled")
+)
+
+// createDispatch is the container creation being relayed to plugins, which
+// is cancelled if its pod is stopped or removed in the meantime.
+type createDispatch struct {
+	sync.Mutex
+	pod    string
+	cancel context.CancelCauseFunc
+}
+
+// start tracking the creation of a container in the given pod. It returns
+// the context to relay the creation with, and a function to stop tracking.
+func (d *createDispatch) start(ctx context.Context, pod string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	d.Lock()
+	d.pod = pod
+	d.cancel = cancel
+	d.Unlock()
+
+	return ctx, func() {
+		d.Lock()
+		d.pod = ""
+		d.cancel = nil
+		d.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancelPod cancels the creation in progress if it is in the given pod.
+func (d *createDispatch) cancelPod(pod string) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.cancel == nil || pod == "" || d.pod != pod {
+		return false
+	}
+
+	d.cancel(ErrCreateCancelled)
+	return true
+}
+
+// isCancelled returns true if the creation relayed with the context has
+// been cancelled.
+func isCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrCreateCancelled)
+}
+
+// CancelCreateContainer cancels relaying the creation of a container in the
+// given pod to plugins, if one is in progress. Plugins yet to handle the
+// creation won't get it, and CreateContainer fails with ErrCreateCancelled.
+// NRI calls this when a pod is stopped or removed, runtimes can call it
+// earlier once they decide to tear down a pod. It returns true if a creation
+// was cancelled.
+func (r *Adaptation) CancelCreateContainer(podID string) bool {
+	if !r.creating.cancelPod(podID) {
+		return false
+	}
+	log.Infof(noCtx, "cancelled container creation in pod %s", podID)
+	return true
+}
+
+// cancelledCreate notifies the given plugins, which have already been
+// relayed the creation of the container, that it has been cancelled. They
+// get a RemoveContainer event with the container marked cancelled, even if
+// they are not subscribed to RemoveContainer events, so plugins handling
+// only creations can release what they allocated. The container is then
+// forgotten, as if the runtime had removed it. The caller must hold the lock.
+func (r *Adaptation) cancelledCreate(ctx context.Context, req *CreateContainerRequest, plugins []*plugin) error {
+	ctx = context.WithoutCancel(ctx)
+
+	ctr := proto.Clone(req.Container).(*Container)
+	ctr.SetExit(&api.ContainerExit{
+		Code:    api.UnknownExitCode,
+		Reason:  api.ExitReasonCancelled,
+		Message: "pod of the container stopped or removed during creation",
+	})
+
+	evt := &StateChangeEvent{
+		Event:     Event_REMOVE_CONTAINER,
+		Pod:       req.Pod,
+		Container: ctr,
+	}
+
+	if relay, _ := r.lifecycle.check(evt.Event, evt.Pod, evt.Container); relay {
+		r.sequenceEvent(evt.Container)
+		r.generateEvent(evt.Pod, evt.Container)
+		for _, plugin := range plugins {
+			if r.isDisabled(plugin) {
+				continue
+			}
+			if err := plugin.stateChange(ctx, evt); err != nil {
+				log.Warnf(ctx, "failed to notify plugin %s of cancelled creation of container %s: %v",
+					plugin.name(), ctr.Id, err)
+			}
+		}
+	}
+
+	r.forgetSequence(evt.Container)
+	r.forgetGeneration(nil, evt.Container)
+	r.cacheEvent(evt)
+
+	return fmt.Errorf("failed to create container %s: %w", ctr.Id, ErrCreateCancelled)
+}
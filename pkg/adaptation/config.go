@@ -0,0 +1,172 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// DefaultPluginConfigWatchInterval is the default interval for checking
+	// the configuration files of plugins for changes.
+	DefaultPluginConfigWatchInterval = 2 * time.Second
+)
+
+// WithPluginConfigWatch returns an option to check the configuration files
+// of NRI-launched plugins in the plugin config path for changes, with the
+// given interval. Plugins are reconfigured with any changed configuration
+// without a reconnect. Where the plugin config path is watched using
+// inotify, changes are also picked up as soon as they are made. If the
+// interval is 0, DefaultPluginConfigWatchInterval is used.
+func WithPluginConfigWatch(interval time.Duration) Option {
+	return func(r *Adaptation) error {
+		if interval < 0 {
+			return fmt.Errorf("invalid plugin configuration watch interval %s", interval)
+		}
+		if interval == 0 {
+			interval = DefaultPluginConfigWatchInterval
+		}
+		r.configIntv = interval
+		return nil
+	}
+}
+
+// ReconfigurePlugin passes updated configuration to the plugin with the
+// given name, without reconnecting it.
+func (r *Adaptation) ReconfigurePlugin(ctx context.Context, name, config string) error {
+	var plugin *plugin
+
+	r.Lock()
+	for _, p := range r.plugins {
+		if p.name() == name && !p.isClosed() {
+			plugin = p
+			break
+		}
+	}
+	r.Unlock()
+
+	if plugin == nil {
+		return fmt.Errorf("failed to reconfigure plugin %q: plugin not found", name)
+	}
+
+	return plugin.reconfigure(ctx, config)
+}
+
+// startConfigWatch starts checking plugin configuration files for changes.
+func (r *Adaptation) startConfigWatch() {
+	if r.configIntv == 0 || r.configStop != nil {
+		return
+	}
+
+	stopC := make(chan struct{})
+	r.configStop = stopC
+
+	go func() {
+		ticker := time.NewTicker(r.configIntv)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				r.checkPluginConfigs()
+			case <-r.configKick:
+				r.checkPluginConfigs()
+			}
+		}
+	}()
+}
+
+// stopConfigWatch stops checking plugin configuration files for changes.
+func (r *Adaptation) stopConfigWatch() {
+	if r.configStop != nil {
+		close(r.configStop)
+		r.configStop = nil
+	}
+}
+
+// kickConfigWatch makes the configuration watch check plugin configuration
+// files for changes right away.
+func (r *Adaptation) kickConfigWatch() {
+	select {
+	case r.configKick <- struct{}{}:
+	default:
+	}
+}
+
+// checkPluginConfigs reconfigures NRI-launched plugins with changed
+// configuration files.
+func (r *Adaptation) checkPluginConfigs() {
+	r.Lock()
+	plugins := append([]*plugin{}, r.plugins...)
+	r.Unlock()
+
+	for _, p := range plugins {
+		if p.isClosed() || (p.isExternal() && !p.impl.isWasm()) {
+			continue
+		}
+
+		cfg, err := r.getPluginConfig(p.idx, p.base)
+		if err != nil {
+			log.Warnf(noCtx, "failed to check configuration of plugin %q: %v", p.name(), err)
+			continue
+		}
+
+		p.Lock()
+		unchanged := cfg == p.cfg || cfg == p.badCfg
+		p.Unlock()
+
+		if unchanged {
+			continue
+		}
+
+		log.Infof(noCtx, "configuration of plugin %q changed, reconfiguring it", p.name())
+
+		if err := p.reconfigure(noCtx, cfg); err != nil {
+			log.Warnf(noCtx, "%v", err)
+		}
+	}
+}
+
+// reconfigure the plugin with the given configuration.
+func (p *plugin) reconfigure(ctx context.Context, config string) error {
+	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	defer cancel()
+
+	req := &ReconfigurePluginRequest{
+		Config: config,
+	}
+
+	err := p.impl.ReconfigurePlugin(ctx, req)
+
+	p.Lock()
+	defer p.Unlock()
+
+	if err != nil {
+		p.badCfg = config
+		return fmt.Errorf("failed to reconfigure plugin %q: %w", p.name(), err)
+	}
+
+	p.cfg = config
+	p.badCfg = ""
+
+	return nil
+}
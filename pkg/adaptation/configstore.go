@@ -0,0 +1,196 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// pluginConfigSuffix is the suffix of plugin configuration drop-in files.
+	pluginConfigSuffix = ".conf"
+)
+
+// PluginConfigChangeFn is notified when the configuration drop-in file of a
+// plugin changes in the plugin config path. The name is that of the drop-in
+// file without the .conf suffix, <idx>-<name> or <name>. It is empty if the
+// configuration of any plugin might have changed.
+type PluginConfigChangeFn func(ctx context.Context, name string)
+
+// WithPluginConfigChangeHook returns an option to notify the given function
+// about changes of plugin configuration drop-in files. Changes are detected
+// using inotify where available. Elsewhere, or if the plugin config path
+// can't be watched, the function is never called.
+func WithPluginConfigChangeHook(fn PluginConfigChangeFn) Option {
+	return func(r *Adaptation) error {
+		r.configFn = fn
+		return nil
+	}
+}
+
+// configStore caches drop-in files of the plugin config path, so plugins can
+// be (re)configured without reading them over and over again. Cached files
+// are invalidated once inotify reports a change. If the config path can't
+// be watched, files are read on every access.
+type configStore struct {
+	sync.Mutex
+	dir     string
+	files   map[string]*cachedFile
+	gen     uint64
+	watcher io.Closer
+	changed func(name string)
+}
+
+// cachedFile is the content of a cached file, or the error reading it.
+type cachedFile struct {
+	data []byte
+	err  error
+}
+
+func newConfigStore(dir string, changed func(name string)) *configStore {
+	return &configStore{
+		dir:     filepath.Clean(dir),
+		changed: changed,
+	}
+}
+
+// start watching the config path and caching files.
+func (s *configStore) start() {
+	if s == nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.watcher != nil {
+		return
+	}
+
+	w, err := watchConfigDir(s.dir, s.invalidate, s.unwatched)
+	if err != nil {
+		log.Infof(noCtx, "not caching plugin configuration, failed to watch %s: %v", s.dir, err)
+		return
+	}
+
+	s.watcher = w
+	s.files = map[string]*cachedFile{}
+}
+
+// stop watching the config path and drop all cached files.
+func (s *configStore) stop() {
+	if s == nil {
+		return
+	}
+
+	s.Lock()
+	w := s.watcher
+	s.watcher = nil
+	s.files = nil
+	s.gen++
+	s.Unlock()
+
+	if w != nil {
+		w.Close()
+	}
+}
+
+// readFile reads the file with the given path, like os.ReadFile, from the
+// cache if possible.
+func (s *configStore) readFile(path string) ([]byte, error) {
+	if s == nil || filepath.Dir(path) != s.dir {
+		return os.ReadFile(path)
+	}
+
+	s.Lock()
+	if f, ok := s.files[path]; ok {
+		s.Unlock()
+		return f.data, f.err
+	}
+	cache, gen := s.files != nil, s.gen
+	s.Unlock()
+
+	data, err := os.ReadFile(path)
+	if !cache || (err != nil && !os.IsNotExist(err)) {
+		return data, err
+	}
+
+	s.Lock()
+	// don't cache content which might have been invalidated while read
+	if s.files != nil && s.gen == gen {
+		s.files[path] = &cachedFile{data: data, err: err}
+	}
+	s.Unlock()
+
+	return data, err
+}
+
+// invalidate the cached file with the given name, or all cached files if
+// the name is empty, then notify about configuration changes.
+func (s *configStore) invalidate(name string) {
+	s.Lock()
+	s.gen++
+	if s.files != nil {
+		if name == "" {
+			s.files = map[string]*cachedFile{}
+		} else {
+			delete(s.files, filepath.Join(s.dir, name))
+		}
+	}
+	s.Unlock()
+
+	if s.changed == nil {
+		return
+	}
+
+	switch {
+	case name == "":
+		s.changed("")
+	case strings.HasSuffix(name, pluginConfigSuffix):
+		s.changed(strings.TrimSuffix(name, pluginConfigSuffix))
+	}
+}
+
+// unwatched stops caching once the config path is no longer watched, for
+// instance because it was removed.
+func (s *configStore) unwatched() {
+	log.Warnf(noCtx, "stopped watching %s, not caching plugin configuration", s.dir)
+
+	s.Lock()
+	s.files = nil
+	s.gen++
+	s.Unlock()
+
+	if s.changed != nil {
+		s.changed("")
+	}
+}
+
+// pluginConfigChanged handles changes of plugin configuration drop-in files.
+func (r *Adaptation) pluginConfigChanged(name string) {
+	if r.configFn != nil {
+		r.configFn(noCtx, name)
+	}
+	r.kickConfigWatch()
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// inotify events which invalidate files in the config path
+	configWatchMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY |
+		unix.IN_CLOSE_WRITE | unix.IN_ATTRIB | unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+		unix.IN_DELETE_SELF | unix.IN_MOVE_SELF
+)
+
+// watchConfigDir watches the given directory using inotify, calling changed
+// with the name of each file changed in it, or with an empty name if any of
+// them might have changed. Once the directory is no longer watched, for
+// instance because it was removed, lost is called.
+func watchConfigDir(dir string, changed func(name string), lost func()) (io.Closer, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inotify instance: %w", err)
+	}
+
+	if _, err := unix.InotifyAddWatch(fd, dir, configWatchMask); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to add inotify watch: %w", err)
+	}
+
+	// a non-blocking fd is handled by the runtime poller, so closing the
+	// file unblocks any pending read
+	f := os.NewFile(uintptr(fd), "inotify")
+
+	go readConfigEvents(f, changed, lost)
+
+	return f, nil
+}
+
+// readConfigEvents reads inotify events until the file is closed or the
+// watch is removed.
+func readConfigEvents(f *os.File, changed func(name string), lost func()) {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for off := 0; off+unix.SizeofInotifyEvent <= n; {
+			e := (*unix.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			name := buf[off+unix.SizeofInotifyEvent : off+unix.SizeofInotifyEvent+int(e.Len)]
+			off += unix.SizeofInotifyEvent + int(e.Len)
+
+			switch {
+			case e.Mask&(unix.IN_IGNORED|unix.IN_MOVE_SELF) != 0:
+				f.Close()
+				lost()
+				return
+			case e.Mask&unix.IN_Q_OVERFLOW != 0:
+				changed("")
+			case len(name) > 0:
+				changed(string(bytes.TrimRight(name, "\x00")))
+			}
+		}
+	}
+}
@@ -0,0 +1,444 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+const (
+	// ValidateEndpoint is the path of the dry-run validation endpoint of
+	// the debug listener.
+	ValidateEndpoint = "/debug/validate"
+	// maxDryRunRequestSize is the maximum size of dry-run requests accepted
+	// by the validation endpoint.
+	maxDryRunRequestSize = 4 << 20
+)
+
+// ValidationConfig is the effective configuration for collecting and
+// validating the responses of plugins to container creation.
+type ValidationConfig struct {
+	// HandlerAnnotationPrefixes are the prefixes of runtime handler
+	// annotations plugins can set.
+	HandlerAnnotationPrefixes []string `json:"handlerAnnotationPrefixes,omitempty"`
+	// AnnotationLimits are the limits of annotations set by plugins.
+	AnnotationLimits *api.AnnotationLimits `json:"annotationLimits,omitempty"`
+	// DropUnsupportedAdjustments is true if adjustments unsupported by the
+	// runtime handler are dropped instead of rejected.
+	DropUnsupportedAdjustments bool `json:"dropUnsupportedAdjustments,omitempty"`
+	// CpusetValidation is true if cpusets are validated against topology.
+	CpusetValidation bool `json:"cpusetValidation,omitempty"`
+	// HugepageSizes are the valid hugepage sizes, if validated.
+	HugepageSizes []string `json:"hugepageSizes,omitempty"`
+	// ConflictResolvers are the field classes with a conflict resolver.
+	ConflictResolvers []FieldClass `json:"conflictResolvers,omitempty"`
+	// AdjustmentPolicies are the adjustment policies of plugins.
+	AdjustmentPolicies map[string]*AdjustmentPolicy `json:"adjustmentPolicies,omitempty"`
+	// UserPolicy is the policy for user and group adjustments.
+	UserPolicy *UserPolicy `json:"userPolicy,omitempty"`
+	// ProtectedApparmorProfiles are the AppArmor profiles plugins can't
+	// change.
+	ProtectedApparmorProfiles []string `json:"protectedApparmorProfiles,omitempty"`
+	// DisabledPlugins are the plugins currently disabled.
+	DisabledPlugins []string `json:"disabledPlugins,omitempty"`
+}
+
+// DryRunRequest is a hypothetical container creation, together with the
+// responses plugins would give to it.
+type DryRunRequest struct {
+	// Pod is the pod of the container.
+	Pod *PodSandbox
+	// Container is the container being created.
+	Container *Container
+	// Responses are the responses of plugins, by full (idx-name) name.
+	Responses map[string]*CreateContainerResponse
+}
+
+// DryRunResult is the outcome of a dry run.
+type DryRunResult struct {
+	// Order is the order the responses of plugins were collected in.
+	Order []string
+	// Disabled lists the plugins whose responses were ignored, since the
+	// plugins are disabled.
+	Disabled []string
+	// Response is the combined response, if it passed validation.
+	Response *CreateContainerResponse
+	// Err is the error collection or validation failed with, if any.
+	Err error
+}
+
+// ValidationConfig returns the effective configuration for collecting and
+// validating the responses of plugins to container creation.
+func (r *Adaptation) ValidationConfig() *ValidationConfig {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.validationConfig()
+}
+
+func (r *Adaptation) validationConfig() *ValidationConfig {
+	cfg := &ValidationConfig{
+		HandlerAnnotationPrefixes:  append([]string(nil), r.hdlrPrefix...),
+		DropUnsupportedAdjustments: r.dropUnsupp,
+		CpusetValidation:           r.topology != nil,
+		HugepageSizes:              append([]string(nil), r.hugeSizes...),
+		UserPolicy:                 r.userPolicy,
+		ProtectedApparmorProfiles:  append([]string(nil), r.aaProfiles...),
+	}
+	if r.annoLimits != nil {
+		cfg.AnnotationLimits = proto.Clone(r.annoLimits).(*api.AnnotationLimits)
+	}
+	for class := range r.resolvers {
+		cfg.ConflictResolvers = append(cfg.ConflictResolvers, class)
+	}
+	sort.Slice(cfg.ConflictResolvers, func(i, j int) bool {
+		return cfg.ConflictResolvers[i] < cfg.ConflictResolvers[j]
+	})
+	if len(r.adjPolicies) > 0 {
+		cfg.AdjustmentPolicies = make(map[string]*AdjustmentPolicy, len(r.adjPolicies))
+		for plugin, policy := range r.adjPolicies {
+			cfg.AdjustmentPolicies[plugin] = policy
+		}
+	}
+
+	r.enableLock.RLock()
+	for name := range r.disabled {
+		cfg.DisabledPlugins = append(cfg.DisabledPlugins, name)
+	}
+	r.enableLock.RUnlock()
+	sort.Strings(cfg.DisabledPlugins)
+
+	return cfg
+}
+
+// DryRunCreateContainer runs hypothetical responses of plugins to container
+// creation through the same collection and validation as CreateContainer,
+// without relaying anything to plugins. Responses are collected in the order
+// the plugins would be invoked in by the plugin order policy. Ordering
+// constraints declared by plugins are only known once they register, so they
+// are not taken into account. Responses of disabled plugins are ignored.
+// The returned error is set for invalid requests, while the result records
+// requests failing validation. CI tests can use this to verify the
+// validation configuration of a runtime against synthetic input.
+func (r *Adaptation) DryRunCreateContainer(req *DryRunRequest) (*DryRunResult, error) {
+	if req.GetContainer() == nil {
+		return nil, fmt.Errorf("invalid dry-run request, no container")
+	}
+
+	plugins := make([]*plugin, 0, len(req.Responses))
+	for name := range req.Responses {
+		idx, base, err := api.ParsePluginName(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dry-run request: %w", err)
+		}
+		plugins = append(plugins, &plugin{idx: idx, base: base})
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].name() < plugins[j].name()
+	})
+
+	r.Lock()
+	options := r.mergeOptionsWith(func(resolver ConflictResolver) ConflictResolver {
+		return resolver
+	})
+	order := r.orderDryRun(plugins)
+	r.Unlock()
+
+	create := &CreateContainerRequest{
+		Pod:       proto.Clone(req.GetPod()).(*PodSandbox),
+		Container: proto.Clone(req.Container).(*Container),
+	}
+	if create.Pod == nil {
+		create.Pod = &PodSandbox{}
+	}
+	fillImage(create.Container)
+
+	var (
+		res     = &DryRunResult{}
+		result  = merge.NewCreateContainerResult(create, options...)
+		relayed []*plugin
+	)
+	for _, p := range order {
+		if r.isDisabled(p) {
+			res.Disabled = append(res.Disabled, p.name())
+			continue
+		}
+		res.Order = append(res.Order, p.name())
+		relayed = append(relayed, p)
+		if err := result.Apply(req.Responses[p.name()], p.name()); err != nil {
+			var rejected *RejectedError
+			if errors.As(err, &rejected) {
+				rejected.Pod = create.Pod.GetName()
+				rejected.Container = create.Container.GetName()
+			}
+			res.Err = err
+			return res, nil
+		}
+	}
+
+	rpl := result.CreateContainerResponse()
+	if err := r.validateAdjustment(noCtx, create, rpl, relayed); err != nil {
+		res.Err = err
+		return res, nil
+	}
+
+	res.Response = rpl
+	return res, nil
+}
+
+// DryRunCreateContainer creates a runtime adaptation with the given options,
+// without starting it, and does a dry run of the container creation using
+// it. CI policy tests can use this to verify validation options without
+// setting up a runtime.
+func DryRunCreateContainer(req *DryRunRequest, opts ...Option) (*DryRunResult, error) {
+	r := &Adaptation{
+		dropinPath: DefaultPluginConfigPath,
+		metrics:    nopMetrics{},
+	}
+	for _, o := range opts {
+		if err := o(r); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	return r.DryRunCreateContainer(req)
+}
+
+// orderDryRun orders dry-run plugins, sorted by name, as the plugin order
+// policy would order them for container creation.
+func (r *Adaptation) orderDryRun(plugins []*plugin) []*plugin {
+	if r.orderPolicy == nil || len(plugins) < 2 {
+		return plugins
+	}
+
+	var (
+		names  = make([]string, 0, len(plugins))
+		byName = make(map[string]*plugin, len(plugins))
+	)
+	for _, p := range plugins {
+		names = append(names, p.name())
+		byName[p.name()] = p
+	}
+
+	ordered := make([]*plugin, 0, len(plugins))
+	for _, name := range r.orderPolicy(Event_CREATE_CONTAINER, names) {
+		if p, ok := byName[name]; ok {
+			ordered = append(ordered, p)
+			delete(byName, name)
+		}
+	}
+	for _, p := range plugins {
+		if _, ok := byName[p.name()]; ok {
+			ordered = append(ordered, p)
+		}
+	}
+
+	return ordered
+}
+
+// GetPod returns the pod of the request.
+func (req *DryRunRequest) GetPod() *PodSandbox {
+	if req == nil {
+		return nil
+	}
+	return req.Pod
+}
+
+// GetContainer returns the container of the request.
+func (req *DryRunRequest) GetContainer() *Container {
+	if req == nil {
+		return nil
+	}
+	return req.Container
+}
+
+// dryRunRequestJSON is the JSON encoding of a DryRunRequest.
+type dryRunRequestJSON struct {
+	Pod       json.RawMessage            `json:"pod,omitempty"`
+	Container json.RawMessage            `json:"container"`
+	Responses map[string]json.RawMessage `json:"responses,omitempty"`
+}
+
+// MarshalJSON marshals a dry-run request to JSON.
+func (req *DryRunRequest) MarshalJSON() ([]byte, error) {
+	var (
+		j   = &dryRunRequestJSON{}
+		err error
+	)
+
+	if j.Pod, err = marshalMessage(req.Pod); err != nil {
+		return nil, err
+	}
+	if j.Container, err = marshalMessage(req.Container); err != nil {
+		return nil, err
+	}
+	if len(req.Responses) > 0 {
+		j.Responses = make(map[string]json.RawMessage, len(req.Responses))
+		for name, rpl := range req.Responses {
+			if j.Responses[name], err = marshalMessage(rpl); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON unmarshals a dry-run request from JSON.
+func (req *DryRunRequest) UnmarshalJSON(data []byte) error {
+	j := &dryRunRequestJSON{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return err
+	}
+
+	*req = DryRunRequest{}
+	if len(j.Pod) > 0 {
+		req.Pod = &PodSandbox{}
+		if err := protojson.Unmarshal(j.Pod, req.Pod); err != nil {
+			return fmt.Errorf("invalid pod: %w", err)
+		}
+	}
+	if len(j.Container) > 0 {
+		req.Container = &Container{}
+		if err := protojson.Unmarshal(j.Container, req.Container); err != nil {
+			return fmt.Errorf("invalid container: %w", err)
+		}
+	}
+	if len(j.Responses) > 0 {
+		req.Responses = make(map[string]*CreateContainerResponse, len(j.Responses))
+		for name, data := range j.Responses {
+			rpl := &CreateContainerResponse{}
+			if err := protojson.Unmarshal(data, rpl); err != nil {
+				return fmt.Errorf("invalid response of plugin %q: %w", name, err)
+			}
+			req.Responses[name] = rpl
+		}
+	}
+
+	return nil
+}
+
+// dryRunResultJSON is the JSON encoding of a DryRunResult.
+type dryRunResultJSON struct {
+	Order    []string        `json:"order"`
+	Disabled []string        `json:"disabled,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Rule     string          `json:"rule,omitempty"`
+	Plugin   string          `json:"plugin,omitempty"`
+	Other    string          `json:"other,omitempty"`
+	Subject  string          `json:"subject,omitempty"`
+}
+
+// MarshalJSON marshals a dry-run result to JSON. Rejections are described
+// by the violated rule, the rejected plugin and subject, and the other
+// plugin for conflicts.
+func (res *DryRunResult) MarshalJSON() ([]byte, error) {
+	var (
+		j   = &dryRunResultJSON{Order: res.Order, Disabled: res.Disabled}
+		err error
+	)
+
+	if j.Response, err = marshalMessage(res.Response); err != nil {
+		return nil, err
+	}
+	if res.Err != nil {
+		j.Error = res.Err.Error()
+		var rejected *RejectedError
+		if errors.As(res.Err, &rejected) {
+			j.Rule = rejected.Rule
+			j.Plugin = rejected.Plugin
+			j.Other = rejected.Other
+			j.Subject = rejected.Subject
+		}
+	}
+
+	return json.Marshal(j)
+}
+
+func marshalMessage(m proto.Message) (json.RawMessage, error) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	return protojson.Marshal(m)
+}
+
+// validateHandler serves the dry-run validation endpoint. GET returns the
+// validation configuration and the current CreateContainer plugin chain.
+// POST does a dry run of the DryRunRequest in the body.
+func (r *Adaptation) validateHandler(w http.ResponseWriter, req *http.Request) {
+	var reply interface{}
+
+	switch req.Method {
+	case http.MethodGet:
+		r.Lock()
+		reply = &struct {
+			Config *ValidationConfig `json:"config"`
+			Chain  []string          `json:"chain"`
+		}{
+			Config: r.validationConfig(),
+			Chain:  r.pluginChain(Event_CREATE_CONTAINER),
+		}
+		r.Unlock()
+
+	case http.MethodPost:
+		data, err := io.ReadAll(io.LimitReader(req.Body, maxDryRunRequestSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dryRun := &DryRunRequest{}
+		if err := json.Unmarshal(data, dryRun); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := r.DryRunCreateContainer(dryRun)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply = &struct {
+			Config *ValidationConfig `json:"config"`
+			Result *DryRunResult     `json:"result"`
+		}{
+			Config: r.ValidationConfig(),
+			Result: res,
+		}
+
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(reply); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
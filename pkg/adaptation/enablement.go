@@ -0,0 +1,228 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// DisabledPluginSuffix is the suffix of drop-in files disabling plugins.
+	// A file <idx>-<name>.disabled in the plugin config path disables the
+	// plugin with the given index and name, a file <name>.disabled disables
+	// the plugin with the given name regardless of its index.
+	DisabledPluginSuffix = ".disabled"
+	// DefaultPluginEnablementInterval is the default interval for checking
+	// drop-in files for changes in plugin enablement.
+	DefaultPluginEnablementInterval = 2 * time.Second
+)
+
+// DisablePlugin disables the plugin with the given name by creating the
+// corresponding drop-in file in the given plugin config path. The name is
+// either <idx>-<name> or <name> for disabling a plugin regardless of its
+// index.
+func DisablePlugin(configPath, name string) error {
+	path, err := disabledPluginFile(configPath, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create plugin config path %q: %w", configPath, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to disable plugin %q: %w", name, err)
+	}
+
+	return f.Close()
+}
+
+// EnablePlugin enables the plugin with the given name by removing the
+// corresponding drop-in file from the given plugin config path.
+func EnablePlugin(configPath, name string) error {
+	path, err := disabledPluginFile(configPath, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to enable plugin %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// DisabledPlugins returns the names of plugins disabled by drop-in files
+// in the given plugin config path.
+func DisabledPlugins(configPath string) ([]string, error) {
+	entries, err := os.ReadDir(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read plugin config path %q: %w", configPath, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), DisabledPluginSuffix) {
+			continue
+		}
+		if name := strings.TrimSuffix(e.Name(), DisabledPluginSuffix); name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func disabledPluginFile(configPath, name string) (string, error) {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) {
+		return "", fmt.Errorf("invalid plugin name %q", name)
+	}
+	return filepath.Join(configPath, name+DisabledPluginSuffix), nil
+}
+
+// WithPluginEnablementWatch returns an option to check drop-in files in the
+// plugin config path for plugins disabled or enabled at runtime, with the
+// given interval. Events are not relayed to disabled plugins and container
+// updates requested by disabled plugins are rejected. If the interval is 0,
+// DefaultPluginEnablementInterval is used.
+func WithPluginEnablementWatch(interval time.Duration) Option {
+	return func(r *Adaptation) error {
+		if interval < 0 {
+			return fmt.Errorf("invalid plugin enablement interval %s", interval)
+		}
+		if interval == 0 {
+			interval = DefaultPluginEnablementInterval
+		}
+		r.enableIntv = interval
+		return nil
+	}
+}
+
+// DisablePlugin disables the plugin with the given name.
+func (r *Adaptation) DisablePlugin(name string) error {
+	if err := DisablePlugin(r.dropinPath, name); err != nil {
+		return err
+	}
+	r.checkPluginEnablement()
+	return nil
+}
+
+// EnablePlugin enables the plugin with the given name.
+func (r *Adaptation) EnablePlugin(name string) error {
+	if err := EnablePlugin(r.dropinPath, name); err != nil {
+		return err
+	}
+	r.checkPluginEnablement()
+	return nil
+}
+
+// isDisabled checks if the given plugin is disabled.
+func (r *Adaptation) isDisabled(p *plugin) bool {
+	r.enableLock.RLock()
+	defer r.enableLock.RUnlock()
+
+	if len(r.disabled) == 0 {
+		return false
+	}
+
+	_, disabled := r.disabled[p.name()]
+	if !disabled {
+		_, disabled = r.disabled[p.base]
+	}
+
+	return disabled
+}
+
+// startEnablementWatch starts checking drop-in files for plugin enablement.
+func (r *Adaptation) startEnablementWatch() {
+	if r.enableIntv == 0 || r.enableStop != nil {
+		return
+	}
+
+	r.checkPluginEnablement()
+
+	stopC := make(chan struct{})
+	r.enableStop = stopC
+
+	go func() {
+		ticker := time.NewTicker(r.enableIntv)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				r.checkPluginEnablement()
+			}
+		}
+	}()
+}
+
+// stopEnablementWatch stops checking drop-in files for plugin enablement.
+func (r *Adaptation) stopEnablementWatch() {
+	if r.enableStop != nil {
+		close(r.enableStop)
+		r.enableStop = nil
+	}
+}
+
+// checkPluginEnablement updates the set of disabled plugins.
+func (r *Adaptation) checkPluginEnablement() {
+	if r.enableIntv == 0 {
+		return
+	}
+
+	names, err := DisabledPlugins(r.dropinPath)
+	if err != nil {
+		log.Errorf(noCtx, "failed to check plugin enablement: %v", err)
+		return
+	}
+
+	disabled := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		disabled[name] = struct{}{}
+	}
+
+	r.enableLock.Lock()
+	defer r.enableLock.Unlock()
+
+	for name := range disabled {
+		if _, ok := r.disabled[name]; !ok {
+			log.Infof(noCtx, "plugin %q disabled", name)
+		}
+	}
+	for name := range r.disabled {
+		if _, ok := disabled[name]; !ok {
+			log.Infof(noCtx, "plugin %q enabled", name)
+		}
+	}
+
+	r.disabled = disabled
+}
@@ -0,0 +1,37 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+// Aliased rules and errors of rejected plugin adjustments and updates.
+const (
+	// ConflictRule is the rule violated by conflicting plugin adjustments.
+	ConflictRule = merge.ConflictRule
+	// InvalidAdjustmentRule is the rule violated by invalid adjustments.
+	InvalidAdjustmentRule = merge.InvalidAdjustmentRule
+	// InvalidUpdateRule is the rule violated by invalid container updates.
+	InvalidUpdateRule = merge.InvalidUpdateRule
+	// AnnotationLimitRule is the rule violated by annotations exceeding limits.
+	AnnotationLimitRule = merge.AnnotationLimitRule
+)
+
+// RejectedError is returned when NRI rejects the adjustments or updates
+// requested by a plugin.
+type RejectedError = merge.RejectedError
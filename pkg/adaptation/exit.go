@@ -0,0 +1,65 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+// fillExit fills in missing exit information of a container from the last
+// event seen for it. Stopped containers are guaranteed to carry exit
+// information in StopContainer requests and RemoveContainer events, and
+// dead ones in ContainerDied events. If the runtime never provided any, the
+// exit is marked unknown.
+func (r *Adaptation) fillExit(event Event, ctr *Container) {
+	if ctr == nil || ctr.HasExit() {
+		return
+	}
+
+	r.cacheLock.Lock()
+	cached := r.cache.ctrs[ctr.Id]
+	r.cacheLock.Unlock()
+
+	if cached.HasExit() {
+		ctr.SetExit(cached.Exit())
+		return
+	}
+
+	if !exitGuaranteed(event, ctr) {
+		return
+	}
+
+	log.Warnf(noCtx, "no exit information for stopped container %s, marking it unknown", ctr.Id)
+
+	ctr.SetExit(&api.ContainerExit{
+		Code:   api.UnknownExitCode,
+		Reason: api.ExitReasonUnknown,
+	})
+}
+
+// exitGuaranteed returns true if the container must carry exit information
+// when relayed to plugins with the given event.
+func exitGuaranteed(event Event, ctr *Container) bool {
+	if event == Event_CONTAINER_DIED {
+		return true
+	}
+	if ctr.GetState() != api.ContainerState_CONTAINER_STOPPED {
+		return false
+	}
+	return event == Event_STOP_CONTAINER || event == Event_REMOVE_CONTAINER
+}
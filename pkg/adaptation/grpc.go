@@ -0,0 +1,160 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/net/multiplex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// transport is the RPC protocol used to talk to a plugin.
+type transport int
+
+const (
+	// ttrpcTransport is the default ttrpc transport.
+	ttrpcTransport transport = iota
+	// grpcTransport is the gRPC transport, for plugins without ttrpc.
+	grpcTransport
+)
+
+// WithGRPCSocketPath returns an option to also accept connections from
+// external plugins using gRPC instead of ttrpc, on the given socket. The
+// connections are multiplexed the same way as ttrpc connections, only
+// the protocol on the multiplexed plugin and runtime connections differs.
+func WithGRPCSocketPath(path string) Option {
+	return func(r *Adaptation) error {
+		if path == "" {
+			return fmt.Errorf("invalid (empty) gRPC socket path")
+		}
+		r.grpcSocket = path
+		return nil
+	}
+}
+
+func (r *Adaptation) startGRPCListener() error {
+	if r.dontListen || r.grpcSocket == "" {
+		return nil
+	}
+
+	os.Remove(r.grpcSocket)
+	if err := os.MkdirAll(filepath.Dir(r.grpcSocket), 0700); err != nil {
+		return fmt.Errorf("failed to create gRPC socket %q: %w", r.grpcSocket, err)
+	}
+
+	l, err := net.ListenUnix("unix", &net.UnixAddr{
+		Name: r.grpcSocket,
+		Net:  "unix",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC socket %q: %w", r.grpcSocket, err)
+	}
+
+	r.grpcLsnr = l
+	r.acceptPluginConnections(l, grpcTransport)
+
+	return nil
+}
+
+func (r *Adaptation) stopGRPCListener() {
+	if r.grpcLsnr != nil {
+		r.grpcLsnr.Close()
+	}
+}
+
+// connectGRPC sets up the gRPC client and server of a plugin on the given
+// multiplexed connections.
+func (p *plugin) connectGRPC(mux multiplex.Mux, pluginID, runtimeID multiplex.ConnID) (retErr error) {
+	pconn, err := mux.Open(pluginID)
+	if err != nil {
+		return fmt.Errorf("failed to mux plugin connection for plugin %q: %w", p.name(), err)
+	}
+
+	// The multiplexed connection can't be reestablished once lost, so only
+	// hand it out for the first connection attempt.
+	dialed := &atomic.Bool{}
+	dialer := func(context.Context, string) (net.Conn, error) {
+		if dialed.Swap(true) {
+			return nil, errors.New("plugin connection closed")
+		}
+		return pconn, nil
+	}
+
+	grpcc, err := grpc.Dial("passthrough:///nri-plugin",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client for plugin %q: %w", p.name(), err)
+	}
+	defer func() {
+		if retErr != nil {
+			grpcc.Close()
+		}
+	}()
+
+	rpcl, err := mux.Listen(runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to create mux runtime listener for plugin %q: %w", p.name(), err)
+	}
+
+	p.mux = mux
+	p.grpcc = grpcc
+	p.rpcl = rpcl
+	p.grpcs = grpc.NewServer()
+	p.impl = &pluginType{ttrpcImpl: api.NewPluginGRPCClient(grpcc)}
+
+	api.RegisterRuntimeGRPCService(p.grpcs, p)
+
+	go p.watchGRPCConn()
+
+	return nil
+}
+
+// watchGRPCConn waits for the gRPC connection to the plugin to go down.
+func (p *plugin) watchGRPCConn() {
+	var (
+		ctx   = context.Background()
+		ready bool
+	)
+
+	for state := p.grpcc.GetState(); ; state = p.grpcc.GetState() {
+		switch state {
+		case connectivity.Ready:
+			ready = true
+		case connectivity.Idle:
+			if ready {
+				p.connectionClosed()
+				return
+			}
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			p.connectionClosed()
+			return
+		}
+		p.grpcc.WaitForStateChange(ctx, state)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultPluginHealthCheckInterval is the default interval for pinging
+	// plugins when health checks are enabled.
+	DefaultPluginHealthCheckInterval = 10 * time.Second
+	// DefaultPluginHealthCheckFailures is the default number of consecutive
+	// failed pings after which a plugin is detached.
+	DefaultPluginHealthCheckFailures = 3
+)
+
+// PluginHealthFn is a runtime callback for plugins detached by NRI for
+// failing health checks or missing keepalives. It is passed the name of the
+// plugin and the error of the last failed health check.
+type PluginHealthFn func(ctx context.Context, plugin string, err error)
+
+// WithPluginHealthCheck returns an option to ping plugins periodically with
+// the given interval and detach plugins which fail to respond the given number
+// of consecutive times. If the interval or the number of failures is 0, the
+// defaults DefaultPluginHealthCheckInterval or DefaultPluginHealthCheckFailures
+// are used.
+func WithPluginHealthCheck(interval time.Duration, failures int) Option {
+	return func(r *Adaptation) error {
+		if interval < 0 {
+			return fmt.Errorf("invalid plugin health check interval %s", interval)
+		}
+		if failures < 0 {
+			return fmt.Errorf("invalid plugin health check failure threshold %d", failures)
+		}
+		if interval == 0 {
+			interval = DefaultPluginHealthCheckInterval
+		}
+		if failures == 0 {
+			failures = DefaultPluginHealthCheckFailures
+		}
+		r.healthIntv = interval
+		r.healthFails = failures
+		return nil
+	}
+}
+
+// WithPluginHealthCallback returns an option to set the runtime callback for
+// plugins detached for failing health checks or missing keepalives.
+func WithPluginHealthCallback(fn PluginHealthFn) Option {
+	return func(r *Adaptation) error {
+		r.healthFn = fn
+		return nil
+	}
+}
+
+// startHealthCheck starts pinging plugins periodically.
+func (r *Adaptation) startHealthCheck() {
+	if r.healthIntv == 0 || r.healthStop != nil {
+		return
+	}
+
+	stopC := make(chan struct{})
+	r.healthStop = stopC
+
+	go func() {
+		ticker := time.NewTicker(r.healthIntv)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				r.checkPluginHealth()
+			}
+		}
+	}()
+}
+
+// stopHealthCheck stops pinging plugins.
+func (r *Adaptation) stopHealthCheck() {
+	if r.healthStop != nil {
+		close(r.healthStop)
+		r.healthStop = nil
+	}
+}
+
+// checkPluginHealth pings all plugins and detaches the ones which have
+// failed the configured number of consecutive health checks.
+func (r *Adaptation) checkPluginHealth() {
+	r.Lock()
+	plugins := append([]*plugin{}, r.plugins...)
+	r.Unlock()
+
+	var (
+		unhealthy = make([]error, len(plugins))
+		wg        sync.WaitGroup
+	)
+
+	for i, p := range plugins {
+		if p.impl.isWasm() || p.isClosed() {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, p *plugin) {
+			defer wg.Done()
+			unhealthy[i] = p.checkHealth(noCtx, r.healthFails)
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	var detached []int
+	for i, err := range unhealthy {
+		if err == nil {
+			continue
+		}
+		log.Errorf(noCtx, "detaching unhealthy plugin %q: %v", plugins[i].name(), err)
+		plugins[i].close()
+		detached = append(detached, i)
+	}
+
+	if len(detached) == 0 {
+		return
+	}
+
+	r.Lock()
+	r.removeClosedPlugins()
+	r.Unlock()
+
+	if r.healthFn != nil {
+		for _, i := range detached {
+			r.healthFn(noCtx, plugins[i].name(), unhealthy[i])
+		}
+	}
+}
+
+// checkHealth pings the plugin. It returns an error once the plugin has
+// failed the given number of consecutive pings. Plugins which don't
+// implement ping are considered healthy as long as they respond.
+func (p *plugin) checkHealth(ctx context.Context, failures int) error {
+	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	defer cancel()
+
+	err := p.impl.Ping(ctx)
+	if err == nil || status.Code(err) == codes.Unimplemented {
+		p.pingFails = 0
+		return nil
+	}
+
+	p.pingFails++
+	log.Warnf(ctx, "plugin %q failed health check (%d/%d): %v", p.name(),
+		p.pingFails, failures, err)
+
+	if p.pingFails < failures {
+		return nil
+	}
+
+	return fmt.Errorf("failed %d consecutive health checks, last error: %w",
+		p.pingFails, err)
+}
@@ -0,0 +1,149 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateInterceptor is called with the merged container updates requested
+// by plugins, once they have been validated and before they are passed to
+// the runtime. It gives the runtime a final point to apply its policies to
+// updates, for instance to annotate them, clamp resources to site limits or
+// drop updates altogether, without forking the adaptation. Interceptors
+// modify the updates in the request in place. Returning an error fails the
+// request the updates were solicited in, the synchronization of the plugin,
+// or the unsolicited updates, like a failed validation would. Interceptors
+// can be called concurrently for plugins being synchronized.
+type UpdateInterceptor func(context.Context, *InterceptUpdatesRequest) error
+
+// InterceptUpdatesRequest is a set of container updates to intercept.
+type InterceptUpdatesRequest struct {
+	// Event is the event the updates were requested in response to. It is
+	// Event_UNKNOWN for updates requested during synchronization and for
+	// unsolicited updates.
+	Event Event
+	// Unsolicited is true for updates requested by a plugin on its own.
+	Unsolicited bool
+	// Pod and Container are those of the event, if any.
+	Pod       *PodSandbox
+	Container *Container
+	// Updates are the updates, one per container. Interceptors must not
+	// add or remove updates, but set Drop to drop them.
+	Updates []*InterceptedUpdate
+}
+
+// InterceptedUpdate is a single intercepted container update.
+type InterceptedUpdate struct {
+	// Update is the update. Interceptors can modify or replace it.
+	Update *ContainerUpdate
+	// Plugins are the plugins which requested the update.
+	Plugins []string
+	// Drop is set by interceptors to drop the update.
+	Drop bool
+	// Reason is set by interceptors to tell why the update was modified or
+	// dropped. It is logged, along with the plugins the update came from.
+	Reason string
+}
+
+// WithUpdateInterceptor returns an option to intercept the container updates
+// requested by plugins before they are passed to the runtime. Updates are
+// intercepted whether they are solicited in an event, requested during
+// synchronization or unsolicited. Multiple interceptors are called in the
+// order they are given, each seeing the changes of earlier ones.
+func WithUpdateInterceptor(interceptor UpdateInterceptor) Option {
+	return func(r *Adaptation) error {
+		if interceptor == nil {
+			return fmt.Errorf("invalid (nil) update interceptor")
+		}
+		r.intercepts = append(r.intercepts, interceptor)
+		return nil
+	}
+}
+
+// interceptUpdates passes validated updates through the interceptors,
+// returning the updates which are not dropped. The plugins which requested
+// the update of a container are looked up using the given function.
+func (r *Adaptation) interceptUpdates(ctx context.Context, req *InterceptUpdatesRequest, updates []*ContainerUpdate, plugins func(id string) []string) ([]*ContainerUpdate, error) {
+	if len(r.intercepts) == 0 || len(updates) == 0 {
+		return updates, nil
+	}
+
+	var (
+		original = make([]*ContainerUpdate, 0, len(updates))
+		keep     []*ContainerUpdate
+	)
+	for _, u := range updates {
+		if u == nil {
+			// UpdateContainerResponse has a nil update for the container
+			// being updated if no plugin has updated it, keep it as such
+			keep = append(keep, u)
+			continue
+		}
+		original = append(original, proto.Clone(u).(*ContainerUpdate))
+		req.Updates = append(req.Updates, &InterceptedUpdate{
+			Update:  u,
+			Plugins: plugins(u.GetContainerId()),
+		})
+	}
+
+	for _, intercept := range r.intercepts {
+		if err := intercept(ctx, req); err != nil {
+			log.Warnf(ctx, "container updates rejected by interceptor: %v", err)
+			return nil, fmt.Errorf("container update interception failed: %w", err)
+		}
+	}
+
+	if len(req.Updates) != len(original) {
+		return nil, fmt.Errorf("container update interception failed: %d updates intercepted, %d returned",
+			len(original), len(req.Updates))
+	}
+
+	intercepted := make([]*ContainerUpdate, 0, len(updates))
+	for i, u := range req.Updates {
+		id := original[i].GetContainerId()
+		reason := u.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		switch {
+		case u.Drop || u.Update == nil:
+			log.Infof(ctx, "update of container %s requested by %v dropped by interceptor (%s)",
+				id, u.Plugins, reason)
+			continue
+		case !proto.Equal(u.Update, original[i]):
+			log.Infof(ctx, "update of container %s requested by %v modified by interceptor (%s)",
+				id, u.Plugins, reason)
+		}
+
+		intercepted = append(intercepted, u.Update)
+	}
+
+	return append(intercepted, keep...), nil
+}
+
+// pluginsOf returns a function which attributes updates to the given plugins.
+func pluginsOf(names ...string) func(string) []string {
+	return func(string) []string {
+		return names
+	}
+}
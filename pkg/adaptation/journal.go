@@ -0,0 +1,158 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// LostUpdatesFn is a container runtime function for reporting journaled
+// container updates which were in flight when the runtime went down, and
+// which could not be reapplied since their containers are gone.
+type LostUpdatesFn func(context.Context, []*ContainerUpdate)
+
+// WithUpdateJournal returns an option to journal the container updates
+// requested by plugins in the given file while they are being applied.
+// If the runtime goes down before an update is applied, the update is
+// found in the journal on restart and reapplied during the synchronization
+// of plugins. Updates of containers which no longer exist are reported to
+// lostFn, if it is set, and logged.
+func WithUpdateJournal(path string, lostFn LostUpdatesFn) Option {
+	return func(r *Adaptation) error {
+		if path == "" {
+			return errors.New("invalid (empty) update journal path")
+		}
+		r.journal = &updateJournal{
+			path:   path,
+			lostFn: lostFn,
+		}
+		return nil
+	}
+}
+
+// updateJournal journals in-flight container updates.
+type updateJournal struct {
+	path    string
+	lostFn  LostUpdatesFn
+	pending []*ContainerUpdate
+}
+
+// load any updates left in the journal.
+func (j *updateJournal) load() error {
+	if j == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read update journal: %w", err)
+	}
+
+	req := &UpdateContainersRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		return fmt.Errorf("failed to parse update journal %s: %w", j.path, err)
+	}
+	j.pending = req.Update
+
+	if len(j.pending) > 0 {
+		log.Infof(noCtx, "found %d in-flight container updates in journal", len(j.pending))
+	}
+
+	return nil
+}
+
+// record updates in the journal before they are applied.
+func (j *updateJournal) record(updates []*ContainerUpdate) error {
+	if j == nil {
+		return nil
+	}
+
+	data, err := proto.Marshal(&UpdateContainersRequest{Update: updates})
+	if err != nil {
+		return fmt.Errorf("failed to journal container updates: %w", err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(j.path), "."+filepath.Base(j.path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to journal container updates: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to journal container updates: %w", err)
+	}
+
+	return nil
+}
+
+// clear the journal once journaled updates are applied.
+func (j *updateJournal) clear() {
+	if j == nil {
+		return
+	}
+
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warnf(noCtx, "failed to clear update journal: %v", err)
+	}
+}
+
+// replay returns the updates left in the journal for reapplying to the given
+// containers, and reports the updates of any other containers as lost.
+func (j *updateJournal) replay(ctx context.Context, containers []*Container) []*ContainerUpdate {
+	if j == nil || len(j.pending) == 0 {
+		return nil
+	}
+
+	exists := make(map[string]struct{}, len(containers))
+	for _, ctr := range containers {
+		exists[ctr.Id] = struct{}{}
+	}
+
+	var replayed, lost []*ContainerUpdate
+	for _, u := range j.pending {
+		if _, ok := exists[u.ContainerId]; ok {
+			replayed = append(replayed, u)
+		} else {
+			lost = append(lost, u)
+		}
+	}
+	j.pending = nil
+
+	if len(replayed) > 0 {
+		log.Infof(ctx, "reapplying %d in-flight container updates from journal", len(replayed))
+	}
+	if len(lost) > 0 {
+		for _, u := range lost {
+			log.Warnf(ctx, "lost in-flight update of container %s, container is gone", u.ContainerId)
+		}
+		if j.lostFn != nil {
+			j.lostFn(ctx, lost)
+		}
+	}
+
+	return replayed
+}
@@ -0,0 +1,120 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// DefaultPluginKeepaliveMisses is the default number of consecutive
+	// keepalives a plugin can miss before it is detached.
+	DefaultPluginKeepaliveMisses = api.DefaultKeepaliveMisses
+)
+
+// WithPluginKeepalive returns an option to ask plugins to send keepalives
+// with the given interval, unless they choose their own. Plugins which then
+// miss the given number of consecutive keepalives are detached, so half-open
+// connections to crashed or unreachable plugins are detected without waiting
+// for the next request. If the number of misses is 0, the default
+// DefaultPluginKeepaliveMisses is used. Plugins which don't send keepalives,
+// for instance ones using an older stub, are not affected.
+func WithPluginKeepalive(interval time.Duration, misses int) Option {
+	return func(r *Adaptation) error {
+		if interval < time.Millisecond {
+			return fmt.Errorf("invalid plugin keepalive interval %s", interval)
+		}
+		if misses < 0 {
+			return fmt.Errorf("invalid plugin keepalive miss threshold %d", misses)
+		}
+		if misses == 0 {
+			misses = DefaultPluginKeepaliveMisses
+		}
+		r.kaIntv = interval
+		r.kaMisses = misses
+		return nil
+	}
+}
+
+// keepaliveMisses returns the number of keepalives plugins can miss.
+func (r *Adaptation) keepaliveMisses() int {
+	if r.kaMisses == 0 {
+		return DefaultPluginKeepaliveMisses
+	}
+	return r.kaMisses
+}
+
+// Keepalive handles a keepalive of the plugin, (re)arming the timer which
+// detaches the plugin if it misses too many of its subsequent keepalives.
+func (p *plugin) Keepalive(ctx context.Context, req *KeepaliveRequest) (*api.Empty, error) {
+	interval := time.Duration(req.GetInterval()) * time.Millisecond
+	if interval <= 0 {
+		return &api.Empty{}, fmt.Errorf("invalid keepalive interval %s", interval)
+	}
+
+	timeout := time.Duration(p.r.keepaliveMisses()) * interval
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.closed {
+		return &api.Empty{}, nil
+	}
+
+	if p.kaTimer == nil {
+		log.Infof(ctx, "plugin %q sends keepalives every %s", p.name(), interval)
+	} else {
+		p.kaTimer.Stop()
+	}
+
+	var t *time.Timer
+	t = time.AfterFunc(timeout, func() {
+		p.keepaliveExpired(t, timeout)
+	})
+	p.kaTimer = t
+
+	return &api.Empty{}, nil
+}
+
+// keepaliveExpired detaches the plugin once its keepalive timer expires.
+func (p *plugin) keepaliveExpired(t *time.Timer, timeout time.Duration) {
+	p.Lock()
+	expired := p.kaTimer == t && !p.closed
+	p.Unlock()
+
+	if !expired {
+		return
+	}
+
+	err := fmt.Errorf("no keepalive received for %s", timeout)
+	log.Errorf(noCtx, "detaching plugin %q: %v", p.name(), err)
+
+	p.close()
+
+	p.r.Lock()
+	p.r.removeClosedPlugins()
+	p.r.Unlock()
+
+	if p.r.healthFn != nil {
+		p.r.healthFn(noCtx, p.name(), err)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// EventPolicy is the policy for handling pod and container events which
+// are invalid in the lifecycle of the pod or container.
+type EventPolicy int
+
+const (
+	// FlagInvalidEvents logs and counts invalid events, but relays them to
+	// plugins. This is the default policy.
+	FlagInvalidEvents EventPolicy = iota
+	// DropInvalidEvents logs and counts invalid events, and doesn't relay
+	// them to plugins.
+	DropInvalidEvents
+	// RejectInvalidEvents logs and counts invalid events, and fails them
+	// with an error without relaying them to plugins.
+	RejectInvalidEvents
+)
+
+// EventAnomaly is the kind of an invalid pod or container event.
+type EventAnomaly string
+
+const (
+	// DuplicateEvent is an event repeating the last event of a pod or container.
+	DuplicateEvent EventAnomaly = "duplicate"
+	// OutOfOrderEvent is an event not allowed in the current state of a pod
+	// or container, for instance starting a stopped container.
+	OutOfOrderEvent EventAnomaly = "out-of-order"
+	// UnknownTargetEvent is an event for a pod or container which has not
+	// been created, or has already been removed.
+	UnknownTargetEvent EventAnomaly = "unknown-target"
+)
+
+// WithEventValidation returns an option to set the policy for handling pod
+// and container events which are invalid in the lifecycle of the pod or
+// container, for instance duplicate or out of order events.
+func WithEventValidation(policy EventPolicy) Option {
+	return func(r *Adaptation) error {
+		switch policy {
+		case FlagInvalidEvents, DropInvalidEvents, RejectInvalidEvents:
+		default:
+			return fmt.Errorf("invalid event validation policy %d", policy)
+		}
+		r.lifecycle.policy = policy
+		return nil
+	}
+}
+
+// EventAnomalies returns the number of invalid events seen so far, by kind.
+func (r *Adaptation) EventAnomalies() map[EventAnomaly]uint64 {
+	return r.lifecycle.anomalies()
+}
+
+// validContainerEvents lists the events allowed to follow each container
+// lifecycle event. Updates are allowed in any state and don't change it.
+var validContainerEvents = map[Event][]Event{
+	Event_CREATE_CONTAINER:      {Event_POST_CREATE_CONTAINER, Event_START_CONTAINER, Event_STOP_CONTAINER, Event_REMOVE_CONTAINER, Event_CREATE_CONTAINER_ROLLBACK},
+	Event_POST_CREATE_CONTAINER: {Event_START_CONTAINER, Event_STOP_CONTAINER, Event_REMOVE_CONTAINER},
+	Event_START_CONTAINER:       {Event_POST_START_CONTAINER, Event_CONTAINER_DIED, Event_STOP_CONTAINER, Event_REMOVE_CONTAINER},
+	Event_POST_START_CONTAINER:  {Event_CONTAINER_DIED, Event_STOP_CONTAINER, Event_REMOVE_CONTAINER},
+	Event_CONTAINER_DIED:        {Event_STOP_CONTAINER, Event_REMOVE_CONTAINER},
+	Event_STOP_CONTAINER:        {Event_CONTAINER_DIED, Event_REMOVE_CONTAINER},
+}
+
+// validPodEvents lists the events allowed to follow each pod lifecycle event.
+var validPodEvents = map[Event][]Event{
+	Event_RUN_POD_SANDBOX:  {Event_STOP_POD_SANDBOX, Event_REMOVE_POD_SANDBOX},
+	Event_STOP_POD_SANDBOX: {Event_REMOVE_POD_SANDBOX},
+}
+
+// lifecycleTracker tracks the last lifecycle event of pods and containers
+// to detect invalid events, before they are relayed to plugins.
+type lifecycleTracker struct {
+	sync.Mutex
+	policy  EventPolicy
+	pods    map[string]Event
+	ctrs    map[string]Event
+	ctrPods map[string]string
+	counts  map[EventAnomaly]uint64
+}
+
+func newLifecycleTracker() *lifecycleTracker {
+	return &lifecycleTracker{
+		pods:    make(map[string]Event),
+		ctrs:    make(map[string]Event),
+		ctrPods: make(map[string]string),
+		counts:  make(map[EventAnomaly]uint64),
+	}
+}
+
+// reset the tracked state to the given runtime snapshot.
+func (l *lifecycleTracker) reset(pods []*PodSandbox, containers []*Container) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.pods = make(map[string]Event, len(pods))
+	l.ctrs = make(map[string]Event, len(containers))
+	l.ctrPods = make(map[string]string, len(containers))
+
+	for _, pod := range pods {
+		l.pods[pod.GetId()] = Event_RUN_POD_SANDBOX
+	}
+	for _, ctr := range containers {
+		switch ctr.GetState() {
+		case ContainerState_CONTAINER_RUNNING, ContainerState_CONTAINER_PAUSED:
+			l.ctrs[ctr.GetId()] = Event_POST_START_CONTAINER
+		case ContainerState_CONTAINER_STOPPED:
+			l.ctrs[ctr.GetId()] = Event_STOP_CONTAINER
+		default:
+			l.ctrs[ctr.GetId()] = Event_POST_CREATE_CONTAINER
+		}
+		l.ctrPods[ctr.GetId()] = ctr.GetPodSandboxId()
+	}
+}
+
+// check an event against the lifecycle of its pod or container, and track
+// the event if it is relayed to plugins. It returns false if the event should
+// not be relayed, and an error if it should be rejected.
+func (l *lifecycleTracker) check(event Event, pod *PodSandbox, ctr *Container) (bool, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	var (
+		anomaly EventAnomaly
+		subject string
+	)
+
+	if ctr != nil {
+		anomaly = l.checkContainer(event, ctr)
+		subject = "container " + ctr.GetId()
+	} else {
+		anomaly = l.checkPod(event, pod)
+		subject = "pod " + pod.GetId()
+	}
+
+	if anomaly != "" {
+		l.counts[anomaly]++
+
+		err := fmt.Errorf("invalid %s event %s for %s", anomaly, event, subject)
+		switch l.policy {
+		case DropInvalidEvents:
+			log.Warnf(noCtx, "dropping %v", err)
+			return false, nil
+		case RejectInvalidEvents:
+			return false, err
+		}
+		log.Warnf(noCtx, "%v", err)
+	}
+
+	if ctr != nil {
+		l.trackContainer(event, ctr)
+	} else {
+		l.trackPod(event, pod)
+	}
+
+	return true, nil
+}
+
+func (l *lifecycleTracker) checkContainer(event Event, ctr *Container) EventAnomaly {
+	last, known := l.ctrs[ctr.GetId()]
+
+	switch {
+	case event == Event_CREATE_CONTAINER:
+		if known {
+			return DuplicateEvent
+		}
+		if pod, ok := l.pods[ctr.GetPodSandboxId()]; ok && pod != Event_RUN_POD_SANDBOX {
+			return OutOfOrderEvent
+		}
+	case !known:
+		return UnknownTargetEvent
+	case event == Event_UPDATE_CONTAINER, event == Event_POST_UPDATE_CONTAINER:
+	case event == last:
+		return DuplicateEvent
+	case !isValidEvent(validContainerEvents[last], event):
+		return OutOfOrderEvent
+	}
+
+	return ""
+}
+
+func (l *lifecycleTracker) trackContainer(event Event, ctr *Container) {
+	id := ctr.GetId()
+
+	switch event {
+	case Event_UPDATE_CONTAINER, Event_POST_UPDATE_CONTAINER:
+	case Event_REMOVE_CONTAINER, Event_CREATE_CONTAINER_ROLLBACK:
+		delete(l.ctrs, id)
+		delete(l.ctrPods, id)
+	default:
+		l.ctrs[id] = event
+		l.ctrPods[id] = ctr.GetPodSandboxId()
+	}
+}
+
+// forgetContainer stops tracking a container whose creation failed. Neither
+// a rollback nor a removal follows for it.
+func (l *lifecycleTracker) forgetContainer(ctr *Container) {
+	l.Lock()
+	defer l.Unlock()
+
+	delete(l.ctrs, ctr.GetId())
+	delete(l.ctrPods, ctr.GetId())
+}
+
+func (l *lifecycleTracker) checkPod(event Event, pod *PodSandbox) EventAnomaly {
+	last, known := l.pods[pod.GetId()]
+
+	switch {
+	case event == Event_RUN_POD_SANDBOX:
+		if known {
+			return DuplicateEvent
+		}
+	case !known:
+		return UnknownTargetEvent
+	case event == last:
+		return DuplicateEvent
+	case !isValidEvent(validPodEvents[last], event):
+		return OutOfOrderEvent
+	}
+
+	return ""
+}
+
+func (l *lifecycleTracker) trackPod(event Event, pod *PodSandbox) {
+	id := pod.GetId()
+
+	if event != Event_REMOVE_POD_SANDBOX {
+		l.pods[id] = event
+		return
+	}
+
+	delete(l.pods, id)
+	for ctrID, podID := range l.ctrPods {
+		if podID == id {
+			delete(l.ctrs, ctrID)
+			delete(l.ctrPods, ctrID)
+		}
+	}
+}
+
+func (l *lifecycleTracker) anomalies() map[EventAnomaly]uint64 {
+	l.Lock()
+	defer l.Unlock()
+
+	counts := make(map[EventAnomaly]uint64, len(l.counts))
+	for k, v := range l.counts {
+		counts[k] = v
+	}
+	return counts
+}
+
+func isValidEvent(valid []Event, event Event) bool {
+	for _, e := range valid {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
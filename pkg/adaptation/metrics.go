@@ -0,0 +1,127 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RequestOutcome is the outcome of a request to a plugin.
+type RequestOutcome int
+
+const (
+	// RequestSucceeded is the outcome of a successful request.
+	RequestSucceeded RequestOutcome = iota
+	// RequestFailed is the outcome of a request which failed.
+	RequestFailed
+	// RequestTimedOut is the outcome of a request which timed out.
+	RequestTimedOut
+)
+
+// String returns the outcome as a string suitable for a metric label.
+func (o RequestOutcome) String() string {
+	switch o {
+	case RequestSucceeded:
+		return "success"
+	case RequestFailed:
+		return "failure"
+	case RequestTimedOut:
+		return "timeout"
+	}
+	return fmt.Sprintf("unknown outcome %d", int(o))
+}
+
+// MetricsRecorder records metrics about relaying pod and container events
+// to plugins. Runtimes can implement it, for instance using Prometheus
+// collectors, to see which plugins slow down or fail container creation.
+// The functions are called synchronously while events are relayed, so they
+// should return quickly.
+type MetricsRecorder interface {
+	// PluginRequest records relaying an event to a single plugin, with the
+	// duration and outcome of the request.
+	PluginRequest(plugin string, event Event, duration time.Duration, outcome RequestOutcome)
+	// AdjustmentConflict records a conflict between the adjustments or
+	// updates of two plugins, with the resolution of the conflict. Rejected
+	// conflicts fail the request.
+	AdjustmentConflict(plugin, owner string, resolution Resolution)
+	// EventFanOut records relaying an event to all plugins, with the total
+	// duration of relaying it.
+	EventFanOut(event Event, duration time.Duration)
+}
+
+// WithMetricsRecorder returns an option to record metrics using the given
+// recorder.
+func WithMetricsRecorder(m MetricsRecorder) Option {
+	return func(r *Adaptation) error {
+		if m == nil {
+			return fmt.Errorf("invalid (nil) metrics recorder")
+		}
+		r.metrics = m
+		return nil
+	}
+}
+
+// recordFanOut records relaying an event, started at the given time, to all
+// plugins.
+func (r *Adaptation) recordFanOut(event Event, start time.Time) {
+	r.metrics.EventFanOut(event, time.Since(start))
+}
+
+// recordConflict records a conflict rejected while merging plugin responses.
+func (r *Adaptation) recordConflict(err error) {
+	var rejected *RejectedError
+	if errors.As(err, &rejected) && rejected.Rule == ConflictRule {
+		r.metrics.AdjustmentConflict(rejected.Plugin, rejected.Other, RejectConflict)
+	}
+}
+
+// recordingResolver returns a conflict resolver which records the conflicts
+// resolved by the given resolver.
+func (r *Adaptation) recordingResolver(resolver ConflictResolver) ConflictResolver {
+	return func(c *Conflict) Resolution {
+		resolution := resolver(c)
+		// rejected conflicts are recorded once they fail the request
+		if resolution != RejectConflict {
+			r.metrics.AdjustmentConflict(c.Plugin, c.Owner, resolution)
+		}
+		return resolution
+	}
+}
+
+// recordRequest records relaying an event, started at the given time, to
+// the plugin.
+func (p *plugin) recordRequest(ctx context.Context, event Event, start time.Time, err error) {
+	outcome := RequestSucceeded
+	switch {
+	case err == nil:
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		outcome = RequestTimedOut
+	default:
+		outcome = RequestFailed
+	}
+	p.r.metrics.PluginRequest(p.name(), event, time.Since(start), outcome)
+}
+
+// nopMetrics is the default metrics recorder, which records nothing.
+type nopMetrics struct{}
+
+func (nopMetrics) PluginRequest(string, Event, time.Duration, RequestOutcome) {}
+func (nopMetrics) AdjustmentConflict(string, string, Resolution)              {}
+func (nopMetrics) EventFanOut(Event, time.Duration)                           {}
@@ -0,0 +1,193 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// PluginOrderPolicy decides the order plugins are invoked in for an event.
+// It gets the names of the plugins in their default order, which is the
+// order of their indices adjusted to the ordering constraints declared by
+// the plugins. It returns the names in the order to invoke the plugins in.
+// Plugins left out by the policy are invoked after the others, in their
+// default order.
+type PluginOrderPolicy func(event Event, plugins []string) []string
+
+// WithPluginOrderPolicy returns an option to override the default order of
+// invoking plugins using the given policy.
+func WithPluginOrderPolicy(policy PluginOrderPolicy) Option {
+	return func(r *Adaptation) error {
+		if policy == nil {
+			return fmt.Errorf("invalid (nil) plugin order policy")
+		}
+		r.orderPolicy = policy
+		return nil
+	}
+}
+
+// PluginOrderOverrides returns a policy which invokes the plugins listed
+// for an event first, in the listed order, then the rest of the plugins
+// in their default order. Plugins are listed by their full (idx-name) name.
+// Events without overrides use the default order.
+func PluginOrderOverrides(overrides map[Event][]string) PluginOrderPolicy {
+	return func(event Event, plugins []string) []string {
+		if order, ok := overrides[event]; ok {
+			return order
+		}
+		return plugins
+	}
+}
+
+// pluginsFor returns the plugins in the order to invoke them for an event.
+func (r *Adaptation) pluginsFor(event Event) []*plugin {
+	if r.orderPolicy == nil || len(r.plugins) < 2 {
+		return r.plugins
+	}
+
+	var (
+		names  = make([]string, 0, len(r.plugins))
+		byName = make(map[string][]*plugin, len(r.plugins))
+	)
+	for _, p := range r.plugins {
+		if _, ok := byName[p.name()]; !ok {
+			names = append(names, p.name())
+		}
+		byName[p.name()] = append(byName[p.name()], p)
+	}
+
+	plugins := make([]*plugin, 0, len(r.plugins))
+	for _, name := range r.orderPolicy(event, names) {
+		plugins = append(plugins, byName[name]...)
+		delete(byName, name)
+	}
+	for _, p := range r.plugins {
+		if _, ok := byName[p.name()]; ok {
+			plugins = append(plugins, p)
+		}
+	}
+
+	return plugins
+}
+
+// orderPlugins orders plugins, sorted by index, to satisfy the ordering
+// constraints declared by them. Plugins are kept in index order wherever
+// the constraints allow it. Constraints on plugins which are not present
+// are ignored. Constraints forming a cycle are broken in index order.
+func orderPlugins(plugins []*plugin) []*plugin {
+	var (
+		cnt   = len(plugins)
+		next  = make([][]int, cnt)
+		waits = make([]int, cnt)
+	)
+
+	constrain := func(first, then int) {
+		if first != then {
+			next[first] = append(next[first], then)
+			waits[then]++
+		}
+	}
+
+	for i, p := range plugins {
+		for j, o := range plugins {
+			if o.isAnyOf(p.runAfter) {
+				constrain(j, i)
+			}
+			if o.isAnyOf(p.runBefore) {
+				constrain(i, j)
+			}
+		}
+	}
+
+	var (
+		ordered = make([]*plugin, 0, cnt)
+		done    = make([]bool, cnt)
+	)
+	for len(ordered) < cnt {
+		pick, cycle := -1, -1
+		for i := range plugins {
+			if done[i] {
+				continue
+			}
+			if cycle < 0 {
+				cycle = i
+			}
+			if waits[i] == 0 {
+				pick = i
+				break
+			}
+		}
+		if pick < 0 {
+			pick = cycle
+			log.Warnf(noCtx, "cyclic ordering constraints for plugin %q, ignoring them",
+				plugins[pick].name())
+		}
+
+		done[pick] = true
+		ordered = append(ordered, plugins[pick])
+		for _, i := range next[pick] {
+			waits[i]--
+		}
+	}
+
+	return ordered
+}
+
+// isAnyOf returns true if any of the given names is the full or base name
+// of the plugin.
+func (p *plugin) isAnyOf(names []string) bool {
+	for _, name := range names {
+		if name == p.name() || name == p.base {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginChain returns the names of the plugins invoked for an event, in the
+// order they are invoked in. Plugins not subscribed to the event, disabled
+// plugins, and standby instances for requests they don't receive are left
+// out. Plugins still skip events of pods filtered out for them. Runtimes can
+// use this to assert invariants, for instance that a given plugin is part
+// of the CreateContainer chain.
+func (r *Adaptation) PluginChain(event Event) []string {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.pluginChain(event)
+}
+
+// pluginChain returns the names of the plugins invoked for an event, with
+// the runtime lock held.
+func (r *Adaptation) pluginChain(event Event) []string {
+	var chain []string
+	for _, p := range r.pluginsFor(event) {
+		if p.isClosed() || r.isDisabled(p) || !p.events.IsSet(event) {
+			continue
+		}
+		switch event {
+		case Event_CREATE_CONTAINER, Event_UPDATE_CONTAINER, Event_STOP_CONTAINER:
+			if p.isStandby() {
+				continue
+			}
+		}
+		chain = append(chain, p.name())
+	}
+	return chain
+}
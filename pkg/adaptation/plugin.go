@@ -33,8 +33,11 @@ import (
 	"github.com/containerd/nri/pkg/net"
 	"github.com/containerd/nri/pkg/net/multiplex"
 	"github.com/containerd/ttrpc"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -44,6 +47,11 @@ const (
 	DefaultPluginRequestTimeout = api.DefaultPluginRequestTimeout
 )
 
+const (
+	// maxSubPlugins is the maximum number of sub-plugins on a connection.
+	maxSubPlugins = 32
+)
+
 var (
 	pluginRegistrationTimeout = DefaultPluginRegistrationTimeout
 	pluginRequestTimeout      = DefaultPluginRequestTimeout
@@ -52,17 +60,55 @@ var (
 
 type plugin struct {
 	sync.Mutex
-	idx    string
-	base   string
-	cfg    string
+	idx  string
+	base string
+	cfg  string
+	// manifest of a pre-installed plugin, if it has one
+	manifest *PluginManifest
+	// configuration the plugin last failed to be reconfigured with
+	badCfg string
 	pid    int
+	uid    int
+	gid    int
 	cmd    *exec.Cmd
 	mux    multiplex.Mux
 	rpcc   *ttrpc.Client
 	rpcl   stdnet.Listener
 	rpcs   *ttrpc.Server
 	events EventMask
+	filter *EventFilter
 	closed bool
+	// gRPC client and server, used instead of ttrpc for gRPC plugins
+	transport transport
+	grpcc     *grpc.ClientConn
+	grpcs     *grpc.Server
+	// ordering constraints declared by the plugin
+	runAfter  []string
+	runBefore []string
+	// consecutive failed health checks
+	pingFails int
+	// timer detaching the plugin if it stops sending keepalives
+	kaTimer *time.Timer
+	// leader election among replicated instances of the plugin
+	election bool
+	standby  bool
+	// subscription restored on reconnect, synchronized with the runtime
+	restored bool
+	synced   bool
+	// connection recorded for the debug status page
+	debugConn string
+	// readiness reported by the plugin, closed readyC once ready
+	notReady  bool
+	statusMsg string
+	readyC    chan struct{}
+	// plugin hosting this sub-plugin on its connection, sub-plugins it hosts
+	host *plugin
+	subs []*plugin
+	// queue of events delivered asynchronously
+	evtQueue *eventQueue
+	// request timeouts from the drop-in file of the plugin, by event
+	timeouts map[Event]time.Duration
+
 	regC   chan error
 	closeC chan struct{}
 	r      *Adaptation
@@ -98,9 +144,8 @@ func getPluginRequestTimeout() time.Duration {
 // newLaunchedPlugin launches a pre-installed plugin with a pre-connected socketpair.
 // If the plugin is a wasm binary, then it will use the internal wasm service
 // to setup the plugin.
-func (r *Adaptation) newLaunchedPlugin(dir, idx, base, cfg string) (p *plugin, retErr error) {
+func (r *Adaptation) newLaunchedPlugin(fullPath, idx, base, cfg string) (p *plugin, retErr error) {
 	name := idx + "-" + base
-	fullPath := filepath.Join(dir, name)
 
 	if isWasm(fullPath) {
 		log.Infof(noCtx, "Found WASM plugin: %s", fullPath)
@@ -190,11 +235,12 @@ func isWasm(path string) bool {
 }
 
 // Create a plugin (stub) for an accepted external plugin connection.
-func (r *Adaptation) newExternalPlugin(conn stdnet.Conn) (p *plugin, retErr error) {
+func (r *Adaptation) newExternalPlugin(conn stdnet.Conn, t transport) (p *plugin, retErr error) {
 	p = &plugin{
-		regC:   make(chan error, 1),
-		closeC: make(chan struct{}),
-		r:      r,
+		transport: t,
+		regC:      make(chan error, 1),
+		closeC:    make(chan struct{}),
+		r:         r,
 	}
 	if err := p.connect(conn); err != nil {
 		return nil, err
@@ -203,6 +249,27 @@ func (r *Adaptation) newExternalPlugin(conn stdnet.Conn) (p *plugin, retErr erro
 	return p, nil
 }
 
+// Create a sub-plugin hosted on the connection of another plugin.
+func (r *Adaptation) newSubPlugin(host *plugin, n int) (*plugin, error) {
+	p := &plugin{
+		pid:       host.pid,
+		uid:       host.uid,
+		gid:       host.gid,
+		host:      host,
+		transport: host.transport,
+		regC:      make(chan error, 1),
+		closeC:    make(chan struct{}),
+		r:         r,
+	}
+
+	pluginID, runtimeID := multiplex.SubPluginConns(n)
+	if err := p.connectMux(host.mux, pluginID, runtimeID); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
 // Get plugin-specific configuration for an NRI-launched plugin.
 func (r *Adaptation) getPluginConfig(id, base string) (string, error) {
 	name := id + "-" + base
@@ -212,7 +279,7 @@ func (r *Adaptation) getPluginConfig(id, base string) (string, error) {
 	}
 
 	for _, path := range dropIns {
-		buf, err := os.ReadFile(path)
+		buf, err := r.configs.readFile(path)
 		if err == nil {
 			return string(buf), nil
 		}
@@ -238,7 +305,27 @@ func (p *plugin) connect(conn stdnet.Conn) (retErr error) {
 		}
 	}()
 
-	pconn, err := mux.Open(multiplex.PluginServiceConn)
+	if err := p.connectMux(mux, multiplex.PluginServiceConn, multiplex.RuntimeServiceConn); err != nil {
+		return err
+	}
+
+	var err error
+	p.pid, p.uid, p.gid, err = getPeerCred(p.mux.Trunk())
+	if err != nil {
+		log.Warnf(noCtx, "failed to determine plugin pid pid: %v", err)
+	}
+
+	return nil
+}
+
+// connectMux sets up the ttrpc client and server of a plugin on the given
+// multiplexed connections.
+func (p *plugin) connectMux(mux multiplex.Mux, pluginID, runtimeID multiplex.ConnID) (retErr error) {
+	if p.transport == grpcTransport {
+		return p.connectGRPC(mux, pluginID, runtimeID)
+	}
+
+	pconn, err := mux.Open(pluginID)
 	if err != nil {
 		return fmt.Errorf("failed to mux plugin connection for plugin %q: %w", p.name(), err)
 	}
@@ -246,9 +333,7 @@ func (p *plugin) connect(conn stdnet.Conn) (retErr error) {
 	clientOpts := []ttrpc.ClientOpts{
 		ttrpc.WithOnClose(
 			func() {
-				log.Infof(noCtx, "connection to plugin %q closed", p.name())
-				close(p.closeC)
-				p.close()
+				p.connectionClosed()
 			}),
 	}
 	rpcc := ttrpc.NewClient(pconn, append(clientOpts, p.r.clientOpts...)...)
@@ -268,7 +353,7 @@ func (p *plugin) connect(conn stdnet.Conn) (retErr error) {
 		}
 	}()
 
-	rpcl, err := mux.Listen(multiplex.RuntimeServiceConn)
+	rpcl, err := mux.Listen(runtimeID)
 	if err != nil {
 		return fmt.Errorf("failed to create mux runtime listener for plugin %q: %w", p.name(), err)
 	}
@@ -279,16 +364,19 @@ func (p *plugin) connect(conn stdnet.Conn) (retErr error) {
 	p.rpcs = rpcs
 	p.impl = &pluginType{ttrpcImpl: api.NewPluginClient(rpcc)}
 
-	p.pid, err = getPeerPid(p.mux.Trunk())
-	if err != nil {
-		log.Warnf(noCtx, "failed to determine plugin pid pid: %v", err)
-	}
-
 	api.RegisterRuntimeService(p.rpcs, p)
 
 	return nil
 }
 
+// connectionClosed cleans up after the connection to the plugin went down.
+func (p *plugin) connectionClosed() {
+	log.Infof(noCtx, "connection to plugin %q closed", p.name())
+	close(p.closeC)
+	p.close()
+	p.r.snapshotPlugin(p)
+}
+
 // Start Runtime service, wait for plugin to register, then configure it.
 func (p *plugin) start(name, version string) (err error) {
 	// skip start for WASM plugins and head right to the registration for
@@ -300,9 +388,16 @@ func (p *plugin) start(name, version string) (err error) {
 		)
 
 		go func() {
-			err := p.rpcs.Serve(context.Background(), p.rpcl)
-			if err != ttrpc.ErrServerClosed {
-				log.Infof(noCtx, "ttrpc server for plugin %q closed (%v)", p.name(), err)
+			if p.transport == grpcTransport {
+				err := p.grpcs.Serve(p.rpcl)
+				if err != nil && err != grpc.ErrServerStopped {
+					log.Infof(noCtx, "gRPC server for plugin %q closed (%v)", p.name(), err)
+				}
+			} else {
+				err := p.rpcs.Serve(context.Background(), p.rpcl)
+				if err != ttrpc.ErrServerClosed {
+					log.Infof(noCtx, "ttrpc server for plugin %q closed (%v)", p.name(), err)
+				}
 			}
 			p.close()
 		}()
@@ -321,6 +416,10 @@ func (p *plugin) start(name, version string) (err error) {
 			p.stop()
 			return errors.New("plugin registration timed out")
 		}
+
+		if p.restored {
+			return nil
+		}
 	}
 
 	err = p.configure(context.Background(), name, version, p.cfg)
@@ -333,7 +432,7 @@ func (p *plugin) start(name, version string) (err error) {
 	return nil
 }
 
-// close a plugin shutting down its multiplexed ttrpc connections.
+// close a plugin shutting down its multiplexed ttrpc or gRPC connections.
 func (p *plugin) close() {
 	if p.impl.isWasm() {
 		return
@@ -346,18 +445,100 @@ func (p *plugin) close() {
 	}
 
 	p.closed = true
-	p.mux.Close()
-	p.rpcc.Close()
-	p.rpcs.Close()
+	if p.kaTimer != nil {
+		p.kaTimer.Stop()
+	}
+	if p.evtQueue != nil {
+		p.evtQueue.stop()
+	}
+	if p.readyC != nil {
+		close(p.readyC)
+		p.readyC = nil
+	}
+	if p.debugConn != "" {
+		p.r.debugTrk.Disconnected(p.debugConn)
+	}
+	if p.host == nil {
+		p.mux.Close()
+	}
+	if p.transport == grpcTransport {
+		p.grpcc.Close()
+		p.grpcs.Stop()
+	} else {
+		p.rpcc.Close()
+		p.rpcs.Close()
+	}
 	p.rpcl.Close()
 }
 
+// trackConnection records the plugin connection for the debug status page.
+func (p *plugin) trackConnection() {
+	if p.r.debugTrk == nil {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if p.closed || p.debugConn != "" {
+		return
+	}
+
+	p.debugConn = p.qualifiedName()
+	p.r.debugTrk.Connected(p.debugConn)
+}
+
 func (p *plugin) isClosed() bool {
 	p.Lock()
 	defer p.Unlock()
 	return p.closed
 }
 
+// markSynchronized marks the plugin synchronized with the runtime.
+func (p *plugin) markSynchronized() {
+	p.Lock()
+	defer p.Unlock()
+	p.synced = true
+}
+
+// isSynchronized returns true if the plugin has been synchronized.
+func (p *plugin) isSynchronized() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.synced
+}
+
+// isStandby returns true if the plugin is a standby instance of a plugin.
+func (p *plugin) isStandby() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.standby
+}
+
+// isLeaderOf returns true if the plugin is the active leader of the group.
+func (p *plugin) isLeaderOf(name string) bool {
+	return p.election && p.name() == name && !p.isClosed() && !p.isStandby()
+}
+
+// setLeadership sets the role of the plugin and notifies it about the role.
+func (p *plugin) setLeadership(ctx context.Context, leader bool) error {
+	p.Lock()
+	p.standby = !leader
+	p.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	defer cancel()
+
+	req := &SetLeadershipRequest{
+		Leader: leader,
+	}
+
+	if err := p.impl.SetLeadership(ctx, req); err != nil {
+		return fmt.Errorf("failed to set leadership of plugin: %w", err)
+	}
+
+	return nil
+}
+
 // stop a plugin (if it was launched by us)
 func (p *plugin) stop() error {
 	if p.isExternal() || p.cmd.Process == nil || p.impl.isWasm() {
@@ -411,19 +592,92 @@ func (p *plugin) RegisterPlugin(ctx context.Context, req *RegisterPluginRequest)
 		}
 		p.base = req.PluginName
 		p.idx = req.PluginIdx
+		p.election = req.LeaderElection
+		if err := p.authorize(ctx, req.AuthToken); err != nil {
+			p.regC <- fmt.Errorf("plugin %q failed authorization: %w", p.name(), err)
+			return &RegisterPluginResponse{}, err
+		}
+		if err := p.verify(ctx); err != nil {
+			p.regC <- fmt.Errorf("plugin %q failed verification: %w", p.name(), err)
+			return &RegisterPluginResponse{}, err
+		}
+	}
+
+	timeouts, err := p.r.getPluginTimeouts(p.idx, p.base)
+	if err != nil {
+		p.regC <- fmt.Errorf("plugin %q failed to register: %w", p.name(), err)
+		return &RegisterPluginResponse{}, err
+	}
+	p.timeouts = timeouts
+
+	if err := p.addSubPlugins(int(req.SubPlugins)); err != nil {
+		p.regC <- fmt.Errorf("plugin %q failed to register sub-plugins: %w", p.name(), err)
+		return &RegisterPluginResponse{}, err
 	}
 
 	log.Infof(ctx, "plugin %q registered as %q", p.qualifiedName(), p.name())
+	p.trackConnection()
+
+	if req.ReportsStatus {
+		p.setStatus(ctx, false, initialStatusMessage)
+	}
+
+	if req.SchemaVersion > api.SchemaVersion {
+		log.Infof(ctx, "plugin %q uses newer payload schema version %d (> %d), "+
+			"passing through unknown fields", p.name(), req.SchemaVersion, api.SchemaVersion)
+	}
+
+	rpl := &RegisterPluginResponse{}
+	if p.isExternal() && req.RestoreSubscription && p.r.restorePlugin(p) {
+		log.Infof(ctx, "restored previous subscription of plugin %q", p.name())
+		rpl.SubscriptionRestored = true
+	}
 
 	p.regC <- nil
-	return &RegisterPluginResponse{}, nil
+	return rpl, nil
+}
+
+// addSubPlugins sets up the given number of sub-plugins on the connection
+// of the plugin. The sub-plugins register and get started separately.
+func (p *plugin) addSubPlugins(cnt int) error {
+	switch {
+	case cnt == 0:
+		return nil
+	case p.host != nil:
+		return errors.New("sub-plugins can't host further sub-plugins")
+	case p.impl.isWasm():
+		return errors.New("WASM plugins can't host sub-plugins")
+	case cnt > maxSubPlugins:
+		return fmt.Errorf("too many sub-plugins (%d > %d)", cnt, maxSubPlugins)
+	}
+
+	for n := 1; n <= cnt; n++ {
+		sub, err := p.r.newSubPlugin(p, n)
+		if err != nil {
+			return err
+		}
+		p.subs = append(p.subs, sub)
+	}
+
+	return nil
 }
 
 // UpdateContainers relays container update request to the runtime.
 func (p *plugin) UpdateContainers(ctx context.Context, req *UpdateContainersRequest) (*UpdateContainersResponse, error) {
 	log.Infof(ctx, "plugin %q requested container updates", p.name())
 
-	failed, err := p.r.updateContainers(ctx, req.Update)
+	if p.isStandby() {
+		return &UpdateContainersResponse{
+			Failed: req.Update,
+		}, fmt.Errorf("plugin %q is on standby, can't update containers", p.name())
+	}
+	if p.r.isDisabled(p) {
+		return &UpdateContainersResponse{
+			Failed: req.Update,
+		}, fmt.Errorf("plugin %q is disabled, can't update containers", p.name())
+	}
+
+	failed, err := p.r.updateContainers(ctx, p, req.Update)
 	return &UpdateContainersResponse{
 		Failed: failed,
 	}, err
@@ -440,6 +694,10 @@ func (p *plugin) configure(ctx context.Context, name, version, config string) (e
 		RuntimeVersion:      version,
 		RegistrationTimeout: getPluginRegistrationTimeout().Milliseconds(),
 		RequestTimeout:      getPluginRequestTimeout().Milliseconds(),
+		SchemaVersion:       api.SchemaVersion,
+		AnnotationLimits:    p.r.annoLimits,
+		RuntimeDefaults:     p.r.defaults,
+		KeepaliveInterval:   p.r.kaIntv.Milliseconds(),
 	}
 
 	rpl, err := p.impl.Configure(ctx, req)
@@ -457,6 +715,13 @@ func (p *plugin) configure(ctx context.Context, name, version, config string) (e
 	}
 	p.events = events
 
+	if err := rpl.Filter.Validate(); err != nil {
+		return err
+	}
+	p.filter = rpl.Filter
+	p.runAfter = rpl.RunAfter
+	p.runBefore = rpl.RunBefore
+
 	return nil
 }
 
@@ -464,30 +729,31 @@ func (p *plugin) configure(ctx context.Context, name, version, config string) (e
 func (p *plugin) synchronize(ctx context.Context, pods []*PodSandbox, containers []*Container) ([]*ContainerUpdate, error) {
 	log.Infof(ctx, "synchronizing plugin %s", p.name())
 
-	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
-	defer cancel()
+	pods, containers = p.filterSnapshot(pods, containers)
 
 	var (
-		podsToSend = pods
-		ctrsToSend = containers
-		podsPerMsg = len(pods)
-		ctrsPerMsg = len(containers)
+		podsToSend   = pods
+		ctrsToSend   = containers
+		chunkSize    = p.r.syncChunk
+		continuation string
 
 		rpl *SynchronizeResponse
 		err error
 	)
 
 	for {
+		podsPerMsg, ctrsPerMsg := nextSyncChunk(podsToSend, ctrsToSend, chunkSize)
 		req := &SynchronizeRequest{
-			Pods:       podsToSend[:podsPerMsg],
-			Containers: ctrsToSend[:ctrsPerMsg],
-			More:       len(podsToSend) > podsPerMsg || len(ctrsToSend) > ctrsPerMsg,
+			Pods:         podsToSend[:podsPerMsg],
+			Containers:   ctrsToSend[:ctrsPerMsg],
+			More:         len(podsToSend) > podsPerMsg || len(ctrsToSend) > ctrsPerMsg,
+			Continuation: continuation,
 		}
 
 		log.Debugf(ctx, "sending sync message, %d/%d, %d/%d (more: %v)",
 			len(req.Pods), len(podsToSend), len(req.Containers), len(ctrsToSend), req.More)
 
-		rpl, err = p.impl.Synchronize(ctx, req)
+		rpl, err = p.syncChunk(ctx, req)
 		if err == nil {
 			if !req.More {
 				break
@@ -500,15 +766,9 @@ func (p *plugin) synchronize(ctx context.Context, pods []*PodSandbox, containers
 
 			podsToSend = podsToSend[podsPerMsg:]
 			ctrsToSend = ctrsToSend[ctrsPerMsg:]
-
-			if podsPerMsg > len(podsToSend) {
-				podsPerMsg = len(podsToSend)
-			}
-			if ctrsPerMsg > len(ctrsToSend) {
-				ctrsPerMsg = len(ctrsToSend)
-			}
+			continuation = rpl.Continuation
 		} else {
-			podsPerMsg, ctrsPerMsg, err = recalcObjsPerSyncMsg(podsPerMsg, ctrsPerMsg, err)
+			chunkSize, err = recalcSyncChunkSize(chunkSize, podsPerMsg+ctrsPerMsg, err)
 			if err != nil {
 				p.close()
 				return nil, err
@@ -518,32 +778,145 @@ func (p *plugin) synchronize(ctx context.Context, pods []*PodSandbox, containers
 		}
 	}
 
+	p.markSynchronized()
+
+	if p.isStandby() && len(rpl.Update) > 0 {
+		log.Warnf(ctx, "ignoring container updates from standby plugin %q", p.name())
+		return nil, nil
+	}
+
 	return rpl.Update, nil
 }
 
-func recalcObjsPerSyncMsg(pods, ctrs int, err error) (int, int, error) {
-	const (
-		minObjsPerMsg = 8
+// syncChunk sends a single chunk of a synchronization to the plugin. Each
+// chunk gets the full request timeout, so synchronizing a plugin with a large
+// number of pods and containers does not time out.
+func (p *plugin) syncChunk(ctx context.Context, req *SynchronizeRequest) (*SynchronizeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	defer cancel()
+
+	return p.impl.Synchronize(ctx, req)
+}
+
+// filterSnapshot returns the pods and containers of a runtime snapshot which
+// match the event filter of the plugin, with annotations not forwarded to the
+// plugin removed and data redacted to the visibility of the plugin. The
+// snapshot itself is not modified.
+func (p *plugin) filterSnapshot(pods []*PodSandbox, containers []*Container) ([]*PodSandbox, []*Container) {
+	if p.filter != nil {
+		pods, containers = p.matchSnapshot(pods, containers)
+	}
+
+	if _, ok := p.annotationPrefixes(); ok || p.visibility() != VisibilityFull {
+		pods, containers = p.forwardSnapshot(pods, containers)
+	}
+
+	return pods, containers
+}
+
+// matchSnapshot returns the pods and containers of a runtime snapshot which
+// match the event filter of the plugin.
+func (p *plugin) matchSnapshot(pods []*PodSandbox, containers []*Container) ([]*PodSandbox, []*Container) {
+	var (
+		matched  = make(map[string]struct{})
+		podsKept = make([]*PodSandbox, 0, len(pods))
+		ctrsKept = make([]*Container, 0, len(containers))
+	)
+
+	for _, pod := range pods {
+		if p.filter.Matches(pod) {
+			matched[pod.Id] = struct{}{}
+			podsKept = append(podsKept, pod)
+		}
+	}
+	for _, ctr := range containers {
+		if _, ok := matched[ctr.PodSandboxId]; ok {
+			ctrsKept = append(ctrsKept, ctr)
+		}
+	}
+
+	return podsKept, ctrsKept
+}
+
+// forwardSnapshot returns the pods and containers of a runtime snapshot as
+// passed to the plugin.
+func (p *plugin) forwardSnapshot(pods []*PodSandbox, containers []*Container) ([]*PodSandbox, []*Container) {
+	var (
+		podsKept = make([]*PodSandbox, 0, len(pods))
+		ctrsKept = make([]*Container, 0, len(containers))
 	)
 
+	for _, pod := range pods {
+		pod, _, _ = p.forward(pod, nil)
+		podsKept = append(podsKept, pod)
+	}
+	for _, ctr := range containers {
+		_, ctr, _ = p.forward(nil, ctr)
+		ctrsKept = append(ctrsKept, ctr)
+	}
+
+	return podsKept, ctrsKept
+}
+
+// nextSyncChunk returns the number of pods and containers to send in the
+// next chunk of a synchronization, with the chunk not exceeding the given
+// size. A chunk always has at least one pod or container.
+func nextSyncChunk(pods []*PodSandbox, ctrs []*Container, size int) (int, int) {
+	var (
+		total = 0
+		nPods = 0
+		nCtrs = 0
+		fits  = func(n int) bool { return total == 0 || total+syncObjSize(n) <= size }
+	)
+
+	for nPods < len(pods) {
+		n := proto.Size(pods[nPods])
+		if !fits(n) {
+			return nPods, nCtrs
+		}
+		total += syncObjSize(n)
+		nPods++
+	}
+	for nCtrs < len(ctrs) {
+		n := proto.Size(ctrs[nCtrs])
+		if !fits(n) {
+			return nPods, nCtrs
+		}
+		total += syncObjSize(n)
+		nCtrs++
+	}
+
+	return nPods, nCtrs
+}
+
+// syncObjSize returns the size of a pod or container of the given size in
+// a SynchronizeRequest, with its field tag and length.
+func syncObjSize(n int) int {
+	return protowire.SizeTag(1) + protowire.SizeBytes(n)
+}
+
+// recalcSyncChunkSize returns a smaller chunk size for retrying a rejected
+// chunk of the given number of objects, if the chunk was rejected for being
+// too large.
+func recalcSyncChunkSize(size, objs int, err error) (int, error) {
 	if status.Code(err) != codes.ResourceExhausted {
-		return pods, ctrs, err
+		return size, err
 	}
 
-	if pods+ctrs <= minObjsPerMsg {
-		return pods, ctrs, fmt.Errorf("failed to synchronize plugin with split messages")
+	if objs <= 1 {
+		return size, fmt.Errorf("failed to synchronize plugin with split messages")
 	}
 
 	var e *ttrpc.OversizedMessageErr
 	if !errors.As(err, &e) {
-		return pods, ctrs, fmt.Errorf("failed to synchronize plugin with split messages")
+		return size, fmt.Errorf("failed to synchronize plugin with split messages")
 	}
 
 	maxLen := e.MaximumLength()
 	msgLen := e.RejectedLength()
 
 	if msgLen == 0 || maxLen == 0 || msgLen <= maxLen {
-		return pods, ctrs, fmt.Errorf("failed to synchronize plugin with split messages")
+		return size, fmt.Errorf("failed to synchronize plugin with split messages")
 	}
 
 	factor := float64(maxLen) / float64(msgLen)
@@ -551,27 +924,36 @@ func recalcObjsPerSyncMsg(pods, ctrs int, err error) (int, int, error) {
 		factor = 0.9
 	}
 
-	pods = int(float64(pods) * factor)
-	ctrs = int(float64(ctrs) * factor)
-
-	if pods+ctrs < minObjsPerMsg {
-		pods = minObjsPerMsg / 2
-		ctrs = minObjsPerMsg / 2
-	}
-
-	return pods, ctrs, nil
+	return int(float64(size) * factor), nil
 }
 
 // Relay CreateContainer request to plugin.
 func (p *plugin) createContainer(ctx context.Context, req *CreateContainerRequest) (*CreateContainerResponse, error) {
-	if !p.events.IsSet(Event_CREATE_CONTAINER) {
+	if !p.events.IsSet(Event_CREATE_CONTAINER) || p.isStandby() || p.isFiltered(req.Pod) {
 		return nil, nil
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	if err := p.waitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	// forwarding only changes the request for plugins which don't see adjustments
+	if !p.seesAdjustments() {
+		pod, ctr, _ := p.forward(req.Pod, req.Container)
+		req = &CreateContainerRequest{Pod: pod, Container: ctr}
+	}
+	req.TimeBudget = timeBudget(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, p.requestTimeout(Event_CREATE_CONTAINER))
 	defer cancel()
 
+	if err := p.drainEvents(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
 	rpl, err := p.impl.CreateContainer(ctx, req)
+	p.recordRequest(ctx, Event_CREATE_CONTAINER, start, err)
 	if err != nil {
 		if isFatalError(err) {
 			log.Errorf(ctx, "closing plugin %s, failed to handle CreateContainer request: %v",
@@ -587,14 +969,29 @@ func (p *plugin) createContainer(ctx context.Context, req *CreateContainerReques
 
 // Relay UpdateContainer request to plugin.
 func (p *plugin) updateContainer(ctx context.Context, req *UpdateContainerRequest) (*UpdateContainerResponse, error) {
-	if !p.events.IsSet(Event_UPDATE_CONTAINER) {
+	if !p.events.IsSet(Event_UPDATE_CONTAINER) || p.isStandby() || p.isFiltered(req.Pod) {
 		return nil, nil
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	if err := p.waitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	if pod, ctr, ok := p.forward(req.Pod, req.Container); ok {
+		req = &UpdateContainerRequest{Pod: pod, Container: ctr, LinuxResources: req.LinuxResources, Stats: req.Stats}
+	}
+	req.TimeBudget = timeBudget(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, p.requestTimeout(Event_UPDATE_CONTAINER))
 	defer cancel()
 
+	if err := p.drainEvents(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
 	rpl, err := p.impl.UpdateContainer(ctx, req)
+	p.recordRequest(ctx, Event_UPDATE_CONTAINER, start, err)
 	if err != nil {
 		if isFatalError(err) {
 			log.Errorf(ctx, "closing plugin %s, failed to handle UpdateContainer request: %v",
@@ -610,14 +1007,29 @@ func (p *plugin) updateContainer(ctx context.Context, req *UpdateContainerReques
 
 // Relay StopContainer request to the plugin.
 func (p *plugin) stopContainer(ctx context.Context, req *StopContainerRequest) (rpl *StopContainerResponse, err error) {
-	if !p.events.IsSet(Event_STOP_CONTAINER) {
+	if !p.events.IsSet(Event_STOP_CONTAINER) || p.isStandby() || p.isFiltered(req.Pod) {
 		return nil, nil
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	if err := p.waitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	if pod, ctr, ok := p.forward(req.Pod, req.Container); ok {
+		req = &StopContainerRequest{Pod: pod, Container: ctr, Stats: req.Stats}
+	}
+	req.TimeBudget = timeBudget(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, p.requestTimeout(Event_STOP_CONTAINER))
 	defer cancel()
 
+	if err := p.drainEvents(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
 	rpl, err = p.impl.StopContainer(ctx, req)
+	p.recordRequest(ctx, Event_STOP_CONTAINER, start, err)
 	if err != nil {
 		if isFatalError(err) {
 			log.Errorf(ctx, "closing plugin %s, failed to handle StopContainer request: %v",
@@ -632,15 +1044,34 @@ func (p *plugin) stopContainer(ctx context.Context, req *StopContainerRequest) (
 }
 
 // Relay other pod or container state change events to the plugin.
-func (p *plugin) StateChange(ctx context.Context, evt *StateChangeEvent) (err error) {
-	if !p.events.IsSet(evt.Event) {
+func (p *plugin) StateChange(ctx context.Context, evt *StateChangeEvent) error {
+	if !p.events.IsSet(evt.Event) || p.isFiltered(evt.Pod) {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, getPluginRequestTimeout())
+	return p.stateChange(ctx, evt)
+}
+
+// stateChange relays a state change event to the plugin, regardless of the
+// events it is subscribed to.
+func (p *plugin) stateChange(ctx context.Context, evt *StateChangeEvent) (err error) {
+	evt = p.forwardEvent(evt)
+	evt.TimeBudget = timeBudget(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, p.requestTimeout(evt.Event))
 	defer cancel()
 
-	if err = p.impl.StateChange(ctx, evt); err != nil {
+	if err := p.drainEvents(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = p.impl.StateChange(ctx, evt)
+	p.recordRequest(ctx, evt.Event, start, err)
+	if err != nil {
+		if reason, ok := api.IsVeto(err); ok {
+			return p.veto(ctx, evt, reason)
+		}
 		if isFatalError(err) {
 			log.Errorf(ctx, "closing plugin %s, failed to handle event %d: %v",
 				p.name(), evt.Event, err)
@@ -653,6 +1084,26 @@ func (p *plugin) StateChange(ctx context.Context, evt *StateChangeEvent) (err er
 	return nil
 }
 
+// isFiltered returns true if the plugin has filtered out events of the pod.
+// Events without a pod are never filtered out.
+func (p *plugin) isFiltered(pod *PodSandbox) bool {
+	return pod != nil && !p.filter.Matches(pod)
+}
+
+// timeBudget returns the time left until the deadline of the runtime
+// operation, in nanoseconds, or 0 if the operation has no deadline.
+func timeBudget(ctx context.Context) int64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	if left := time.Until(deadline); left > 0 {
+		return int64(left)
+	}
+	// the deadline has already passed, let the plugin know it has no time left
+	return 1
+}
+
 // isFatalError returns true if the error is fatal and the plugin connection should be closed.
 func isFatalError(err error) bool {
 	switch {
@@ -664,6 +1115,9 @@ func isFatalError(err error) bool {
 		return true
 	case errors.Is(err, context.DeadlineExceeded):
 		return true
+	case status.Code(err) == codes.Unavailable, status.Code(err) == codes.DeadlineExceeded:
+		// connection to a gRPC plugin lost or request timed out
+		return true
 	}
 	return false
 }
@@ -22,33 +22,54 @@ import (
 	"errors"
 	"fmt"
 	stdnet "net"
+	"os"
+	"strconv"
 
 	"golang.org/x/sys/unix"
 )
 
-// getPeerPid returns the process id at the other end of the connection.
-func getPeerPid(conn stdnet.Conn) (int, error) {
+// getPeerCred returns the process, user and group id at the other end of the
+// connection.
+func getPeerCred(conn stdnet.Conn) (pid, uid, gid int, err error) {
 	var cred *unix.Ucred
 
 	uc, ok := conn.(*stdnet.UnixConn)
 	if !ok {
-		return 0, errors.New("invalid connection, not *net.UnixConn")
+		return 0, -1, -1, errors.New("invalid connection, not *net.UnixConn")
 	}
 
 	raw, err := uc.SyscallConn()
 	if err != nil {
-		return 0, fmt.Errorf("failed get raw unix domain connection: %w", err)
+		return 0, -1, -1, fmt.Errorf("failed get raw unix domain connection: %w", err)
 	}
 
 	ctrlErr := raw.Control(func(fd uintptr) {
 		cred, err = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
 	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to get process credentials: %w", err)
+		return 0, -1, -1, fmt.Errorf("failed to get process credentials: %w", err)
 	}
 	if ctrlErr != nil {
-		return 0, fmt.Errorf("uc.SyscallConn().Control() failed: %w", ctrlErr)
+		return 0, -1, -1, fmt.Errorf("uc.SyscallConn().Control() failed: %w", ctrlErr)
 	}
 
-	return int(cred.Pid), nil
+	return int(cred.Pid), int(cred.Uid), int(cred.Gid), nil
+}
+
+// openPeerExecutable opens the executable of the process with the given id,
+// returning its path and the opened executable.
+func openPeerExecutable(pid int) (string, *os.File, error) {
+	exe := "/proc/" + strconv.Itoa(pid) + "/exe"
+
+	path, err := os.Readlink(exe)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve executable of process %d: %w", pid, err)
+	}
+
+	f, err := os.Open(exe)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open executable of process %d: %w", pid, err)
+	}
+
+	return path, f, nil
 }
@@ -21,10 +21,18 @@ package adaptation
 import (
 	"fmt"
 	"net"
+	"os"
 	"runtime"
 )
 
-// getPeerPid returns the process id at the other end of the connection.
-func getPeerPid(conn net.Conn) (int, error) {
-	return 0, fmt.Errorf("getPeerPid() unimplemented on %s", runtime.GOOS)
+// getPeerCred returns the process, user and group id at the other end of the
+// connection.
+func getPeerCred(conn net.Conn) (pid, uid, gid int, err error) {
+	return 0, -1, -1, fmt.Errorf("getPeerCred() unimplemented on %s", runtime.GOOS)
+}
+
+// openPeerExecutable opens the executable of the process with the given id,
+// returning its path and the opened executable.
+func openPeerExecutable(_ int) (string, *os.File, error) {
+	return "", nil, fmt.Errorf("openPeerExecutable() unimplemented on %s", runtime.GOOS)
 }
@@ -78,3 +78,30 @@ func (p *pluginType) StateChange(ctx context.Context, req *StateChangeEvent) (er
 	}
 	return err
 }
+
+func (p *pluginType) SetLeadership(ctx context.Context, req *SetLeadershipRequest) (err error) {
+	if p.wasmImpl != nil {
+		_, err = p.wasmImpl.SetLeadership(ctx, req)
+	} else {
+		_, err = p.ttrpcImpl.SetLeadership(ctx, req)
+	}
+	return err
+}
+
+func (p *pluginType) Ping(ctx context.Context) (err error) {
+	if p.wasmImpl != nil {
+		_, err = p.wasmImpl.Ping(ctx, &api.Empty{})
+	} else {
+		_, err = p.ttrpcImpl.Ping(ctx, &api.Empty{})
+	}
+	return err
+}
+
+func (p *pluginType) ReconfigurePlugin(ctx context.Context, req *ReconfigurePluginRequest) (err error) {
+	if p.wasmImpl != nil {
+		_, err = p.wasmImpl.ReconfigurePlugin(ctx, req)
+	} else {
+		_, err = p.ttrpcImpl.ReconfigurePlugin(ctx, req)
+	}
+	return err
+}
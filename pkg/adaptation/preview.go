@@ -0,0 +1,100 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+// createPreview is the container creation in progress, which the plugin
+// handling it can preview its adjustments against.
+type createPreview struct {
+	sync.Mutex
+	id     string
+	plugin string
+	result *merge.Result
+}
+
+// start tracking the creation of the given container.
+func (c *createPreview) start(id string, result *merge.Result) {
+	c.Lock()
+	defer c.Unlock()
+	c.id = id
+	c.result = result
+}
+
+// stop tracking container creation.
+func (c *createPreview) stop() {
+	c.Lock()
+	defer c.Unlock()
+	c.id = ""
+	c.plugin = ""
+	c.result = nil
+}
+
+// setPlugin sets the plugin handling the creation, or clears it. Clearing
+// the plugin waits for any preview in progress to finish.
+func (c *createPreview) setPlugin(name string) {
+	c.Lock()
+	defer c.Unlock()
+	c.plugin = name
+}
+
+// preview the given adjustment of a plugin.
+func (c *createPreview) preview(plugin string, req *PreviewAdjustmentRequest, options ...merge.Option) (*PreviewAdjustmentResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.result == nil || c.id != req.ContainerId || c.plugin != plugin {
+		return nil, fmt.Errorf("plugin %q is not handling the creation of container %q",
+			plugin, req.ContainerId)
+	}
+
+	conflicts, err := c.result.Preview(req.Adjust, plugin, options...)
+
+	rpl := &PreviewAdjustmentResponse{}
+	for _, conflict := range conflicts {
+		rpl.Conflicts = append(rpl.Conflicts, &AdjustmentConflict{
+			Subject: conflict.Subject,
+			Owner:   conflict.Owner,
+		})
+	}
+	if err != nil {
+		rpl.Error = err.Error()
+	}
+
+	return rpl, nil
+}
+
+// previewOptions returns the options for previewing adjustments. These use
+// the conflict resolvers of the runtime without recording metrics.
+func (r *Adaptation) previewOptions() []merge.Option {
+	var options []merge.Option
+	for class, resolver := range r.resolvers {
+		options = append(options, merge.WithConflictResolver(class, resolver))
+	}
+	return options
+}
+
+// PreviewAdjustment handles the plugin's request to preview an adjustment.
+func (p *plugin) PreviewAdjustment(_ context.Context, req *PreviewAdjustmentRequest) (*PreviewAdjustmentResponse, error) {
+	return p.r.preview.preview(p.name(), req, p.r.previewOptions()...)
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+)
+
+// GetPods handles the plugin's request for the current pods. Pods are
+// returned as last seen in synchronization or relayed events, excluding
+// the ones filtered out for the plugin. Plugins can query pods while
+// handling events, since this does not take the runtime lock.
+func (p *plugin) GetPods(_ context.Context, _ *GetPodsRequest) (*GetPodsResponse, error) {
+	p.r.cacheLock.Lock()
+	pods := p.r.cache.getPods()
+	p.r.cacheLock.Unlock()
+
+	pods, _ = p.filterSnapshot(pods, nil)
+
+	return &GetPodsResponse{
+		Pods: pods,
+	}, nil
+}
+
+// GetContainers handles the plugin's request for the current containers.
+// Containers are returned as last seen in synchronization or relayed events,
+// excluding the ones in pods filtered out for the plugin.
+func (p *plugin) GetContainers(_ context.Context, req *GetContainersRequest) (*GetContainersResponse, error) {
+	p.r.cacheLock.Lock()
+	pods := p.r.cache.getPods()
+	ctrs := p.r.cache.getContainers(req.Filter)
+	p.r.cacheLock.Unlock()
+
+	_, ctrs = p.filterSnapshot(pods, ctrs)
+
+	return &GetContainersResponse{
+		Containers: ctrs,
+	}, nil
+}
@@ -0,0 +1,242 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Visibility is the class of pod and container data passed to a plugin.
+type Visibility int
+
+const (
+	// VisibilityFull passes all pod and container data to the plugin.
+	VisibilityFull Visibility = iota
+	// VisibilityMetadata passes only the identity, labels, annotations,
+	// state, image and exit information of pods and containers to the
+	// plugin. Their specs, for instance command line arguments, environment
+	// variables, mounts, resources and network configuration, are dropped.
+	VisibilityMetadata
+	// VisibilityAnonymized passes metadata like VisibilityMetadata, with
+	// labels, annotations and exit messages dropped, and pod and container
+	// names, pod namespaces and UIDs, and image names and IDs replaced by
+	// opaque hashes. Hashes are stable for the lifetime of the runtime
+	// adaptation, so plugins can still correlate pods and containers.
+	VisibilityAnonymized
+)
+
+var visibilityNames = map[Visibility]string{
+	VisibilityFull:       "full",
+	VisibilityMetadata:   "metadata",
+	VisibilityAnonymized: "anonymized",
+}
+
+// String returns the name of the visibility class.
+func (v Visibility) String() string {
+	if name, ok := visibilityNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("<unknown visibility %d>", v)
+}
+
+// ParseVisibility parses a visibility class name, as used in runtime
+// configuration: full, metadata or anonymized.
+func ParseVisibility(name string) (Visibility, error) {
+	for v, n := range visibilityNames {
+		if n == name {
+			return v, nil
+		}
+	}
+	return VisibilityFull, fmt.Errorf("invalid plugin visibility %q", name)
+}
+
+// WithPluginVisibility returns an option to set the class of pod and
+// container data passed to a plugin. This lets lower-trust plugins, for
+// instance third-party ones, participate without seeing sensitive data of
+// pods and containers. The plugin is given by its full (idx-name) or base
+// name, or AnyPlugin to set the class of all plugins without one of their
+// own. Data is redacted in all events, synchronization and plugin queries.
+// Event filters of plugins are matched against unredacted pods. By default
+// all plugins get full visibility.
+func WithPluginVisibility(plugin string, visibility Visibility) Option {
+	return func(r *Adaptation) error {
+		if plugin == "" {
+			return fmt.Errorf("invalid plugin visibility, no plugin given")
+		}
+		if _, ok := visibilityNames[visibility]; !ok {
+			return fmt.Errorf("invalid visibility %d for plugin %q", visibility, plugin)
+		}
+		if r.redactKey == nil {
+			key := make([]byte, sha256.Size)
+			if _, err := rand.Read(key); err != nil {
+				return fmt.Errorf("failed to generate redaction key: %w", err)
+			}
+			r.redactKey = key
+		}
+		if r.visibility == nil {
+			r.visibility = map[string]Visibility{}
+		}
+		r.visibility[plugin] = visibility
+		return nil
+	}
+}
+
+// seesAdjustments returns true if the adjustments collected from preceding
+// plugins are passed to the plugin in CreateContainer requests. This is only
+// the case for plugins with full visibility and all annotations forwarded.
+func (p *plugin) seesAdjustments() bool {
+	_, limited := p.annotationPrefixes()
+	return !limited && p.visibility() == VisibilityFull
+}
+
+// visibility returns the class of data passed to the plugin.
+func (p *plugin) visibility() Visibility {
+	for _, name := range []string{p.name(), p.base, AnyPlugin} {
+		if v, ok := p.r.visibility[name]; ok {
+			return v
+		}
+	}
+	return VisibilityFull
+}
+
+// forward returns the pod and container as passed to the plugin, with
+// annotations not forwarded to the plugin removed and data redacted to the
+// visibility of the plugin, and true if either was changed. The given pod
+// and container are not modified.
+func (p *plugin) forward(pod *PodSandbox, ctr *Container) (*PodSandbox, *Container, bool) {
+	pod, ctr, stripped := p.forwardAnnotations(pod, ctr)
+
+	v := p.visibility()
+	if v == VisibilityFull {
+		return pod, ctr, stripped
+	}
+
+	return p.r.redactPod(pod, v), p.r.redactContainer(ctr, v), true
+}
+
+// forwardEvent returns the event as passed to the plugin, with its pod and
+// container forwarded and the adjustment of a creation rollback left out for
+// plugins which don't see adjustments. The given event is not modified.
+func (p *plugin) forwardEvent(evt *StateChangeEvent) *StateChangeEvent {
+	pod, ctr, changed := p.forward(evt.Pod, evt.Container)
+	if !changed && (evt.Adjust == nil || p.seesAdjustments()) {
+		return evt
+	}
+
+	fwd := &StateChangeEvent{
+		Event:      evt.Event,
+		Pod:        pod,
+		Container:  ctr,
+		TimeBudget: evt.TimeBudget,
+		Error:      evt.Error,
+	}
+	if p.seesAdjustments() {
+		fwd.Adjust = evt.Adjust
+	}
+
+	return fwd
+}
+
+// redactPod returns a copy of the pod with only the data of the given
+// visibility class.
+func (r *Adaptation) redactPod(pod *PodSandbox, v Visibility) *PodSandbox {
+	if pod == nil {
+		return nil
+	}
+
+	redacted := &PodSandbox{
+		Id:             pod.Id,
+		Name:           pod.Name,
+		Uid:            pod.Uid,
+		Namespace:      pod.Namespace,
+		Labels:         maps.Clone(pod.Labels),
+		Annotations:    maps.Clone(pod.Annotations),
+		RuntimeHandler: pod.RuntimeHandler,
+		Generation:     pod.Generation,
+	}
+	if pod.RuntimeHandlerCapabilities != nil {
+		redacted.RuntimeHandlerCapabilities = proto.Clone(pod.RuntimeHandlerCapabilities).(*RuntimeHandlerCapabilities)
+	}
+
+	if v == VisibilityAnonymized {
+		redacted.Name = r.anonymize(pod.Name)
+		redacted.Uid = r.anonymize(pod.Uid)
+		redacted.Namespace = r.anonymize(pod.Namespace)
+		redacted.Labels = nil
+		redacted.Annotations = nil
+	}
+
+	return redacted
+}
+
+// redactContainer returns a copy of the container with only the data of
+// the given visibility class.
+func (r *Adaptation) redactContainer(ctr *Container, v Visibility) *Container {
+	if ctr == nil {
+		return nil
+	}
+
+	redacted := &Container{
+		Id:            ctr.Id,
+		PodSandboxId:  ctr.PodSandboxId,
+		Name:          ctr.Name,
+		State:         ctr.State,
+		Labels:        maps.Clone(ctr.Labels),
+		Annotations:   maps.Clone(ctr.Annotations),
+		EventSequence: ctr.EventSequence,
+		Generation:    ctr.Generation,
+		FinishedAt:    ctr.FinishedAt,
+		ExitCode:      ctr.ExitCode,
+		StatusReason:  ctr.StatusReason,
+		StatusMessage: ctr.StatusMessage,
+		OomKilled:     ctr.OomKilled,
+		ExitSignal:    ctr.ExitSignal,
+	}
+	if ctr.Image != nil {
+		redacted.Image = proto.Clone(ctr.Image).(*ContainerImage)
+	}
+
+	if v == VisibilityAnonymized {
+		redacted.Name = r.anonymize(ctr.Name)
+		redacted.Labels = nil
+		redacted.Annotations = nil
+		redacted.StatusMessage = ""
+		if redacted.Image != nil {
+			redacted.Image.Name = r.anonymize(redacted.Image.Name)
+			redacted.Image.Id = r.anonymize(redacted.Image.Id)
+		}
+	}
+
+	return redacted
+}
+
+// anonymize returns an opaque hash of the given value.
+func (r *Adaptation) anonymize(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, r.redactKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
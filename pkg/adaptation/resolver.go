@@ -0,0 +1,176 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+// Aliased types for resolving conflicting plugin adjustments and updates.
+type (
+	FieldClass       = merge.FieldClass
+	Conflict         = merge.Conflict
+	Resolution       = merge.Resolution
+	ConflictResolver = merge.ConflictResolver
+	AdjustmentPolicy = merge.AdjustmentPolicy
+	UserPolicy       = merge.UserPolicy
+)
+
+// Aliased field classes and conflict resolutions.
+const (
+	AnnotationsClass = merge.AnnotationsClass
+	MountsClass      = merge.MountsClass
+	ResourcesClass   = merge.ResourcesClass
+
+	RejectConflict = merge.RejectConflict
+	KeepCurrent    = merge.KeepCurrent
+	UseProposed    = merge.UseProposed
+	UseMerged      = merge.UseMerged
+)
+
+// Aliased functions for conflicts. AsConflict returns the structured
+// description of the conflict between plugins an error was caused by.
+var (
+	AsConflict = merge.AsConflict
+)
+
+// Aliased conflict resolution strategies.
+var (
+	MaxValue       = merge.MaxValue
+	MinValue       = merge.MinValue
+	CPUSetUnion    = merge.CPUSetUnion
+	PluginPriority = merge.PluginPriority
+	ByField        = merge.ByField
+)
+
+// WithConflictResolver returns an option to resolve conflicting adjustments
+// and updates of plugins within the given field class using the resolver,
+// instead of rejecting them. This allows runtimes to implement site-specific
+// policies, for instance taking the largest of conflicting memory limits.
+// Common policies are available as ready-made strategies, like MaxValue,
+// CPUSetUnion or PluginPriority, which can be combined per field using
+// ByField.
+func WithConflictResolver(class FieldClass, resolver ConflictResolver) Option {
+	return func(r *Adaptation) error {
+		switch class {
+		case AnnotationsClass, MountsClass, ResourcesClass:
+		default:
+			return fmt.Errorf("invalid conflict resolver field class %q", class)
+		}
+		if resolver == nil {
+			return fmt.Errorf("invalid (nil) conflict resolver for field class %q", class)
+		}
+		if r.resolvers == nil {
+			r.resolvers = map[FieldClass]ConflictResolver{}
+		}
+		r.resolvers[class] = resolver
+		return nil
+	}
+}
+
+// WithAdjustmentPolicy returns an option to restrict the container
+// adjustments of a plugin, by full (idx-name) or base name, using the
+// policy. Adjustments the policy doesn't allow fail container creation.
+// This lets runtimes allow a plugin, for instance, to only adjust the
+// environment and annotations of containers.
+func WithAdjustmentPolicy(plugin string, policy *AdjustmentPolicy) Option {
+	return func(r *Adaptation) error {
+		if plugin == "" {
+			return fmt.Errorf("invalid (empty) plugin name for adjustment policy")
+		}
+		if policy == nil {
+			return fmt.Errorf("invalid (nil) adjustment policy for plugin %q", plugin)
+		}
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("plugin %q: %w", plugin, err)
+		}
+		if r.adjPolicies == nil {
+			r.adjPolicies = map[string]*AdjustmentPolicy{}
+		}
+		r.adjPolicies[plugin] = policy
+		return nil
+	}
+}
+
+// WithUserPolicy returns an option to restrict how plugins can adjust the
+// user and groups of containers, for instance to reject running containers
+// as root. Adjustments the policy doesn't allow fail container creation.
+func WithUserPolicy(policy *UserPolicy) Option {
+	return func(r *Adaptation) error {
+		if policy == nil {
+			return fmt.Errorf("invalid (nil) user policy")
+		}
+		r.userPolicy = policy
+		return nil
+	}
+}
+
+// WithProtectedApparmorProfiles returns an option to reject adjustments
+// which change the AppArmor profile of containers running with any of the
+// given profiles, typically the default profiles of the runtime. Plugins
+// can still set the profile of other containers. The protection relies on
+// the runtime passing the current profile of containers in CreateContainer
+// requests. The profile of containers without one can't be protected.
+func WithProtectedApparmorProfiles(profiles ...string) Option {
+	return func(r *Adaptation) error {
+		for _, profile := range profiles {
+			if profile == "" {
+				return fmt.Errorf("invalid (empty) protected AppArmor profile")
+			}
+		}
+		r.aaProfiles = append(r.aaProfiles, profiles...)
+		return nil
+	}
+}
+
+// mergeOptions returns the options for merging plugin responses.
+func (r *Adaptation) mergeOptions() []merge.Option {
+	return r.mergeOptionsWith(r.recordingResolver)
+}
+
+// mergeOptionsWith returns the options for merging plugin responses, with
+// conflict resolvers wrapped using the given function.
+func (r *Adaptation) mergeOptionsWith(wrap func(ConflictResolver) ConflictResolver) []merge.Option {
+	options := []merge.Option{
+		merge.WithHandlerAnnotationPrefixes(r.hdlrPrefix),
+		merge.WithAnnotationLimits(r.annoLimits),
+	}
+	if r.dropUnsupp {
+		options = append(options, merge.WithDroppedUnsupportedAdjustments())
+	}
+	if r.topology != nil {
+		options = append(options, merge.WithTopology(r.topology))
+	}
+	if r.hugeSizes != nil {
+		options = append(options, merge.WithHugepageSizes(r.hugeSizes))
+	}
+	for class, resolver := range r.resolvers {
+		options = append(options, merge.WithConflictResolver(class, wrap(resolver)))
+	}
+	for plugin, policy := range r.adjPolicies {
+		options = append(options, merge.WithAdjustmentPolicy(plugin, policy))
+	}
+	if r.userPolicy != nil {
+		options = append(options, merge.WithUserPolicy(r.userPolicy))
+	}
+	if len(r.aaProfiles) > 0 {
+		options = append(options, merge.WithProtectedApparmorProfiles(r.aaProfiles))
+	}
+	return options
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+// WithContainerStats returns an option to read the resource usage of
+// containers from the cgroup filesystem mounted at the given root, or the
+// default root if it is empty, and pass it to plugins in UpdateContainer
+// and StopContainer requests. Stats set by the runtime in the requests
+// are passed as such.
+func WithContainerStats(root string) Option {
+	return func(r *Adaptation) error {
+		if root == "" {
+			root = api.DefaultCgroupRoot
+		}
+		r.statsRoot = root
+		return nil
+	}
+}
+
+// containerStats returns the resource usage of a container, if reading it
+// is enabled.
+func (r *Adaptation) containerStats(ctr *Container) *ContainerStats {
+	if r.statsRoot == "" || ctr.GetLinux().GetCgroupsPath() == "" {
+		return nil
+	}
+
+	stats, err := api.ReadContainerStats(r.statsRoot, ctr.Linux.CgroupsPath)
+	if err != nil {
+		log.Warnf(noCtx, "failed to read stats of container %s: %v", ctr.GetId(), err)
+		return nil
+	}
+
+	return stats
+}
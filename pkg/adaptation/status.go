@@ -0,0 +1,173 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// initialStatusMessage is the status of plugins which have not reported
+	// readiness yet.
+	initialStatusMessage = "waiting for plugin to report readiness"
+)
+
+// PluginStatus is the status of a registered plugin.
+type PluginStatus struct {
+	// Name of the plugin, <idx>-<name>.
+	Name string
+	// Ready is true if the plugin is ready to process requests.
+	Ready bool
+	// Message is the last status message reported by the plugin.
+	Message string
+	// Standby is true if the plugin is a standby instance of a plugin.
+	Standby bool
+	// Disabled is true if the plugin is disabled.
+	Disabled bool
+	// Manifest is the manifest of a pre-installed plugin, or nil if the
+	// plugin has none.
+	Manifest *PluginManifest
+}
+
+// WithPluginReadinessWait returns an option to hold CreateContainer,
+// UpdateContainer and StopContainer requests to plugins which report
+// themselves not ready, for at most the given duration. If a plugin is
+// still not ready once the duration expires, the request fails. Plugins
+// which don't report their status are always considered ready.
+func WithPluginReadinessWait(timeout time.Duration) Option {
+	return func(r *Adaptation) error {
+		if timeout < 0 {
+			return fmt.Errorf("invalid plugin readiness wait %s", timeout)
+		}
+		r.readyWait = timeout
+		return nil
+	}
+}
+
+// PluginStatus returns the status of all registered plugins.
+func (r *Adaptation) PluginStatus() []*PluginStatus {
+	r.Lock()
+	plugins := append([]*plugin{}, r.plugins...)
+	r.Unlock()
+
+	status := make([]*PluginStatus, 0, len(plugins))
+	for _, p := range plugins {
+		if p.isClosed() {
+			continue
+		}
+		ready, msg := p.status()
+		status = append(status, &PluginStatus{
+			Name:     p.name(),
+			Ready:    ready,
+			Message:  msg,
+			Standby:  p.isStandby(),
+			Disabled: r.isDisabled(p),
+			Manifest: p.manifest,
+		})
+	}
+
+	return status
+}
+
+// ReportStatus handles the plugin's status report.
+func (p *plugin) ReportStatus(ctx context.Context, req *ReportStatusRequest) (*api.Empty, error) {
+	p.setStatus(ctx, req.Ready, req.Message)
+	return &api.Empty{}, nil
+}
+
+// setStatus updates the readiness status of the plugin.
+func (p *plugin) setStatus(ctx context.Context, ready bool, msg string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if ready != !p.notReady {
+		if ready {
+			log.Infof(ctx, "plugin %q is ready: %s", p.name(), msg)
+		} else {
+			log.Infof(ctx, "plugin %q is not ready: %s", p.name(), msg)
+		}
+	}
+
+	switch {
+	case ready && p.readyC != nil:
+		close(p.readyC)
+		p.readyC = nil
+	case !ready && p.readyC == nil:
+		p.readyC = make(chan struct{})
+	}
+
+	p.notReady = !ready
+	p.statusMsg = msg
+
+	if p.debugConn != "" {
+		p.r.debugTrk.SetStatus(p.debugConn, statusString(ready, msg))
+	}
+}
+
+// status returns the readiness status of the plugin.
+func (p *plugin) status() (bool, string) {
+	p.Lock()
+	defer p.Unlock()
+	return !p.notReady, p.statusMsg
+}
+
+// waitReady waits for the plugin to become ready, if the runtime has been
+// configured to hold requests to plugins which are not ready.
+func (p *plugin) waitReady(ctx context.Context) error {
+	if p.r.readyWait == 0 {
+		return nil
+	}
+
+	p.Lock()
+	readyC := p.readyC
+	p.Unlock()
+
+	if readyC == nil {
+		return nil
+	}
+
+	log.Infof(ctx, "waiting for plugin %q to become ready...", p.name())
+
+	timer := time.NewTimer(p.r.readyWait)
+	defer timer.Stop()
+
+	select {
+	case <-readyC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		_, msg := p.status()
+		return fmt.Errorf("plugin %q is not ready: %s", p.name(), msg)
+	}
+}
+
+func statusString(ready bool, msg string) string {
+	status := "ready"
+	if !ready {
+		status = "not ready"
+	}
+	if msg != "" {
+		status += ": " + msg
+	}
+	return status
+}
@@ -20,10 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -128,12 +130,147 @@ func (s *Suite) Cleanup() {
 	Expect(os.RemoveAll(s.dir)).To(Succeed())
 }
 
+// A test cluster consists of a set of nodes, each a test suite of its own,
+// with an independent runtime, socket and set of plugins.
+type Cluster struct {
+	names []string          // node names, in the order added
+	nodes map[string]*Suite // test suites by node name
+}
+
+// AddNode prepares a new node with the given runtime and plugins.
+func (c *Cluster) AddNode(name string, runtime *mockRuntime, plugins ...*mockPlugin) *Suite {
+	if c.nodes == nil {
+		c.nodes = make(map[string]*Suite)
+	}
+
+	Expect(c.nodes).ToNot(HaveKey(name), "duplicate test node %q", name)
+
+	s := &Suite{}
+	s.Prepare(runtime, plugins...)
+
+	c.names = append(c.names, name)
+	c.nodes[name] = s
+
+	return s
+}
+
+// Node returns the test suite of the named node.
+func (c *Cluster) Node(name string) *Suite {
+	s, ok := c.nodes[name]
+	Expect(ok).To(BeTrue(), "unknown test node %q", name)
+	return s
+}
+
+// Nodes returns the names of all nodes, in the order they were added.
+func (c *Cluster) Nodes() []string {
+	return append([]string{}, c.names...)
+}
+
+// ForEachNode calls fn for each node, in the order they were added.
+func (c *Cluster) ForEachNode(fn func(name string, s *Suite)) {
+	for _, name := range c.names {
+		fn(name, c.nodes[name])
+	}
+}
+
+// Startup starts up all nodes.
+func (c *Cluster) Startup() {
+	c.ForEachNode(func(_ string, s *Suite) {
+		s.StartRuntime()
+	})
+	c.ForEachNode(func(_ string, s *Suite) {
+		s.StartPlugins()
+	})
+	c.ForEachNode(func(_ string, s *Suite) {
+		s.WaitForPluginsToSync()
+	})
+}
+
+// Cleanup all nodes, removing them from the cluster.
+func (c *Cluster) Cleanup() {
+	c.ForEachNode(func(_ string, s *Suite) {
+		s.Cleanup()
+	})
+	c.names = nil
+	c.nodes = nil
+}
+
 // ------------------------------------
 
 func Log(format string, args ...interface{}) {
 	GinkgoWriter.Printf(format+"\n", args...)
 }
 
+// mockMetrics records metrics as strings.
+type mockMetrics struct {
+	sync.Mutex
+	requests  []string
+	conflicts []string
+	fanOuts   []string
+	queued    []string
+	dropped   []string
+}
+
+func (m *mockMetrics) PluginRequest(plugin string, event nri.Event, _ time.Duration, outcome nri.RequestOutcome) {
+	m.Lock()
+	defer m.Unlock()
+	m.requests = append(m.requests, plugin+":"+event.String()+":"+outcome.String())
+}
+
+func (m *mockMetrics) AdjustmentConflict(plugin, owner string, resolution nri.Resolution) {
+	m.Lock()
+	defer m.Unlock()
+	m.conflicts = append(m.conflicts, fmt.Sprintf("%s:%s:%d", plugin, owner, resolution))
+}
+
+func (m *mockMetrics) EventFanOut(event nri.Event, _ time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+	m.fanOuts = append(m.fanOuts, event.String())
+}
+
+func (m *mockMetrics) EventQueued(plugin string, event nri.Event, _ int, _ time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+	m.queued = append(m.queued, plugin+":"+event.String())
+}
+
+func (m *mockMetrics) EventDropped(plugin string, event nri.Event) {
+	m.Lock()
+	defer m.Unlock()
+	m.dropped = append(m.dropped, plugin+":"+event.String())
+}
+
+func (m *mockMetrics) Requests() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.requests...)
+}
+
+func (m *mockMetrics) Conflicts() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.conflicts...)
+}
+
+func (m *mockMetrics) FanOuts() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.fanOuts...)
+}
+
+func (m *mockMetrics) Queued() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.queued...)
+}
+
+func (m *mockMetrics) Dropped() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.dropped...)
+}
+
 type mockRuntime struct {
 	name    string
 	version string
@@ -141,6 +278,12 @@ type mockRuntime struct {
 	runtime *nri.Adaptation
 	pods    map[string]*api.PodSandbox
 	ctrs    map[string]*api.Container
+	syncs   int32
+	// updates returned by the last synchronization
+	synced   []*api.ContainerUpdate
+	syncLock sync.Mutex
+	// also accept gRPC plugins
+	grpc bool
 
 	updateFn nri.UpdateFn
 }
@@ -159,6 +302,9 @@ func (m *mockRuntime) Start(dir string) error {
 		return errors.New("mock runtime already started")
 	}
 
+	if m.grpc {
+		options = append(options, nri.WithGRPCSocketPath(filepath.Join(dir, "nri-grpc.sock")))
+	}
 	options = append(options, m.options...)
 	m.runtime, err = nri.New(m.name, m.version, m.synchronize, m.update, options...)
 	if err != nil {
@@ -189,6 +335,8 @@ func (m *mockRuntime) Stop() {
 }
 
 func (m *mockRuntime) synchronize(ctx context.Context, cb nri.SyncCB) error {
+	atomic.AddInt32(&m.syncs, 1)
+
 	var (
 		pods []*api.PodSandbox
 		ctrs []*api.Container
@@ -212,10 +360,17 @@ func (m *mockRuntime) synchronize(ctx context.Context, cb nri.SyncCB) error {
 		ctrs = append(ctrs, m.ctrs[id])
 	}
 
-	_, err := cb(ctx, pods, ctrs)
+	updates, err := cb(ctx, pods, ctrs)
+	m.syncLock.Lock()
+	m.synced = updates
+	m.syncLock.Unlock()
 	return err
 }
 
+func (m *mockRuntime) SyncCount() int {
+	return int(atomic.LoadInt32(&m.syncs))
+}
+
 func (m *mockRuntime) RunPodSandbox(ctx context.Context, evt *api.StateChangeEvent) error {
 	b := m.runtime.BlockPluginSync()
 	defer b.Unblock()
@@ -337,6 +492,38 @@ type mockPlugin struct {
 	runtime string
 	version string
 
+	// take part in leader election
+	election bool
+	// accept restored subscription on reconnect
+	restore bool
+	// serve debug endpoints on this address
+	debugAddr string
+	// report readiness to the runtime
+	readiness bool
+	// decode configuration into this
+	config interface{}
+	// only receive events for pods matching this
+	filter *api.EventFilter
+	// plugins to run after and before
+	runAfter  []string
+	runBefore []string
+	// talk to the runtime using gRPC
+	grpc bool
+	// checkpoint state in this directory
+	stateDir string
+	// state to checkpoint, and state restored from the checkpoint
+	state    []byte
+	restored []byte
+	// token to register with
+	authToken string
+	// interval and misses for sending keepalives
+	keepalive time.Duration
+	kaMisses  int
+	// policy for reconnecting to the runtime
+	reconnect *stub.ReconnectPolicy
+	// dialer to connect to the runtime with
+	dialer func(string) (net.Conn, error)
+
 	q    *EventQ
 	pods map[string]*api.PodSandbox
 	ctrs map[string]*api.Container
@@ -352,6 +539,13 @@ type mockPlugin struct {
 	postUpdateContainer func(*mockPlugin, *api.PodSandbox, *api.Container) error
 	stopContainer       func(*mockPlugin, *api.PodSandbox, *api.Container) ([]*api.ContainerUpdate, error)
 	removeContainer     func(*mockPlugin, *api.PodSandbox, *api.Container) error
+	containerDied       func(*mockPlugin, *api.PodSandbox, *api.Container) error
+	rollbackContainer   func(*mockPlugin, *api.PodSandbox, *api.Container, *api.ContainerAdjustment, string) error
+	ping                func(*mockPlugin) error
+	reconfigure         func(*mockPlugin, string) error
+	timeBudget          func(*mockPlugin, time.Duration, bool)
+	stats               func(*mockPlugin, *api.ContainerStats)
+	collected           func(*mockPlugin, map[string]string, *api.ContainerAdjustment)
 }
 
 var (
@@ -368,6 +562,11 @@ var (
 	_ = stub.PostCreateContainerInterface(&mockPlugin{})
 	_ = stub.PostStartContainerInterface(&mockPlugin{})
 	_ = stub.PostUpdateContainerInterface(&mockPlugin{})
+	_ = stub.ContainerDiedInterface(&mockPlugin{})
+	_ = stub.CreateContainerRollbackInterface(&mockPlugin{})
+	_ = stub.SetLeadershipInterface(&mockPlugin{})
+	_ = stub.ReconfigurePluginInterface(&mockPlugin{})
+	_ = stub.StateInterface(&mockPlugin{})
 )
 
 func (m *mockPlugin) Log(format string, args ...interface{}) {
@@ -417,12 +616,59 @@ func (m *mockPlugin) Init(dir string) error {
 
 	m.Log("Init()...")
 
-	m.stub, err = stub.New(m,
+	opts := []stub.Option{
 		stub.WithPluginName(m.name),
 		stub.WithPluginIdx(m.idx),
 		stub.WithSocketPath(filepath.Join(dir, "nri.sock")),
 		stub.WithOnClose(m.onClose),
-	)
+	}
+	if m.election {
+		opts = append(opts, stub.WithLeaderElection())
+	}
+	if m.restore {
+		opts = append(opts, stub.WithSubscriptionRestore())
+	}
+	if m.debugAddr != "" {
+		opts = append(opts, stub.WithDebugListener(m.debugAddr))
+	}
+	if m.readiness {
+		opts = append(opts, stub.WithStatusReporting())
+	}
+	if m.config != nil {
+		opts = append(opts, stub.WithConfigInto(m.config))
+	}
+	if m.filter != nil {
+		opts = append(opts, stub.WithEventFilter(m.filter))
+	}
+	if len(m.runAfter) > 0 {
+		opts = append(opts, stub.WithRunAfter(m.runAfter...))
+	}
+	if len(m.runBefore) > 0 {
+		opts = append(opts, stub.WithRunBefore(m.runBefore...))
+	}
+	if m.grpc {
+		opts = append(opts,
+			stub.WithGRPCTransport(),
+			stub.WithSocketPath(filepath.Join(dir, "nri-grpc.sock")),
+		)
+	}
+	if m.stateDir != "" {
+		opts = append(opts, stub.WithStateStore(m.stateDir))
+	}
+	if m.authToken != "" {
+		opts = append(opts, stub.WithAuthToken(m.authToken))
+	}
+	if m.keepalive != 0 {
+		opts = append(opts, stub.WithKeepalive(m.keepalive, m.kaMisses))
+	}
+	if m.reconnect != nil {
+		opts = append(opts, stub.WithReconnect(*m.reconnect))
+	}
+	if m.dialer != nil {
+		opts = append(opts, stub.WithDialer(m.dialer))
+	}
+
+	m.stub, err = stub.New(m, opts...)
 	if err != nil {
 		m.q.Add(PluginCreationError)
 		return err
@@ -458,6 +704,12 @@ func (m *mockPlugin) Init(dir string) error {
 	if m.postUpdateContainer == nil {
 		m.postUpdateContainer = nopEvent
 	}
+	if m.containerDied == nil {
+		m.containerDied = nopEvent
+	}
+	if m.rollbackContainer == nil {
+		m.rollbackContainer = nopRollbackContainer
+	}
 	if m.stopContainer == nil {
 		m.stopContainer = nopStopContainer
 	}
@@ -536,7 +788,59 @@ func (m *mockPlugin) Shutdown(_ context.Context) {
 	m.q.Add(PluginShutdown)
 }
 
-func (m *mockPlugin) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+func (m *mockPlugin) SetLeadership(_ context.Context, leader bool) error {
+	if leader {
+		m.q.Add(PluginElectedLeader)
+	} else {
+		m.q.Add(PluginOnStandby)
+	}
+	return nil
+}
+
+func (m *mockPlugin) Ping(_ context.Context) error {
+	if m.ping == nil {
+		return nil
+	}
+	return m.ping(m)
+}
+
+func (m *mockPlugin) ReconfigurePlugin(_ context.Context, config string) error {
+	if m.reconfigure == nil {
+		return nil
+	}
+	return m.reconfigure(m, config)
+}
+
+func (m *mockPlugin) SaveState(_ context.Context) ([]byte, error) {
+	return m.state, nil
+}
+
+func (m *mockPlugin) LoadState(_ context.Context, state []byte) error {
+	m.restored = state
+	return nil
+}
+
+func (m *mockPlugin) checkTimeBudget(ctx context.Context) {
+	if m.timeBudget != nil {
+		budget, ok := stub.TimeBudget(ctx)
+		m.timeBudget(m, budget, ok)
+	}
+}
+
+func (m *mockPlugin) checkStats(ctx context.Context) {
+	if m.stats != nil {
+		m.stats(m, stub.ContainerStats(ctx))
+	}
+}
+
+func (m *mockPlugin) checkCollected(ctx context.Context) {
+	if m.collected != nil {
+		m.collected(m, stub.AdjustmentOwners(ctx), stub.CollectedAdjustment(ctx))
+	}
+}
+
+func (m *mockPlugin) RunPodSandbox(ctx context.Context, pod *api.PodSandbox) error {
+	m.checkTimeBudget(ctx)
 	m.pods[pod.Id] = pod
 	err := m.runPodSandbox(m, pod, nil)
 	m.q.Add(PodSandboxEvent(pod, RunPodSandbox))
@@ -557,7 +861,9 @@ func (m *mockPlugin) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) er
 	return err
 }
 
-func (m *mockPlugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+func (m *mockPlugin) CreateContainer(ctx context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	m.checkTimeBudget(ctx)
+	m.checkCollected(ctx)
 	m.pods[pod.Id] = pod
 	m.ctrs[ctr.Id] = ctr
 	m.q.Add(ContainerEvent(ctr, CreateContainer))
@@ -589,7 +895,8 @@ func (m *mockPlugin) PostStartContainer(_ context.Context, pod *api.PodSandbox,
 	return m.postStartContainer(m, pod, ctr)
 }
 
-func (m *mockPlugin) UpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+func (m *mockPlugin) UpdateContainer(ctx context.Context, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	m.checkStats(ctx)
 	m.pods[pod.Id] = pod
 	m.ctrs[ctr.Id] = ctr
 	m.q.Add(ContainerEvent(ctr, UpdateContainer))
@@ -605,7 +912,8 @@ func (m *mockPlugin) PostUpdateContainer(_ context.Context, pod *api.PodSandbox,
 	return m.postUpdateContainer(m, pod, ctr)
 }
 
-func (m *mockPlugin) StopContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+func (m *mockPlugin) StopContainer(ctx context.Context, pod *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+	m.checkStats(ctx)
 	m.pods[pod.Id] = pod
 	m.ctrs[ctr.Id] = ctr
 	m.q.Add(ContainerEvent(ctr, StopContainer))
@@ -620,10 +928,29 @@ func (m *mockPlugin) RemoveContainer(_ context.Context, pod *api.PodSandbox, ctr
 	return m.removeContainer(m, pod, ctr)
 }
 
+func (m *mockPlugin) ContainerDied(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	m.pods[pod.Id] = pod
+	m.ctrs[ctr.Id] = ctr
+	m.q.Add(ContainerEvent(ctr, ContainerDied))
+
+	return m.containerDied(m, pod, ctr)
+}
+
+func (m *mockPlugin) CreateContainerRollback(_ context.Context, pod *api.PodSandbox, ctr *api.Container, adjust *api.ContainerAdjustment, reason string) error {
+	delete(m.ctrs, ctr.Id)
+	m.q.Add(ContainerEvent(ctr, CreateContainerRollback))
+
+	return m.rollbackContainer(m, pod, ctr, adjust, reason)
+}
+
 func nopEvent(*mockPlugin, *api.PodSandbox, *api.Container) error {
 	return nil
 }
 
+func nopRollbackContainer(*mockPlugin, *api.PodSandbox, *api.Container, *api.ContainerAdjustment, string) error {
+	return nil
+}
+
 func nopCreateContainer(*mockPlugin, *api.PodSandbox, *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
 	return nil, nil, nil
 }
@@ -647,6 +974,8 @@ const (
 	Shutdown     = "shutdown"
 	Disconnected = "closed"
 	Stopped      = "stopped"
+	Leader       = "leader"
+	Standby      = "standby"
 
 	RunPodSandbox       = "RunPodSandbox"
 	StopPodSandbox      = "StopPodSandbox"
@@ -659,6 +988,9 @@ const (
 	PostCreateContainer = "PostCreateContainer"
 	PostStartContainer  = "PostStartContainer"
 	PostUpdateContainer = "PostUpdateContainer"
+	ContainerDied       = "ContainerDied"
+
+	CreateContainerRollback = "CreateContainerRollback"
 
 	Error   = "Error"
 	Timeout = ""
@@ -678,6 +1010,8 @@ var (
 	PluginShutdown      = &Event{Type: Shutdown}
 	PluginDisconnected  = &Event{Type: Disconnected}
 	PluginStopped       = &Event{Type: Stopped}
+	PluginElectedLeader = &Event{Type: Leader}
+	PluginOnStandby     = &Event{Type: Standby}
 
 	PodSandboxEvent = func(pod *api.PodSandbox, t EventType) *Event {
 		return &Event{Type: t, Pod: pod}
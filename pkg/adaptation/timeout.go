@@ -0,0 +1,189 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const (
+	// defaultTimeoutKey is the key of the timeout for all requests in
+	// timeout drop-in files.
+	defaultTimeoutKey = "default"
+)
+
+// WithPluginRequestTimeout returns an option to override the request timeout
+// of a plugin, by full (idx-name) or base name. If events are given, the
+// timeout only applies to requests for those events. Otherwise it applies to
+// all requests of the plugin for which there is no event-specific timeout.
+// This lets runtimes, for instance, give a plugin provisioning devices more
+// time to handle CreateContainer than other plugins get.
+func WithPluginRequestTimeout(plugin string, timeout time.Duration, events ...Event) Option {
+	return func(r *Adaptation) error {
+		if plugin == "" {
+			return fmt.Errorf("invalid (empty) plugin name for request timeout")
+		}
+		return r.setRequestTimeout(plugin, timeout, events...)
+	}
+}
+
+// WithEventRequestTimeout returns an option to override the request timeout
+// of all plugins for an event. Timeouts set for a plugin take precedence.
+func WithEventRequestTimeout(event Event, timeout time.Duration) Option {
+	return func(r *Adaptation) error {
+		return r.setRequestTimeout("", timeout, event)
+	}
+}
+
+func (r *Adaptation) setRequestTimeout(plugin string, timeout time.Duration, events ...Event) error {
+	if timeout <= 0 {
+		return fmt.Errorf("invalid request timeout %s", timeout)
+	}
+	for _, e := range events {
+		if e <= Event_UNKNOWN || e >= api.Event_LAST {
+			return fmt.Errorf("invalid event %d for request timeout", e)
+		}
+	}
+
+	if r.timeouts == nil {
+		r.timeouts = map[string]map[Event]time.Duration{}
+	}
+	if r.timeouts[plugin] == nil {
+		r.timeouts[plugin] = map[Event]time.Duration{}
+	}
+
+	if len(events) == 0 {
+		r.timeouts[plugin][Event_UNKNOWN] = timeout
+	}
+	for _, e := range events {
+		r.timeouts[plugin][e] = timeout
+	}
+
+	return nil
+}
+
+// requestTimeout returns the timeout for a request of the plugin for the
+// event. Timeouts from drop-in files take precedence over ones set using
+// options, which take precedence over the global request timeout. Event
+// specific timeouts take precedence over the other ones from the same
+// source.
+func (p *plugin) requestTimeout(event Event) time.Duration {
+	if t, ok := p.timeouts[event]; ok {
+		return t
+	}
+	if t, ok := p.timeouts[Event_UNKNOWN]; ok {
+		return t
+	}
+
+	for _, key := range []struct {
+		plugin string
+		event  Event
+	}{
+		{p.name(), event},
+		{p.base, event},
+		{p.name(), Event_UNKNOWN},
+		{p.base, Event_UNKNOWN},
+		{"", event},
+	} {
+		if t, ok := p.r.timeouts[key.plugin][key.event]; ok {
+			return t
+		}
+	}
+
+	return getPluginRequestTimeout()
+}
+
+// getPluginTimeouts reads the request timeouts of a plugin from its timeout
+// drop-in file in the plugin configuration directory, idx-name.timeouts or
+// name.timeouts. The file maps event names, like CreateContainer or
+// CREATE_CONTAINER, or 'default' for all events, to timeouts, like 500ms.
+func (r *Adaptation) getPluginTimeouts(idx, base string) (map[Event]time.Duration, error) {
+	name := idx + "-" + base
+	dropIns := []string{
+		filepath.Join(r.dropinPath, name+".timeouts"),
+		filepath.Join(r.dropinPath, base+".timeouts"),
+	}
+
+	for _, path := range dropIns {
+		buf, err := r.configs.readFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read timeouts for plugin %q: %w", name, err)
+		}
+
+		timeouts, err := parseTimeouts(buf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeouts for plugin %q in %s: %w", name, path, err)
+		}
+		return timeouts, nil
+	}
+
+	return nil, nil
+}
+
+// parseTimeouts parses the content of a timeout drop-in file.
+func parseTimeouts(data []byte) (map[Event]time.Duration, error) {
+	entries := map[string]string{}
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	timeouts := map[Event]time.Duration{}
+	for key, value := range entries {
+		event := Event_UNKNOWN
+		if !strings.EqualFold(key, defaultTimeoutKey) {
+			e, ok := parseEventName(key)
+			if !ok {
+				return nil, fmt.Errorf("unknown event %q", key)
+			}
+			event = e
+		}
+
+		t, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for %s: %w", key, err)
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("invalid timeout for %s: %s", key, t)
+		}
+		timeouts[event] = t
+	}
+
+	return timeouts, nil
+}
+
+// parseEventName parses an event name, either as in the API, for instance
+// CREATE_CONTAINER, or in camel case, CreateContainer.
+func parseEventName(name string) (Event, bool) {
+	normalized := strings.ToUpper(strings.ReplaceAll(name, "_", ""))
+	for e := Event_UNKNOWN + 1; e < api.Event_LAST; e++ {
+		if strings.ReplaceAll(e.String(), "_", "") == normalized {
+			return e, true
+		}
+	}
+	return Event_UNKNOWN, false
+}
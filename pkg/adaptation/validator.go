@@ -0,0 +1,143 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// ContainerValidator validates the changes plugins request to containers,
+// once they have been collected and merged, before they reach the runtime.
+// Validators can enforce runtime policies which the validation options of
+// the adaptation can't express.
+type ContainerValidator interface {
+	// ValidateContainerAdjustment validates the adjustment of a container
+	// being created, and any updates to other containers requested along
+	// with it. Returning an error fails the creation of the container.
+	ValidateContainerAdjustment(context.Context, *ValidateContainerAdjustmentRequest) error
+	// ValidateContainerUpdates validates updates to containers, requested
+	// by plugins in response to UpdateContainer or StopContainer, during
+	// synchronization, or unsolicited. Returning an error fails the request
+	// the updates were solicited in, the synchronization of the plugin, or
+	// the unsolicited updates.
+	ValidateContainerUpdates(context.Context, *ValidateContainerUpdatesRequest) error
+}
+
+// ValidateContainerAdjustmentRequest is the collected adjustment of a
+// container being created.
+type ValidateContainerAdjustmentRequest struct {
+	// Pod is the pod of the container.
+	Pod *PodSandbox
+	// Container is the container being created.
+	Container *Container
+	// Adjust is the collected adjustment of the container.
+	Adjust *ContainerAdjustment
+	// Update is the collected updates to other containers.
+	Update []*ContainerUpdate
+	// Plugins are the plugins the creation was relayed to.
+	Plugins []string
+}
+
+// ValidateContainerUpdatesRequest is a set of container updates requested
+// by plugins.
+type ValidateContainerUpdatesRequest struct {
+	// Event is the event the updates were requested in response to. It is
+	// Event_UNKNOWN for updates requested during synchronization and for
+	// unsolicited updates.
+	Event Event
+	// Unsolicited is true for updates requested by a plugin on its own.
+	Unsolicited bool
+	// Pod and Container are those of the event, if any.
+	Pod       *PodSandbox
+	Container *Container
+	// Update is the collected updates. Unsolicited updates with a container
+	// selector are expanded to updates of the selected containers.
+	Update []*ContainerUpdate
+	// Plugins are the plugins the event was relayed to, or the plugin which
+	// requested the updates otherwise.
+	Plugins []string
+}
+
+// WithContainerValidator returns an option to validate the changes plugins
+// request to containers using the given validator. Both the adjustments of
+// created containers and updates are validated, whether solicited in an
+// event or not, so plugins can't bypass validation by requesting changes
+// through another path. Multiple validators are called in the order they
+// are given, until one of them fails.
+func WithContainerValidator(validator ContainerValidator) Option {
+	return func(r *Adaptation) error {
+		if validator == nil {
+			return fmt.Errorf("invalid (nil) container validator")
+		}
+		r.validators = append(r.validators, validator)
+		return nil
+	}
+}
+
+// validateAdjustment validates the collected adjustment of a container.
+func (r *Adaptation) validateAdjustment(ctx context.Context, req *CreateContainerRequest, rpl *CreateContainerResponse, plugins []*plugin) error {
+	if len(r.validators) == 0 {
+		return nil
+	}
+
+	vreq := &ValidateContainerAdjustmentRequest{
+		Pod:       req.GetPod(),
+		Container: req.GetContainer(),
+		Adjust:    rpl.GetAdjust(),
+		Update:    rpl.GetUpdate(),
+		Plugins:   pluginNames(plugins),
+	}
+
+	for _, v := range r.validators {
+		if err := v.ValidateContainerAdjustment(ctx, vreq); err != nil {
+			log.Warnf(ctx, "adjustment of container %s rejected by validator: %v",
+				req.GetContainer().GetId(), err)
+			return fmt.Errorf("container adjustment validation failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateUpdates validates a set of container updates.
+func (r *Adaptation) validateUpdates(ctx context.Context, vreq *ValidateContainerUpdatesRequest) error {
+	if len(r.validators) == 0 || len(vreq.Update) == 0 {
+		return nil
+	}
+
+	for _, v := range r.validators {
+		if err := v.ValidateContainerUpdates(ctx, vreq); err != nil {
+			log.Warnf(ctx, "container updates of plugins %v rejected by validator: %v",
+				vreq.Plugins, err)
+			return fmt.Errorf("container update validation failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// pluginNames returns the names of the given plugins.
+func pluginNames(plugins []*plugin) []string {
+	names := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		names = append(names, p.name())
+	}
+	return names
+}
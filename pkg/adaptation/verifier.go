@@ -0,0 +1,249 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containerd/nri/pkg/log"
+)
+
+// PluginExecutable describes the executable of an external plugin process.
+type PluginExecutable struct {
+	// Name is the name the plugin registered with, idx-name.
+	Name string
+	// Pid is the process ID of the plugin.
+	Pid int
+	// Path is the path of the executable of the plugin.
+	Path string
+	// Digest is the SHA-256 digest of the executable, as sha256:<hex>.
+	Digest string
+}
+
+// PluginVerifier verifies the executables of external plugins.
+type PluginVerifier interface {
+	// VerifyPlugin returns an error if the executable of a plugin is
+	// not trusted.
+	VerifyPlugin(context.Context, *PluginExecutable) error
+}
+
+// WithPluginVerifier returns an option to verify the executable of external
+// plugins when they register. The executable is resolved from the process at
+// the other end of the plugin connection, and its digest is calculated from
+// the executable the process runs, not from the file at its path. Plugins the
+// verifier does not trust fail to register. Plugins launched by the runtime
+// and sub-plugins, which share the process of their host, are not verified.
+func WithPluginVerifier(verifier PluginVerifier) Option {
+	return func(r *Adaptation) error {
+		if verifier == nil {
+			return fmt.Errorf("invalid (nil) plugin verifier")
+		}
+		r.verifier = verifier
+		return nil
+	}
+}
+
+// verify verifies the executable of an external plugin, if necessary.
+func (p *plugin) verify(ctx context.Context) error {
+	if p.r.verifier == nil || !p.isExternal() || p.host != nil {
+		return nil
+	}
+
+	if p.pid <= 0 {
+		return errors.New("failed to verify plugin, unknown process")
+	}
+
+	path, exe, err := openPeerExecutable(p.pid)
+	if err != nil {
+		return fmt.Errorf("failed to verify plugin: %w", err)
+	}
+	defer exe.Close()
+
+	digest, err := fileDigest(exe)
+	if err != nil {
+		return fmt.Errorf("failed to verify plugin %s: %w", path, err)
+	}
+
+	if err := p.r.verifier.VerifyPlugin(ctx, &PluginExecutable{
+		Name:   p.name(),
+		Pid:    p.pid,
+		Path:   path,
+		Digest: digest,
+	}); err != nil {
+		return fmt.Errorf("plugin executable %s (%s) not trusted: %w", path, digest, err)
+	}
+
+	log.Infof(ctx, "verified plugin %q, executable %s (%s)", p.name(), path, digest)
+
+	return nil
+}
+
+// fileDigest returns the SHA-256 digest of a file, as sha256:<hex>.
+func fileDigest(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileVerifier is a reference PluginVerifier using files. It trusts plugin
+// executables with an allowlisted digest, or with a detached signature which
+// verifies with one of its public keys. The signature of an executable is
+// read from the file next to it with a .sig suffix, and is the base64 encoded
+// ECDSA or RSA PKCS #1 v1.5 signature of its SHA-256 digest, as created with
+// 'cosign sign-blob --key' or 'openssl dgst -sha256 -sign'.
+type FileVerifier struct {
+	digests map[string]struct{}
+	keys    []crypto.PublicKey
+}
+
+// NewFileVerifier creates a FileVerifier with the given allowlist and PEM
+// encoded public key files. The allowlist has one digest per line, either
+// as sha256:<hex> or in the output format of sha256sum. Empty lines and ones
+// starting with '#' are ignored. Either the allowlist or the keys can be
+// omitted, but not both.
+func NewFileVerifier(allowlist string, keys ...string) (*FileVerifier, error) {
+	v := &FileVerifier{
+		digests: map[string]struct{}{},
+	}
+
+	if allowlist == "" && len(keys) == 0 {
+		return nil, errors.New("plugin verifier without allowlist or keys")
+	}
+
+	if allowlist != "" {
+		if err := v.readAllowlist(allowlist); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range keys {
+		key, err := readPublicKey(file)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = append(v.keys, key)
+	}
+
+	return v, nil
+}
+
+// VerifyPlugin verifies the executable of a plugin.
+func (v *FileVerifier) VerifyPlugin(_ context.Context, exe *PluginExecutable) error {
+	if _, ok := v.digests[exe.Digest]; ok {
+		return nil
+	}
+
+	if len(v.keys) == 0 {
+		return errors.New("digest not allowlisted")
+	}
+
+	algo, value, _ := strings.Cut(exe.Digest, ":")
+	sum, err := hex.DecodeString(value)
+	if err != nil || algo != "sha256" || len(sum) != sha256.Size {
+		return fmt.Errorf("invalid digest %q", exe.Digest)
+	}
+
+	data, err := os.ReadFile(exe.Path + ".sig")
+	if err != nil {
+		return fmt.Errorf("digest not allowlisted, and no signature: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return fmt.Errorf("invalid signature %s.sig: %w", exe.Path, err)
+	}
+
+	for _, key := range v.keys {
+		if verifySignature(key, sum, sig) {
+			return nil
+		}
+	}
+
+	return errors.New("digest not allowlisted, and signature does not verify")
+}
+
+func (v *FileVerifier) readAllowlist(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin allowlist: %w", err)
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value := strings.TrimPrefix(strings.Fields(line)[0], "sha256:")
+		if sum, err := hex.DecodeString(value); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("invalid digest on line %d of plugin allowlist %s", n, file)
+		}
+		v.digests["sha256:"+strings.ToLower(value)] = struct{}{}
+	}
+
+	return s.Err()
+}
+
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin verification key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid plugin verification key %s, no PEM data", file)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin verification key %s: %w", file, err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported plugin verification key %s of type %T", file, key)
+	}
+}
+
+func verifySignature(key crypto.PublicKey, sum, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, sum, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, sum, sig) == nil
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package adaptation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VetoedError is returned when a plugin vetoes starting a container. The
+// runtime should then destroy the created container instead of starting it.
+type VetoedError struct {
+	// Plugin is the plugin which vetoed starting the container.
+	Plugin string
+	// Event is the event the plugin vetoed starting the container in.
+	Event Event
+	// Reason is a human-readable explanation of the veto.
+	Reason string
+	// Pod is the name of the pod of the container.
+	Pod string
+	// Container is the name of the container.
+	Container string
+}
+
+// Error returns the error message of the veto.
+func (e *VetoedError) Error() string {
+	return fmt.Sprintf("plugin %q vetoed starting container %s/%s: %s",
+		e.Plugin, e.Pod, e.Container, e.Reason)
+}
+
+// EventHint returns a short message suitable for a pod event.
+func (e *VetoedError) EventHint() string {
+	return fmt.Sprintf("NRI plugin %s vetoed starting container %s/%s: %s",
+		e.Plugin, e.Pod, e.Container, e.Reason)
+}
+
+// GRPCStatus returns the gRPC status corresponding to the veto.
+func (e *VetoedError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// WithContainerStartVeto returns an option to allow the given plugins to
+// veto starting containers in their PostCreateContainer or StartContainer
+// handler. Plugins are listed by their full (idx-name) or base name. The
+// vetoes of other plugins are ignored.
+func WithContainerStartVeto(plugins ...string) Option {
+	return func(r *Adaptation) error {
+		for _, name := range plugins {
+			if name == "" {
+				return fmt.Errorf("invalid (empty) plugin name for container start veto")
+			}
+		}
+		r.vetoers = append(r.vetoers, plugins...)
+		return nil
+	}
+}
+
+// veto returns the error for a plugin vetoing starting a container, or nil
+// if the plugin is not allowed to veto it.
+func (p *plugin) veto(ctx context.Context, evt *StateChangeEvent, reason string) error {
+	switch {
+	case evt.Event != Event_POST_CREATE_CONTAINER && evt.Event != Event_START_CONTAINER:
+		log.Warnf(ctx, "ignoring veto of plugin %s in event %s", p.name(), evt.Event)
+		return nil
+	case !p.isAnyOf(p.r.vetoers):
+		log.Warnf(ctx, "ignoring veto of plugin %s, not allowed to veto container starts",
+			p.name())
+		return nil
+	}
+
+	log.Infof(ctx, "plugin %s vetoed starting container %s: %s", p.name(),
+		evt.GetContainer().GetId(), reason)
+
+	return &VetoedError{
+		Plugin:    p.name(),
+		Event:     evt.Event,
+		Reason:    reason,
+		Pod:       evt.GetPod().GetName(),
+		Container: evt.GetContainer().GetName(),
+	}
+}
@@ -47,11 +47,37 @@ func (a *ContainerAdjustment) RemoveAnnotation(key string) {
 	a.Annotations[MarkForRemoval(key)] = ""
 }
 
+// AddHandlerAnnotation records the addition of the runtime handler
+// annotation key=value. Runtimes pass handler annotations through to the
+// shim or container monitor, if the key has a prefix allowed by the runtime.
+func (a *ContainerAdjustment) AddHandlerAnnotation(key, value string) {
+	a.initHandlerAnnotations()
+	a.HandlerAnnotations[key] = value
+}
+
+// RemoveHandlerAnnotation records the removal of the runtime handler
+// annotation for the given key.
+func (a *ContainerAdjustment) RemoveHandlerAnnotation(key string) {
+	a.initHandlerAnnotations()
+	a.HandlerAnnotations[MarkForRemoval(key)] = ""
+}
+
 // AddMount records the addition of a mount to a container.
 func (a *ContainerAdjustment) AddMount(m *Mount) {
 	a.Mounts = append(a.Mounts, m) // TODO: should we dup m here ?
 }
 
+// AddMountWithIDMapping records the addition of an idmapped mount to a
+// container, with the given UID and GID mappings. The mapping of IDs in the
+// container to IDs on the host lets containers in a user namespace access
+// volumes owned by host IDs without changing their ownership.
+func (a *ContainerAdjustment) AddMountWithIDMapping(m *Mount, uidMappings, gidMappings []*LinuxIDMapping) {
+	mount := m.StripRemovalMarker() // copy, leaving the caller's mount intact
+	mount.UidMappings = dupIDMappings(uidMappings)
+	mount.GidMappings = dupIDMappings(gidMappings)
+	a.AddMount(mount)
+}
+
 // RemoveMount records the removal of a mount from a container.
 // Normally it is an error for a plugin to try and alter a mount
 // touched by another plugin. However, this is not an error if the
@@ -59,6 +85,7 @@ func (a *ContainerAdjustment) AddMount(m *Mount) {
 func (a *ContainerAdjustment) RemoveMount(ContainerPath string) {
 	a.Mounts = append(a.Mounts, &Mount{
 		Destination: MarkForRemoval(ContainerPath),
+		Remove:      true,
 	})
 }
 
@@ -76,10 +103,41 @@ func (a *ContainerAdjustment) AddEnv(key, value string) {
 // the plugin removes that variable prior to touching it.
 func (a *ContainerAdjustment) RemoveEnv(key string) {
 	a.Env = append(a.Env, &KeyValue{
-		Key: MarkForRemoval(key),
+		Key:    MarkForRemoval(key),
+		Remove: true,
 	})
 }
 
+// AddEnvFile records the addition of an environment file to a container. The
+// runtime sources variables from the file, at the given path within the
+// container, when it starts the container. The container fails to start if
+// the file does not exist, unless it is optional.
+func (a *ContainerAdjustment) AddEnvFile(path string, optional bool) {
+	a.EnvFiles = append(a.EnvFiles, &EnvFile{
+		Path:     path,
+		Optional: optional,
+	})
+}
+
+// SetUser records setting the user ID of the container process.
+func (a *ContainerAdjustment) SetUser(uid uint32) {
+	a.initUser()
+	a.User.Uid = UInt32(uid)
+}
+
+// SetGroup records setting the group ID of the container process.
+func (a *ContainerAdjustment) SetGroup(gid uint32) {
+	a.initUser()
+	a.User.Gid = UInt32(gid)
+}
+
+// AddAdditionalGid records the addition of a supplementary group ID to the
+// container process.
+func (a *ContainerAdjustment) AddAdditionalGid(gid uint32) {
+	a.initUser()
+	a.User.AdditionalGids = append(a.User.AdditionalGids, gid)
+}
+
 // AddHooks records the addition of the given hooks to a container.
 func (a *ContainerAdjustment) AddHooks(h *Hooks) {
 	a.initHooks()
@@ -125,10 +183,38 @@ func (a *ContainerAdjustment) AddDevice(d *LinuxDevice) {
 func (a *ContainerAdjustment) RemoveDevice(path string) {
 	a.initLinux()
 	a.Linux.Devices = append(a.Linux.Devices, &LinuxDevice{
-		Path: MarkForRemoval(path),
+		Path:   MarkForRemoval(path),
+		Remove: true,
 	})
 }
 
+// AddCapability records the addition of a capability to the given capability
+// sets of a container, or to the bounding, effective and permitted sets if no
+// sets are given.
+func (a *ContainerAdjustment) AddCapability(capability string, sets ...CapabilitySet) {
+	if len(sets) == 0 {
+		sets = DefaultAddCapabilitySets
+	}
+	a.initLinuxCapabilities()
+	for _, set := range sets {
+		a.Linux.Capabilities.Set(set,
+			append(a.Linux.Capabilities.Get(set), CapabilityName(capability)))
+	}
+}
+
+// DropCapability records the removal of a capability from the given capability
+// sets of a container, or from all sets if no sets are given.
+func (a *ContainerAdjustment) DropCapability(capability string, sets ...CapabilitySet) {
+	if len(sets) == 0 {
+		sets = CapabilitySets
+	}
+	a.initLinuxCapabilities()
+	for _, set := range sets {
+		a.Linux.Capabilities.Set(set,
+			append(a.Linux.Capabilities.Get(set), MarkForRemoval(CapabilityName(capability))))
+	}
+}
+
 // AddCDIDevice records the addition of the given CDI device to a container.
 func (a *ContainerAdjustment) AddCDIDevice(d *CDIDevice) {
 	a.CDIDevices = append(a.CDIDevices, d) // TODO: should we dup d here ?
@@ -231,12 +317,39 @@ func (a *ContainerAdjustment) SetLinuxPidLimits(value int64) {
 }
 
 // AddLinuxHugepageLimit records adding a hugepage limit for a container.
+// The page size is normalized, so 2MiB and 2048kB both become 2MB. Any
+// earlier limit for the same page size is replaced.
 func (a *ContainerAdjustment) AddLinuxHugepageLimit(pageSize string, value uint64) {
 	a.initLinuxResources()
-	a.Linux.Resources.HugepageLimits = append(a.Linux.Resources.HugepageLimits,
-		&HugepageLimit{
-			PageSize: pageSize,
-			Limit:    value,
+	a.Linux.Resources.HugepageLimits = addHugepageLimit(a.Linux.Resources.HugepageLimits,
+		pageSize, value)
+}
+
+// AddLinuxDeviceCgroupRule records the addition of a device cgroup rule
+// for a container. A nil major or minor number matches all numbers. Any
+// earlier rule for the same device type and numbers is replaced.
+func (a *ContainerAdjustment) AddLinuxDeviceCgroupRule(allow bool, typ string, major, minor *int64, access string) {
+	a.initLinuxResources()
+	a.Linux.Resources.Devices = append(a.Linux.Resources.Devices,
+		&LinuxDeviceCgroup{
+			Allow:  allow,
+			Type:   typ,
+			Major:  Int64(major),
+			Minor:  Int64(minor),
+			Access: access,
+		})
+}
+
+// RemoveLinuxDeviceCgroupRule records the removal of the device cgroup rules
+// for the given device type and numbers from a container.
+func (a *ContainerAdjustment) RemoveLinuxDeviceCgroupRule(typ string, major, minor *int64) {
+	a.initLinuxResources()
+	a.Linux.Resources.Devices = append(a.Linux.Resources.Devices,
+		&LinuxDeviceCgroup{
+			Type:   typ,
+			Major:  Int64(major),
+			Minor:  Int64(minor),
+			Remove: true,
 		})
 }
 
@@ -264,12 +377,112 @@ func (a *ContainerAdjustment) SetLinuxCgroupsPath(value string) {
 	a.Linux.CgroupsPath = value
 }
 
+// SetLinuxApparmorProfile records setting the AppArmor profile for a container.
+func (a *ContainerAdjustment) SetLinuxApparmorProfile(profile string) {
+	a.initLinux()
+	a.Linux.ApparmorProfile = profile
+}
+
+// SetLinuxSeccompPolicy records setting the seccomp policy for a container.
+func (a *ContainerAdjustment) SetLinuxSeccompPolicy(policy *LinuxSeccomp) {
+	a.initLinux()
+	a.Linux.SeccompPolicy = policy
+}
+
+// SetLinuxCPUAffinity records setting the CPU affinity for a container.
+func (a *ContainerAdjustment) SetLinuxCPUAffinity(cpus string) {
+	a.initLinux()
+	a.Linux.CpuAffinity = cpus
+}
+
+// SetLinuxNetworkBandwidth records setting the network bandwidth limits for a container.
+func (a *ContainerAdjustment) SetLinuxNetworkBandwidth(b *LinuxNetworkBandwidth) {
+	a.initLinux()
+	a.Linux.NetworkBandwidth = b
+}
+
+// SetLinuxNetworkIngressBandwidth records setting the ingress bandwidth rate,
+// in bits per second, and burst, in bytes, for a container. A zero burst is
+// left unset.
+func (a *ContainerAdjustment) SetLinuxNetworkIngressBandwidth(rate, burst uint64) {
+	a.initLinuxNetworkBandwidth()
+	a.Linux.NetworkBandwidth.IngressRate = UInt64(rate)
+	if burst != 0 {
+		a.Linux.NetworkBandwidth.IngressBurst = UInt64(burst)
+	}
+}
+
+// SetLinuxNetworkEgressBandwidth records setting the egress bandwidth rate,
+// in bits per second, and burst, in bytes, for a container. A zero burst is
+// left unset.
+func (a *ContainerAdjustment) SetLinuxNetworkEgressBandwidth(rate, burst uint64) {
+	a.initLinuxNetworkBandwidth()
+	a.Linux.NetworkBandwidth.EgressRate = UInt64(rate)
+	if burst != 0 {
+		a.Linux.NetworkBandwidth.EgressBurst = UInt64(burst)
+	}
+}
+
 // SetLinuxOomScoreAdj records setting the kernel's Out-Of-Memory (OOM) killer score for a container.
 func (a *ContainerAdjustment) SetLinuxOomScoreAdj(value *int) {
 	a.initLinux()
 	a.Linux.OomScoreAdj = Int(value) // using Int(value) from ./options.go to optionally allocate a pointer to normalized copy of value
 }
 
+// SetTopologyHintNUMANodes records the preferred NUMA nodes for a container.
+func (a *ContainerAdjustment) SetTopologyHintNUMANodes(nodes ...uint32) {
+	a.initTopologyHints()
+	a.TopologyHints.NumaNodes = append([]uint32{}, nodes...)
+}
+
+// AddDeviceTopologyHint records a topology hint for an injected device.
+func (a *ContainerAdjustment) AddDeviceTopologyHint(h *DeviceTopologyHint) {
+	a.initTopologyHints()
+	a.TopologyHints.Devices = append(a.TopologyHints.Devices, h)
+}
+
+// SetSchedulingHintNUMANode records the preferred NUMA node for the CPUs of a container.
+func (a *ContainerAdjustment) SetSchedulingHintNUMANode(node uint32) {
+	a.initSchedulingHints()
+	a.SchedulingHints.PreferredNumaNode = UInt32(node)
+}
+
+// SetSchedulingHintSMTPolicy records the SMT policy for a container.
+func (a *ContainerAdjustment) SetSchedulingHintSMTPolicy(policy SMTPolicy) {
+	a.initSchedulingHints()
+	a.SchedulingHints.SmtPolicy = policy
+}
+
+// SetSchedulingHintExclusiveCores records whether a container wants exclusive cores.
+func (a *ContainerAdjustment) SetSchedulingHintExclusiveCores(exclusive bool) {
+	a.initSchedulingHints()
+	a.SchedulingHints.ExclusiveCores = Bool(exclusive)
+}
+
+// SetWindowsMemoryLimit records setting the memory limit for a Windows container.
+func (a *ContainerAdjustment) SetWindowsMemoryLimit(value uint64) {
+	a.initWindowsResourcesMemory()
+	a.Windows.Resources.Memory.Limit = UInt64(value)
+}
+
+// SetWindowsCPUCount records setting the number of CPUs for a Windows container.
+func (a *ContainerAdjustment) SetWindowsCPUCount(value uint64) {
+	a.initWindowsResourcesCPU()
+	a.Windows.Resources.Cpu.Count = UInt64(value)
+}
+
+// SetWindowsCPUShares records setting the relative CPU shares for a Windows container.
+func (a *ContainerAdjustment) SetWindowsCPUShares(value uint16) {
+	a.initWindowsResourcesCPU()
+	a.Windows.Resources.Cpu.Shares = UInt32(value)
+}
+
+// SetWindowsCPUMaximum records setting the CPU maximum for a Windows container.
+func (a *ContainerAdjustment) SetWindowsCPUMaximum(value uint16) {
+	a.initWindowsResourcesCPU()
+	a.Windows.Resources.Cpu.Maximum = UInt32(value)
+}
+
 //
 // Initializing a container adjustment and container update.
 //
@@ -280,24 +493,62 @@ func (a *ContainerAdjustment) initAnnotations() {
 	}
 }
 
+func (a *ContainerAdjustment) initHandlerAnnotations() {
+	if a.HandlerAnnotations == nil {
+		a.HandlerAnnotations = make(map[string]string)
+	}
+}
+
 func (a *ContainerAdjustment) initHooks() {
 	if a.Hooks == nil {
 		a.Hooks = &Hooks{}
 	}
 }
 
+func (a *ContainerAdjustment) initUser() {
+	if a.User == nil {
+		a.User = &User{}
+	}
+}
+
 func (a *ContainerAdjustment) initRlimits() {
 	if a.Rlimits == nil {
 		a.Rlimits = []*POSIXRlimit{}
 	}
 }
 
+func (a *ContainerAdjustment) initTopologyHints() {
+	if a.TopologyHints == nil {
+		a.TopologyHints = &TopologyHints{}
+	}
+}
+
+func (a *ContainerAdjustment) initLinuxNetworkBandwidth() {
+	a.initLinux()
+	if a.Linux.NetworkBandwidth == nil {
+		a.Linux.NetworkBandwidth = &LinuxNetworkBandwidth{}
+	}
+}
+
+func (a *ContainerAdjustment) initSchedulingHints() {
+	if a.SchedulingHints == nil {
+		a.SchedulingHints = &SchedulingHints{}
+	}
+}
+
 func (a *ContainerAdjustment) initLinux() {
 	if a.Linux == nil {
 		a.Linux = &LinuxContainerAdjustment{}
 	}
 }
 
+func (a *ContainerAdjustment) initLinuxCapabilities() {
+	a.initLinux()
+	if a.Linux.Capabilities == nil {
+		a.Linux.Capabilities = &LinuxCapabilities{}
+	}
+}
+
 func (a *ContainerAdjustment) initLinuxResources() {
 	a.initLinux()
 	if a.Linux.Resources == nil {
@@ -332,3 +583,30 @@ func (a *ContainerAdjustment) initLinuxResourcesUnified() {
 		a.Linux.Resources.Unified = make(map[string]string)
 	}
 }
+
+func (a *ContainerAdjustment) initWindows() {
+	if a.Windows == nil {
+		a.Windows = &WindowsContainerAdjustment{}
+	}
+}
+
+func (a *ContainerAdjustment) initWindowsResources() {
+	a.initWindows()
+	if a.Windows.Resources == nil {
+		a.Windows.Resources = &WindowsResources{}
+	}
+}
+
+func (a *ContainerAdjustment) initWindowsResourcesMemory() {
+	a.initWindowsResources()
+	if a.Windows.Resources.Memory == nil {
+		a.Windows.Resources.Memory = &WindowsMemory{}
+	}
+}
+
+func (a *ContainerAdjustment) initWindowsResourcesCPU() {
+	a.initWindowsResources()
+	if a.Windows.Resources.Cpu == nil {
+		a.Windows.Resources.Cpu = &WindowsCPU{}
+	}
+}
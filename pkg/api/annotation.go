@@ -0,0 +1,98 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// CheckKey checks an annotation key against the limits.
+func (l *AnnotationLimits) CheckKey(key string) error {
+	if max := l.GetMaxKeyLength(); max > 0 && len(key) > int(max) {
+		return fmt.Errorf("annotation key %q exceeds maximum length %d", key, max)
+	}
+	return nil
+}
+
+// CheckValue checks an annotation value against the limits. It returns
+// the value, truncated to the maximum length if the policy allows that.
+func (l *AnnotationLimits) CheckValue(key, value string) (string, error) {
+	max := l.GetMaxValueLength()
+	if max == 0 || len(value) <= int(max) {
+		return value, nil
+	}
+	if l.GetPolicy() != AnnotationLimitPolicy_ANNOTATION_LIMIT_TRUNCATE {
+		return "", fmt.Errorf("value of annotation %q exceeds maximum length %d (%d)",
+			key, max, len(value))
+	}
+	return TruncateAnnotationValue(value, int(max)), nil
+}
+
+// CheckCount checks the number of annotations against the limits.
+func (l *AnnotationLimits) CheckCount(count int) error {
+	if max := l.GetMaxCount(); max > 0 && count > int(max) {
+		return fmt.Errorf("number of annotations exceeds maximum %d (%d)", max, count)
+	}
+	return nil
+}
+
+// Check checks the annotations against the limits. It returns the
+// annotations, with values truncated if the policy allows that.
+func (l *AnnotationLimits) Check(annotations map[string]string) (map[string]string, error) {
+	if l == nil || len(annotations) == 0 {
+		return annotations, nil
+	}
+
+	var (
+		checked = make(map[string]string, len(annotations))
+		count   int
+	)
+	for k, v := range annotations {
+		if _, marked := IsMarkedForRemoval(k); marked {
+			checked[k] = v
+			continue
+		}
+		if err := l.CheckKey(k); err != nil {
+			return nil, err
+		}
+		value, err := l.CheckValue(k, v)
+		if err != nil {
+			return nil, err
+		}
+		checked[k] = value
+		count++
+	}
+
+	if err := l.CheckCount(count); err != nil {
+		return nil, err
+	}
+
+	return checked, nil
+}
+
+// TruncateAnnotationValue truncates an annotation value to at most the given
+// length in bytes, without splitting multi-byte UTF-8 characters.
+func TruncateAnnotationValue(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	for length > 0 && !utf8.RuneStart(value[length]) {
+		length--
+	}
+	return value[:length]
+}
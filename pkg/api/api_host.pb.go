@@ -192,6 +192,18 @@ func (p *PluginPlugin) Load(ctx context.Context, pluginPath string, hostFunction
 	if statechange == nil {
 		return nil, errors.New("plugin_state_change is not exported")
 	}
+	setleadership := module.ExportedFunction("plugin_set_leadership")
+	if setleadership == nil {
+		return nil, errors.New("plugin_set_leadership is not exported")
+	}
+	ping := module.ExportedFunction("plugin_ping")
+	if ping == nil {
+		return nil, errors.New("plugin_ping is not exported")
+	}
+	reconfigureplugin := module.ExportedFunction("plugin_reconfigure_plugin")
+	if reconfigureplugin == nil {
+		return nil, errors.New("plugin_reconfigure_plugin is not exported")
+	}
 
 	malloc := module.ExportedFunction("malloc")
 	if malloc == nil {
@@ -203,17 +215,20 @@ func (p *PluginPlugin) Load(ctx context.Context, pluginPath string, hostFunction
 		return nil, errors.New("free is not exported")
 	}
 	return &pluginPlugin{
-		runtime:         r,
-		module:          module,
-		malloc:          malloc,
-		free:            free,
-		configure:       configure,
-		synchronize:     synchronize,
-		shutdown:        shutdown,
-		createcontainer: createcontainer,
-		updatecontainer: updatecontainer,
-		stopcontainer:   stopcontainer,
-		statechange:     statechange,
+		runtime:           r,
+		module:            module,
+		malloc:            malloc,
+		free:              free,
+		configure:         configure,
+		synchronize:       synchronize,
+		shutdown:          shutdown,
+		createcontainer:   createcontainer,
+		updatecontainer:   updatecontainer,
+		stopcontainer:     stopcontainer,
+		statechange:       statechange,
+		setleadership:     setleadership,
+		ping:              ping,
+		reconfigureplugin: reconfigureplugin,
 	}, nil
 }
 
@@ -225,17 +240,20 @@ func (p *pluginPlugin) Close(ctx context.Context) (err error) {
 }
 
 type pluginPlugin struct {
-	runtime         wazero.Runtime
-	module          api.Module
-	malloc          api.Function
-	free            api.Function
-	configure       api.Function
-	synchronize     api.Function
-	shutdown        api.Function
-	createcontainer api.Function
-	updatecontainer api.Function
-	stopcontainer   api.Function
-	statechange     api.Function
+	runtime           wazero.Runtime
+	module            api.Module
+	malloc            api.Function
+	free              api.Function
+	configure         api.Function
+	synchronize       api.Function
+	shutdown          api.Function
+	createcontainer   api.Function
+	updatecontainer   api.Function
+	stopcontainer     api.Function
+	statechange       api.Function
+	setleadership     api.Function
+	ping              api.Function
+	reconfigureplugin api.Function
 }
 
 func (p *pluginPlugin) Configure(ctx context.Context, request *ConfigureRequest) (*ConfigureResponse, error) {
@@ -665,3 +683,186 @@ func (p *pluginPlugin) StateChange(ctx context.Context, request *StateChangeEven
 
 	return response, nil
 }
+func (p *pluginPlugin) SetLeadership(ctx context.Context, request *SetLeadershipRequest) (*Empty, error) {
+	data, err := request.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	dataSize := uint64(len(data))
+
+	var dataPtr uint64
+	// If the input data is not empty, we must allocate the in-Wasm memory to store it, and pass to the plugin.
+	if dataSize != 0 {
+		results, err := p.malloc.Call(ctx, dataSize)
+		if err != nil {
+			return nil, err
+		}
+		dataPtr = results[0]
+		// This pointer is managed by TinyGo, but TinyGo is unaware of external usage.
+		// So, we have to free it when finished
+		defer p.free.Call(ctx, dataPtr)
+
+		// The pointer is a linear memory offset, which is where we write the name.
+		if !p.module.Memory().Write(uint32(dataPtr), data) {
+			return nil, fmt.Errorf("Memory.Write(%d, %d) out of range of memory size %d", dataPtr, dataSize, p.module.Memory().Size())
+		}
+	}
+
+	ptrSize, err := p.setleadership.Call(ctx, dataPtr, dataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resPtr := uint32(ptrSize[0] >> 32)
+	resSize := uint32(ptrSize[0])
+	var isErrResponse bool
+	if (resSize & (1 << 31)) > 0 {
+		isErrResponse = true
+		resSize &^= (1 << 31)
+	}
+
+	// We don't need the memory after deserialization: make sure it is freed.
+	if resPtr != 0 {
+		defer p.free.Call(ctx, uint64(resPtr))
+	}
+
+	// The pointer is a linear memory offset, which is where we write the name.
+	bytes, ok := p.module.Memory().Read(resPtr, resSize)
+	if !ok {
+		return nil, fmt.Errorf("Memory.Read(%d, %d) out of range of memory size %d",
+			resPtr, resSize, p.module.Memory().Size())
+	}
+
+	if isErrResponse {
+		return nil, errors.New(string(bytes))
+	}
+
+	response := new(Empty)
+	if err = response.UnmarshalVT(bytes); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+func (p *pluginPlugin) Ping(ctx context.Context, request *Empty) (*Empty, error) {
+	data, err := request.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	dataSize := uint64(len(data))
+
+	var dataPtr uint64
+	// If the input data is not empty, we must allocate the in-Wasm memory to store it, and pass to the plugin.
+	if dataSize != 0 {
+		results, err := p.malloc.Call(ctx, dataSize)
+		if err != nil {
+			return nil, err
+		}
+		dataPtr = results[0]
+		// This pointer is managed by TinyGo, but TinyGo is unaware of external usage.
+		// So, we have to free it when finished
+		defer p.free.Call(ctx, dataPtr)
+
+		// The pointer is a linear memory offset, which is where we write the name.
+		if !p.module.Memory().Write(uint32(dataPtr), data) {
+			return nil, fmt.Errorf("Memory.Write(%d, %d) out of range of memory size %d", dataPtr, dataSize, p.module.Memory().Size())
+		}
+	}
+
+	ptrSize, err := p.ping.Call(ctx, dataPtr, dataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resPtr := uint32(ptrSize[0] >> 32)
+	resSize := uint32(ptrSize[0])
+	var isErrResponse bool
+	if (resSize & (1 << 31)) > 0 {
+		isErrResponse = true
+		resSize &^= (1 << 31)
+	}
+
+	// We don't need the memory after deserialization: make sure it is freed.
+	if resPtr != 0 {
+		defer p.free.Call(ctx, uint64(resPtr))
+	}
+
+	// The pointer is a linear memory offset, which is where we write the name.
+	bytes, ok := p.module.Memory().Read(resPtr, resSize)
+	if !ok {
+		return nil, fmt.Errorf("Memory.Read(%d, %d) out of range of memory size %d",
+			resPtr, resSize, p.module.Memory().Size())
+	}
+
+	if isErrResponse {
+		return nil, errors.New(string(bytes))
+	}
+
+	response := new(Empty)
+	if err = response.UnmarshalVT(bytes); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+func (p *pluginPlugin) ReconfigurePlugin(ctx context.Context, request *ReconfigurePluginRequest) (*Empty, error) {
+	data, err := request.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	dataSize := uint64(len(data))
+
+	var dataPtr uint64
+	// If the input data is not empty, we must allocate the in-Wasm memory to store it, and pass to the plugin.
+	if dataSize != 0 {
+		results, err := p.malloc.Call(ctx, dataSize)
+		if err != nil {
+			return nil, err
+		}
+		dataPtr = results[0]
+		// This pointer is managed by TinyGo, but TinyGo is unaware of external usage.
+		// So, we have to free it when finished
+		defer p.free.Call(ctx, dataPtr)
+
+		// The pointer is a linear memory offset, which is where we write the name.
+		if !p.module.Memory().Write(uint32(dataPtr), data) {
+			return nil, fmt.Errorf("Memory.Write(%d, %d) out of range of memory size %d", dataPtr, dataSize, p.module.Memory().Size())
+		}
+	}
+
+	ptrSize, err := p.reconfigureplugin.Call(ctx, dataPtr, dataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resPtr := uint32(ptrSize[0] >> 32)
+	resSize := uint32(ptrSize[0])
+	var isErrResponse bool
+	if (resSize & (1 << 31)) > 0 {
+		isErrResponse = true
+		resSize &^= (1 << 31)
+	}
+
+	// We don't need the memory after deserialization: make sure it is freed.
+	if resPtr != 0 {
+		defer p.free.Call(ctx, uint64(resPtr))
+	}
+
+	// The pointer is a linear memory offset, which is where we write the name.
+	bytes, ok := p.module.Memory().Read(resPtr, resSize)
+	if !ok {
+		return nil, fmt.Errorf("Memory.Read(%d, %d) out of range of memory size %d",
+			resPtr, resSize, p.module.Memory().Size())
+	}
+
+	if isErrResponse {
+		return nil, errors.New(string(bytes))
+	}
+
+	response := new(Empty)
+	if err = response.UnmarshalVT(bytes); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
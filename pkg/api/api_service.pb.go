@@ -81,6 +81,18 @@ type Plugin interface {
 	// plugin-specific processing which needs to occur in connection with any of
 	// these events.
 	StateChange(context.Context, *StateChangeEvent) (*Empty, error)
+	// SetLeadership notifies a plugin taking part in leader election about
+	// its role. Only the leader receives CreateContainer, UpdateContainer and
+	// StopContainer requests. Standby instances receive only notifications.
+	SetLeadership(context.Context, *SetLeadershipRequest) (*Empty, error)
+	// Ping checks the liveness of the plugin. The runtime pings plugins
+	// periodically if health checks are enabled, and detaches plugins which
+	// repeatedly fail to respond.
+	Ping(context.Context, *Empty) (*Empty, error)
+	// ReconfigurePlugin passes updated configuration to a running plugin,
+	// without reconnecting it. The event subscription of the plugin is not
+	// changed.
+	ReconfigurePlugin(context.Context, *ReconfigurePluginRequest) (*Empty, error)
 }
 
 // go:plugin type=host
@@ -10,8 +10,13 @@ import (
 )
 
 type RuntimeService interface {
-	RegisterPlugin(context.Context, *RegisterPluginRequest) (*Empty, error)
+	RegisterPlugin(context.Context, *RegisterPluginRequest) (*RegisterPluginResponse, error)
 	UpdateContainers(context.Context, *UpdateContainersRequest) (*UpdateContainersResponse, error)
+	ReportStatus(context.Context, *ReportStatusRequest) (*Empty, error)
+	PreviewAdjustment(context.Context, *PreviewAdjustmentRequest) (*PreviewAdjustmentResponse, error)
+	GetPods(context.Context, *GetPodsRequest) (*GetPodsResponse, error)
+	GetContainers(context.Context, *GetContainersRequest) (*GetContainersResponse, error)
+	Keepalive(context.Context, *KeepaliveRequest) (*Empty, error)
 }
 
 func RegisterRuntimeService(srv *ttrpc.Server, svc RuntimeService) {
@@ -31,6 +36,41 @@ func RegisterRuntimeService(srv *ttrpc.Server, svc RuntimeService) {
 				}
 				return svc.UpdateContainers(ctx, &req)
 			},
+			"ReportStatus": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ReportStatusRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ReportStatus(ctx, &req)
+			},
+			"PreviewAdjustment": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req PreviewAdjustmentRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.PreviewAdjustment(ctx, &req)
+			},
+			"GetPods": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req GetPodsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetPods(ctx, &req)
+			},
+			"GetContainers": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req GetContainersRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetContainers(ctx, &req)
+			},
+			"Keepalive": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req KeepaliveRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Keepalive(ctx, &req)
+			},
 		},
 	})
 }
@@ -45,8 +85,8 @@ func NewRuntimeClient(client *ttrpc.Client) RuntimeService {
 	}
 }
 
-func (c *runtimeClient) RegisterPlugin(ctx context.Context, req *RegisterPluginRequest) (*Empty, error) {
-	var resp Empty
+func (c *runtimeClient) RegisterPlugin(ctx context.Context, req *RegisterPluginRequest) (*RegisterPluginResponse, error) {
+	var resp RegisterPluginResponse
 	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "RegisterPlugin", req, &resp); err != nil {
 		return nil, err
 	}
@@ -61,6 +101,46 @@ func (c *runtimeClient) UpdateContainers(ctx context.Context, req *UpdateContain
 	return &resp, nil
 }
 
+func (c *runtimeClient) ReportStatus(ctx context.Context, req *ReportStatusRequest) (*Empty, error) {
+	var resp Empty
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "ReportStatus", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *runtimeClient) PreviewAdjustment(ctx context.Context, req *PreviewAdjustmentRequest) (*PreviewAdjustmentResponse, error) {
+	var resp PreviewAdjustmentResponse
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "PreviewAdjustment", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *runtimeClient) GetPods(ctx context.Context, req *GetPodsRequest) (*GetPodsResponse, error) {
+	var resp GetPodsResponse
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "GetPods", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *runtimeClient) GetContainers(ctx context.Context, req *GetContainersRequest) (*GetContainersResponse, error) {
+	var resp GetContainersResponse
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "GetContainers", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *runtimeClient) Keepalive(ctx context.Context, req *KeepaliveRequest) (*Empty, error) {
+	var resp Empty
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Runtime", "Keepalive", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 type PluginService interface {
 	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
 	Synchronize(context.Context, *SynchronizeRequest) (*SynchronizeResponse, error)
@@ -69,6 +149,9 @@ type PluginService interface {
 	UpdateContainer(context.Context, *UpdateContainerRequest) (*UpdateContainerResponse, error)
 	StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error)
 	StateChange(context.Context, *StateChangeEvent) (*Empty, error)
+	SetLeadership(context.Context, *SetLeadershipRequest) (*Empty, error)
+	Ping(context.Context, *Empty) (*Empty, error)
+	ReconfigurePlugin(context.Context, *ReconfigurePluginRequest) (*Empty, error)
 }
 
 func RegisterPluginService(srv *ttrpc.Server, svc PluginService) {
@@ -123,6 +206,27 @@ func RegisterPluginService(srv *ttrpc.Server, svc PluginService) {
 				}
 				return svc.StateChange(ctx, &req)
 			},
+			"SetLeadership": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req SetLeadershipRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.SetLeadership(ctx, &req)
+			},
+			"Ping": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req Empty
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Ping(ctx, &req)
+			},
+			"ReconfigurePlugin": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ReconfigurePluginRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ReconfigurePlugin(ctx, &req)
+			},
 		},
 	})
 }
@@ -193,6 +297,30 @@ func (c *pluginClient) StateChange(ctx context.Context, req *StateChangeEvent) (
 	return &resp, nil
 }
 
+func (c *pluginClient) SetLeadership(ctx context.Context, req *SetLeadershipRequest) (*Empty, error) {
+	var resp Empty
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Plugin", "SetLeadership", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *pluginClient) Ping(ctx context.Context, req *Empty) (*Empty, error) {
+	var resp Empty
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Plugin", "Ping", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *pluginClient) ReconfigurePlugin(ctx context.Context, req *ReconfigurePluginRequest) (*Empty, error) {
+	var resp Empty
+	if err := c.client.Call(ctx, "nri.pkg.api.v1alpha1.Plugin", "ReconfigurePlugin", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 type HostFunctionsService interface {
 	Log(context.Context, *LogRequest) (*Empty, error)
 }
@@ -0,0 +1,66 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+)
+
+// NetworkBandwidth returns network bandwidth limits with the given ingress
+// and egress rates in bits per second. A zero rate is left unset.
+func NetworkBandwidth(ingress, egress uint64) *LinuxNetworkBandwidth {
+	b := &LinuxNetworkBandwidth{}
+	if ingress != 0 {
+		b.IngressRate = UInt64(ingress)
+	}
+	if egress != 0 {
+		b.EgressRate = UInt64(egress)
+	}
+	return b
+}
+
+// IsEmpty returns true if no network bandwidth limits are set.
+func (b *LinuxNetworkBandwidth) IsEmpty() bool {
+	return b.GetIngressRate() == nil && b.GetIngressBurst() == nil &&
+		b.GetEgressRate() == nil && b.GetEgressBurst() == nil
+}
+
+// Validate checks that the network bandwidth limits are valid. Set rates
+// and bursts must be non-zero, and bursts can only be set with a rate.
+func (b *LinuxNetworkBandwidth) Validate() error {
+	for _, l := range []struct {
+		dir   string
+		rate  *OptionalUInt64
+		burst *OptionalUInt64
+	}{
+		{"ingress", b.GetIngressRate(), b.GetIngressBurst()},
+		{"egress", b.GetEgressRate(), b.GetEgressBurst()},
+	} {
+		if l.rate != nil && l.rate.GetValue() == 0 {
+			return fmt.Errorf("invalid zero %s bandwidth rate", l.dir)
+		}
+		if l.burst != nil {
+			if l.burst.GetValue() == 0 {
+				return fmt.Errorf("invalid zero %s bandwidth burst", l.dir)
+			}
+			if l.rate == nil {
+				return fmt.Errorf("invalid %s bandwidth burst without rate", l.dir)
+			}
+		}
+	}
+	return nil
+}
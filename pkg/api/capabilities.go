@@ -0,0 +1,156 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// CapabilitySet identifies one of the capability sets of a process.
+type CapabilitySet int
+
+const (
+	// CapabilityBounding is the bounding capability set.
+	CapabilityBounding CapabilitySet = iota
+	// CapabilityEffective is the effective capability set.
+	CapabilityEffective
+	// CapabilityPermitted is the permitted capability set.
+	CapabilityPermitted
+	// CapabilityInheritable is the inheritable capability set.
+	CapabilityInheritable
+	// CapabilityAmbient is the ambient capability set.
+	CapabilityAmbient
+)
+
+var (
+	// CapabilitySets lists all capability sets.
+	CapabilitySets = []CapabilitySet{
+		CapabilityBounding,
+		CapabilityEffective,
+		CapabilityPermitted,
+		CapabilityInheritable,
+		CapabilityAmbient,
+	}
+	// DefaultAddCapabilitySets are the sets capabilities are added to
+	// if no sets are given.
+	DefaultAddCapabilitySets = []CapabilitySet{
+		CapabilityBounding,
+		CapabilityEffective,
+		CapabilityPermitted,
+	}
+)
+
+// String returns the name of the capability set.
+func (s CapabilitySet) String() string {
+	switch s {
+	case CapabilityBounding:
+		return "bounding"
+	case CapabilityEffective:
+		return "effective"
+	case CapabilityPermitted:
+		return "permitted"
+	case CapabilityInheritable:
+		return "inheritable"
+	case CapabilityAmbient:
+		return "ambient"
+	}
+	return "unknown"
+}
+
+// CapabilityName returns the canonical name of a capability, in upper
+// case and with a CAP_ prefix, for instance CAP_SYS_ADMIN for sys_admin.
+func CapabilityName(capability string) string {
+	name := strings.ToUpper(capability)
+	if !strings.HasPrefix(name, "CAP_") {
+		name = "CAP_" + name
+	}
+	return name
+}
+
+// FromOCILinuxCapabilities returns capabilities from an OCI runtime Spec.
+func FromOCILinuxCapabilities(o *rspec.LinuxCapabilities) *LinuxCapabilities {
+	if o == nil {
+		return nil
+	}
+	return &LinuxCapabilities{
+		Bounding:    DupStringSlice(o.Bounding),
+		Effective:   DupStringSlice(o.Effective),
+		Permitted:   DupStringSlice(o.Permitted),
+		Inheritable: DupStringSlice(o.Inheritable),
+		Ambient:     DupStringSlice(o.Ambient),
+	}
+}
+
+// ToOCI returns capabilities for an OCI runtime Spec.
+func (c *LinuxCapabilities) ToOCI() *rspec.LinuxCapabilities {
+	if c == nil {
+		return nil
+	}
+	return &rspec.LinuxCapabilities{
+		Bounding:    DupStringSlice(c.Bounding),
+		Effective:   DupStringSlice(c.Effective),
+		Permitted:   DupStringSlice(c.Permitted),
+		Inheritable: DupStringSlice(c.Inheritable),
+		Ambient:     DupStringSlice(c.Ambient),
+	}
+}
+
+// Get returns the capabilities in the given set.
+func (c *LinuxCapabilities) Get(set CapabilitySet) []string {
+	if c == nil {
+		return nil
+	}
+	if s := c.set(set); s != nil {
+		return *s
+	}
+	return nil
+}
+
+// Set sets the capabilities in the given set.
+func (c *LinuxCapabilities) Set(set CapabilitySet, capabilities []string) {
+	if s := c.set(set); s != nil {
+		*s = capabilities
+	}
+}
+
+// Has returns true if the given set contains the capability.
+func (c *LinuxCapabilities) Has(set CapabilitySet, capability string) bool {
+	for _, name := range c.Get(set) {
+		if name == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LinuxCapabilities) set(set CapabilitySet) *[]string {
+	switch set {
+	case CapabilityBounding:
+		return &c.Bounding
+	case CapabilityEffective:
+		return &c.Effective
+	case CapabilityPermitted:
+		return &c.Permitted
+	case CapabilityInheritable:
+		return &c.Inheritable
+	case CapabilityAmbient:
+		return &c.Ambient
+	}
+	return nil
+}
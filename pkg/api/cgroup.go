@@ -0,0 +1,97 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultCgroupRoot is the default mount point of the cgroup filesystem.
+	DefaultCgroupRoot = "/sys/fs/cgroup"
+)
+
+// CgroupFSPath resolves the absolute path of a container cgroup in the
+// cgroup filesystem mounted at root, given the OCI Spec cgroups path of
+// the container. Paths of the form slice:prefix:name are resolved as the
+// systemd cgroup driver does it, with an empty slice standing for the
+// system slice. Other paths are resolved as the cgroupfs
+// driver does it, relative to root.
+func CgroupFSPath(root, cgroupsPath string) (string, error) {
+	if root == "" {
+		root = DefaultCgroupRoot
+	}
+	if cgroupsPath == "" {
+		return "", fmt.Errorf("can't resolve empty cgroups path")
+	}
+
+	split := strings.Split(cgroupsPath, ":")
+	if len(split) != 3 {
+		return filepath.Join(root, cgroupsPath), nil
+	}
+
+	// like runc, put units without a slice in the system slice
+	slice := split[0]
+	if slice == "" {
+		slice = "system.slice"
+	}
+	slice, err := expandSystemdSlice(slice)
+	if err != nil {
+		return "", fmt.Errorf("can't resolve cgroups path %q: %w", cgroupsPath, err)
+	}
+
+	prefix, name := split[1], split[2]
+	unit := name
+	if !strings.HasSuffix(name, ".slice") {
+		if prefix != "" {
+			unit = prefix + "-" + name + ".scope"
+		} else {
+			unit = name + ".scope"
+		}
+	}
+
+	return filepath.Join(root, slice, unit), nil
+}
+
+// expandSystemdSlice expands a systemd slice name into a cgroup path,
+// for instance a-b-c.slice into a.slice/a-b.slice/a-b-c.slice.
+func expandSystemdSlice(slice string) (string, error) {
+	if slice == "" || slice == "-.slice" {
+		return "", nil
+	}
+
+	name, ok := strings.CutSuffix(slice, ".slice")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", fmt.Errorf("invalid systemd slice %q", slice)
+	}
+
+	var (
+		path   string
+		prefix string
+	)
+	for _, component := range strings.Split(name, "-") {
+		if component == "" {
+			return "", fmt.Errorf("invalid systemd slice %q", slice)
+		}
+		path = filepath.Join(path, prefix+component+".slice")
+		prefix += component + "-"
+	}
+
+	return path, nil
+}
@@ -0,0 +1,117 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/containerd/nri/pkg/api"
+
+	require "github.com/stretchr/testify/require"
+)
+
+func TestCgroupFSPath(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		root        string
+		cgroupsPath string
+		result      string
+		invalid     bool
+	}{
+		{
+			name:        "cgroupfs path",
+			cgroupsPath: "/kubepods/burstable/pod1234/ctr0",
+			result:      "/sys/fs/cgroup/kubepods/burstable/pod1234/ctr0",
+		},
+		{
+			name:        "relative cgroupfs path",
+			cgroupsPath: "kubepods/ctr0",
+			result:      "/sys/fs/cgroup/kubepods/ctr0",
+		},
+		{
+			name:        "cgroupfs path with custom root",
+			root:        "/host/sys/fs/cgroup",
+			cgroupsPath: "/kubepods/ctr0",
+			result:      "/host/sys/fs/cgroup/kubepods/ctr0",
+		},
+		{
+			name:        "systemd path",
+			cgroupsPath: "system.slice:cri-containerd:1234",
+			result:      "/sys/fs/cgroup/system.slice/cri-containerd-1234.scope",
+		},
+		{
+			name:        "systemd path in nested slice",
+			cgroupsPath: "kubepods-burstable-pod1234.slice:cri-containerd:5678",
+			result: "/sys/fs/cgroup/kubepods.slice/kubepods-burstable.slice/" +
+				"kubepods-burstable-pod1234.slice/cri-containerd-5678.scope",
+		},
+		{
+			name:        "systemd path in root slice",
+			cgroupsPath: "-.slice:crio:1234",
+			result:      "/sys/fs/cgroup/crio-1234.scope",
+		},
+		{
+			name:        "systemd path without slice",
+			cgroupsPath: ":crio:1234",
+			result:      "/sys/fs/cgroup/system.slice/crio-1234.scope",
+		},
+		{
+			name:        "systemd path with empty prefix",
+			cgroupsPath: "system.slice::1234",
+			result:      "/sys/fs/cgroup/system.slice/1234.scope",
+		},
+		{
+			name:        "systemd path with slice name",
+			cgroupsPath: "kubepods.slice:crio:kubepods-pod1234.slice",
+			result:      "/sys/fs/cgroup/kubepods.slice/kubepods-pod1234.slice",
+		},
+		{
+			name:    "empty path",
+			invalid: true,
+		},
+		{
+			name:        "slice with empty component",
+			cgroupsPath: "a--b.slice:crio:1234",
+			invalid:     true,
+		},
+		{
+			name:        "slice without suffix",
+			cgroupsPath: "system:crio:1234",
+			invalid:     true,
+		},
+		{
+			name:        "slice without name",
+			cgroupsPath: ".slice:crio:1234",
+			invalid:     true,
+		},
+		{
+			name:        "slice with path separator",
+			cgroupsPath: "a/b.slice:crio:1234",
+			invalid:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := api.CgroupFSPath(tc.root, tc.cgroupsPath)
+			if tc.invalid {
+				require.Error(t, err, "CgroupFSPath(%q)", tc.cgroupsPath)
+				return
+			}
+			require.NoError(t, err, "CgroupFSPath(%q)", tc.cgroupsPath)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// FromOCIRuntimeDefaults returns the runtime defaults for the given base
+// OCI runtime Spec of the runtime.
+func FromOCIRuntimeDefaults(o *rspec.Spec) *RuntimeDefaults {
+	if o == nil {
+		return nil
+	}
+
+	d := &RuntimeDefaults{
+		Mounts: FromOCIMounts(o.Mounts),
+	}
+	if p := o.Process; p != nil {
+		d.Rlimits = FromOCIRlimits(p.Rlimits)
+		d.Capabilities = FromOCILinuxCapabilities(p.Capabilities)
+		d.Env = FromOCIEnv(p.Env)
+	}
+	if l := o.Linux; l != nil && l.Seccomp != nil {
+		d.SeccompProfileDigest = SeccompProfileDigest(l.Seccomp)
+	}
+
+	return d
+}
+
+// FromOCIRlimits returns rlimits from an OCI runtime Spec.
+func FromOCIRlimits(o []rspec.POSIXRlimit) []*POSIXRlimit {
+	var rlimits []*POSIXRlimit
+	for _, r := range o {
+		rlimits = append(rlimits, &POSIXRlimit{
+			Type: r.Type,
+			Hard: r.Hard,
+			Soft: r.Soft,
+		})
+	}
+	return rlimits
+}
+
+// SeccompProfileDigest returns the digest of a seccomp profile, the SHA-256
+// of its JSON encoding. It returns an empty string for a nil profile.
+func SeccompProfileDigest(profile *rspec.LinuxSeccomp) string {
+	if profile == nil {
+		return ""
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// GetRlimit returns the default rlimit of the given type, if any.
+func (d *RuntimeDefaults) GetRlimit(typ string) *POSIXRlimit {
+	for _, r := range d.GetRlimits() {
+		if r.Type == typ {
+			return r
+		}
+	}
+	return nil
+}
@@ -17,6 +17,8 @@
 package api
 
 import (
+	"strconv"
+
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -82,8 +84,78 @@ func (d *LinuxDevice) Cmp(v *LinuxDevice) bool {
 	return d.Major != v.Major || d.Minor != v.Minor
 }
 
-// IsMarkedForRemoval checks if a LinuxDevice is marked for removal.
+// IsMarkedForRemoval checks if a LinuxDevice is marked for removal, either
+// by its Remove field or by a legacy '-' prefix of its path. It also returns
+// the path stripped of any removal marker.
 func (d *LinuxDevice) IsMarkedForRemoval() (string, bool) {
-	key, marked := IsMarkedForRemoval(d.Path)
-	return key, marked
+	key, marked := IsMarkedForRemoval(d.GetPath())
+	return key, marked || d.GetRemove()
+}
+
+// StripRemovalMarker returns a copy of the LinuxDevice with any removal
+// marker stripped from it.
+func (d *LinuxDevice) StripRemovalMarker() *LinuxDevice {
+	if d == nil {
+		return nil
+	}
+	return &LinuxDevice{
+		Path:     StripRemovalMarker(d.Path),
+		Type:     d.Type,
+		Major:    d.Major,
+		Minor:    d.Minor,
+		FileMode: d.FileMode,
+		Uid:      d.Uid,
+		Gid:      d.Gid,
+	}
+}
+
+// Key returns the device type and numbers the LinuxDeviceCgroup rule applies
+// to, in the format of a cgroup v1 devices.allow entry without the access
+// modes, for instance "c 1:3" or "b *:*". Unset numbers match all devices.
+func (d *LinuxDeviceCgroup) Key() string {
+	typ := d.GetType()
+	if typ == "" {
+		typ = "a"
+	}
+	return typ + " " + deviceNumber(d.GetMajor()) + ":" + deviceNumber(d.GetMinor())
+}
+
+func deviceNumber(n *OptionalInt64) string {
+	if n == nil {
+		return "*"
+	}
+	return strconv.FormatInt(n.GetValue(), 10)
+}
+
+// IsMarkedForRemoval checks if a LinuxDeviceCgroup rule is marked for
+// removal. It also returns the key of the rule.
+func (d *LinuxDeviceCgroup) IsMarkedForRemoval() (string, bool) {
+	return d.Key(), d.GetRemove()
+}
+
+// StripRemovalMarker returns a copy of the LinuxDeviceCgroup rule with any
+// removal marker stripped from it.
+func (d *LinuxDeviceCgroup) StripRemovalMarker() *LinuxDeviceCgroup {
+	if d == nil {
+		return nil
+	}
+	o := d.Copy()
+	o.Remove = false
+	return o
+}
+
+// Copy creates a copy of the LinuxDeviceCgroup rule.
+func (d *LinuxDeviceCgroup) Copy() *LinuxDeviceCgroup {
+	if d == nil {
+		return nil
+	}
+	return &LinuxDeviceCgroup{
+		Allow:         d.Allow,
+		Type:          d.Type,
+		Major:         Int64(d.Major.Get()),
+		Minor:         Int64(d.Minor.Get()),
+		Access:        d.Access,
+		Remove:        d.Remove,
+		unknownFields: copyUnknownFields(d.unknownFields),
+	}
 }
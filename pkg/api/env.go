@@ -17,6 +17,10 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
 	"strings"
 )
 
@@ -53,8 +57,70 @@ func FromOCIEnv(in []string) []*KeyValue {
 	return out
 }
 
-// IsMarkedForRemoval checks if an environment variable is marked for removal.
+// IsMarkedForRemoval checks if an environment variable is marked for
+// removal, either by its Remove field or by a legacy '-' prefix of its
+// key. It also returns the key stripped of any removal marker.
 func (e *KeyValue) IsMarkedForRemoval() (string, bool) {
-	key, marked := IsMarkedForRemoval(e.Key)
-	return key, marked
+	key, marked := IsMarkedForRemoval(e.GetKey())
+	return key, marked || e.GetRemove()
+}
+
+// StripRemovalMarker returns a copy of the environment variable with any
+// removal marker stripped from it.
+func (e *KeyValue) StripRemovalMarker() *KeyValue {
+	if e == nil {
+		return nil
+	}
+	return &KeyValue{
+		Key:   StripRemovalMarker(e.Key),
+		Value: e.Value,
+	}
+}
+
+// Validate checks that the environment file has a clean absolute path.
+func (f *EnvFile) Validate() error {
+	p := f.GetPath()
+	if !path.IsAbs(p) || path.Clean(p) != p {
+		return fmt.Errorf("environment file %q is not a clean absolute path", p)
+	}
+	return nil
+}
+
+// IsWithin returns true if the environment file is within the given mount
+// destination.
+func (f *EnvFile) IsWithin(destination string) bool {
+	if destination == "/" {
+		return true
+	}
+	return strings.HasPrefix(f.GetPath(), strings.TrimSuffix(destination, "/")+"/")
+}
+
+// ParseEnvFile parses the contents of an environment file into OCI Env
+// entries, for runtimes sourcing environment files of containers. Each
+// line of the file is a KEY=value pair. Empty lines and lines starting
+// with '#' are ignored.
+func ParseEnvFile(data []byte) ([]string, error) {
+	var (
+		env     = []string{}
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+		lineNo  = 0
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimLeft(scanner.Text(), " \t")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, ok := strings.Cut(line, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid environment file entry at line %d", lineNo)
+		}
+		env = append(env, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse environment file: %w", err)
+	}
+
+	return env, nil
 }
@@ -47,6 +47,11 @@ type (
 	PostStartContainerResponse  = Empty
 	PostUpdateContainerRequest  = StateChangeEvent
 	PostUpdateContainerResponse = Empty
+	ContainerDiedRequest        = StateChangeEvent
+	ContainerDiedResponse       = Empty
+
+	CreateContainerRollbackRequest  = StateChangeEvent
+	CreateContainerRollbackResponse = Empty
 
 	ShutdownRequest  = Empty
 	ShutdownResponse = Empty
@@ -71,6 +76,9 @@ func ParseEventMask(events ...string) (EventMask, error) {
 		"postupdatecontainer": Event_POST_UPDATE_CONTAINER,
 		"stopcontainer":       Event_STOP_CONTAINER,
 		"removecontainer":     Event_REMOVE_CONTAINER,
+		"containerdied":       Event_CONTAINER_DIED,
+
+		"createcontainerrollback": Event_CREATE_CONTAINER_ROLLBACK,
 	}
 
 	for _, event := range events {
@@ -130,6 +138,9 @@ func (m *EventMask) PrettyString() string {
 		Event_POST_UPDATE_CONTAINER: "PostUpdateContainer",
 		Event_STOP_CONTAINER:        "StopContainer",
 		Event_REMOVE_CONTAINER:      "RemoveContainer",
+		Event_CONTAINER_DIED:        "ContainerDied",
+
+		Event_CREATE_CONTAINER_ROLLBACK: "CreateContainerRollback",
 	}
 
 	mask := *m
@@ -0,0 +1,136 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+)
+
+const (
+	// ExitReasonCompleted is the reason of containers which exited with 0.
+	ExitReasonCompleted = "Completed"
+	// ExitReasonError is the reason of containers which exited with an
+	// error.
+	ExitReasonError = "Error"
+	// ExitReasonOOMKilled is the reason of containers killed by the OOM
+	// killer.
+	ExitReasonOOMKilled = "OOMKilled"
+	// ExitReasonCancelled is the reason of containers the creation of which
+	// was cancelled, because their pod was stopped or removed meanwhile.
+	ExitReasonCancelled = "Cancelled"
+	// ExitReasonUnknown is the reason of stopped containers for which the
+	// runtime did not provide any exit information.
+	ExitReasonUnknown = "Unknown"
+	// UnknownExitCode is the exit code of stopped containers for which the
+	// runtime did not provide any exit information.
+	UnknownExitCode = -1
+)
+
+// ContainerExit is the exit information of a container.
+type ContainerExit struct {
+	// Code is the exit code of the container process.
+	Code int32
+	// Reason is a brief, CamelCase reason of the exit.
+	Reason string
+	// Message is a human-readable message with details of the exit.
+	Message string
+	// FinishedAt is the time the container exited, or the zero time if
+	// it is not known.
+	FinishedAt time.Time
+	// OOMKilled is true if the container was killed by the OOM killer.
+	OOMKilled bool
+	// Signal is the signal which terminated the container process, or 0
+	// if the process exited on its own or the signal is not known.
+	Signal int32
+}
+
+// Exit returns the exit information of the container, or nil if it has
+// none, because the container has not exited or the runtime did not set it.
+func (c *Container) Exit() *ContainerExit {
+	if !c.HasExit() {
+		return nil
+	}
+
+	e := &ContainerExit{
+		Code:      c.ExitCode,
+		Reason:    c.StatusReason,
+		Message:   c.StatusMessage,
+		OOMKilled: c.OomKilled,
+		Signal:    c.ExitSignal,
+	}
+	if c.FinishedAt != 0 {
+		e.FinishedAt = time.Unix(0, c.FinishedAt)
+	}
+
+	return e
+}
+
+// SetExit sets the exit information of the container. If no reason is
+// given, it is derived from the exit code, unless the container was killed
+// by the OOM killer.
+func (c *Container) SetExit(e *ContainerExit) {
+	if e == nil {
+		c.FinishedAt = 0
+		c.ExitCode = 0
+		c.StatusReason = ""
+		c.StatusMessage = ""
+		c.OomKilled = false
+		c.ExitSignal = 0
+		return
+	}
+
+	c.ExitCode = e.Code
+	c.StatusReason = e.Reason
+	c.StatusMessage = e.Message
+	c.OomKilled = e.OOMKilled
+	c.ExitSignal = e.Signal
+	c.FinishedAt = 0
+	if !e.FinishedAt.IsZero() {
+		c.FinishedAt = e.FinishedAt.UnixNano()
+	}
+
+	if c.StatusReason == "" {
+		switch {
+		case c.OomKilled:
+			c.StatusReason = ExitReasonOOMKilled
+		case e.Code == 0:
+			c.StatusReason = ExitReasonCompleted
+		default:
+			c.StatusReason = ExitReasonError
+		}
+	}
+}
+
+// HasExit returns true if the container carries exit information.
+func (c *Container) HasExit() bool {
+	return c.GetFinishedAt() != 0 || c.GetStatusReason() != "" || c.GetOomKilled()
+}
+
+// Succeeded returns true if the container exited successfully.
+func (e *ContainerExit) Succeeded() bool {
+	return e != nil && e.Code == 0 && e.Reason != ExitReasonUnknown
+}
+
+// IsUnknown returns true if the runtime provided no exit information.
+func (e *ContainerExit) IsUnknown() bool {
+	return e != nil && e.Reason == ExitReasonUnknown
+}
+
+// IsCancelled returns true if the creation of the container was cancelled.
+func (e *ContainerExit) IsCancelled() bool {
+	return e != nil && e.Reason == ExitReasonCancelled
+}
@@ -0,0 +1,69 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"path"
+)
+
+// Validate checks the filter for invalid namespace patterns.
+func (f *EventFilter) Validate() error {
+	for _, pattern := range f.GetNamespaces() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespace pattern %q in event filter: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the filter matches the given pod. A nil filter
+// matches any pod.
+func (f *EventFilter) Matches(pod *PodSandbox) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Namespaces) > 0 {
+		matched := false
+		for _, pattern := range f.Namespaces {
+			if ok, _ := path.Match(pattern, pod.GetNamespace()); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	labels := pod.GetLabels()
+	for k, v := range f.Labels {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	annotations := pod.GetAnnotations()
+	for k, v := range f.Annotations {
+		if a, ok := annotations[k]; !ok || a != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+// IsNewerThan returns true if the pod is a newer copy than the other one.
+func (p *PodSandbox) IsNewerThan(o *PodSandbox) bool {
+	return p.GetGeneration() > o.GetGeneration()
+}
+
+// IsNewerThan returns true if the container is a newer copy than the other one.
+func (c *Container) IsNewerThan(o *Container) bool {
+	return c.GetGeneration() > o.GetGeneration()
+}
+
+// GetLatestPodSandbox returns the latest of the given copies of a pod. A
+// plugin can use it to update its cached copy with one received in an event,
+// without overwriting it with a stale one.
+func GetLatestPodSandbox(pods ...*PodSandbox) *PodSandbox {
+	var latest *PodSandbox
+	for _, p := range pods {
+		if p != nil && (latest == nil || p.IsNewerThan(latest)) {
+			latest = p
+		}
+	}
+	return latest
+}
+
+// GetLatestContainer returns the latest of the given copies of a container.
+// A plugin can use it to update its cached copy with one received in an event,
+// without overwriting it with a stale one.
+func GetLatestContainer(ctrs ...*Container) *Container {
+	var latest *Container
+	for _, c := range ctrs {
+		if c != nil && (latest == nil || c.IsNewerThan(latest)) {
+			latest = c
+		}
+	}
+	return latest
+}
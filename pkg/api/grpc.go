@@ -0,0 +1,194 @@
+//go:build !tinygo.wasm
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// gRPC bindings of the Runtime and Plugin services, for plugins written in
+// languages without ttrpc tooling. These use the same service and method
+// names as the ttrpc bindings, so plugins can generate their gRPC bindings
+// from api.proto using the stock gRPC tooling of their language. The
+// bindings are kept in sync with api.proto by hand.
+
+const (
+	runtimeServiceName = "nri.pkg.api.v1alpha1.Runtime"
+	pluginServiceName  = "nri.pkg.api.v1alpha1.Plugin"
+)
+
+// RegisterRuntimeGRPCService registers the Runtime service with a gRPC server.
+func RegisterRuntimeGRPCService(srv grpc.ServiceRegistrar, svc RuntimeService) {
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: runtimeServiceName,
+		HandlerType: (*RuntimeService)(nil),
+		Methods: []grpc.MethodDesc{
+			grpcMethod(runtimeServiceName, "RegisterPlugin", RuntimeService.RegisterPlugin),
+			grpcMethod(runtimeServiceName, "UpdateContainers", RuntimeService.UpdateContainers),
+			grpcMethod(runtimeServiceName, "ReportStatus", RuntimeService.ReportStatus),
+			grpcMethod(runtimeServiceName, "PreviewAdjustment", RuntimeService.PreviewAdjustment),
+			grpcMethod(runtimeServiceName, "GetPods", RuntimeService.GetPods),
+			grpcMethod(runtimeServiceName, "GetContainers", RuntimeService.GetContainers),
+			grpcMethod(runtimeServiceName, "Keepalive", RuntimeService.Keepalive),
+		},
+		Metadata: "pkg/api/api.proto",
+	}, svc)
+}
+
+// RegisterPluginGRPCService registers the Plugin service with a gRPC server.
+func RegisterPluginGRPCService(srv grpc.ServiceRegistrar, svc PluginService) {
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: pluginServiceName,
+		HandlerType: (*PluginService)(nil),
+		Methods: []grpc.MethodDesc{
+			grpcMethod(pluginServiceName, "Configure", PluginService.Configure),
+			grpcMethod(pluginServiceName, "Synchronize", PluginService.Synchronize),
+			grpcMethod(pluginServiceName, "Shutdown", PluginService.Shutdown),
+			grpcMethod(pluginServiceName, "CreateContainer", PluginService.CreateContainer),
+			grpcMethod(pluginServiceName, "UpdateContainer", PluginService.UpdateContainer),
+			grpcMethod(pluginServiceName, "StopContainer", PluginService.StopContainer),
+			grpcMethod(pluginServiceName, "StateChange", PluginService.StateChange),
+			grpcMethod(pluginServiceName, "SetLeadership", PluginService.SetLeadership),
+			grpcMethod(pluginServiceName, "Ping", PluginService.Ping),
+			grpcMethod(pluginServiceName, "ReconfigurePlugin", PluginService.ReconfigurePlugin),
+		},
+		Metadata: "pkg/api/api.proto",
+	}, svc)
+}
+
+// grpcMethod returns the gRPC method descriptor for a unary service method.
+func grpcMethod[S any, Req any, Rsp any](service, method string, call func(S, context.Context, *Req) (*Rsp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return call(srv.(S), ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + service + "/" + method,
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(srv.(S), ctx, req.(*Req))
+			}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
+
+// grpcInvoke invokes a unary method of a service using a gRPC client.
+func grpcInvoke[Rsp any](ctx context.Context, cc grpc.ClientConnInterface, service, method string, req interface{}) (*Rsp, error) {
+	rsp := new(Rsp)
+	if err := cc.Invoke(ctx, "/"+service+"/"+method, req, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+type runtimeGRPCClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewRuntimeGRPCClient returns a Runtime service client using a gRPC client.
+func NewRuntimeGRPCClient(cc grpc.ClientConnInterface) RuntimeService {
+	return &runtimeGRPCClient{cc: cc}
+}
+
+func (c *runtimeGRPCClient) RegisterPlugin(ctx context.Context, req *RegisterPluginRequest) (*RegisterPluginResponse, error) {
+	return grpcInvoke[RegisterPluginResponse](ctx, c.cc, runtimeServiceName, "RegisterPlugin", req)
+}
+
+func (c *runtimeGRPCClient) UpdateContainers(ctx context.Context, req *UpdateContainersRequest) (*UpdateContainersResponse, error) {
+	return grpcInvoke[UpdateContainersResponse](ctx, c.cc, runtimeServiceName, "UpdateContainers", req)
+}
+
+func (c *runtimeGRPCClient) ReportStatus(ctx context.Context, req *ReportStatusRequest) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, runtimeServiceName, "ReportStatus", req)
+}
+
+func (c *runtimeGRPCClient) PreviewAdjustment(ctx context.Context, req *PreviewAdjustmentRequest) (*PreviewAdjustmentResponse, error) {
+	return grpcInvoke[PreviewAdjustmentResponse](ctx, c.cc, runtimeServiceName, "PreviewAdjustment", req)
+}
+
+func (c *runtimeGRPCClient) GetPods(ctx context.Context, req *GetPodsRequest) (*GetPodsResponse, error) {
+	return grpcInvoke[GetPodsResponse](ctx, c.cc, runtimeServiceName, "GetPods", req)
+}
+
+func (c *runtimeGRPCClient) GetContainers(ctx context.Context, req *GetContainersRequest) (*GetContainersResponse, error) {
+	return grpcInvoke[GetContainersResponse](ctx, c.cc, runtimeServiceName, "GetContainers", req)
+}
+
+func (c *runtimeGRPCClient) Keepalive(ctx context.Context, req *KeepaliveRequest) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, runtimeServiceName, "Keepalive", req)
+}
+
+type pluginGRPCClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewPluginGRPCClient returns a Plugin service client using a gRPC client.
+func NewPluginGRPCClient(cc grpc.ClientConnInterface) PluginService {
+	return &pluginGRPCClient{cc: cc}
+}
+
+func (c *pluginGRPCClient) Configure(ctx context.Context, req *ConfigureRequest) (*ConfigureResponse, error) {
+	return grpcInvoke[ConfigureResponse](ctx, c.cc, pluginServiceName, "Configure", req)
+}
+
+func (c *pluginGRPCClient) Synchronize(ctx context.Context, req *SynchronizeRequest) (*SynchronizeResponse, error) {
+	return grpcInvoke[SynchronizeResponse](ctx, c.cc, pluginServiceName, "Synchronize", req)
+}
+
+func (c *pluginGRPCClient) Shutdown(ctx context.Context, req *Empty) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, pluginServiceName, "Shutdown", req)
+}
+
+func (c *pluginGRPCClient) CreateContainer(ctx context.Context, req *CreateContainerRequest) (*CreateContainerResponse, error) {
+	return grpcInvoke[CreateContainerResponse](ctx, c.cc, pluginServiceName, "CreateContainer", req)
+}
+
+func (c *pluginGRPCClient) UpdateContainer(ctx context.Context, req *UpdateContainerRequest) (*UpdateContainerResponse, error) {
+	return grpcInvoke[UpdateContainerResponse](ctx, c.cc, pluginServiceName, "UpdateContainer", req)
+}
+
+func (c *pluginGRPCClient) StopContainer(ctx context.Context, req *StopContainerRequest) (*StopContainerResponse, error) {
+	return grpcInvoke[StopContainerResponse](ctx, c.cc, pluginServiceName, "StopContainer", req)
+}
+
+func (c *pluginGRPCClient) StateChange(ctx context.Context, req *StateChangeEvent) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, pluginServiceName, "StateChange", req)
+}
+
+func (c *pluginGRPCClient) SetLeadership(ctx context.Context, req *SetLeadershipRequest) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, pluginServiceName, "SetLeadership", req)
+}
+
+func (c *pluginGRPCClient) Ping(ctx context.Context, req *Empty) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, pluginServiceName, "Ping", req)
+}
+
+func (c *pluginGRPCClient) ReconfigurePlugin(ctx context.Context, req *ReconfigurePluginRequest) (*Empty, error) {
+	return grpcInvoke[Empty](ctx, c.cc, pluginServiceName, "ReconfigurePlugin", req)
+}
@@ -0,0 +1,162 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+// Names of container adjustments, used by runtimes to list the adjustments
+// which don't take effect with the runtime handler of a pod.
+const (
+	AnnotationsAdjustment        = "annotations"
+	MountsAdjustment             = "mounts"
+	EnvAdjustment                = "env"
+	HooksAdjustment              = "hooks"
+	DevicesAdjustment            = "devices"
+	ResourcesAdjustment          = "resources"
+	CgroupsPathAdjustment        = "cgroups_path"
+	OomScoreAdjAdjustment        = "oom_score_adj"
+	RlimitsAdjustment            = "rlimits"
+	CDIDevicesAdjustment         = "cdi_devices"
+	TopologyHintsAdjustment      = "topology_hints"
+	HandlerAnnotationsAdjustment = "handler_annotations"
+	WindowsResourcesAdjustment   = "windows_resources"
+	CapabilitiesAdjustment       = "capabilities"
+	EnvFilesAdjustment           = "env_files"
+	UserAdjustment               = "user"
+	ApparmorProfileAdjustment    = "apparmor_profile"
+	SeccompPolicyAdjustment      = "seccomp_policy"
+	CPUAffinityAdjustment        = "cpu_affinity"
+	SchedulingHintsAdjustment    = "scheduling_hints"
+	NetworkBandwidthAdjustment   = "network_bandwidth"
+)
+
+// Supports returns true if the given adjustment takes effect with the
+// runtime handler. Without known capabilities all adjustments do.
+func (c *RuntimeHandlerCapabilities) Supports(adjustment string) bool {
+	for _, a := range c.GetUnsupportedAdjustments() {
+		if a == adjustment {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAdjustment returns true if the given adjustment is present.
+func (a *ContainerAdjustment) HasAdjustment(adjustment string) bool {
+	switch adjustment {
+	case AnnotationsAdjustment:
+		return len(a.GetAnnotations()) > 0
+	case MountsAdjustment:
+		return len(a.GetMounts()) > 0
+	case EnvAdjustment:
+		return len(a.GetEnv()) > 0
+	case HooksAdjustment:
+		h := a.GetHooks()
+		return len(h.GetPrestart()) > 0 || len(h.GetCreateRuntime()) > 0 ||
+			len(h.GetCreateContainer()) > 0 || len(h.GetStartContainer()) > 0 ||
+			len(h.GetPoststart()) > 0 || len(h.GetPoststop()) > 0
+	case DevicesAdjustment:
+		return len(a.GetLinux().GetDevices()) > 0
+	case ResourcesAdjustment:
+		return a.GetLinux().GetResources() != nil
+	case CgroupsPathAdjustment:
+		return a.GetLinux().GetCgroupsPath() != ""
+	case OomScoreAdjAdjustment:
+		return a.GetLinux().GetOomScoreAdj() != nil
+	case RlimitsAdjustment:
+		return len(a.GetRlimits()) > 0
+	case CDIDevicesAdjustment:
+		return len(a.GetCDIDevices()) > 0
+	case TopologyHintsAdjustment:
+		return a.GetTopologyHints() != nil
+	case HandlerAnnotationsAdjustment:
+		return len(a.GetHandlerAnnotations()) > 0
+	case WindowsResourcesAdjustment:
+		return a.GetWindows().GetResources() != nil
+	case CapabilitiesAdjustment:
+		return a.GetLinux().GetCapabilities() != nil
+	case ApparmorProfileAdjustment:
+		return a.GetLinux().GetApparmorProfile() != ""
+	case SeccompPolicyAdjustment:
+		return a.GetLinux().GetSeccompPolicy() != nil
+	case CPUAffinityAdjustment:
+		return a.GetLinux().GetCpuAffinity() != ""
+	case NetworkBandwidthAdjustment:
+		return !a.GetLinux().GetNetworkBandwidth().IsEmpty()
+	case SchedulingHintsAdjustment:
+		return !a.GetSchedulingHints().IsEmpty()
+	case EnvFilesAdjustment:
+		return len(a.GetEnvFiles()) > 0
+	case UserAdjustment:
+		u := a.GetUser()
+		return u.GetUid() != nil || u.GetGid() != nil || len(u.GetAdditionalGids()) > 0
+	}
+	return false
+}
+
+// ClearAdjustment removes the given adjustment.
+func (a *ContainerAdjustment) ClearAdjustment(adjustment string) {
+	switch adjustment {
+	case AnnotationsAdjustment:
+		a.Annotations = nil
+	case MountsAdjustment:
+		a.Mounts = nil
+	case EnvAdjustment:
+		a.Env = nil
+	case HooksAdjustment:
+		a.Hooks = nil
+	case RlimitsAdjustment:
+		a.Rlimits = nil
+	case CDIDevicesAdjustment:
+		a.CDIDevices = nil
+	case TopologyHintsAdjustment:
+		a.TopologyHints = nil
+	case SchedulingHintsAdjustment:
+		a.SchedulingHints = nil
+	case HandlerAnnotationsAdjustment:
+		a.HandlerAnnotations = nil
+	case WindowsResourcesAdjustment:
+		a.Windows = nil
+	case EnvFilesAdjustment:
+		a.EnvFiles = nil
+	case UserAdjustment:
+		a.User = nil
+	}
+
+	if a.Linux == nil {
+		return
+	}
+
+	switch adjustment {
+	case DevicesAdjustment:
+		a.Linux.Devices = nil
+	case ResourcesAdjustment:
+		a.Linux.Resources = nil
+	case CgroupsPathAdjustment:
+		a.Linux.CgroupsPath = ""
+	case OomScoreAdjAdjustment:
+		a.Linux.OomScoreAdj = nil
+	case CapabilitiesAdjustment:
+		a.Linux.Capabilities = nil
+	case ApparmorProfileAdjustment:
+		a.Linux.ApparmorProfile = ""
+	case SeccompPolicyAdjustment:
+		a.Linux.SeccompPolicy = nil
+	case CPUAffinityAdjustment:
+		a.Linux.CpuAffinity = ""
+	case NetworkBandwidthAdjustment:
+		a.Linux.NetworkBandwidth = nil
+	}
+}
@@ -26,6 +26,16 @@ func DupStringSlice(in []string) []string {
 	return out
 }
 
+// DupUint32Slice creates a copy of a uint32 slice.
+func DupUint32Slice(in []uint32) []uint32 {
+	if in == nil {
+		return nil
+	}
+	out := make([]uint32, len(in))
+	copy(out, in)
+	return out
+}
+
 // DupStringMap creates a copy of a map with string keys and values.
 func DupStringMap(in map[string]string) map[string]string {
 	if in == nil {
@@ -41,8 +51,11 @@ func DupStringMap(in map[string]string) map[string]string {
 // IsMarkedForRemoval checks if a key is marked for removal.
 //
 // The key can be an annotation name, a mount container path, a device path,
-// or an environment variable name. These are all marked for removal in
-// adjustments by preceding their corresponding key with a '-'.
+// an environment variable name, or a capability name. These are all marked
+// for removal in adjustments by preceding their corresponding key with a '-'.
+// Mounts, devices and environment variables are also marked for removal by
+// their Remove field. Use their IsMarkedForRemoval() method, which checks
+// both, instead of this function for them.
 func IsMarkedForRemoval(key string) (string, bool) {
 	if key == "" {
 		return "", false
@@ -58,13 +71,15 @@ func MarkForRemoval(key string) string {
 	return "-" + key
 }
 
+// StripRemovalMarker returns a key stripped of any removal marker.
+func StripRemovalMarker(key string) string {
+	key, _ = IsMarkedForRemoval(key)
+	return key
+}
+
 // ClearRemovalMarker returns a key cleared from any removal marker.
+//
+// Deprecated: use StripRemovalMarker instead.
 func ClearRemovalMarker(key string) string {
-	if key == "" {
-		return ""
-	}
-	if key[0] == '-' {
-		return key[1:]
-	}
-	return key
+	return StripRemovalMarker(key)
 }
@@ -0,0 +1,158 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sysfsHugepages is the directory of the supported hugepage sizes.
+	sysfsHugepages = "/sys/kernel/mm/hugepages"
+)
+
+// hugepageUnits are the units of hugepage sizes, largest first.
+var hugepageUnits = []struct {
+	name  string
+	bytes uint64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+}
+
+// hugepageSuffixes are the accepted unit suffixes of hugepage sizes.
+var hugepageSuffixes = map[string]uint64{
+	"B":   1,
+	"KB":  1 << 10,
+	"kB":  1 << 10,
+	"KiB": 1 << 10,
+	"MB":  1 << 20,
+	"MiB": 1 << 20,
+	"GB":  1 << 30,
+	"GiB": 1 << 30,
+}
+
+// ParseHugepageSize parses a hugepage size, returning it in bytes. The size
+// must have one of the units B, KB or kB, MB, GB, KiB, MiB or GiB. Units are
+// taken to be binary regardless of their spelling, so 2MB, 2MiB and 2048kB
+// all denote the same size.
+func ParseHugepageSize(size string) (uint64, error) {
+	i := strings.IndexFunc(size, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid hugepage size %q", size)
+	}
+
+	value, err := strconv.ParseUint(size[:i], 10, 64)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("invalid hugepage size %q", size)
+	}
+
+	mult, ok := hugepageSuffixes[size[i:]]
+	if !ok {
+		return 0, fmt.Errorf("invalid hugepage size %q: unknown unit %q", size, size[i:])
+	}
+
+	if value > (1<<64-1)/mult {
+		return 0, fmt.Errorf("invalid hugepage size %q: out of range", size)
+	}
+
+	return value * mult, nil
+}
+
+// FormatHugepageSize returns the canonical form of a hugepage size given in
+// bytes, as used in the names of hugetlb cgroup control files, for instance
+// 2MB or 1GB.
+func FormatHugepageSize(bytes uint64) string {
+	for _, u := range hugepageUnits {
+		if bytes >= u.bytes && bytes%u.bytes == 0 {
+			return strconv.FormatUint(bytes/u.bytes, 10) + u.name
+		}
+	}
+	return strconv.FormatUint(bytes, 10) + "B"
+}
+
+// NormalizeHugepageSize returns the canonical form of a hugepage size.
+func NormalizeHugepageSize(size string) (string, error) {
+	bytes, err := ParseHugepageSize(size)
+	if err != nil {
+		return "", err
+	}
+	return FormatHugepageSize(bytes), nil
+}
+
+// HostHugepageSizes returns the hugepage sizes supported by the host, in
+// canonical form, read from sysfs.
+func HostHugepageSizes() ([]string, error) {
+	return ReadHugepageSizes("/")
+}
+
+// ReadHugepageSizes returns the hugepage sizes supported by a host with its
+// sysfs mounted under the given root directory, in canonical form.
+func ReadHugepageSizes(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, sysfsHugepages))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hugepage sizes: %w", err)
+	}
+
+	var (
+		sizes []string
+		bytes []uint64
+	)
+	for _, e := range entries {
+		size, ok := strings.CutPrefix(e.Name(), "hugepages-")
+		if !ok {
+			continue
+		}
+		b, err := ParseHugepageSize(size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hugepage sizes: %w", err)
+		}
+		bytes = append(bytes, b)
+	}
+
+	sort.Slice(bytes, func(i, j int) bool { return bytes[i] < bytes[j] })
+	for _, b := range bytes {
+		sizes = append(sizes, FormatHugepageSize(b))
+	}
+
+	return sizes, nil
+}
+
+// addHugepageLimit adds a limit for a page size, replacing any earlier
+// limit for the same size. Valid page sizes are normalized, invalid ones
+// are kept as they are, to be rejected when the limit is applied.
+func addHugepageLimit(limits []*HugepageLimit, pageSize string, value uint64) []*HugepageLimit {
+	if size, err := NormalizeHugepageSize(pageSize); err == nil {
+		pageSize = size
+	}
+	for _, l := range limits {
+		if l.PageSize == pageSize {
+			l.Limit = value
+			return limits
+		}
+	}
+	return append(limits, &HugepageLimit{
+		PageSize: pageSize,
+		Limit:    value,
+	})
+}
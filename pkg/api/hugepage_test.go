@@ -0,0 +1,75 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/containerd/nri/pkg/api"
+
+	require "github.com/stretchr/testify/require"
+)
+
+func TestParseHugepageSize(t *testing.T) {
+	for _, tc := range []struct {
+		size       string
+		bytes      uint64
+		normalized string
+		invalid    bool
+	}{
+		{size: "2MB", bytes: 2 << 20, normalized: "2MB"},
+		{size: "2MiB", bytes: 2 << 20, normalized: "2MB"},
+		{size: "2048kB", bytes: 2 << 20, normalized: "2MB"},
+		{size: "2048KB", bytes: 2 << 20, normalized: "2MB"},
+		{size: "2048KiB", bytes: 2 << 20, normalized: "2MB"},
+		{size: "1GB", bytes: 1 << 30, normalized: "1GB"},
+		{size: "1GiB", bytes: 1 << 30, normalized: "1GB"},
+		{size: "64KB", bytes: 64 << 10, normalized: "64KB"},
+		{size: "1536B", bytes: 1536, normalized: "1536B"},
+		{size: "", invalid: true},
+		{size: "MB", invalid: true},
+		{size: "0MB", invalid: true},
+		{size: "2", invalid: true},
+		{size: "2iB", invalid: true},
+		{size: "2M", invalid: true},
+		{size: "2Mi", invalid: true},
+		{size: "2mb", invalid: true},
+		{size: "2Mb", invalid: true},
+		{size: "2MBB", invalid: true},
+		{size: "2BiB", invalid: true},
+		{size: "2XB", invalid: true},
+		{size: " 2MB", invalid: true},
+		{size: "2MB ", invalid: true},
+		{size: "-2MB", invalid: true},
+		{size: "2.5MB", invalid: true},
+		{size: "18446744073709551615GB", invalid: true},
+	} {
+		t.Run(tc.size, func(t *testing.T) {
+			bytes, err := api.ParseHugepageSize(tc.size)
+			if tc.invalid {
+				require.Error(t, err, "ParseHugepageSize(%q)", tc.size)
+				return
+			}
+			require.NoError(t, err, "ParseHugepageSize(%q)", tc.size)
+			require.Equal(t, tc.bytes, bytes)
+
+			normalized, err := api.NormalizeHugepageSize(tc.size)
+			require.NoError(t, err)
+			require.Equal(t, tc.normalized, normalized)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+)
+
+const (
+	// CRIImageNameAnnotation is the container annotation the containerd
+	// CRI plugin records the image name of a container in.
+	CRIImageNameAnnotation = "io.kubernetes.cri.image-name"
+	// CRIOImageNameAnnotation is the container annotation CRI-O records
+	// the image name of a container in.
+	CRIOImageNameAnnotation = "io.kubernetes.cri-o.ImageName"
+	// CRIOImageRefAnnotation is the container annotation CRI-O records
+	// the resolved image reference of a container in.
+	CRIOImageRefAnnotation = "io.kubernetes.cri-o.ImageRef"
+)
+
+// ImageFromAnnotations returns container image information from the
+// well-known CRI container annotations, or nil if there is none.
+func ImageFromAnnotations(annotations map[string]string) *ContainerImage {
+	name, ok := annotations[CRIImageNameAnnotation]
+	if !ok {
+		name, ok = annotations[CRIOImageNameAnnotation]
+	}
+	if !ok || name == "" {
+		return nil
+	}
+
+	img := &ContainerImage{
+		Name:   name,
+		Id:     annotations[CRIOImageRefAnnotation],
+		Digest: ImageDigest(name),
+	}
+	if img.Digest == "" {
+		img.Digest = ImageDigest(img.Id)
+	}
+
+	return img
+}
+
+// ImageDigest returns the digest of a digested image reference, or an
+// empty string for references without a digest.
+func ImageDigest(ref string) string {
+	if _, digest, ok := strings.Cut(ref, "@"); ok {
+		return digest
+	}
+	return ""
+}
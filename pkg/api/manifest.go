@@ -0,0 +1,137 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// PluginManifestSuffixes are the suffixes of plugin manifest files, in
+	// order of preference. The manifest of a plugin is looked up by adding
+	// one of these to the path of the plugin binary.
+	PluginManifestSuffixes = []string{
+		".manifest.yaml",
+		".manifest.yml",
+		".manifest.json",
+	}
+)
+
+// PluginManifest describes a pre-installed plugin. It is read from a YAML
+// or JSON file next to the plugin binary, and takes precedence over the
+// index and name encoded in the filename of the binary.
+type PluginManifest struct {
+	// Name of the plugin, without the index.
+	Name string `json:"name"`
+	// Index of the plugin, two digits.
+	Index string `json:"index"`
+	// APIVersion is the oldest payload schema version the plugin works
+	// with. Runtimes using an older one don't launch the plugin. If 0, the
+	// plugin works with any version.
+	APIVersion int `json:"apiVersion,omitempty"`
+	// ConfigSchema is a reference, for instance a path or a URL, to the
+	// schema of the configuration of the plugin.
+	ConfigSchema string `json:"configSchema,omitempty"`
+	// Capabilities lists the capabilities of the plugin, for instance the
+	// kinds of adjustments it makes. They are purely informational.
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// FindPluginManifest returns the path of the manifest of the plugin binary
+// at the given path, or an empty string if it has none.
+func FindPluginManifest(binary string) (string, error) {
+	for _, suffix := range PluginManifestSuffixes {
+		path := binary + suffix
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to look up plugin manifest %s: %w", path, err)
+		}
+	}
+	return "", nil
+}
+
+// IsPluginManifest returns true if the given file name is that of a plugin
+// manifest.
+func IsPluginManifest(name string) bool {
+	for _, suffix := range PluginManifestSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadPluginManifest reads and validates the plugin manifest at the given path.
+func ReadPluginManifest(path string) (*PluginManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin manifest %s: %w", path, err)
+	}
+
+	m, err := ParsePluginManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// ParsePluginManifest parses and validates a YAML or JSON plugin manifest.
+func ParsePluginManifest(data []byte) (*PluginManifest, error) {
+	m := &PluginManifest{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks the validity of the plugin manifest.
+func (m *PluginManifest) Validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("no plugin name")
+	}
+	if strings.ContainsAny(m.Name, "/\\") {
+		return fmt.Errorf("invalid plugin name %q", m.Name)
+	}
+	if err := CheckPluginIndex(m.Index); err != nil {
+		return err
+	}
+	if m.APIVersion < 0 {
+		return fmt.Errorf("invalid plugin API version %d", m.APIVersion)
+	}
+	return nil
+}
+
+// Supported returns an error if the plugin requires a newer payload schema
+// version than the given one.
+func (m *PluginManifest) Supported(schemaVersion int) error {
+	if m.APIVersion > schemaVersion {
+		return fmt.Errorf("plugin %s-%s requires API version %d, runtime has %d",
+			m.Index, m.Name, m.APIVersion, schemaVersion)
+	}
+	return nil
+}
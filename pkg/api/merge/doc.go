@@ -0,0 +1,43 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package merge implements the collection of container adjustments and
+// updates requested by NRI plugins, the same way the runtime adaptation
+// collects them. Validators, proxies and tests can use it to get exactly
+// the merge semantics the runtime applies.
+//
+// A Result is created for a single CreateContainer, UpdateContainer or
+// StopContainer request. The responses of plugins are then applied to it in
+// plugin invocation order, and the merged response is taken from it once all
+// plugins have been invoked.
+//
+// Every adjusted or updated container parameter is owned by the plugin which
+// first sets it. It is an error for another plugin to set the same parameter,
+// unless it removes the parameter first, for parameters which support removal
+// using a removal marker. These are annotations, mounts, environment variables,
+// devices and runtime handler annotations. OCI hooks are appended and never
+// conflict. Capabilities are owned separately in each capability set. The
+// parameters of container updates are owned separately for each container.
+//
+// Adjustments are also applied to the container in the CreateContainer request
+// as they are collected, so that each plugin sees the changes made by the ones
+// invoked before it. A plugin asking for an update of the container being
+// created is an error, it should adjust the container instead.
+//
+// Changes rejected by the merge rules are reported as a RejectedError, which
+// carries the violated rule, the offending plugin and, for conflicts, the
+// plugin owning the parameter.
+package merge
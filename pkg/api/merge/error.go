@@ -0,0 +1,141 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ConflictRule is the rule violated by conflicting plugin adjustments.
+	ConflictRule = "conflict"
+	// InvalidAdjustmentRule is the rule violated by invalid adjustments.
+	InvalidAdjustmentRule = "invalid-adjustment"
+	// InvalidUpdateRule is the rule violated by invalid container updates.
+	InvalidUpdateRule = "invalid-update"
+	// AnnotationLimitRule is the rule violated by annotations exceeding limits.
+	AnnotationLimitRule = "annotation-limit"
+)
+
+// RejectedError is returned when NRI rejects the adjustments or updates
+// requested by a plugin. It carries machine-readable details about the
+// rejection, so that the runtime can report it as a distinct error and
+// generate a useful pod event.
+type RejectedError struct {
+	// Plugin is the plugin which requested the rejected changes.
+	Plugin string
+	// Other is the plugin the changes conflict with, if any.
+	Other string
+	// Rule is the rule violated by the rejected changes.
+	Rule string
+	// Subject is the subject of the rejected changes.
+	Subject string
+	// Reason is a human-readable explanation of the rejection.
+	Reason string
+	// Pod is the name of the pod, if known.
+	Pod string
+	// Container is the name of the container, if known.
+	Container string
+	// Conflict describes the conflict in structured form, for rejected
+	// conflicts.
+	Conflict *Conflict
+}
+
+// Error returns the error message of the rejection.
+func (e *RejectedError) Error() string {
+	if e.Rule == ConflictRule {
+		return fmt.Sprintf("plugins %q and %q both tried to set %s",
+			e.Plugin, e.Other, e.Subject)
+	}
+	return fmt.Sprintf("plugin %q: %s", e.Plugin, e.Reason)
+}
+
+// EventHint returns a short message suitable for a pod event.
+func (e *RejectedError) EventHint() string {
+	var target string
+
+	switch {
+	case e.Pod != "" && e.Container != "":
+		target = " of container " + e.Pod + "/" + e.Container
+	case e.Pod != "":
+		target = " of pod " + e.Pod
+	}
+
+	if e.Rule == ConflictRule {
+		return fmt.Sprintf("NRI plugins %s and %s made conflicting adjustments%s (%s)",
+			e.Plugin, e.Other, target, e.Subject)
+	}
+	return fmt.Sprintf("NRI plugin %s made rejected adjustments%s: %s",
+		e.Plugin, target, e.Reason)
+}
+
+// GRPCStatus returns the gRPC status corresponding to the rejection.
+func (e *RejectedError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// AsConflict returns the structured description of the conflict an error
+// was caused by, if any.
+func AsConflict(err error) (*Conflict, bool) {
+	var rejErr *RejectedError
+	if !errors.As(err, &rejErr) || rejErr.Rule != ConflictRule || rejErr.Conflict == nil {
+		return nil, false
+	}
+	return rejErr.Conflict, true
+}
+
+func conflict(plugin, other, field, subject string, qualif ...string) error {
+	subject = strings.Join(append([]string{subject}, qualif...), " ")
+	return &RejectedError{
+		Plugin:  plugin,
+		Other:   other,
+		Rule:    ConflictRule,
+		Subject: subject,
+		Conflict: &Conflict{
+			Field:   field,
+			Subject: subject,
+			Owner:   other,
+			Plugin:  plugin,
+		},
+	}
+}
+
+// conflictDetails completes the description of the conflict an error was
+// caused by, if any, with the field class, container and values involved.
+func conflictDetails(err error, class FieldClass, id string, current, proposed interface{}) error {
+	if c, ok := AsConflict(err); ok {
+		c.Class = class
+		c.Container = id
+		c.Current = current
+		c.Proposed = proposed
+	}
+	return err
+}
+
+func rejected(plugin, rule, subject, format string, args ...interface{}) error {
+	return &RejectedError{
+		Plugin:  plugin,
+		Rule:    rule,
+		Subject: subject,
+		Reason:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,280 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge_test
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+// The fuzzers below feed arbitrary plugin responses, decoded from protobuf
+// wire format, into the collection and validation of adjustments and updates.
+// Plugins are untrusted, so no response may crash the runtime, whether it is
+// rejected or not. Run them using 'make test-fuzz' or, one at a time, using
+// 'go test -run ^$ -fuzz <Fuzzer> ./pkg/api/merge'. Without -fuzz only the
+// seed corpus is run, as part of the regular tests.
+
+// fuzzOptions returns the merge options the fuzzers use, enabling all the
+// optional validation of adjustments.
+func fuzzOptions() []merge.Option {
+	return []merge.Option{
+		merge.WithTopology(topology),
+		merge.WithHugepageSizes([]string{"2MB", "1GB"}),
+		merge.WithHandlerAnnotationPrefixes([]string{"io.katacontainers."}),
+		merge.WithAnnotationLimits(&api.AnnotationLimits{
+			MaxKeyLength:   64,
+			MaxValueLength: 256,
+			MaxCount:       16,
+			Policy:         api.AnnotationLimitPolicy_ANNOTATION_LIMIT_TRUNCATE,
+		}),
+		merge.WithAdjustmentPolicy("10-restricted", &merge.AdjustmentPolicy{
+			Deny: []string{api.MountsAdjustment, api.HooksAdjustment},
+		}),
+		merge.WithUserPolicy(&merge.UserPolicy{RejectRoot: true}),
+		merge.WithProtectedApparmorProfiles([]string{"runtime/default"}),
+	}
+}
+
+// seedAdjustments returns adjustments for seeding the fuzzers, resembling
+// the ones used by the regular tests, plus some hostile ones.
+func seedAdjustments() []*api.ContainerAdjustment {
+	var seeds []*api.ContainerAdjustment
+
+	a := &api.ContainerAdjustment{}
+	a.AddAnnotation("key", "value")
+	a.RemoveAnnotation("existing")
+	a.AddHandlerAnnotation("io.katacontainers.config", "value")
+	a.AddMount(mount("/mnt", "/host/mnt"))
+	a.RemoveMount("/data")
+	a.AddEnv("BAR", "bar")
+	a.RemoveEnv("FOO")
+	a.AddEnvFile("/mnt/env", true)
+	a.AddHooks(&api.Hooks{Prestart: []*api.Hook{{Path: "/bin/true"}}})
+	a.AddRlimit("RLIMIT_NOFILE", 1024, 512)
+	a.AddDevice(device("/dev/foo"))
+	a.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/device=foo"})
+	a.AddCapability("CAP_NET_ADMIN")
+	a.DropCapability("CAP_SYS_ADMIN")
+	a.SetUser(1000)
+	a.SetGroup(1000)
+	a.AddAdditionalGid(10)
+	seeds = append(seeds, a)
+
+	a = &api.ContainerAdjustment{}
+	a.SetLinuxMemoryLimit(1 << 30)
+	a.SetLinuxMemorySwappiness(60)
+	a.SetLinuxCPUShares(1024)
+	a.SetLinuxCPUQuota(100000)
+	a.SetLinuxCPUPeriod(100000)
+	a.SetLinuxCPUSetCPUs("0-3")
+	a.SetLinuxCPUSetMems("0")
+	a.SetLinuxPidLimits(1024)
+	a.AddLinuxHugepageLimit("2MB", 1<<21)
+	a.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rwm")
+	a.SetLinuxBlockIOClass("slow")
+	a.SetLinuxRDTClass("gold")
+	a.AddLinuxUnified("memory.high", "max")
+	a.SetLinuxCgroupsPath("/nri/ctr0")
+	a.SetLinuxApparmorProfile("custom")
+	oomScoreAdj := 100
+	a.SetLinuxOomScoreAdj(&oomScoreAdj)
+	a.SetTopologyHintNUMANodes(0)
+	a.SetSchedulingHintNUMANode(0)
+	a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_FULL_CORES)
+	a.SetSchedulingHintExclusiveCores(true)
+	a.SetWindowsMemoryLimit(1 << 30)
+	a.SetWindowsCPUCount(2)
+	seeds = append(seeds, a)
+
+	// absurd values
+	a = &api.ContainerAdjustment{}
+	a.SetLinuxMemoryLimit(-1)
+	a.SetLinuxCPUQuota(-1 << 63)
+	a.SetLinuxCPUSetCPUs("0-9223372036854775807")
+	a.SetLinuxCPUSetMems("-1,,3-1")
+	a.AddLinuxHugepageLimit("-", 1<<63)
+	a.AddRlimit("", 1<<64-1, 1<<64-1)
+	a.AddLinuxDeviceCgroupRule(false, "", nil, nil, "")
+	a.SetUser(0)
+	a.AddAdditionalGid(0)
+	seeds = append(seeds, a)
+
+	// broken removal markers
+	a = &api.ContainerAdjustment{}
+	a.RemoveAnnotation("")
+	a.RemoveMount("")
+	a.RemoveEnv("-")
+	a.RemoveDevice("--")
+	a.AddMount(&api.Mount{Destination: "-", Remove: true})
+	a.AddEnv("-", "value")
+	a.AddEnvFile("-/../env", false)
+	a.RemoveLinuxDeviceCgroupRule("", nil, nil)
+	seeds = append(seeds, a)
+
+	// huge maps and lists
+	a = &api.ContainerAdjustment{}
+	for i := 0; i < 1024; i++ {
+		a.AddAnnotation(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+		a.AddEnv(fmt.Sprintf("ENV%d", i), "value")
+		a.AddLinuxUnified(fmt.Sprintf("memory.key%d", i), "value")
+	}
+	seeds = append(seeds, a)
+
+	return seeds
+}
+
+// seedUpdates returns container updates for seeding the fuzzers.
+func seedUpdates() []*api.ContainerUpdate {
+	return []*api.ContainerUpdate{
+		containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+			u.SetLinuxMemoryLimit(1 << 30)
+			u.SetLinuxCPUSetCPUs("0-1")
+			u.AddLinuxHugepageLimit("1GB", 1<<30)
+			u.AddAnnotation("key", "value")
+		}),
+		containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+			u.SetLinuxCPUShares(2)
+			u.SetIgnoreFailure()
+		}),
+		containerUpdate("", func(u *api.ContainerUpdate) {
+			u.SetSelectorPodUID("uid0")
+			u.AddSelectorLabel("app", "test")
+			u.RemoveAnnotation("")
+			u.SetLinuxCPUSetMems("3-1")
+		}),
+	}
+}
+
+func marshal(t testing.TB, m proto.Message) []byte {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to marshal fuzzer seed: %v", err)
+	}
+	return data
+}
+
+// checkResponse checks that a merged response is still well-formed.
+func checkResponse(t *testing.T, m proto.Message) {
+	if _, err := proto.Marshal(m); err != nil {
+		t.Fatalf("failed to marshal merged response: %v", err)
+	}
+}
+
+func FuzzCreateContainerResult(f *testing.F) {
+	var (
+		adjustments = seedAdjustments()
+		updates     = seedUpdates()
+	)
+
+	for i, a := range adjustments {
+		other := adjustments[(i+1)%len(adjustments)]
+		f.Add(
+			marshal(f, &api.CreateContainerResponse{Adjust: a, Update: updates}),
+			marshal(f, &api.CreateContainerResponse{Adjust: other}),
+		)
+	}
+
+	f.Fuzz(func(t *testing.T, data0, data1 []byte) {
+		var rpl0, rpl1 api.CreateContainerResponse
+		if err := proto.Unmarshal(data0, &rpl0); err != nil {
+			t.Skip()
+		}
+		if err := proto.Unmarshal(data1, &rpl1); err != nil {
+			t.Skip()
+		}
+
+		result := merge.NewCreateContainerResult(createRequest(nil), fuzzOptions()...)
+		if err := result.Apply(&rpl0, "00-plugin"); err != nil {
+			return
+		}
+
+		result.Preview(rpl1.Adjust, "10-restricted")
+
+		if err := result.Apply(&rpl1, "10-restricted"); err != nil {
+			return
+		}
+
+		checkResponse(t, result.CreateContainerResponse())
+	})
+}
+
+func FuzzUpdateContainerResult(f *testing.F) {
+	updates := seedUpdates()
+
+	for i := range updates {
+		f.Add(
+			marshal(f, &api.UpdateContainerResponse{Update: updates[i:]}),
+			marshal(f, &api.UpdateContainerResponse{Update: updates[:i]}),
+		)
+	}
+
+	f.Fuzz(func(t *testing.T, data0, data1 []byte) {
+		var rpl0, rpl1 api.UpdateContainerResponse
+		if err := proto.Unmarshal(data0, &rpl0); err != nil {
+			t.Skip()
+		}
+		if err := proto.Unmarshal(data1, &rpl1); err != nil {
+			t.Skip()
+		}
+
+		result := merge.NewUpdateContainerResult(updateRequest(), fuzzOptions()...)
+		if err := result.Apply(&rpl0, "00-plugin"); err != nil {
+			return
+		}
+		if err := result.Apply(&rpl1, "10-restricted"); err != nil {
+			return
+		}
+
+		checkResponse(t, result.UpdateContainerResponse())
+	})
+}
+
+func FuzzStopContainerResult(f *testing.F) {
+	updates := seedUpdates()
+
+	for i := range updates {
+		f.Add(
+			marshal(f, &api.StopContainerResponse{Update: updates[i:]}),
+			marshal(f, &api.StopContainerResponse{Update: updates[:i]}),
+		)
+	}
+
+	f.Fuzz(func(t *testing.T, data0, data1 []byte) {
+		var rpl0, rpl1 api.StopContainerResponse
+		if err := proto.Unmarshal(data0, &rpl0); err != nil {
+			t.Skip()
+		}
+		if err := proto.Unmarshal(data1, &rpl1); err != nil {
+			t.Skip()
+		}
+
+		result := merge.NewStopContainerResult(fuzzOptions()...)
+		if err := result.Apply(&rpl0, "00-plugin"); err != nil {
+			return
+		}
+		if err := result.Apply(&rpl1, "10-restricted"); err != nil {
+			return
+		}
+
+		checkResponse(t, result.StopContainerResponse())
+	})
+}
@@ -0,0 +1,3108 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// Result collects the container adjustments and updates requested by plugins
+// in response to a single request, tracking which plugin owns which parameter
+// and rejecting conflicting or invalid changes.
+type Result struct {
+	request resultRequest
+	reply   resultReply
+	updates map[string]*api.ContainerUpdate
+	owners  resultOwners
+	// plugins which requested updates, by container ID
+	updaters map[string][]string
+
+	// drop adjustments unsupported by the runtime handler
+	dropUnsupported bool
+	// key prefixes of allowed runtime handler annotations
+	handlerPrefixes []string
+	// limits on annotations injected by plugins
+	annotationLimits *api.AnnotationLimits
+	// topology to validate cpuset adjustments and updates against
+	topology *api.Topology
+	// supported hugepage sizes, in canonical form
+	hugepageSizes map[string]struct{}
+	// resolvers for conflicts within field classes
+	resolvers map[FieldClass]ConflictResolver
+	// adjustment policies of plugins, by full or base name
+	policies map[string]*AdjustmentPolicy
+	// policy for adjusting the user and groups of containers
+	userPolicy *UserPolicy
+	// AppArmor profiles plugins can't change
+	protectedProfiles []string
+	// whether this is a copy for previewing an adjustment
+	preview bool
+}
+
+type resultRequest struct {
+	create *api.CreateContainerRequest
+	update *api.UpdateContainerRequest
+}
+
+type resultReply struct {
+	adjust *api.ContainerAdjustment
+	update []*api.ContainerUpdate
+}
+
+type resultOwners map[string]*owners
+
+var (
+	// Used instead of nil Context in logging.
+	noCtx = context.TODO()
+)
+
+// Option to apply to a Result.
+type Option func(*Result)
+
+// WithDroppedUnsupportedAdjustments returns an option to drop adjustments
+// which don't take effect with the runtime handler of the pod, instead of
+// only warning about them.
+func WithDroppedUnsupportedAdjustments() Option {
+	return func(r *Result) {
+		r.dropUnsupported = true
+	}
+}
+
+// WithHandlerAnnotationPrefixes returns an option to set the key prefixes of
+// runtime handler annotations plugins are allowed to set.
+func WithHandlerAnnotationPrefixes(prefixes []string) Option {
+	return func(r *Result) {
+		r.handlerPrefixes = prefixes
+	}
+}
+
+// WithAnnotationLimits returns an option to set limits on the annotations
+// plugins inject into containers.
+func WithAnnotationLimits(limits *api.AnnotationLimits) Option {
+	return func(r *Result) {
+		r.annotationLimits = limits
+	}
+}
+
+// WithTopology returns an option to validate the cpuset CPUs and memory
+// nodes plugins set against the online CPUs and memory nodes of a host.
+func WithTopology(topology *api.Topology) Option {
+	return func(r *Result) {
+		r.topology = topology
+	}
+}
+
+// WithHugepageSizes returns an option to validate the page sizes of the
+// hugepage limits plugins set against the given supported sizes.
+func WithHugepageSizes(sizes []string) Option {
+	return func(r *Result) {
+		r.hugepageSizes = map[string]struct{}{}
+		for _, size := range sizes {
+			r.hugepageSizes[normalHugepageSize(size)] = struct{}{}
+		}
+	}
+}
+
+// WithProtectedApparmorProfiles returns an option to reject adjustments
+// which change the AppArmor profile of containers with any of the given
+// profiles, typically the runtime default ones. Only the profile of
+// containers in the request is checked, so containers without a profile
+// are not protected.
+func WithProtectedApparmorProfiles(profiles []string) Option {
+	return func(r *Result) {
+		r.protectedProfiles = profiles
+	}
+}
+
+// NewCreateContainerResult returns a Result for collecting the adjustments
+// and updates of plugins in response to a CreateContainer request. The
+// container in the request is updated as adjustments are collected, so that
+// each plugin sees the changes made by the preceding ones.
+func NewCreateContainerResult(request *api.CreateContainerRequest, options ...Option) *Result {
+	initCreateRequest(request)
+
+	r := &Result{
+		request: resultRequest{
+			create: request,
+		},
+		reply: resultReply{
+			adjust: initAdjustment(&api.ContainerAdjustment{}),
+		},
+		updates: map[string]*api.ContainerUpdate{},
+		owners:  resultOwners{},
+	}
+	for _, o := range options {
+		o(r)
+	}
+
+	return r
+}
+
+// initCreateRequest initializes the container parameters of a request which
+// are adjusted in place, if they are unset.
+func initCreateRequest(request *api.CreateContainerRequest) {
+	if request.Container.Labels == nil {
+		request.Container.Labels = map[string]string{}
+	}
+	if request.Container.Annotations == nil {
+		request.Container.Annotations = map[string]string{}
+	}
+	if request.Container.Mounts == nil {
+		request.Container.Mounts = []*api.Mount{}
+	}
+	if request.Container.Env == nil {
+		request.Container.Env = []string{}
+	}
+	if request.Container.Hooks == nil {
+		request.Container.Hooks = &api.Hooks{}
+	}
+	if request.Container.Rlimits == nil {
+		request.Container.Rlimits = []*api.POSIXRlimit{}
+	}
+	if request.Container.Linux == nil {
+		request.Container.Linux = &api.LinuxContainer{}
+	}
+	if request.Container.Linux.Devices == nil {
+		request.Container.Linux.Devices = []*api.LinuxDevice{}
+	}
+	if request.Container.Linux.Resources == nil {
+		request.Container.Linux.Resources = &api.LinuxResources{}
+	}
+	if request.Container.Linux.Resources.Memory == nil {
+		request.Container.Linux.Resources.Memory = &api.LinuxMemory{}
+	}
+	if request.Container.Linux.Resources.Cpu == nil {
+		request.Container.Linux.Resources.Cpu = &api.LinuxCPU{}
+	}
+	if request.Container.Linux.Resources.Unified == nil {
+		request.Container.Linux.Resources.Unified = map[string]string{}
+	}
+}
+
+// initAdjustment initializes the parameters of collected adjustments, if
+// they are unset.
+func initAdjustment(adjust *api.ContainerAdjustment) *api.ContainerAdjustment {
+	if adjust.Annotations == nil {
+		adjust.Annotations = map[string]string{}
+	}
+	if adjust.Mounts == nil {
+		adjust.Mounts = []*api.Mount{}
+	}
+	if adjust.Env == nil {
+		adjust.Env = []*api.KeyValue{}
+	}
+	if adjust.Hooks == nil {
+		adjust.Hooks = &api.Hooks{}
+	}
+	if adjust.Rlimits == nil {
+		adjust.Rlimits = []*api.POSIXRlimit{}
+	}
+	if adjust.CDIDevices == nil {
+		adjust.CDIDevices = []*api.CDIDevice{}
+	}
+	if adjust.EnvFiles == nil {
+		adjust.EnvFiles = []*api.EnvFile{}
+	}
+	if adjust.TopologyHints == nil {
+		adjust.TopologyHints = &api.TopologyHints{}
+	}
+	if adjust.TopologyHints.NumaNodes == nil {
+		adjust.TopologyHints.NumaNodes = []uint32{}
+	}
+	if adjust.TopologyHints.Devices == nil {
+		adjust.TopologyHints.Devices = []*api.DeviceTopologyHint{}
+	}
+	if adjust.HandlerAnnotations == nil {
+		adjust.HandlerAnnotations = map[string]string{}
+	}
+	if adjust.Linux == nil {
+		adjust.Linux = &api.LinuxContainerAdjustment{}
+	}
+	if adjust.Linux.Devices == nil {
+		adjust.Linux.Devices = []*api.LinuxDevice{}
+	}
+	if adjust.Linux.Resources == nil {
+		adjust.Linux.Resources = &api.LinuxResources{}
+	}
+	if adjust.Linux.Resources.Memory == nil {
+		adjust.Linux.Resources.Memory = &api.LinuxMemory{}
+	}
+	if adjust.Linux.Resources.Cpu == nil {
+		adjust.Linux.Resources.Cpu = &api.LinuxCPU{}
+	}
+	if adjust.Linux.Resources.HugepageLimits == nil {
+		adjust.Linux.Resources.HugepageLimits = []*api.HugepageLimit{}
+	}
+	if adjust.Linux.Resources.Unified == nil {
+		adjust.Linux.Resources.Unified = map[string]string{}
+	}
+	return adjust
+}
+
+// NewUpdateContainerResult returns a Result for collecting the updates of
+// plugins in response to an UpdateContainer request. The resources in the
+// request are updated as updates of the container are collected.
+func NewUpdateContainerResult(request *api.UpdateContainerRequest, options ...Option) *Result {
+	if request != nil {
+		if request.LinuxResources == nil {
+			request.LinuxResources = &api.LinuxResources{}
+		}
+		if request.LinuxResources.Memory == nil {
+			request.LinuxResources.Memory = &api.LinuxMemory{}
+		}
+		if request.LinuxResources.Cpu == nil {
+			request.LinuxResources.Cpu = &api.LinuxCPU{}
+		}
+	}
+
+	r := &Result{
+		request: resultRequest{
+			update: request,
+		},
+		reply: resultReply{
+			update: []*api.ContainerUpdate{},
+		},
+		updates: map[string]*api.ContainerUpdate{},
+		owners:  resultOwners{},
+	}
+	for _, o := range options {
+		o(r)
+	}
+
+	return r
+}
+
+// NewStopContainerResult returns a Result for collecting the updates of
+// plugins in response to a StopContainer request.
+func NewStopContainerResult(options ...Option) *Result {
+	return NewUpdateContainerResult(nil, options...)
+}
+
+// CreateContainerResponse returns the collected adjustments and updates.
+func (r *Result) CreateContainerResponse() *api.CreateContainerResponse {
+	return &api.CreateContainerResponse{
+		Adjust: r.reply.adjust,
+		Update: r.reply.update,
+	}
+}
+
+// UpdateContainerResponse returns the collected updates, including the
+// update of the container in the request.
+func (r *Result) UpdateContainerResponse() *api.UpdateContainerResponse {
+	requested := r.updates[r.request.update.Container.Id]
+	return &api.UpdateContainerResponse{
+		Update: append(r.reply.update, requested),
+	}
+}
+
+// StopContainerResponse returns the collected updates.
+func (r *Result) StopContainerResponse() *api.StopContainerResponse {
+	return &api.StopContainerResponse{
+		Update: r.reply.update,
+	}
+}
+
+// UpdatePlugins returns the plugins which requested updates of the given
+// container, in the order their updates were collected.
+func (r *Result) UpdatePlugins(id string) []string {
+	return slices.Clone(r.updaters[id])
+}
+
+// Adjustment returns a copy of the adjustment collected so far.
+func (r *Result) Adjustment() *api.ContainerAdjustment {
+	// copy without reflection, which would alter the internal state of
+	// the collected adjustment returned in CreateContainerResponse
+	data, err := r.reply.adjust.MarshalVT()
+	if err != nil {
+		log.Warnf(noCtx, "failed to copy collected adjustment: %v", err)
+		return nil
+	}
+	adjust := &api.ContainerAdjustment{}
+	if err := adjust.UnmarshalVT(data); err != nil {
+		log.Warnf(noCtx, "failed to copy collected adjustment: %v", err)
+		return nil
+	}
+	return adjust
+}
+
+// Owners returns the plugins owning the parameters of the given container
+// which were adjusted or updated so far, by parameter. Parameters are named
+// by their path in the adjustment, as in conflicts.
+func (r *Result) Owners(id string) map[string]string {
+	o, ok := r.owners[id]
+	if !ok {
+		return nil
+	}
+	return o.fields()
+}
+
+// Apply collects the adjustments and updates in the response of the given
+// plugin. The response is a CreateContainerResponse, UpdateContainerResponse
+// or StopContainerResponse, matching the type of the Result. Apply returns a
+// RejectedError if the changes conflict with the ones collected from other
+// plugins or are otherwise invalid.
+func (r *Result) Apply(response interface{}, plugin string) error {
+	switch rpl := response.(type) {
+	case *api.CreateContainerResponse:
+		if rpl == nil {
+			return nil
+		}
+		if err := r.adjust(rpl.Adjust, plugin); err != nil {
+			return err
+		}
+		if err := r.update(rpl.Update, plugin); err != nil {
+			return err
+		}
+	case *api.UpdateContainerResponse:
+		if rpl == nil {
+			return nil
+		}
+		if err := r.update(rpl.Update, plugin); err != nil {
+			return err
+		}
+	case *api.StopContainerResponse:
+		if rpl == nil {
+			return nil
+		}
+		if err := r.update(rpl.Update, plugin); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot apply response of invalid type %T", response)
+	}
+
+	return nil
+}
+
+func (r *Result) adjust(rpl *api.ContainerAdjustment, plugin string) error {
+	if rpl == nil {
+		return nil
+	}
+	r.checkHandlerSupport(rpl, plugin)
+	if err := r.checkAdjustmentPolicy(rpl, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustAnnotations(rpl.Annotations, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustMounts(rpl.Mounts, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustEnv(rpl.Env, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustEnvFiles(rpl.EnvFiles, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustHooks(rpl.Hooks); err != nil {
+		return err
+	}
+	if rpl.Linux != nil {
+		if err := r.adjustDevices(rpl.Linux.Devices, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustResources(rpl.Linux.Resources, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustCgroupsPath(rpl.Linux.CgroupsPath, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustOomScoreAdj(rpl.Linux.OomScoreAdj, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustCapabilities(rpl.Linux.Capabilities, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustApparmorProfile(rpl.Linux.ApparmorProfile, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustSeccompPolicy(rpl.Linux.SeccompPolicy, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustCPUAffinity(rpl.Linux.CpuAffinity, plugin); err != nil {
+			return err
+		}
+		if err := r.adjustNetworkBandwidth(rpl.Linux.NetworkBandwidth, plugin); err != nil {
+			return err
+		}
+	}
+	if err := r.adjustUser(rpl.User, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustRlimits(rpl.Rlimits, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustCDIDevices(rpl.CDIDevices, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustTopologyHints(rpl.TopologyHints, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustSchedulingHints(rpl.SchedulingHints, plugin); err != nil {
+		return err
+	}
+	if err := r.adjustHandlerAnnotations(rpl.HandlerAnnotations, plugin); err != nil {
+		return err
+	}
+	if rpl.Windows != nil {
+		if err := r.adjustWindowsResources(rpl.Windows.Resources, plugin); err != nil {
+			return err
+		}
+	}
+
+	preserveUnknownFields(r.reply.adjust, rpl)
+
+	return nil
+}
+
+// checkHandlerSupport warns about, and optionally drops, adjustments which
+// don't take effect with the runtime handler of the pod.
+func (r *Result) checkHandlerSupport(rpl *api.ContainerAdjustment, plugin string) {
+	pod := r.request.create.Pod
+	caps := pod.GetRuntimeHandlerCapabilities()
+
+	for _, adjustment := range caps.GetUnsupportedAdjustments() {
+		if !rpl.HasAdjustment(adjustment) {
+			continue
+		}
+		if r.preview {
+			if r.dropUnsupported {
+				rpl.ClearAdjustment(adjustment)
+			}
+			continue
+		}
+		if r.dropUnsupported {
+			log.Warnf(noCtx, "dropping %s adjustment of plugin %q, unsupported by runtime handler %q of pod %s",
+				adjustment, plugin, pod.GetRuntimeHandler(), pod.GetName())
+			rpl.ClearAdjustment(adjustment)
+		} else {
+			log.Warnf(noCtx, "%s adjustment of plugin %q has no effect with runtime handler %q of pod %s",
+				adjustment, plugin, pod.GetRuntimeHandler(), pod.GetName())
+		}
+	}
+}
+
+func (r *Result) update(updates []*api.ContainerUpdate, plugin string) error {
+	for _, u := range updates {
+		reply, err := r.getContainerUpdate(u, plugin)
+		if err != nil {
+			return err
+		}
+		r.addUpdater(u.ContainerId, plugin)
+		if err := r.updateAnnotations(reply, u, plugin); err != nil && !u.IgnoreFailure {
+			return err
+		}
+		if err := r.updateLinux(reply, u, plugin); err != nil && !u.IgnoreFailure {
+			return err
+		}
+		preserveUnknownFields(reply, u)
+	}
+
+	return nil
+}
+
+// addUpdater records a plugin as having requested an update of a container.
+func (r *Result) addUpdater(id, plugin string) {
+	if r.updaters == nil {
+		r.updaters = map[string][]string{}
+	}
+	if slices.Contains(r.updaters[id], plugin) {
+		return
+	}
+	r.updaters[id] = append(r.updaters[id], plugin)
+}
+
+// preserveUnknownFields carries over fields unknown to our payload schema
+// from a plugin response, so that we don't drop data from newer peers.
+func preserveUnknownFields(dst, src proto.Message) {
+	if u := src.ProtoReflect().GetUnknown(); len(u) > 0 {
+		m := dst.ProtoReflect()
+		m.SetUnknown(append(m.GetUnknown(), u...))
+	}
+}
+
+func (r *Result) adjustAnnotations(annotations map[string]string, plugin string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	del := map[string]struct{}{}
+	for k := range annotations {
+		if key, marked := api.IsMarkedForRemoval(k); marked {
+			del[key] = struct{}{}
+			delete(annotations, k)
+		}
+	}
+
+	for k, v := range annotations {
+		v, err := r.checkAnnotation(k, v, plugin)
+		if err != nil {
+			return err
+		}
+		if _, ok := del[k]; ok {
+			r.owners.clearAnnotation(id, k)
+			delete(create.Container.Annotations, k)
+			r.reply.adjust.Annotations[api.MarkForRemoval(k)] = ""
+		}
+		apply, err := r.resolve(r.owners.claimAnnotation(id, k, plugin), AnnotationsClass, id,
+			r.reply.adjust.Annotations[k], v)
+		if err != nil {
+			return err
+		}
+		if !apply {
+			continue
+		}
+		create.Container.Annotations[k] = v
+		r.reply.adjust.Annotations[k] = v
+		delete(del, k)
+	}
+
+	for k := range del {
+		r.reply.adjust.Annotations[api.MarkForRemoval(k)] = ""
+	}
+
+	return r.checkAnnotationCount(plugin)
+}
+
+func (r *Result) checkAnnotation(key, value, plugin string) (string, error) {
+	limits := r.annotationLimits
+	if limits == nil {
+		return value, nil
+	}
+
+	if err := limits.CheckKey(key); err != nil {
+		return "", rejected(plugin, AnnotationLimitRule, "annotation "+key, "%v", err)
+	}
+
+	checked, err := limits.CheckValue(key, value)
+	if err != nil {
+		return "", rejected(plugin, AnnotationLimitRule, "annotation "+key, "%v", err)
+	}
+	if len(checked) != len(value) {
+		log.Warnf(noCtx, "truncated value of annotation %q of plugin %q to %d bytes",
+			key, plugin, len(checked))
+	}
+
+	return checked, nil
+}
+
+func (r *Result) checkAnnotationCount(plugin string) error {
+	if r.annotationLimits.GetMaxCount() == 0 {
+		return nil
+	}
+
+	count := 0
+	for k := range r.reply.adjust.Annotations {
+		if _, marked := api.IsMarkedForRemoval(k); !marked {
+			count++
+		}
+	}
+
+	if err := r.annotationLimits.CheckCount(count); err != nil {
+		return rejected(plugin, AnnotationLimitRule, "annotations", "%v", err)
+	}
+
+	return nil
+}
+
+func (r *Result) adjustHandlerAnnotations(annotations map[string]string, plugin string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	id := r.request.create.Container.Id
+	del := map[string]struct{}{}
+	for k := range annotations {
+		if key, marked := api.IsMarkedForRemoval(k); marked {
+			del[key] = struct{}{}
+			delete(annotations, k)
+		}
+	}
+
+	for k, v := range annotations {
+		if err := r.checkHandlerAnnotation(k, plugin); err != nil {
+			return err
+		}
+		if _, ok := del[k]; ok {
+			r.owners.clearHandlerAnnotation(id, k)
+			r.reply.adjust.HandlerAnnotations[api.MarkForRemoval(k)] = ""
+		}
+		if err := r.owners.claimHandlerAnnotation(id, k, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.HandlerAnnotationsAdjustment), id,
+				r.reply.adjust.HandlerAnnotations[k], v)
+		}
+		r.reply.adjust.HandlerAnnotations[k] = v
+		delete(del, k)
+	}
+
+	for k := range del {
+		if err := r.checkHandlerAnnotation(k, plugin); err != nil {
+			return err
+		}
+		r.reply.adjust.HandlerAnnotations[api.MarkForRemoval(k)] = ""
+	}
+
+	return nil
+}
+
+// checkHandlerAnnotation checks if a handler annotation is allowed.
+func (r *Result) checkHandlerAnnotation(key, plugin string) error {
+	for _, prefix := range r.handlerPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return nil
+		}
+	}
+	return rejected(plugin, InvalidAdjustmentRule, "handler annotation "+key,
+		"handler annotation %q not allowed by runtime", key)
+}
+
+func (r *Result) adjustMounts(mounts []*api.Mount, plugin string) error {
+	if len(mounts) == 0 {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	// first split removals from the rest of adjustments
+	add := []*api.Mount{}
+	del := map[string]*api.Mount{}
+	mod := map[string]*api.Mount{}
+	for _, m := range mounts {
+		if key, marked := m.IsMarkedForRemoval(); marked {
+			del[key] = m
+		} else {
+			if err := m.Validate(); err != nil {
+				return rejected(plugin, InvalidAdjustmentRule, "mount "+key, "%v", err)
+			}
+			add = append(add, m)
+			mod[key] = m
+		}
+	}
+
+	// next remove marked mounts from collected adjustments
+	cleared := []*api.Mount{}
+	for _, m := range r.reply.adjust.Mounts {
+		if _, removed := del[m.Destination]; removed {
+			r.owners.clearMount(id, m.Destination)
+			continue
+		}
+		cleared = append(cleared, m)
+	}
+	r.reply.adjust.Mounts = cleared
+
+	// next, claim additions/modifications, resolving any conflicts
+	claimed := []*api.Mount{}
+	for _, m := range add {
+		apply, err := r.resolve(r.owners.claimMount(id, m.Destination, plugin), MountsClass, id,
+			r.collectedMount(m.Destination), m)
+		if err != nil {
+			return err
+		}
+		if !apply {
+			delete(mod, m.Destination)
+			continue
+		}
+		r.dropCollectedMount(m.Destination)
+		claimed = append(claimed, m)
+	}
+	add = claimed
+
+	// next remove marked and modified mounts from container creation request
+	cleared = []*api.Mount{}
+	for _, m := range create.Container.Mounts {
+		if _, removed := del[m.Destination]; removed {
+			continue
+		}
+		if _, modified := mod[m.Destination]; modified {
+			continue
+		}
+		cleared = append(cleared, m)
+	}
+	create.Container.Mounts = cleared
+
+	// next, apply additions/modifications to collected adjustments
+	r.reply.adjust.Mounts = append(r.reply.adjust.Mounts, add...)
+
+	// next, apply deletions with no corresponding additions
+	for key, m := range del {
+		if _, ok := mod[key]; !ok {
+			r.reply.adjust.Mounts = append(r.reply.adjust.Mounts, m)
+		}
+	}
+
+	// finally, apply additions/modifications to plugin container creation request
+	create.Container.Mounts = append(create.Container.Mounts, add...)
+
+	return nil
+}
+
+// collectedMount returns the collected mount with the given destination.
+func (r *Result) collectedMount(destination string) *api.Mount {
+	for _, m := range r.reply.adjust.Mounts {
+		if m.Destination == destination {
+			return m
+		}
+	}
+	return nil
+}
+
+// dropCollectedMount drops the collected mount with the given destination.
+func (r *Result) dropCollectedMount(destination string) {
+	kept := []*api.Mount{}
+	for _, m := range r.reply.adjust.Mounts {
+		if m.Destination != destination {
+			kept = append(kept, m)
+		}
+	}
+	r.reply.adjust.Mounts = kept
+}
+
+func (r *Result) adjustDevices(devices []*api.LinuxDevice, plugin string) error {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	// first split removals from the rest of adjustments
+	add := []*api.LinuxDevice{}
+	del := map[string]*api.LinuxDevice{}
+	mod := map[string]*api.LinuxDevice{}
+	for _, d := range devices {
+		if key, marked := d.IsMarkedForRemoval(); marked {
+			del[key] = d
+		} else {
+			add = append(add, d)
+			mod[key] = d
+		}
+	}
+
+	// next remove marked devices from collected adjustments
+	cleared := []*api.LinuxDevice{}
+	for _, d := range r.reply.adjust.Linux.Devices {
+		if _, removed := del[d.Path]; removed {
+			r.owners.clearDevice(id, d.Path)
+			continue
+		}
+		cleared = append(cleared, d)
+	}
+	r.reply.adjust.Linux.Devices = cleared
+
+	// next remove marked and modified devices from container creation request
+	cleared = []*api.LinuxDevice{}
+	for _, d := range create.Container.Linux.Devices {
+		if _, removed := del[d.Path]; removed {
+			continue
+		}
+		if _, modified := mod[d.Path]; modified {
+			continue
+		}
+		cleared = append(cleared, d)
+	}
+	create.Container.Linux.Devices = cleared
+
+	// next, apply additions/modifications to collected adjustments
+	for _, d := range add {
+		if err := r.owners.claimDevice(id, d.Path, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.DevicesAdjustment), id,
+				find(create.Container.Linux.Devices, (*api.LinuxDevice).GetPath, d.Path), d)
+		}
+		r.reply.adjust.Linux.Devices = append(r.reply.adjust.Linux.Devices, d)
+	}
+
+	// finally, apply additions/modifications to plugin container creation request
+	create.Container.Linux.Devices = append(create.Container.Linux.Devices, add...)
+
+	return nil
+}
+
+func (r *Result) adjustCDIDevices(devices []*api.CDIDevice, plugin string) error {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	// Notes:
+	//   CDI devices are opaque references, typically to vendor specific
+	//   devices. They get resolved to actual devices and potential related
+	//   mounts, environment variables, etc. in the runtime. Unlike with
+	//   devices, we only allow CDI device references to be added to a
+	//   container, not removed. We pass them unresolved to the runtime and
+	//   have them resolved there. Also unlike with devices, we don't include
+	//   CDI device references in creation requests. However, since there
+	//   is typically a strong ownership and a single related management entity
+	//   per vendor/class for these devices we do treat multiple injection of
+	//   the same CDI device reference as an error here.
+
+	id := r.request.create.Container.Id
+
+	// apply additions to collected adjustments
+	for _, d := range devices {
+		if err := r.owners.claimCDIDevice(id, d.Name, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.CDIDevicesAdjustment), id,
+				find(r.reply.adjust.CDIDevices, (*api.CDIDevice).GetName, d.Name), d)
+		}
+		r.reply.adjust.CDIDevices = append(r.reply.adjust.CDIDevices, d)
+	}
+
+	return nil
+}
+
+func (r *Result) adjustTopologyHints(hints *api.TopologyHints, plugin string) error {
+	if hints == nil {
+		return nil
+	}
+
+	// Notes:
+	//   Topology hints are advisory. They are not applied to the container
+	//   by NRI itself but passed on to the runtime, which may use them for
+	//   placement decisions. We only allow a single plugin to set the
+	//   preferred NUMA nodes and the hint for any single device.
+
+	id := r.request.create.Container.Id
+
+	if len(hints.NumaNodes) > 0 {
+		if err := r.owners.claimTopologyNumaNodes(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.TopologyHintsAdjustment), id,
+				r.reply.adjust.TopologyHints.NumaNodes, hints.NumaNodes)
+		}
+		r.reply.adjust.TopologyHints.NumaNodes = hints.NumaNodes
+		if err := r.checkSchedulingHints(id, plugin); err != nil {
+			return err
+		}
+	}
+
+	for _, h := range hints.Devices {
+		if h.Device == "" {
+			return rejected(plugin, InvalidAdjustmentRule, "topology hint",
+				"invalid topology hint with empty device")
+		}
+		if err := r.owners.claimDeviceTopologyHint(id, h.Device, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.TopologyHintsAdjustment), id,
+				find(r.reply.adjust.TopologyHints.Devices, (*api.DeviceTopologyHint).GetDevice, h.Device), h)
+		}
+		r.reply.adjust.TopologyHints.Devices = append(r.reply.adjust.TopologyHints.Devices, h)
+	}
+
+	return nil
+}
+
+func (r *Result) adjustWindowsResources(resources *api.WindowsResources, plugin string) error {
+	if resources == nil {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	container := create.Container.GetWindows().GetResources()
+	if container == nil {
+		container = &api.WindowsResources{}
+	}
+	reply := r.reply.adjust.GetWindows().GetResources()
+	if reply == nil {
+		reply = &api.WindowsResources{}
+	}
+
+	if mem := resources.Memory; mem != nil {
+		if container.Memory == nil {
+			container.Memory = &api.WindowsMemory{}
+		}
+		if reply.Memory == nil {
+			reply.Memory = &api.WindowsMemory{}
+		}
+		if v := mem.GetLimit(); v != nil {
+			if err := r.owners.claimWindowsMemLimit(id, plugin); err != nil {
+				return conflictDetails(err, FieldClass(api.WindowsResourcesAdjustment), id,
+					reply.Memory.GetLimit().GetValue(), v.GetValue())
+			}
+			container.Memory.Limit = v
+			reply.Memory.Limit = v
+		}
+	}
+
+	if cpu := resources.Cpu; cpu != nil {
+		if container.Cpu == nil {
+			container.Cpu = &api.WindowsCPU{}
+		}
+		if reply.Cpu == nil {
+			reply.Cpu = &api.WindowsCPU{}
+		}
+		if v := cpu.GetCount(); v != nil {
+			if err := r.owners.claimWindowsCpuCount(id, plugin); err != nil {
+				return conflictDetails(err, FieldClass(api.WindowsResourcesAdjustment), id,
+					reply.Cpu.GetCount().GetValue(), v.GetValue())
+			}
+			container.Cpu.Count = v
+			reply.Cpu.Count = v
+		}
+		if v := cpu.GetShares(); v != nil {
+			if err := r.owners.claimWindowsCpuShares(id, plugin); err != nil {
+				return conflictDetails(err, FieldClass(api.WindowsResourcesAdjustment), id,
+					reply.Cpu.GetShares().GetValue(), v.GetValue())
+			}
+			container.Cpu.Shares = v
+			reply.Cpu.Shares = v
+		}
+		if v := cpu.GetMaximum(); v != nil {
+			if err := r.owners.claimWindowsCpuMaximum(id, plugin); err != nil {
+				return conflictDetails(err, FieldClass(api.WindowsResourcesAdjustment), id,
+					reply.Cpu.GetMaximum().GetValue(), v.GetValue())
+			}
+			container.Cpu.Maximum = v
+			reply.Cpu.Maximum = v
+		}
+	}
+
+	if create.Container.Windows == nil {
+		create.Container.Windows = &api.WindowsContainer{}
+	}
+	create.Container.Windows.Resources = container
+	if r.reply.adjust.Windows == nil {
+		r.reply.adjust.Windows = &api.WindowsContainerAdjustment{}
+	}
+	r.reply.adjust.Windows.Resources = reply
+
+	return nil
+}
+
+func (r *Result) adjustEnv(env []*api.KeyValue, plugin string) error {
+	if len(env) == 0 {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	// first split removals from the rest of adjustments
+	add := []*api.KeyValue{}
+	del := map[string]struct{}{}
+	mod := map[string]struct{}{}
+	for _, e := range env {
+		if key, marked := e.IsMarkedForRemoval(); marked {
+			del[key] = struct{}{}
+		} else {
+			add = append(add, e)
+			mod[key] = struct{}{}
+		}
+	}
+
+	// next remove marked environment variables from collected adjustments
+	cleared := []*api.KeyValue{}
+	for _, e := range r.reply.adjust.Env {
+		if _, removed := del[e.Key]; removed {
+			r.owners.clearEnv(id, e.Key)
+			continue
+		}
+		cleared = append(cleared, e)
+	}
+	r.reply.adjust.Env = cleared
+
+	// next remove marked and modified environment from container creation request
+	clearedEnv := []string{}
+	for _, e := range create.Container.Env {
+		key, _ := splitEnvVar(e)
+		if _, removed := del[key]; removed {
+			continue
+		}
+		if _, modified := mod[key]; modified {
+			continue
+		}
+		clearedEnv = append(clearedEnv, e)
+	}
+	create.Container.Env = clearedEnv
+
+	// next, apply additions/modifications to collected adjustments
+	for _, e := range add {
+		if err := r.owners.claimEnv(id, e.Key, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.EnvAdjustment), id,
+				find(r.reply.adjust.Env, (*api.KeyValue).GetKey, e.Key), e)
+		}
+		r.reply.adjust.Env = append(r.reply.adjust.Env, e)
+	}
+
+	// finally, apply additions/modifications to plugin container creation request
+	for _, e := range add {
+		create.Container.Env = append(create.Container.Env, e.ToOCI())
+	}
+
+	return nil
+}
+
+func (r *Result) adjustEnvFiles(files []*api.EnvFile, plugin string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	// Notes:
+	//   Environment files are sourced by the runtime when it starts the
+	//   container, so their contents never pass through NRI. We only allow
+	//   files to be added, within a mount of the container, typically one
+	//   injected by a plugin. Mounts adjusted by the plugin itself or by
+	//   earlier plugins count. We treat adding the same file by multiple
+	//   plugins as a conflict.
+
+	id := r.request.create.Container.Id
+
+	for _, f := range files {
+		if err := r.checkEnvFile(f, plugin); err != nil {
+			return err
+		}
+		if err := r.owners.claimEnvFile(id, f.Path, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.EnvFilesAdjustment), id,
+				find(r.reply.adjust.EnvFiles, (*api.EnvFile).GetPath, f.Path), f)
+		}
+		r.reply.adjust.EnvFiles = append(r.reply.adjust.EnvFiles, f)
+	}
+
+	return nil
+}
+
+// checkEnvFile checks that an environment file is within a container mount.
+func (r *Result) checkEnvFile(f *api.EnvFile, plugin string) error {
+	if err := f.Validate(); err != nil {
+		return rejected(plugin, InvalidAdjustmentRule, "env file "+f.Path, "%v", err)
+	}
+	for _, m := range r.request.create.Container.Mounts {
+		if f.IsWithin(m.Destination) {
+			return nil
+		}
+	}
+	return rejected(plugin, InvalidAdjustmentRule, "env file "+f.Path,
+		"environment file %q is not within any mount of the container", f.Path)
+}
+
+func (r *Result) adjustUser(user *api.User, plugin string) error {
+	if user == nil {
+		return nil
+	}
+
+	if err := r.checkUser(user, plugin); err != nil {
+		return err
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	if create.Container.User == nil {
+		create.Container.User = &api.User{}
+	}
+	if r.reply.adjust.User == nil {
+		r.reply.adjust.User = &api.User{}
+	}
+	current, adjusted := create.Container.User, r.reply.adjust.User
+
+	if uid := user.GetUid(); uid != nil {
+		if err := r.owners.claimUid(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.UserAdjustment), id,
+				adjusted.GetUid().GetValue(), uid.GetValue())
+		}
+		current.Uid = uid
+		adjusted.Uid = uid
+	}
+	if gid := user.GetGid(); gid != nil {
+		if err := r.owners.claimGid(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.UserAdjustment), id,
+				adjusted.GetGid().GetValue(), gid.GetValue())
+		}
+		current.Gid = gid
+		adjusted.Gid = gid
+	}
+
+	// additional groups are only ever added, so plugins can't conflict
+	for _, gid := range user.GetAdditionalGids() {
+		if !slices.Contains(current.AdditionalGids, gid) {
+			current.AdditionalGids = append(current.AdditionalGids, gid)
+		}
+		if !slices.Contains(adjusted.AdditionalGids, gid) {
+			adjusted.AdditionalGids = append(adjusted.AdditionalGids, gid)
+		}
+	}
+
+	return nil
+}
+
+// find returns the first item with the given key, or nil if there is none.
+func find[T any](items []T, key func(T) string, k string) interface{} {
+	for _, item := range items {
+		if key(item) == k {
+			return item
+		}
+	}
+	return nil
+}
+
+func splitEnvVar(s string) (string, string) {
+	split := strings.SplitN(s, "=", 2)
+	if len(split) < 1 {
+		return "", ""
+	}
+	if len(split) != 2 {
+		return split[0], ""
+	}
+	return split[0], split[1]
+}
+
+func (r *Result) adjustHooks(hooks *api.Hooks) error {
+	if hooks == nil {
+		return nil
+	}
+
+	reply := r.reply.adjust
+	container := r.request.create.Container
+
+	if h := hooks.Prestart; len(h) > 0 {
+		reply.Hooks.Prestart = append(reply.Hooks.Prestart, h...)
+		container.Hooks.Prestart = append(container.Hooks.Prestart, h...)
+	}
+	if h := hooks.Poststart; len(h) > 0 {
+		reply.Hooks.Poststart = append(reply.Hooks.Poststart, h...)
+		container.Hooks.Poststart = append(container.Hooks.Poststart, h...)
+	}
+	if h := hooks.Poststop; len(h) > 0 {
+		reply.Hooks.Poststop = append(reply.Hooks.Poststop, h...)
+		container.Hooks.Poststop = append(container.Hooks.Poststop, h...)
+	}
+	if h := hooks.CreateRuntime; len(h) > 0 {
+		reply.Hooks.CreateRuntime = append(reply.Hooks.CreateRuntime, h...)
+		container.Hooks.CreateRuntime = append(container.Hooks.CreateRuntime, h...)
+	}
+	if h := hooks.CreateContainer; len(h) > 0 {
+		reply.Hooks.CreateContainer = append(reply.Hooks.CreateContainer, h...)
+		container.Hooks.CreateContainer = append(container.Hooks.CreateContainer, h...)
+	}
+	if h := hooks.StartContainer; len(h) > 0 {
+		reply.Hooks.StartContainer = append(reply.Hooks.StartContainer, h...)
+		container.Hooks.StartContainer = append(container.Hooks.StartContainer, h...)
+	}
+
+	return nil
+}
+
+// checkCpuset checks the cpuset CPUs and memory nodes set by a plugin
+// against the topology of the host, if we have one.
+func (r *Result) checkCpuset(cpu *api.LinuxCPU, plugin, rule string) error {
+	if r.topology == nil || cpu == nil {
+		return nil
+	}
+	if v := cpu.GetCpus(); v != "" {
+		if err := r.topology.CheckCPUs(v); err != nil {
+			return rejected(plugin, rule, "cpuset CPUs", "invalid cpuset CPUs %q: %v", v, err)
+		}
+	}
+	if v := cpu.GetMems(); v != "" {
+		if err := r.topology.CheckMems(v); err != nil {
+			return rejected(plugin, rule, "cpuset memory nodes", "invalid cpuset memory nodes %q: %v", v, err)
+		}
+	}
+	return nil
+}
+
+func (r *Result) adjustResources(resources *api.LinuxResources, plugin string) error {
+	if resources == nil {
+		return nil
+	}
+
+	if err := r.checkCpuset(resources.Cpu, plugin, InvalidAdjustmentRule); err != nil {
+		return err
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	container := create.Container.Linux.Resources
+	reply := r.reply.adjust.Linux.Resources
+
+	if mem := resources.Memory; mem != nil {
+		if v := mem.GetLimit(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemLimit(id, plugin), ResourcesClass, id,
+				reply.Memory.Limit.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.Limit = api.Int64(value)
+				reply.Memory.Limit = api.Int64(value)
+			}
+		}
+		if v := mem.GetReservation(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemReservation(id, plugin), ResourcesClass, id,
+				reply.Memory.Reservation.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.Reservation = api.Int64(value)
+				reply.Memory.Reservation = api.Int64(value)
+			}
+		}
+		if v := mem.GetSwap(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemSwapLimit(id, plugin), ResourcesClass, id,
+				reply.Memory.Swap.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.Swap = api.Int64(value)
+				reply.Memory.Swap = api.Int64(value)
+			}
+		}
+		if v := mem.GetKernel(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemKernelLimit(id, plugin), ResourcesClass, id,
+				reply.Memory.Kernel.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.Kernel = api.Int64(value)
+				reply.Memory.Kernel = api.Int64(value)
+			}
+		}
+		if v := mem.GetKernelTcp(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemTCPLimit(id, plugin), ResourcesClass, id,
+				reply.Memory.KernelTcp.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.KernelTcp = api.Int64(value)
+				reply.Memory.KernelTcp = api.Int64(value)
+			}
+		}
+		if v := mem.GetSwappiness(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemSwappiness(id, plugin), ResourcesClass, id,
+				reply.Memory.Swappiness.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.Swappiness = api.UInt64(value)
+				reply.Memory.Swappiness = api.UInt64(value)
+			}
+		}
+		if v := mem.GetDisableOomKiller(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemDisableOomKiller(id, plugin), ResourcesClass, id,
+				reply.Memory.DisableOomKiller.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.DisableOomKiller = api.Bool(value)
+				reply.Memory.DisableOomKiller = api.Bool(value)
+			}
+		}
+		if v := mem.GetUseHierarchy(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemUseHierarchy(id, plugin), ResourcesClass, id,
+				reply.Memory.UseHierarchy.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Memory.UseHierarchy = api.Bool(value)
+				reply.Memory.UseHierarchy = api.Bool(value)
+			}
+		}
+	}
+	if cpu := resources.Cpu; cpu != nil {
+		if v := cpu.GetShares(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuShares(id, plugin), ResourcesClass, id,
+				reply.Cpu.Shares.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.Shares = api.UInt64(value)
+				reply.Cpu.Shares = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetQuota(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuQuota(id, plugin), ResourcesClass, id,
+				reply.Cpu.Quota.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.Quota = api.Int64(value)
+				reply.Cpu.Quota = api.Int64(value)
+			}
+		}
+		if v := cpu.GetPeriod(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuPeriod(id, plugin), ResourcesClass, id,
+				reply.Cpu.Period.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.Period = api.UInt64(value)
+				reply.Cpu.Period = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetRealtimeRuntime(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuRealtimeRuntime(id, plugin), ResourcesClass, id,
+				reply.Cpu.RealtimeRuntime.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.RealtimeRuntime = api.Int64(value)
+				reply.Cpu.RealtimeRuntime = api.Int64(value)
+			}
+		}
+		if v := cpu.GetRealtimePeriod(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuRealtimePeriod(id, plugin), ResourcesClass, id,
+				reply.Cpu.RealtimePeriod.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.RealtimePeriod = api.UInt64(value)
+				reply.Cpu.RealtimePeriod = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetCpus(); v != "" {
+			value, apply, err := resolveValue(r, r.owners.claimCpusetCpus(id, plugin), ResourcesClass, id,
+				reply.Cpu.Cpus, v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.Cpus = value
+				reply.Cpu.Cpus = value
+			}
+			if err := r.checkCPUAffinity(id, plugin); err != nil {
+				return err
+			}
+		}
+		if v := cpu.GetMems(); v != "" {
+			value, apply, err := resolveValue(r, r.owners.claimCpusetMems(id, plugin), ResourcesClass, id,
+				reply.Cpu.Mems, v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Cpu.Mems = value
+				reply.Cpu.Mems = value
+			}
+		}
+	}
+
+	for _, l := range resources.HugepageLimits {
+		if err := r.checkHugepageLimit(l, plugin, InvalidAdjustmentRule); err != nil {
+			return err
+		}
+		apply, err := r.resolve(r.owners.claimHugepageLimit(id, l.PageSize, plugin), ResourcesClass, id,
+			hugepageLimit(reply.HugepageLimits, l.PageSize), l.Limit)
+		if err != nil {
+			return err
+		}
+		if apply {
+			container.HugepageLimits = setHugepageLimit(container.HugepageLimits, l)
+			reply.HugepageLimits = setHugepageLimit(reply.HugepageLimits, l)
+		}
+	}
+
+	if len(resources.Unified) != 0 {
+		for k, v := range resources.Unified {
+			apply, err := r.resolve(r.owners.claimUnified(id, k, plugin), ResourcesClass, id,
+				reply.Unified[k], v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				container.Unified[k] = v
+				reply.Unified[k] = v
+			}
+		}
+	}
+
+	if v := resources.GetBlockioClass(); v != nil {
+		apply, err := r.resolve(r.owners.claimBlockioClass(id, plugin), ResourcesClass, id,
+			reply.BlockioClass.GetValue(), v.GetValue())
+		if err != nil {
+			return err
+		}
+		if apply {
+			container.BlockioClass = api.String(v.GetValue())
+			reply.BlockioClass = api.String(v.GetValue())
+		}
+	}
+	if v := resources.GetRdtClass(); v != nil {
+		apply, err := r.resolve(r.owners.claimRdtClass(id, plugin), ResourcesClass, id,
+			reply.RdtClass.GetValue(), v.GetValue())
+		if err != nil {
+			return err
+		}
+		if apply {
+			container.RdtClass = api.String(v.GetValue())
+			reply.RdtClass = api.String(v.GetValue())
+		}
+	}
+	if v := resources.GetPids(); v != nil {
+		apply, err := r.resolve(r.owners.claimPidsLimit(id, plugin), ResourcesClass, id,
+			reply.GetPids().GetLimit(), v.GetLimit())
+		if err != nil {
+			return err
+		}
+		if apply {
+			pidv := &api.LinuxPids{
+				Limit: v.GetLimit(),
+			}
+			container.Pids = pidv
+			reply.Pids = pidv
+		}
+	}
+	if err := r.adjustDeviceCgroupRules(resources.Devices, &reply.Devices, &container.Devices,
+		id, plugin); err != nil {
+		return err
+	}
+	return nil
+}
+
+// adjustDeviceCgroupRules adjusts the collected device cgroup rules and, if
+// given, the ones of the container. A rule replaces any earlier one for the
+// same device type and numbers. Removals are kept in the collected rules.
+func (r *Result) adjustDeviceCgroupRules(rules []*api.LinuxDeviceCgroup, collected, container *[]*api.LinuxDeviceCgroup, id, plugin string) error {
+	for _, d := range rules {
+		key, marked := d.IsMarkedForRemoval()
+		if marked {
+			r.owners.clearDeviceCgroupRule(id, key)
+			*collected = append(dropDeviceCgroupRule(*collected, key), d)
+			if container != nil {
+				*container = dropDeviceCgroupRule(*container, key)
+			}
+			continue
+		}
+
+		apply, err := r.resolve(r.owners.claimDeviceCgroupRule(id, key, plugin), ResourcesClass, id,
+			deviceCgroupRule(*collected, key), d)
+		if err != nil {
+			return err
+		}
+		if !apply {
+			continue
+		}
+		*collected = append(dropDeviceCgroupRule(*collected, key), d)
+		if container != nil {
+			*container = append(dropDeviceCgroupRule(*container, key), d)
+		}
+	}
+	return nil
+}
+
+// deviceCgroupRule returns the device cgroup rule with the given key.
+func deviceCgroupRule(rules []*api.LinuxDeviceCgroup, key string) *api.LinuxDeviceCgroup {
+	for _, d := range rules {
+		if d.Key() == key {
+			return d
+		}
+	}
+	return nil
+}
+
+// dropDeviceCgroupRule drops the device cgroup rules with the given key.
+func dropDeviceCgroupRule(rules []*api.LinuxDeviceCgroup, key string) []*api.LinuxDeviceCgroup {
+	kept := []*api.LinuxDeviceCgroup{}
+	for _, d := range rules {
+		if d.Key() != key {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// checkHugepageLimit normalizes the page size of a hugepage limit set by
+// a plugin, and checks that it is valid and supported, if we know the
+// supported sizes. Otherwise page sizes which are not valid are kept as
+// they are, to be checked by the runtime.
+func (r *Result) checkHugepageLimit(l *api.HugepageLimit, plugin, rule string) error {
+	size, err := api.NormalizeHugepageSize(l.PageSize)
+	if err != nil {
+		if r.hugepageSizes == nil {
+			return nil
+		}
+		return rejected(plugin, rule, "hugepage limit", "%v", err)
+	}
+	if r.hugepageSizes != nil {
+		if _, ok := r.hugepageSizes[size]; !ok {
+			return rejected(plugin, rule, "hugepage limit "+size,
+				"unsupported hugepage size %s", l.PageSize)
+		}
+	}
+	l.PageSize = size
+	return nil
+}
+
+// normalHugepageSize returns the canonical form of a page size, or the
+// page size itself if it is invalid.
+func normalHugepageSize(pageSize string) string {
+	if size, err := api.NormalizeHugepageSize(pageSize); err == nil {
+		return size
+	}
+	return pageSize
+}
+
+// hugepageLimit returns the limit for the given page size.
+func hugepageLimit(limits []*api.HugepageLimit, pageSize string) uint64 {
+	for _, l := range limits {
+		if normalHugepageSize(l.PageSize) == pageSize {
+			return l.Limit
+		}
+	}
+	return 0
+}
+
+// setHugepageLimit replaces the limit for the page size of l, or appends l.
+func setHugepageLimit(limits []*api.HugepageLimit, l *api.HugepageLimit) []*api.HugepageLimit {
+	for i, o := range limits {
+		if normalHugepageSize(o.PageSize) == l.PageSize {
+			limits[i] = l
+			return limits
+		}
+	}
+	return append(limits, l)
+}
+
+func (r *Result) adjustCgroupsPath(path, plugin string) error {
+	if path == "" {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	if err := r.owners.claimCgroupsPath(id, plugin); err != nil {
+		return conflictDetails(err, FieldClass(api.CgroupsPathAdjustment), id,
+			r.reply.adjust.Linux.CgroupsPath, path)
+	}
+
+	create.Container.Linux.CgroupsPath = path
+	r.reply.adjust.Linux.CgroupsPath = path
+
+	return nil
+}
+
+func (r *Result) adjustOomScoreAdj(OomScoreAdj *api.OptionalInt, plugin string) error {
+	if OomScoreAdj == nil {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	if err := r.owners.claimOomScoreAdj(id, plugin); err != nil {
+		return conflictDetails(err, FieldClass(api.OomScoreAdjAdjustment), id,
+			r.reply.adjust.Linux.OomScoreAdj.GetValue(), OomScoreAdj.GetValue())
+	}
+
+	create.Container.Linux.OomScoreAdj = OomScoreAdj
+	r.reply.adjust.Linux.OomScoreAdj = OomScoreAdj
+
+	return nil
+}
+
+func (r *Result) adjustApparmorProfile(profile, plugin string) error {
+	if profile == "" {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	if current := create.Container.Linux.ApparmorProfile; current != profile {
+		if slices.Contains(r.protectedProfiles, current) {
+			return rejected(plugin, InvalidAdjustmentRule, api.ApparmorProfileAdjustment,
+				"changing protected AppArmor profile %q not allowed", current)
+		}
+	}
+
+	if err := r.owners.claimApparmorProfile(id, plugin); err != nil {
+		return conflictDetails(err, FieldClass(api.ApparmorProfileAdjustment), id,
+			r.reply.adjust.Linux.ApparmorProfile, profile)
+	}
+
+	create.Container.Linux.ApparmorProfile = profile
+	r.reply.adjust.Linux.ApparmorProfile = profile
+
+	return nil
+}
+
+func (r *Result) adjustSeccompPolicy(policy *api.LinuxSeccomp, plugin string) error {
+	if policy == nil {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	if err := r.owners.claimSeccompPolicy(id, plugin); err != nil {
+		return conflictDetails(err, FieldClass(api.SeccompPolicyAdjustment), id,
+			r.reply.adjust.Linux.SeccompPolicy, policy)
+	}
+
+	create.Container.Linux.SeccompPolicy = policy
+	r.reply.adjust.Linux.SeccompPolicy = policy
+
+	return nil
+}
+
+func (r *Result) adjustCPUAffinity(cpus, plugin string) error {
+	if cpus == "" {
+		return nil
+	}
+
+	if _, err := api.ParseCPUSet(cpus); err != nil {
+		return rejected(plugin, InvalidAdjustmentRule, api.CPUAffinityAdjustment,
+			"invalid CPU affinity %q: %v", cpus, err)
+	}
+	if r.topology != nil {
+		if err := r.topology.CheckCPUs(cpus); err != nil {
+			return rejected(plugin, InvalidAdjustmentRule, api.CPUAffinityAdjustment,
+				"invalid CPU affinity %q: %v", cpus, err)
+		}
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+
+	if err := r.owners.claimCPUAffinity(id, plugin); err != nil {
+		return conflictDetails(err, FieldClass(api.CPUAffinityAdjustment), id,
+			r.reply.adjust.Linux.CpuAffinity, cpus)
+	}
+
+	create.Container.Linux.CpuAffinity = cpus
+	r.reply.adjust.Linux.CpuAffinity = cpus
+
+	return r.checkCPUAffinity(id, plugin)
+}
+
+// checkCPUAffinity checks that the CPU affinity of the container, if set,
+// is within its cpuset CPUs, if known. Otherwise the plugin which adjusted
+// either one last is rejected, in conflict with the plugin which adjusted
+// the other one, if any.
+func (r *Result) checkCPUAffinity(id, plugin string) error {
+	linux := r.request.create.Container.Linux
+	affinity, cpus := linux.GetCpuAffinity(), linux.GetResources().GetCpu().GetCpus()
+	if affinity == "" || cpus == "" {
+		return nil
+	}
+
+	allowed, err := api.ParseCPUSet(cpus)
+	if err != nil {
+		return nil
+	}
+	requested, err := api.ParseCPUSet(affinity)
+	if err != nil || allowed.Contains(requested) {
+		return nil
+	}
+
+	o := r.owners.ownersFor(id)
+	other := o.cpuAffinity
+	if other == plugin {
+		other = o.cpusetCpus
+	}
+	if other == "" || other == plugin {
+		return rejected(plugin, InvalidAdjustmentRule, api.CPUAffinityAdjustment,
+			"CPU affinity %q not within cpuset CPUs %q", affinity, cpus)
+	}
+
+	return conflictDetails(conflict(plugin, other, "linux.cpu_affinity", "contradictory CPU affinity and cpuset CPUs"),
+		FieldClass(api.CPUAffinityAdjustment), id, cpus, affinity)
+}
+
+func (r *Result) adjustNetworkBandwidth(bandwidth *api.LinuxNetworkBandwidth, plugin string) error {
+	if bandwidth.IsEmpty() {
+		return nil
+	}
+
+	if err := r.checkNetworkBandwidth(bandwidth, plugin, InvalidAdjustmentRule); err != nil {
+		return err
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	if create.Container.Linux.NetworkBandwidth == nil {
+		create.Container.Linux.NetworkBandwidth = &api.LinuxNetworkBandwidth{}
+	}
+	if r.reply.adjust.Linux.NetworkBandwidth == nil {
+		r.reply.adjust.Linux.NetworkBandwidth = &api.LinuxNetworkBandwidth{}
+	}
+
+	return r.mergeNetworkBandwidth(id, plugin, bandwidth,
+		r.reply.adjust.Linux.NetworkBandwidth, create.Container.Linux.NetworkBandwidth)
+}
+
+func (r *Result) checkNetworkBandwidth(bandwidth *api.LinuxNetworkBandwidth, plugin, rule string) error {
+	if err := bandwidth.Validate(); err != nil {
+		return rejected(plugin, rule, api.NetworkBandwidthAdjustment,
+			"invalid network bandwidth: %v", err)
+	}
+	return nil
+}
+
+// mergeNetworkBandwidth claims the network bandwidth limits set by the plugin
+// and sets them in the given targets, the first of which is the one collecting
+// the limits set by plugins.
+func (r *Result) mergeNetworkBandwidth(id, plugin string, bandwidth *api.LinuxNetworkBandwidth, targets ...*api.LinuxNetworkBandwidth) error {
+	for _, l := range []struct {
+		field string
+		value *api.OptionalUInt64
+		get   func(*api.LinuxNetworkBandwidth) **api.OptionalUInt64
+	}{
+		{"ingress_rate", bandwidth.GetIngressRate(),
+			func(b *api.LinuxNetworkBandwidth) **api.OptionalUInt64 { return &b.IngressRate }},
+		{"ingress_burst", bandwidth.GetIngressBurst(),
+			func(b *api.LinuxNetworkBandwidth) **api.OptionalUInt64 { return &b.IngressBurst }},
+		{"egress_rate", bandwidth.GetEgressRate(),
+			func(b *api.LinuxNetworkBandwidth) **api.OptionalUInt64 { return &b.EgressRate }},
+		{"egress_burst", bandwidth.GetEgressBurst(),
+			func(b *api.LinuxNetworkBandwidth) **api.OptionalUInt64 { return &b.EgressBurst }},
+	} {
+		if l.value == nil {
+			continue
+		}
+		if err := r.owners.claimNetworkBandwidth(id, l.field, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.NetworkBandwidthAdjustment), id,
+				(*l.get(targets[0])).GetValue(), l.value.GetValue())
+		}
+		for _, t := range targets {
+			*l.get(t) = api.UInt64(l.value.GetValue())
+		}
+	}
+
+	return nil
+}
+
+func (r *Result) adjustSchedulingHints(hints *api.SchedulingHints, plugin string) error {
+	if hints.IsEmpty() {
+		return nil
+	}
+
+	// Notes:
+	//   Like topology hints, scheduling hints are advisory and passed on to
+	//   the runtime as such. We only allow a single plugin to set any single
+	//   hint, and reject hints which contradict each other or the preferred
+	//   NUMA nodes of the topology hints.
+
+	if err := hints.Validate(); err != nil {
+		return rejected(plugin, InvalidAdjustmentRule, api.SchedulingHintsAdjustment,
+			"invalid scheduling hints: %v", err)
+	}
+
+	id := r.request.create.Container.Id
+	if r.reply.adjust.SchedulingHints == nil {
+		r.reply.adjust.SchedulingHints = &api.SchedulingHints{}
+	}
+	adjusted := r.reply.adjust.SchedulingHints
+
+	if node := hints.GetPreferredNumaNode(); node != nil {
+		if r.topology != nil {
+			if err := r.topology.CheckMems(strconv.FormatUint(uint64(node.GetValue()), 10)); err != nil {
+				return rejected(plugin, InvalidAdjustmentRule, api.SchedulingHintsAdjustment,
+					"invalid preferred NUMA node %d: %v", node.GetValue(), err)
+			}
+		}
+		if err := r.owners.claimSchedulingNumaNode(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.SchedulingHintsAdjustment), id,
+				adjusted.GetPreferredNumaNode().GetValue(), node.GetValue())
+		}
+		adjusted.PreferredNumaNode = node
+	}
+	if policy := hints.GetSmtPolicy(); policy != api.SMTPolicy_SMT_POLICY_UNSPECIFIED {
+		if err := r.owners.claimSMTPolicy(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.SchedulingHintsAdjustment), id,
+				adjusted.GetSmtPolicy(), policy)
+		}
+		adjusted.SmtPolicy = policy
+	}
+	if exclusive := hints.GetExclusiveCores(); exclusive != nil {
+		if err := r.owners.claimExclusiveCores(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.SchedulingHintsAdjustment), id,
+				adjusted.GetExclusiveCores().GetValue(), exclusive.GetValue())
+		}
+		adjusted.ExclusiveCores = exclusive
+	}
+
+	return r.checkSchedulingHints(id, plugin)
+}
+
+// checkSchedulingHints checks that the scheduling hints of the container
+// don't contradict each other, and that the preferred NUMA node is one of
+// the preferred NUMA nodes of the topology hints, if both are set. Otherwise
+// the plugin which set either one last is rejected, in conflict with the
+// plugin which set the other one, if any.
+func (r *Result) checkSchedulingHints(id, plugin string) error {
+	var (
+		hints = r.reply.adjust.GetSchedulingHints()
+		nodes = r.reply.adjust.GetTopologyHints().GetNumaNodes()
+		o     = r.owners.ownersFor(id)
+	)
+
+	if hints.WantsExclusiveCores() && hints.SharesSMTSiblings() {
+		return contradictoryHints(plugin, o.exclusiveCores, o.smtPolicy,
+			"scheduling_hints.exclusive_cores", "exclusive cores with shared SMT siblings", id,
+			hints.GetSmtPolicy(), hints.GetExclusiveCores().GetValue())
+	}
+
+	if node, ok := hints.PreferredNUMANode(); ok && len(nodes) > 0 && !slices.Contains(nodes, node) {
+		return contradictoryHints(plugin, o.schedNumaNode, o.topologyNumaNodes,
+			"scheduling_hints.preferred_numa_node", "preferred NUMA node not among topology hint NUMA nodes", id,
+			nodes, node)
+	}
+
+	return nil
+}
+
+// contradictoryHints returns the error for contradictory hints, set by the
+// given owners. The plugin is rejected in conflict with the other owner, if
+// any, or for an invalid adjustment otherwise.
+func contradictoryHints(plugin, owner, other, field, reason, id string, current, proposed interface{}) error {
+	if owner != plugin {
+		other = owner
+	}
+	if other == "" || other == plugin {
+		return rejected(plugin, InvalidAdjustmentRule, api.SchedulingHintsAdjustment,
+			"contradictory scheduling hints, %s", reason)
+	}
+	return conflictDetails(conflict(plugin, other, field, "contradictory scheduling hints,", reason),
+		FieldClass(api.SchedulingHintsAdjustment), id, current, proposed)
+}
+
+func (r *Result) adjustCapabilities(caps *api.LinuxCapabilities, plugin string) error {
+	if caps == nil {
+		return nil
+	}
+
+	create, id := r.request.create, r.request.create.Container.Id
+	if create.Container.Linux.Capabilities == nil {
+		create.Container.Linux.Capabilities = &api.LinuxCapabilities{}
+	}
+	if r.reply.adjust.Linux.Capabilities == nil {
+		r.reply.adjust.Linux.Capabilities = &api.LinuxCapabilities{}
+	}
+	current, adjusted := create.Container.Linux.Capabilities, r.reply.adjust.Linux.Capabilities
+
+	for _, set := range api.CapabilitySets {
+		for _, c := range caps.Get(set) {
+			name, marked := api.IsMarkedForRemoval(c)
+			if err := r.owners.claimCapability(id, set.String(), name, plugin); err != nil {
+				return conflictDetails(err, FieldClass(api.CapabilitiesAdjustment), id,
+					find(adjusted.Get(set), api.ClearRemovalMarker, name), c)
+			}
+
+			adjusted.Set(set, append(adjusted.Get(set), c))
+
+			// drop the capability from, or add it to, the creation request
+			kept := []string{}
+			for _, k := range current.Get(set) {
+				if k != name {
+					kept = append(kept, k)
+				}
+			}
+			if !marked {
+				kept = append(kept, name)
+			}
+			current.Set(set, kept)
+		}
+	}
+
+	return nil
+}
+
+func (r *Result) adjustRlimits(rlimits []*api.POSIXRlimit, plugin string) error {
+	create, id, adjust := r.request.create, r.request.create.Container.Id, r.reply.adjust
+	for _, l := range rlimits {
+		if err := r.owners.claimRlimits(id, l.Type, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.RlimitsAdjustment), id,
+				find(adjust.Rlimits, (*api.POSIXRlimit).GetType, l.Type), l)
+		}
+
+		create.Container.Rlimits = append(create.Container.Rlimits, l)
+		adjust.Rlimits = append(adjust.Rlimits, l)
+	}
+	return nil
+}
+
+func (r *Result) updateAnnotations(reply, u *api.ContainerUpdate, plugin string) error {
+	if len(u.Annotations) == 0 {
+		return nil
+	}
+
+	id := u.ContainerId
+
+	// operate on a copy: we won't touch anything on (ignored) failures
+	annotations := map[string]string{}
+	for k, v := range reply.Annotations {
+		annotations[k] = v
+	}
+
+	del := map[string]struct{}{}
+	for k := range u.Annotations {
+		if key, marked := api.IsMarkedForRemoval(k); marked {
+			del[key] = struct{}{}
+		}
+	}
+
+	for k, v := range u.Annotations {
+		if _, marked := api.IsMarkedForRemoval(k); marked {
+			continue
+		}
+		if _, ok := del[k]; ok {
+			r.owners.clearAnnotation(id, k)
+			delete(del, k)
+		}
+		apply, err := r.resolve(r.owners.claimAnnotation(id, k, plugin), AnnotationsClass, id,
+			annotations[k], v)
+		if err != nil {
+			return err
+		}
+		if !apply {
+			continue
+		}
+		delete(annotations, api.MarkForRemoval(k))
+		annotations[k] = v
+	}
+
+	for k := range del {
+		delete(annotations, k)
+		annotations[api.MarkForRemoval(k)] = ""
+	}
+
+	reply.Annotations = annotations
+
+	return nil
+}
+
+// updateLinux collects the Linux resource and OOM score updates of a
+// container. Either all or, on failure, none of them are collected.
+func (r *Result) updateLinux(reply, u *api.ContainerUpdate, plugin string) error {
+	if u.Linux == nil || (u.Linux.Resources == nil && u.Linux.OomScoreAdj == nil &&
+		u.Linux.NetworkBandwidth.IsEmpty()) {
+		return nil
+	}
+
+	var resources *api.LinuxResources
+	request, id := r.request.update, u.ContainerId
+
+	updates := u.Linux.Resources
+	if updates == nil {
+		updates = &api.LinuxResources{}
+	}
+
+	if err := r.checkCpuset(updates.Cpu, plugin, InvalidUpdateRule); err != nil {
+		return err
+	}
+	if err := r.checkNetworkBandwidth(u.Linux.NetworkBandwidth, plugin, InvalidUpdateRule); err != nil {
+		return err
+	}
+
+	// operate on a copy: we won't touch anything on (ignored) failures
+	if request != nil && request.Container.Id == id {
+		resources = request.LinuxResources.Copy()
+	} else {
+		resources = reply.Linux.Resources.Copy()
+	}
+
+	if mem := updates.Memory; mem != nil {
+		if v := mem.GetLimit(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemLimit(id, plugin), ResourcesClass, id,
+				resources.Memory.Limit.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.Limit = api.Int64(value)
+			}
+		}
+		if v := mem.GetReservation(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemReservation(id, plugin), ResourcesClass, id,
+				resources.Memory.Reservation.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.Reservation = api.Int64(value)
+			}
+		}
+		if v := mem.GetSwap(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemSwapLimit(id, plugin), ResourcesClass, id,
+				resources.Memory.Swap.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.Swap = api.Int64(value)
+			}
+		}
+		if v := mem.GetKernel(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemKernelLimit(id, plugin), ResourcesClass, id,
+				resources.Memory.Kernel.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.Kernel = api.Int64(value)
+			}
+		}
+		if v := mem.GetKernelTcp(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemTCPLimit(id, plugin), ResourcesClass, id,
+				resources.Memory.KernelTcp.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.KernelTcp = api.Int64(value)
+			}
+		}
+		if v := mem.GetSwappiness(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemSwappiness(id, plugin), ResourcesClass, id,
+				resources.Memory.Swappiness.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.Swappiness = api.UInt64(value)
+			}
+		}
+		if v := mem.GetDisableOomKiller(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemDisableOomKiller(id, plugin), ResourcesClass, id,
+				resources.Memory.DisableOomKiller.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.DisableOomKiller = api.Bool(value)
+			}
+		}
+		if v := mem.GetUseHierarchy(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimMemUseHierarchy(id, plugin), ResourcesClass, id,
+				resources.Memory.UseHierarchy.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Memory.UseHierarchy = api.Bool(value)
+			}
+		}
+	}
+	if cpu := updates.Cpu; cpu != nil {
+		if v := cpu.GetShares(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuShares(id, plugin), ResourcesClass, id,
+				resources.Cpu.Shares.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.Shares = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetQuota(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuQuota(id, plugin), ResourcesClass, id,
+				resources.Cpu.Quota.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.Quota = api.Int64(value)
+			}
+		}
+		if v := cpu.GetPeriod(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuPeriod(id, plugin), ResourcesClass, id,
+				resources.Cpu.Period.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.Period = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetRealtimeRuntime(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuRealtimeRuntime(id, plugin), ResourcesClass, id,
+				resources.Cpu.RealtimeRuntime.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.RealtimeRuntime = api.Int64(value)
+			}
+		}
+		if v := cpu.GetRealtimePeriod(); v != nil {
+			value, apply, err := resolveValue(r, r.owners.claimCpuRealtimePeriod(id, plugin), ResourcesClass, id,
+				resources.Cpu.RealtimePeriod.GetValue(), v.GetValue())
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.RealtimePeriod = api.UInt64(value)
+			}
+		}
+		if v := cpu.GetCpus(); v != "" {
+			value, apply, err := resolveValue(r, r.owners.claimCpusetCpus(id, plugin), ResourcesClass, id,
+				resources.Cpu.Cpus, v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.Cpus = value
+			}
+		}
+		if v := cpu.GetMems(); v != "" {
+			value, apply, err := resolveValue(r, r.owners.claimCpusetMems(id, plugin), ResourcesClass, id,
+				resources.Cpu.Mems, v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Cpu.Mems = value
+			}
+		}
+	}
+
+	for _, l := range updates.HugepageLimits {
+		if err := r.checkHugepageLimit(l, plugin, InvalidUpdateRule); err != nil {
+			return err
+		}
+		apply, err := r.resolve(r.owners.claimHugepageLimit(id, l.PageSize, plugin), ResourcesClass, id,
+			hugepageLimit(resources.HugepageLimits, l.PageSize), l.Limit)
+		if err != nil {
+			return err
+		}
+		if apply {
+			resources.HugepageLimits = setHugepageLimit(resources.HugepageLimits, l)
+		}
+	}
+
+	if len(updates.Unified) != 0 {
+		if resources.Unified == nil {
+			resources.Unified = make(map[string]string)
+		}
+		for k, v := range updates.Unified {
+			apply, err := r.resolve(r.owners.claimUnified(id, k, plugin), ResourcesClass, id,
+				resources.Unified[k], v)
+			if err != nil {
+				return err
+			}
+			if apply {
+				resources.Unified[k] = v
+			}
+		}
+	}
+
+	if v := updates.GetBlockioClass(); v != nil {
+		apply, err := r.resolve(r.owners.claimBlockioClass(id, plugin), ResourcesClass, id,
+			resources.BlockioClass.GetValue(), v.GetValue())
+		if err != nil {
+			return err
+		}
+		if apply {
+			resources.BlockioClass = api.String(v.GetValue())
+		}
+	}
+	if v := updates.GetRdtClass(); v != nil {
+		apply, err := r.resolve(r.owners.claimRdtClass(id, plugin), ResourcesClass, id,
+			resources.RdtClass.GetValue(), v.GetValue())
+		if err != nil {
+			return err
+		}
+		if apply {
+			resources.RdtClass = api.String(v.GetValue())
+		}
+	}
+	if v := updates.GetPids(); v != nil {
+		apply, err := r.resolve(r.owners.claimPidsLimit(id, plugin), ResourcesClass, id,
+			resources.GetPids().GetLimit(), v.GetLimit())
+		if err != nil {
+			return err
+		}
+		if apply {
+			resources.Pids = &api.LinuxPids{
+				Limit: v.GetLimit(),
+			}
+		}
+	}
+	if err := r.adjustDeviceCgroupRules(updates.Devices, &resources.Devices, nil,
+		id, plugin); err != nil {
+		return err
+	}
+
+	oomScoreAdj := reply.Linux.OomScoreAdj
+	if v := u.Linux.OomScoreAdj; v != nil {
+		if err := r.owners.claimOomScoreAdj(id, plugin); err != nil {
+			return conflictDetails(err, FieldClass(api.OomScoreAdjAdjustment), id,
+				oomScoreAdj.GetValue(), v.GetValue())
+		}
+		oomScoreAdj = v
+	}
+
+	bandwidth := &api.LinuxNetworkBandwidth{}
+	if b := reply.Linux.NetworkBandwidth; b != nil {
+		bandwidth = proto.Clone(b).(*api.LinuxNetworkBandwidth)
+	}
+	if err := r.mergeNetworkBandwidth(id, plugin, u.Linux.NetworkBandwidth, bandwidth); err != nil {
+		return err
+	}
+
+	// update request/reply from copy on success
+	reply.Linux.Resources = resources.Copy()
+	reply.Linux.OomScoreAdj = oomScoreAdj
+	if !bandwidth.IsEmpty() {
+		reply.Linux.NetworkBandwidth = bandwidth
+	}
+
+	if request != nil && request.Container.Id == id {
+		request.LinuxResources = resources.Copy()
+	}
+
+	return nil
+}
+
+func (r *Result) getContainerUpdate(u *api.ContainerUpdate, plugin string) (*api.ContainerUpdate, error) {
+	id := u.ContainerId
+	if r.request.create != nil && r.request.create.Container != nil {
+		if r.request.create.Container.Id == id {
+			return nil, rejected(plugin, InvalidUpdateRule, id,
+				"asked update of %q during creation", id)
+		}
+	}
+
+	if update, ok := r.updates[id]; ok {
+		update.IgnoreFailure = update.IgnoreFailure && u.IgnoreFailure
+		return update, nil
+	}
+
+	update := &api.ContainerUpdate{
+		ContainerId: id,
+		Linux: &api.LinuxContainerUpdate{
+			Resources: &api.LinuxResources{
+				Memory:         &api.LinuxMemory{},
+				Cpu:            &api.LinuxCPU{},
+				HugepageLimits: []*api.HugepageLimit{},
+				Unified:        map[string]string{},
+			},
+		},
+		IgnoreFailure: u.IgnoreFailure,
+	}
+
+	r.updates[id] = update
+
+	// for update requests delay appending the requested container (in the response getter)
+	if r.request.update == nil || r.request.update.Container.Id != id {
+		r.reply.update = append(r.reply.update, update)
+	}
+
+	return update, nil
+}
+
+type owners struct {
+	annotations         map[string]string
+	handlerAnnotations  map[string]string
+	mounts              map[string]string
+	devices             map[string]string
+	deviceCgroupRules   map[string]string
+	cdiDevices          map[string]string
+	env                 map[string]string
+	envFiles            map[string]string
+	memLimit            string
+	memReservation      string
+	memSwapLimit        string
+	memKernelLimit      string
+	memTCPLimit         string
+	memSwappiness       string
+	memDisableOomKiller string
+	memUseHierarchy     string
+	cpuShares           string
+	cpuQuota            string
+	cpuPeriod           string
+	cpuRealtimeRuntime  string
+	cpuRealtimePeriod   string
+	cpusetCpus          string
+	cpusetMems          string
+	pidsLimit           string
+	hugepageLimits      map[string]string
+	blockioClass        string
+	rdtClass            string
+	unified             map[string]string
+	cgroupsPath         string
+	oomScoreAdj         string
+	rlimits             map[string]string
+	topologyNumaNodes   string
+	deviceTopologyHints map[string]string
+	windowsMemLimit     string
+	windowsCpuCount     string
+	windowsCpuShares    string
+	windowsCpuMaximum   string
+	capabilities        map[string]string
+	uid                 string
+	gid                 string
+	apparmorProfile     string
+	seccompPolicy       string
+	cpuAffinity         string
+	schedNumaNode       string
+	smtPolicy           string
+	exclusiveCores      string
+	networkBandwidth    map[string]string
+}
+
+func (ro resultOwners) ownersFor(id string) *owners {
+	o, ok := ro[id]
+	if !ok {
+		o = &owners{}
+		ro[id] = o
+	}
+	return o
+}
+
+func (ro resultOwners) claimAnnotation(id, key, plugin string) error {
+	return ro.ownersFor(id).claimAnnotation(key, plugin)
+}
+
+func (ro resultOwners) claimHandlerAnnotation(id, key, plugin string) error {
+	return ro.ownersFor(id).claimHandlerAnnotation(key, plugin)
+}
+
+func (ro resultOwners) claimMount(id, destination, plugin string) error {
+	return ro.ownersFor(id).claimMount(destination, plugin)
+}
+
+func (ro resultOwners) claimDevice(id, path, plugin string) error {
+	return ro.ownersFor(id).claimDevice(path, plugin)
+}
+
+func (ro resultOwners) claimDeviceCgroupRule(id, key, plugin string) error {
+	return ro.ownersFor(id).claimDeviceCgroupRule(key, plugin)
+}
+
+func (ro resultOwners) claimCDIDevice(id, path, plugin string) error {
+	return ro.ownersFor(id).claimCDIDevice(path, plugin)
+}
+
+func (ro resultOwners) claimEnv(id, name, plugin string) error {
+	return ro.ownersFor(id).claimEnv(name, plugin)
+}
+
+func (ro resultOwners) claimEnvFile(id, path, plugin string) error {
+	return ro.ownersFor(id).claimEnvFile(path, plugin)
+}
+
+func (ro resultOwners) claimApparmorProfile(id, plugin string) error {
+	return ro.ownersFor(id).claimApparmorProfile(plugin)
+}
+
+func (ro resultOwners) claimSeccompPolicy(id, plugin string) error {
+	return ro.ownersFor(id).claimSeccompPolicy(plugin)
+}
+
+func (ro resultOwners) claimCPUAffinity(id, plugin string) error {
+	return ro.ownersFor(id).claimCPUAffinity(plugin)
+}
+
+func (ro resultOwners) claimUid(id, plugin string) error {
+	return ro.ownersFor(id).claimUid(plugin)
+}
+
+func (ro resultOwners) claimGid(id, plugin string) error {
+	return ro.ownersFor(id).claimGid(plugin)
+}
+
+func (ro resultOwners) claimMemLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimMemLimit(plugin)
+}
+
+func (ro resultOwners) claimMemReservation(id, plugin string) error {
+	return ro.ownersFor(id).claimMemReservation(plugin)
+}
+
+func (ro resultOwners) claimMemSwapLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimMemSwapLimit(plugin)
+}
+
+func (ro resultOwners) claimMemKernelLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimMemKernelLimit(plugin)
+}
+
+func (ro resultOwners) claimMemTCPLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimMemTCPLimit(plugin)
+}
+
+func (ro resultOwners) claimMemSwappiness(id, plugin string) error {
+	return ro.ownersFor(id).claimMemSwappiness(plugin)
+}
+
+func (ro resultOwners) claimMemDisableOomKiller(id, plugin string) error {
+	return ro.ownersFor(id).claimMemDisableOomKiller(plugin)
+}
+
+func (ro resultOwners) claimMemUseHierarchy(id, plugin string) error {
+	return ro.ownersFor(id).claimMemUseHierarchy(plugin)
+}
+
+func (ro resultOwners) claimCpuShares(id, plugin string) error {
+	return ro.ownersFor(id).claimCpuShares(plugin)
+}
+
+func (ro resultOwners) claimCpuQuota(id, plugin string) error {
+	return ro.ownersFor(id).claimCpuQuota(plugin)
+}
+
+func (ro resultOwners) claimCpuPeriod(id, plugin string) error {
+	return ro.ownersFor(id).claimCpuPeriod(plugin)
+}
+
+func (ro resultOwners) claimCpuRealtimeRuntime(id, plugin string) error {
+	return ro.ownersFor(id).claimCpuRealtimeRuntime(plugin)
+}
+
+func (ro resultOwners) claimCpuRealtimePeriod(id, plugin string) error {
+	return ro.ownersFor(id).claimCpuRealtimePeriod(plugin)
+}
+
+func (ro resultOwners) claimCpusetCpus(id, plugin string) error {
+	return ro.ownersFor(id).claimCpusetCpus(plugin)
+}
+
+func (ro resultOwners) claimCpusetMems(id, plugin string) error {
+	return ro.ownersFor(id).claimCpusetMems(plugin)
+}
+
+func (ro resultOwners) claimPidsLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimPidsLimit(plugin)
+}
+
+func (ro resultOwners) claimHugepageLimit(id, size, plugin string) error {
+	return ro.ownersFor(id).claimHugepageLimit(size, plugin)
+}
+
+func (ro resultOwners) claimBlockioClass(id, plugin string) error {
+	return ro.ownersFor(id).claimBlockioClass(plugin)
+}
+
+func (ro resultOwners) claimRdtClass(id, plugin string) error {
+	return ro.ownersFor(id).claimRdtClass(plugin)
+}
+
+func (ro resultOwners) claimUnified(id, key, plugin string) error {
+	return ro.ownersFor(id).claimUnified(key, plugin)
+}
+
+func (ro resultOwners) claimCgroupsPath(id, plugin string) error {
+	return ro.ownersFor(id).claimCgroupsPath(plugin)
+}
+
+func (ro resultOwners) claimOomScoreAdj(id, plugin string) error {
+	return ro.ownersFor(id).claimOomScoreAdj(plugin)
+}
+
+func (ro resultOwners) claimRlimits(id, typ, plugin string) error {
+	return ro.ownersFor(id).claimRlimit(typ, plugin)
+}
+
+func (ro resultOwners) claimTopologyNumaNodes(id, plugin string) error {
+	return ro.ownersFor(id).claimTopologyNumaNodes(plugin)
+}
+
+func (ro resultOwners) claimDeviceTopologyHint(id, device, plugin string) error {
+	return ro.ownersFor(id).claimDeviceTopologyHint(device, plugin)
+}
+
+func (ro resultOwners) claimSchedulingNumaNode(id, plugin string) error {
+	return ro.ownersFor(id).claimSchedulingNumaNode(plugin)
+}
+
+func (ro resultOwners) claimSMTPolicy(id, plugin string) error {
+	return ro.ownersFor(id).claimSMTPolicy(plugin)
+}
+
+func (ro resultOwners) claimExclusiveCores(id, plugin string) error {
+	return ro.ownersFor(id).claimExclusiveCores(plugin)
+}
+
+func (ro resultOwners) claimNetworkBandwidth(id, field, plugin string) error {
+	return ro.ownersFor(id).claimNetworkBandwidth(field, plugin)
+}
+
+func (ro resultOwners) claimWindowsMemLimit(id, plugin string) error {
+	return ro.ownersFor(id).claimWindowsMemLimit(plugin)
+}
+
+func (ro resultOwners) claimWindowsCpuCount(id, plugin string) error {
+	return ro.ownersFor(id).claimWindowsCpuCount(plugin)
+}
+
+func (ro resultOwners) claimWindowsCpuShares(id, plugin string) error {
+	return ro.ownersFor(id).claimWindowsCpuShares(plugin)
+}
+
+func (ro resultOwners) claimWindowsCpuMaximum(id, plugin string) error {
+	return ro.ownersFor(id).claimWindowsCpuMaximum(plugin)
+}
+
+func (ro resultOwners) claimCapability(id, set, name, plugin string) error {
+	return ro.ownersFor(id).claimCapability(set, name, plugin)
+}
+
+func (o *owners) claimAnnotation(key, plugin string) error {
+	if o.annotations == nil {
+		o.annotations = make(map[string]string)
+	}
+	if other, taken := o.annotations[key]; taken {
+		return conflict(plugin, other, "annotations["+key+"]", "annotation", key)
+	}
+	o.annotations[key] = plugin
+	return nil
+}
+
+func (o *owners) claimHandlerAnnotation(key, plugin string) error {
+	if o.handlerAnnotations == nil {
+		o.handlerAnnotations = make(map[string]string)
+	}
+	if other, taken := o.handlerAnnotations[key]; taken {
+		return conflict(plugin, other, "handler_annotations["+key+"]", "handler annotation", key)
+	}
+	o.handlerAnnotations[key] = plugin
+	return nil
+}
+
+func (o *owners) claimMount(destination, plugin string) error {
+	if o.mounts == nil {
+		o.mounts = make(map[string]string)
+	}
+	if other, taken := o.mounts[destination]; taken {
+		return conflict(plugin, other, "mounts["+destination+"]", "mount", destination)
+	}
+	o.mounts[destination] = plugin
+	return nil
+}
+
+func (o *owners) claimDevice(path, plugin string) error {
+	if o.devices == nil {
+		o.devices = make(map[string]string)
+	}
+	if other, taken := o.devices[path]; taken {
+		return conflict(plugin, other, "linux.devices["+path+"]", "device", path)
+	}
+	o.devices[path] = plugin
+	return nil
+}
+
+func (o *owners) claimDeviceCgroupRule(key, plugin string) error {
+	if o.deviceCgroupRules == nil {
+		o.deviceCgroupRules = make(map[string]string)
+	}
+	if other, taken := o.deviceCgroupRules[key]; taken {
+		return conflict(plugin, other, "linux.resources.devices["+key+"]", "device cgroup rule", key)
+	}
+	o.deviceCgroupRules[key] = plugin
+	return nil
+}
+
+func (o *owners) claimCDIDevice(name, plugin string) error {
+	if o.cdiDevices == nil {
+		o.cdiDevices = make(map[string]string)
+	}
+	if other, taken := o.cdiDevices[name]; taken {
+		return conflict(plugin, other, "cdi_devices["+name+"]", "CDI device", name)
+	}
+	o.cdiDevices[name] = plugin
+	return nil
+}
+
+func (o *owners) claimEnv(name, plugin string) error {
+	if o.env == nil {
+		o.env = make(map[string]string)
+	}
+	if other, taken := o.env[name]; taken {
+		return conflict(plugin, other, "env["+name+"]", "env", name)
+	}
+	o.env[name] = plugin
+	return nil
+}
+
+func (o *owners) claimEnvFile(path, plugin string) error {
+	if o.envFiles == nil {
+		o.envFiles = make(map[string]string)
+	}
+	if other, taken := o.envFiles[path]; taken {
+		return conflict(plugin, other, "env_files["+path+"]", "env file", path)
+	}
+	o.envFiles[path] = plugin
+	return nil
+}
+
+func (o *owners) claimApparmorProfile(plugin string) error {
+	if other := o.apparmorProfile; other != "" {
+		return conflict(plugin, other, "linux.apparmor_profile", "AppArmor profile")
+	}
+	o.apparmorProfile = plugin
+	return nil
+}
+
+func (o *owners) claimSeccompPolicy(plugin string) error {
+	if other := o.seccompPolicy; other != "" {
+		return conflict(plugin, other, "linux.seccomp_policy", "seccomp policy")
+	}
+	o.seccompPolicy = plugin
+	return nil
+}
+
+func (o *owners) claimCPUAffinity(plugin string) error {
+	if other := o.cpuAffinity; other != "" {
+		return conflict(plugin, other, "linux.cpu_affinity", "CPU affinity")
+	}
+	o.cpuAffinity = plugin
+	return nil
+}
+
+func (o *owners) claimUid(plugin string) error {
+	if other := o.uid; other != "" {
+		return conflict(plugin, other, "user.uid", "user")
+	}
+	o.uid = plugin
+	return nil
+}
+
+func (o *owners) claimGid(plugin string) error {
+	if other := o.gid; other != "" {
+		return conflict(plugin, other, "user.gid", "group")
+	}
+	o.gid = plugin
+	return nil
+}
+
+func (o *owners) claimCapability(set, name, plugin string) error {
+	if o.capabilities == nil {
+		o.capabilities = make(map[string]string)
+	}
+	key := set + "/" + name
+	if other, taken := o.capabilities[key]; taken {
+		return conflict(plugin, other, "linux.capabilities."+set+"["+name+"]", "capability", set, name)
+	}
+	o.capabilities[key] = plugin
+	return nil
+}
+
+func (o *owners) claimMemLimit(plugin string) error {
+	if other := o.memLimit; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.limit", "memory limit")
+	}
+	o.memLimit = plugin
+	return nil
+}
+
+func (o *owners) claimMemReservation(plugin string) error {
+	if other := o.memReservation; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.reservation", "memory reservation")
+	}
+	o.memReservation = plugin
+	return nil
+}
+
+func (o *owners) claimMemSwapLimit(plugin string) error {
+	if other := o.memSwapLimit; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.swap", "memory swap limit")
+	}
+	o.memSwapLimit = plugin
+	return nil
+}
+
+func (o *owners) claimMemKernelLimit(plugin string) error {
+	if other := o.memKernelLimit; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.kernel", "memory kernel limit")
+	}
+	o.memKernelLimit = plugin
+	return nil
+}
+
+func (o *owners) claimMemTCPLimit(plugin string) error {
+	if other := o.memTCPLimit; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.kernel_tcp", "memory TCP limit")
+	}
+	o.memTCPLimit = plugin
+	return nil
+}
+
+func (o *owners) claimMemSwappiness(plugin string) error {
+	if other := o.memSwappiness; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.swappiness", "memory swappiness")
+	}
+	o.memSwappiness = plugin
+	return nil
+}
+
+func (o *owners) claimMemDisableOomKiller(plugin string) error {
+	if other := o.memDisableOomKiller; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.disable_oom_killer", "memory disable OOM killer")
+	}
+	o.memDisableOomKiller = plugin
+	return nil
+}
+
+func (o *owners) claimMemUseHierarchy(plugin string) error {
+	if other := o.memUseHierarchy; other != "" {
+		return conflict(plugin, other, "linux.resources.memory.use_hierarchy", "memory 'UseHierarchy'")
+	}
+	o.memUseHierarchy = plugin
+	return nil
+}
+
+func (o *owners) claimCpuShares(plugin string) error {
+	if other := o.cpuShares; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.shares", "CPU shares")
+	}
+	o.cpuShares = plugin
+	return nil
+}
+
+func (o *owners) claimCpuQuota(plugin string) error {
+	if other := o.cpuQuota; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.quota", "CPU quota")
+	}
+	o.cpuQuota = plugin
+	return nil
+}
+
+func (o *owners) claimCpuPeriod(plugin string) error {
+	if other := o.cpuPeriod; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.period", "CPU period")
+	}
+	o.cpuPeriod = plugin
+	return nil
+}
+
+func (o *owners) claimCpuRealtimeRuntime(plugin string) error {
+	if other := o.cpuRealtimeRuntime; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.realtime_runtime", "CPU realtime runtime")
+	}
+	o.cpuRealtimeRuntime = plugin
+	return nil
+}
+
+func (o *owners) claimCpuRealtimePeriod(plugin string) error {
+	if other := o.cpuRealtimePeriod; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.realtime_period", "CPU realtime period")
+	}
+	o.cpuRealtimePeriod = plugin
+	return nil
+}
+
+func (o *owners) claimCpusetCpus(plugin string) error {
+	if other := o.cpusetCpus; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.cpus", "CPU pinning")
+	}
+	o.cpusetCpus = plugin
+	return nil
+}
+
+func (o *owners) claimCpusetMems(plugin string) error {
+	if other := o.cpusetMems; other != "" {
+		return conflict(plugin, other, "linux.resources.cpu.mems", "memory pinning")
+	}
+	o.cpusetMems = plugin
+	return nil
+}
+
+func (o *owners) claimPidsLimit(plugin string) error {
+	if other := o.pidsLimit; other != "" {
+		return conflict(plugin, other, "linux.resources.pids.limit", "pids pinning")
+	}
+	o.pidsLimit = plugin
+	return nil
+}
+
+func (o *owners) claimHugepageLimit(size, plugin string) error {
+	if o.hugepageLimits == nil {
+		o.hugepageLimits = make(map[string]string)
+	}
+
+	if other, taken := o.hugepageLimits[size]; taken {
+		return conflict(plugin, other, "linux.resources.hugepage_limits["+size+"]", "hugepage limit of size", size)
+	}
+	o.hugepageLimits[size] = plugin
+	return nil
+}
+
+func (o *owners) claimBlockioClass(plugin string) error {
+	if other := o.blockioClass; other != "" {
+		return conflict(plugin, other, "linux.resources.blockio_class", "block I/O class")
+	}
+	o.blockioClass = plugin
+	return nil
+}
+
+func (o *owners) claimRdtClass(plugin string) error {
+	if other := o.rdtClass; other != "" {
+		return conflict(plugin, other, "linux.resources.rdt_class", "RDT class")
+	}
+	o.rdtClass = plugin
+	return nil
+}
+
+func (o *owners) claimUnified(key, plugin string) error {
+	if o.unified == nil {
+		o.unified = make(map[string]string)
+	}
+	if other, taken := o.unified[key]; taken {
+		return conflict(plugin, other, "linux.resources.unified["+key+"]", "unified resource", key)
+	}
+	o.unified[key] = plugin
+	return nil
+}
+
+func (o *owners) claimRlimit(typ, plugin string) error {
+	if o.rlimits == nil {
+		o.rlimits = make(map[string]string)
+	}
+	if other, taken := o.rlimits[typ]; taken {
+		return conflict(plugin, other, "rlimits["+typ+"]", "rlimit", typ)
+	}
+	o.rlimits[typ] = plugin
+	return nil
+}
+
+func (o *owners) claimCgroupsPath(plugin string) error {
+	if other := o.cgroupsPath; other != "" {
+		return conflict(plugin, other, "linux.cgroups_path", "cgroups path")
+	}
+	o.cgroupsPath = plugin
+	return nil
+}
+
+func (o *owners) claimOomScoreAdj(plugin string) error {
+	if other := o.oomScoreAdj; other != "" {
+		return conflict(plugin, other, "linux.oom_score_adj", "oom score adj")
+	}
+	o.oomScoreAdj = plugin
+	return nil
+}
+
+func (o *owners) claimTopologyNumaNodes(plugin string) error {
+	if other := o.topologyNumaNodes; other != "" {
+		return conflict(plugin, other, "topology_hints.numa_nodes", "topology hint NUMA nodes")
+	}
+	o.topologyNumaNodes = plugin
+	return nil
+}
+
+func (o *owners) claimDeviceTopologyHint(device, plugin string) error {
+	if o.deviceTopologyHints == nil {
+		o.deviceTopologyHints = make(map[string]string)
+	}
+	if other, taken := o.deviceTopologyHints[device]; taken {
+		return conflict(plugin, other, "topology_hints.devices["+device+"]", "topology hint for device", device)
+	}
+	o.deviceTopologyHints[device] = plugin
+	return nil
+}
+
+func (o *owners) claimSchedulingNumaNode(plugin string) error {
+	if other := o.schedNumaNode; other != "" {
+		return conflict(plugin, other, "scheduling_hints.preferred_numa_node", "scheduling hint preferred NUMA node")
+	}
+	o.schedNumaNode = plugin
+	return nil
+}
+
+func (o *owners) claimSMTPolicy(plugin string) error {
+	if other := o.smtPolicy; other != "" {
+		return conflict(plugin, other, "scheduling_hints.smt_policy", "scheduling hint SMT policy")
+	}
+	o.smtPolicy = plugin
+	return nil
+}
+
+func (o *owners) claimExclusiveCores(plugin string) error {
+	if other := o.exclusiveCores; other != "" {
+		return conflict(plugin, other, "scheduling_hints.exclusive_cores", "scheduling hint exclusive cores")
+	}
+	o.exclusiveCores = plugin
+	return nil
+}
+
+func (o *owners) claimNetworkBandwidth(field, plugin string) error {
+	if o.networkBandwidth == nil {
+		o.networkBandwidth = make(map[string]string)
+	}
+	if other, taken := o.networkBandwidth[field]; taken {
+		return conflict(plugin, other, "linux.network_bandwidth."+field, "network bandwidth", field)
+	}
+	o.networkBandwidth[field] = plugin
+	return nil
+}
+
+func (o *owners) claimWindowsMemLimit(plugin string) error {
+	if other := o.windowsMemLimit; other != "" {
+		return conflict(plugin, other, "windows.resources.memory.limit", "Windows memory limit")
+	}
+	o.windowsMemLimit = plugin
+	return nil
+}
+
+func (o *owners) claimWindowsCpuCount(plugin string) error {
+	if other := o.windowsCpuCount; other != "" {
+		return conflict(plugin, other, "windows.resources.cpu.count", "Windows CPU count")
+	}
+	o.windowsCpuCount = plugin
+	return nil
+}
+
+func (o *owners) claimWindowsCpuShares(plugin string) error {
+	if other := o.windowsCpuShares; other != "" {
+		return conflict(plugin, other, "windows.resources.cpu.shares", "Windows CPU shares")
+	}
+	o.windowsCpuShares = plugin
+	return nil
+}
+
+func (o *owners) claimWindowsCpuMaximum(plugin string) error {
+	if other := o.windowsCpuMaximum; other != "" {
+		return conflict(plugin, other, "windows.resources.cpu.maximum", "Windows CPU maximum")
+	}
+	o.windowsCpuMaximum = plugin
+	return nil
+}
+
+// fields returns the owned fields, by their path in the adjustment.
+func (o *owners) fields() map[string]string {
+	fields := map[string]string{}
+
+	keyed := func(path string, owners map[string]string) {
+		for key, plugin := range owners {
+			fields[path+"["+key+"]"] = plugin
+		}
+	}
+	keyed("annotations", o.annotations)
+	keyed("handler_annotations", o.handlerAnnotations)
+	keyed("mounts", o.mounts)
+	keyed("linux.devices", o.devices)
+	keyed("linux.resources.devices", o.deviceCgroupRules)
+	keyed("cdi_devices", o.cdiDevices)
+	keyed("env", o.env)
+	keyed("env_files", o.envFiles)
+	keyed("linux.resources.hugepage_limits", o.hugepageLimits)
+	keyed("linux.resources.unified", o.unified)
+	keyed("rlimits", o.rlimits)
+	keyed("topology_hints.devices", o.deviceTopologyHints)
+
+	for key, plugin := range o.capabilities {
+		set, name, _ := strings.Cut(key, "/")
+		fields["linux.capabilities."+set+"["+name+"]"] = plugin
+	}
+	for field, plugin := range o.networkBandwidth {
+		fields["linux.network_bandwidth."+field] = plugin
+	}
+
+	for path, plugin := range map[string]string{
+		"linux.resources.memory.limit":              o.memLimit,
+		"linux.resources.memory.reservation":        o.memReservation,
+		"linux.resources.memory.swap":               o.memSwapLimit,
+		"linux.resources.memory.kernel":             o.memKernelLimit,
+		"linux.resources.memory.kernel_tcp":         o.memTCPLimit,
+		"linux.resources.memory.swappiness":         o.memSwappiness,
+		"linux.resources.memory.disable_oom_killer": o.memDisableOomKiller,
+		"linux.resources.memory.use_hierarchy":      o.memUseHierarchy,
+		"linux.resources.cpu.shares":                o.cpuShares,
+		"linux.resources.cpu.quota":                 o.cpuQuota,
+		"linux.resources.cpu.period":                o.cpuPeriod,
+		"linux.resources.cpu.realtime_runtime":      o.cpuRealtimeRuntime,
+		"linux.resources.cpu.realtime_period":       o.cpuRealtimePeriod,
+		"linux.resources.cpu.cpus":                  o.cpusetCpus,
+		"linux.resources.cpu.mems":                  o.cpusetMems,
+		"linux.resources.pids.limit":                o.pidsLimit,
+		"linux.resources.blockio_class":             o.blockioClass,
+		"linux.resources.rdt_class":                 o.rdtClass,
+		"linux.cgroups_path":                        o.cgroupsPath,
+		"linux.oom_score_adj":                       o.oomScoreAdj,
+		"linux.apparmor_profile":                    o.apparmorProfile,
+		"linux.seccomp_policy":                      o.seccompPolicy,
+		"linux.cpu_affinity":                        o.cpuAffinity,
+		"topology_hints.numa_nodes":                 o.topologyNumaNodes,
+		"scheduling_hints.preferred_numa_node":      o.schedNumaNode,
+		"scheduling_hints.smt_policy":               o.smtPolicy,
+		"scheduling_hints.exclusive_cores":          o.exclusiveCores,
+		"windows.resources.memory.limit":            o.windowsMemLimit,
+		"windows.resources.cpu.count":               o.windowsCpuCount,
+		"windows.resources.cpu.shares":              o.windowsCpuShares,
+		"windows.resources.cpu.maximum":             o.windowsCpuMaximum,
+		"user.uid":                                  o.uid,
+		"user.gid":                                  o.gid,
+	} {
+		if plugin != "" {
+			fields[path] = plugin
+		}
+	}
+
+	return fields
+}
+
+func (ro resultOwners) clearAnnotation(id, key string) {
+	ro.ownersFor(id).clearAnnotation(key)
+}
+
+func (ro resultOwners) clearHandlerAnnotation(id, key string) {
+	ro.ownersFor(id).clearHandlerAnnotation(key)
+}
+
+func (ro resultOwners) clearMount(id, destination string) {
+	ro.ownersFor(id).clearMount(destination)
+}
+
+func (ro resultOwners) clearDevice(id, path string) {
+	ro.ownersFor(id).clearDevice(path)
+}
+
+func (ro resultOwners) clearDeviceCgroupRule(id, key string) {
+	ro.ownersFor(id).clearDeviceCgroupRule(key)
+}
+
+func (ro resultOwners) clearEnv(id, name string) {
+	ro.ownersFor(id).clearEnv(name)
+}
+
+func (o *owners) clearAnnotation(key string) {
+	if o.annotations == nil {
+		return
+	}
+	delete(o.annotations, key)
+}
+
+func (o *owners) clearHandlerAnnotation(key string) {
+	if o.handlerAnnotations == nil {
+		return
+	}
+	delete(o.handlerAnnotations, key)
+}
+
+func (o *owners) clearMount(destination string) {
+	if o.mounts == nil {
+		return
+	}
+	delete(o.mounts, destination)
+}
+
+func (o *owners) clearDevice(path string) {
+	if o.devices == nil {
+		return
+	}
+	delete(o.devices, path)
+}
+
+func (o *owners) clearDeviceCgroupRule(key string) {
+	if o.deviceCgroupRules == nil {
+		return
+	}
+	delete(o.deviceCgroupRules, key)
+}
+
+func (o *owners) clearEnv(name string) {
+	if o.env == nil {
+		return
+	}
+	delete(o.env, name)
+}
@@ -0,0 +1,2341 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/api/merge"
+)
+
+func TestMerge(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Merge Suite")
+}
+
+// reply is the response of a single plugin.
+type reply struct {
+	plugin   string
+	response interface{}
+}
+
+// rejection is the expected rejection of a response, if any.
+type rejection struct {
+	rule   string
+	plugin string
+	other  string
+}
+
+type createCase struct {
+	options   []merge.Option
+	pod       *api.PodSandbox
+	replies   []reply
+	rejection *rejection
+	check     func(*api.CreateContainerRequest, *api.CreateContainerResponse)
+}
+
+type updateCase struct {
+	options   []merge.Option
+	replies   []reply
+	rejection *rejection
+	check     func(*api.UpdateContainerRequest, *api.UpdateContainerResponse)
+}
+
+type stopCase struct {
+	replies   []reply
+	rejection *rejection
+	check     func(*api.StopContainerResponse)
+}
+
+func adjust(plugin string, fn func(*api.ContainerAdjustment)) reply {
+	a := &api.ContainerAdjustment{}
+	fn(a)
+	return reply{
+		plugin:   plugin,
+		response: &api.CreateContainerResponse{Adjust: a},
+	}
+}
+
+func createUpdate(plugin string, updates ...*api.ContainerUpdate) reply {
+	return reply{
+		plugin:   plugin,
+		response: &api.CreateContainerResponse{Update: updates},
+	}
+}
+
+func update(plugin string, updates ...*api.ContainerUpdate) reply {
+	return reply{
+		plugin:   plugin,
+		response: &api.UpdateContainerResponse{Update: updates},
+	}
+}
+
+func stopUpdate(plugin string, updates ...*api.ContainerUpdate) reply {
+	return reply{
+		plugin:   plugin,
+		response: &api.StopContainerResponse{Update: updates},
+	}
+}
+
+func containerUpdate(id string, fn func(*api.ContainerUpdate)) *api.ContainerUpdate {
+	u := &api.ContainerUpdate{}
+	u.SetContainerId(id)
+	fn(u)
+	return u
+}
+
+func conflict(plugin, other string) *rejection {
+	return &rejection{rule: merge.ConflictRule, plugin: plugin, other: other}
+}
+
+func rejected(rule, plugin string) *rejection {
+	return &rejection{rule: rule, plugin: plugin}
+}
+
+func apply(result *merge.Result, replies []reply) error {
+	for _, r := range replies {
+		if err := result.Apply(r.response, r.plugin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRejection(err error, expected *rejection) {
+	if expected == nil {
+		Expect(err).ToNot(HaveOccurred())
+		return
+	}
+
+	var rejErr *merge.RejectedError
+	Expect(errors.As(err, &rejErr)).To(BeTrue(), "expected RejectedError, got %v", err)
+	Expect(rejErr.Rule).To(Equal(expected.rule))
+	Expect(rejErr.Plugin).To(Equal(expected.plugin))
+	Expect(rejErr.Other).To(Equal(expected.other))
+}
+
+func createRequest(pod *api.PodSandbox) *api.CreateContainerRequest {
+	if pod == nil {
+		pod = &api.PodSandbox{
+			Id:   "pod0",
+			Name: "pod0",
+			Uid:  "uid0",
+		}
+	}
+	return &api.CreateContainerRequest{
+		Pod: pod,
+		Container: &api.Container{
+			Id:           "ctr0",
+			PodSandboxId: pod.Id,
+			Name:         "ctr0",
+			Annotations: map[string]string{
+				"existing": "value",
+			},
+			Env: []string{
+				"FOO=foo",
+			},
+			Mounts: []*api.Mount{
+				{
+					Destination: "/data",
+					Source:      "/host/data",
+					Type:        "bind",
+				},
+			},
+		},
+	}
+}
+
+func updateRequest() *api.UpdateContainerRequest {
+	return &api.UpdateContainerRequest{
+		Pod: &api.PodSandbox{
+			Id:   "pod0",
+			Name: "pod0",
+			Uid:  "uid0",
+		},
+		Container: &api.Container{
+			Id:           "ctr0",
+			PodSandboxId: "pod0",
+			Name:         "ctr0",
+		},
+		LinuxResources: &api.LinuxResources{
+			Memory: &api.LinuxMemory{
+				Limit: api.Int64(1024),
+			},
+		},
+	}
+}
+
+var (
+	major = int64(1)
+	minor = int64(3)
+
+	topology = &api.Topology{
+		CPUs: api.CPUSet{0: {}, 1: {}, 2: {}, 3: {}},
+		Mems: api.CPUSet{0: {}},
+	}
+)
+
+func deviceRules(rules []*api.LinuxDeviceCgroup) []string {
+	out := []string{}
+	for _, d := range rules {
+		switch {
+		case d.Remove:
+			out = append(out, "remove "+d.Key())
+		case d.Allow:
+			out = append(out, "allow "+d.Key()+" "+d.Access)
+		default:
+			out = append(out, "deny "+d.Key()+" "+d.Access)
+		}
+	}
+	return out
+}
+
+func mount(destination, source string) *api.Mount {
+	return &api.Mount{
+		Destination: destination,
+		Source:      source,
+		Type:        "bind",
+	}
+}
+
+func device(path string) *api.LinuxDevice {
+	return &api.LinuxDevice{
+		Path:  path,
+		Type:  "c",
+		Major: 1,
+		Minor: 3,
+	}
+}
+
+func envKeys(env []*api.KeyValue) []string {
+	keys := []string{}
+	for _, e := range env {
+		keys = append(keys, e.Key+"="+e.Value)
+	}
+	return keys
+}
+
+func mountDestinations(mounts []*api.Mount) []string {
+	dests := []string{}
+	for _, m := range mounts {
+		dests = append(dests, m.Destination+":"+m.Source)
+	}
+	return dests
+}
+
+// takeMax resolves conflicts by taking the larger of numeric values.
+func takeMax(c *merge.Conflict) merge.Resolution {
+	switch current := c.Current.(type) {
+	case int64:
+		if c.Proposed.(int64) > current {
+			return merge.UseProposed
+		}
+		return merge.KeepCurrent
+	case uint64:
+		if c.Proposed.(uint64) > current {
+			return merge.UseProposed
+		}
+		return merge.KeepCurrent
+	}
+	return merge.RejectConflict
+}
+
+// always resolves all conflicts the same way.
+func always(resolution merge.Resolution) merge.ConflictResolver {
+	return func(*merge.Conflict) merge.Resolution {
+		return resolution
+	}
+}
+
+var _ = Describe("CreateContainer result", func() {
+	DescribeTable("collecting plugin responses",
+		func(tc createCase) {
+			req := createRequest(tc.pod)
+			result := merge.NewCreateContainerResult(req, tc.options...)
+
+			err := apply(result, tc.replies)
+			checkRejection(err, tc.rejection)
+			if err == nil && tc.check != nil {
+				tc.check(req, result.CreateContainerResponse())
+			}
+		},
+
+		Entry("no adjustments", createCase{
+			replies: []reply{
+				{plugin: "p1", response: (*api.CreateContainerResponse)(nil)},
+				{plugin: "p2", response: &api.CreateContainerResponse{}},
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(BeEmpty())
+				Expect(rsp.Adjust.Mounts).To(BeEmpty())
+				Expect(rsp.Adjust.Env).To(BeEmpty())
+				Expect(rsp.Update).To(BeEmpty())
+			},
+		}),
+
+		Entry("annotations", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key1", "value1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddAnnotation("key2", "value2") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(Equal(map[string]string{
+					"key1": "value1",
+					"key2": "value2",
+				}))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("key1", "value1"))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("key2", "value2"))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("existing", "value"))
+			},
+		}),
+
+		Entry("conflicting annotations", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value2") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("annotation removed, then set by another plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.RemoveAnnotation("key")
+					a.AddAnnotation("key", "value2")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(Equal(map[string]string{
+					api.MarkForRemoval("key"): "",
+					"key":                     "value2",
+				}))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("key", "value2"))
+			},
+		}),
+
+		Entry("annotation removed", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.RemoveAnnotation("existing") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(Equal(map[string]string{
+					api.MarkForRemoval("existing"): "",
+				}))
+			},
+		}),
+
+		Entry("mounts", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt1", "/host/mnt1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt2", "/host/mnt2")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(mountDestinations(rsp.Adjust.Mounts)).To(Equal([]string{
+					"/mnt1:/host/mnt1",
+					"/mnt2:/host/mnt2",
+				}))
+				Expect(mountDestinations(req.Container.Mounts)).To(Equal([]string{
+					"/data:/host/data",
+					"/mnt1:/host/mnt1",
+					"/mnt2:/host/mnt2",
+				}))
+			},
+		}),
+
+		Entry("mount replacing an existing one", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/data", "/host/other")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(mountDestinations(req.Container.Mounts)).To(Equal([]string{
+					"/data:/host/other",
+				}))
+			},
+		}),
+
+		Entry("conflicting mounts", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt2")) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("idmapped mount with propagation", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					m := mount("/mnt", "/host/mnt")
+					m.Propagation = "rslave"
+					ids := []*api.LinuxIDMapping{{ContainerId: 0, HostId: 1000, Size: 1}}
+					a.AddMountWithIDMapping(m, ids, ids)
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Mounts).To(HaveLen(1))
+				Expect(rsp.Adjust.Mounts[0].Propagation).To(Equal("rslave"))
+				Expect(rsp.Adjust.Mounts[0].UidMappings[0].HostId).To(Equal(uint32(1000)))
+			},
+		}),
+
+		Entry("mount with invalid propagation", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					m := mount("/mnt", "/host/mnt")
+					m.Propagation = "rprivat"
+					a.AddMount(m)
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("idmapped mount without GID mappings", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					ids := []*api.LinuxIDMapping{{ContainerId: 0, HostId: 1000, Size: 1}}
+					a.AddMountWithIDMapping(mount("/mnt", "/host/mnt"), ids, nil)
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("mount removed, then added by another plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.RemoveMount("/mnt")
+					a.AddMount(mount("/mnt", "/host/mnt2"))
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(mountDestinations(rsp.Adjust.Mounts)).To(Equal([]string{
+					"/mnt:/host/mnt2",
+				}))
+			},
+		}),
+
+		Entry("mount removed", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.RemoveMount("/data") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(req.Container.Mounts).To(BeEmpty())
+				Expect(rsp.Adjust.Mounts).To(HaveLen(1))
+				key, marked := rsp.Adjust.Mounts[0].IsMarkedForRemoval()
+				Expect(marked).To(BeTrue())
+				Expect(key).To(Equal("/data"))
+			},
+		}),
+
+		Entry("mount removed by its remove field", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddMount(&api.Mount{Destination: "/data", Remove: true})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(req.Container.Mounts).To(BeEmpty())
+				Expect(rsp.Adjust.Mounts).To(HaveLen(1))
+				key, marked := rsp.Adjust.Mounts[0].IsMarkedForRemoval()
+				Expect(marked).To(BeTrue())
+				Expect(key).To(Equal("/data"))
+			},
+		}),
+
+		Entry("device cgroup rules", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rw")
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddLinuxDeviceCgroupRule(false, "b", &major, nil, "m")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(deviceRules(rsp.Adjust.Linux.Resources.Devices)).To(Equal([]string{
+					"allow c 1:3 rw", "deny b 1:* m",
+				}))
+				Expect(deviceRules(req.Container.Linux.Resources.Devices)).To(Equal([]string{
+					"allow c 1:3 rw", "deny b 1:* m",
+				}))
+			},
+		}),
+
+		Entry("conflicting device cgroup rules", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rw")
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddLinuxDeviceCgroupRule(false, "c", &major, &minor, "rw")
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("device cgroup rule removed, then added by another plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rw")
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.RemoveLinuxDeviceCgroupRule("c", &major, &minor)
+					a.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "r")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(deviceRules(rsp.Adjust.Linux.Resources.Devices)).To(Equal([]string{
+					"allow c 1:3 r",
+				}))
+			},
+		}),
+
+		Entry("device cgroup rule removed", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.RemoveLinuxDeviceCgroupRule("c", &major, &minor)
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(deviceRules(rsp.Adjust.Linux.Resources.Devices)).To(Equal([]string{
+					"remove c 1:3",
+				}))
+				Expect(deviceRules(req.Container.Linux.Resources.Devices)).To(BeEmpty())
+			},
+		}),
+
+		Entry("environment variables", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnv("BAR", "bar") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddEnv("FOO", "xyzzy") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(envKeys(rsp.Adjust.Env)).To(Equal([]string{"BAR=bar", "FOO=xyzzy"}))
+				Expect(req.Container.Env).To(Equal([]string{"BAR=bar", "FOO=xyzzy"}))
+			},
+		}),
+
+		Entry("conflicting environment variables", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnv("BAR", "bar1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddEnv("BAR", "bar2") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("environment variable removed, then set by another plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnv("BAR", "bar1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.RemoveEnv("BAR")
+					a.AddEnv("BAR", "bar2")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(envKeys(rsp.Adjust.Env)).To(Equal([]string{"BAR=bar2"}))
+				Expect(req.Container.Env).To(Equal([]string{"FOO=foo", "BAR=bar2"}))
+			},
+		}),
+
+		Entry("environment variable removed by its remove field", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.Env = append(a.Env, &api.KeyValue{Key: "FOO", Remove: true})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(req.Container.Env).To(BeEmpty())
+			},
+		}),
+
+		Entry("OCI hooks never conflict", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddHooks(&api.Hooks{Prestart: []*api.Hook{{Path: "/bin/hook1"}}})
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddHooks(&api.Hooks{Prestart: []*api.Hook{{Path: "/bin/hook2"}}})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Hooks.Prestart).To(HaveLen(2))
+				Expect(rsp.Adjust.Hooks.Prestart[0].Path).To(Equal("/bin/hook1"))
+				Expect(rsp.Adjust.Hooks.Prestart[1].Path).To(Equal("/bin/hook2"))
+				Expect(req.Container.Hooks.Prestart).To(HaveLen(2))
+			},
+		}),
+
+		Entry("devices", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddDevice(device("/dev/dev1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddDevice(device("/dev/dev2")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Devices).To(HaveLen(2))
+				Expect(req.Container.Linux.Devices).To(HaveLen(2))
+			},
+		}),
+
+		Entry("conflicting devices", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddDevice(device("/dev/dev1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddDevice(device("/dev/dev1")) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("device removed, then added by another plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddDevice(device("/dev/dev1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.RemoveDevice("/dev/dev1")
+					a.AddDevice(device("/dev/dev1"))
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Devices).To(HaveLen(1))
+				Expect(req.Container.Linux.Devices).To(HaveLen(1))
+			},
+		}),
+
+		Entry("CDI devices", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/dev=one"})
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/dev=two"})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.CDIDevices).To(HaveLen(2))
+			},
+		}),
+
+		Entry("conflicting CDI devices", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/dev=one"})
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddCDIDevice(&api.CDIDevice{Name: "vendor.com/dev=one"})
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("environment files", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddMount(mount("/run/secrets", "/host/secrets"))
+					a.AddEnvFile("/run/secrets/env", false)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddEnvFile("/run/secrets/more.env", true)
+					a.AddEnvFile("/data/env", false)
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.EnvFiles).To(HaveLen(3))
+				Expect(rsp.Adjust.EnvFiles[1].Optional).To(BeTrue())
+			},
+		}),
+
+		Entry("conflicting environment files", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnvFile("/data/env", false) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddEnvFile("/data/env", true) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("environment file outside of mounts", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnvFile("/etc/env", false) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("environment file with an unclean path", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnvFile("/data/../etc/env", false) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("AppArmor profile", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxApparmorProfile("custom") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.ApparmorProfile).To(Equal("custom"))
+				Expect(req.Container.Linux.ApparmorProfile).To(Equal("custom"))
+			},
+		}),
+
+		Entry("conflicting AppArmor profiles", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxApparmorProfile("custom") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxApparmorProfile("unconfined") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("seccomp policy", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxSeccompPolicy(&api.LinuxSeccomp{DefaultAction: "SCMP_ACT_LOG"})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.SeccompPolicy.DefaultAction).To(Equal("SCMP_ACT_LOG"))
+				Expect(req.Container.Linux.SeccompPolicy.DefaultAction).To(Equal("SCMP_ACT_LOG"))
+			},
+		}),
+
+		Entry("conflicting seccomp policies", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxSeccompPolicy(&api.LinuxSeccomp{DefaultAction: "SCMP_ACT_LOG"})
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.SetLinuxSeccompPolicy(&api.LinuxSeccomp{DefaultAction: "SCMP_ACT_ALLOW"})
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("CPU affinity", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("0-1") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.CpuAffinity).To(Equal("0-1"))
+				Expect(req.Container.Linux.CpuAffinity).To(Equal("0-1"))
+			},
+		}),
+
+		Entry("conflicting CPU affinities", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("0-1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("2-3") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("invalid CPU affinity", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("3-1") }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("CPU affinity within cpuset CPUs", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-3") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("1-2") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("0-3"))
+				Expect(rsp.Adjust.Linux.CpuAffinity).To(Equal("1-2"))
+			},
+		}),
+
+		Entry("CPU affinity outside of cpuset CPUs", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("1-2") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("cpuset CPUs excluding CPU affinity", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUAffinity("2-3") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-1") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("contradictory CPU affinity and cpuset CPUs of a single plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxCPUSetCPUs("0-1")
+					a.SetLinuxCPUAffinity("2")
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("network bandwidth", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxNetworkIngressBandwidth(10_000_000, 65536) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxNetworkEgressBandwidth(5_000_000, 0) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				b := rsp.Adjust.Linux.NetworkBandwidth
+				Expect(b.IngressRate.GetValue()).To(Equal(uint64(10_000_000)))
+				Expect(b.IngressBurst.GetValue()).To(Equal(uint64(65536)))
+				Expect(b.EgressRate.GetValue()).To(Equal(uint64(5_000_000)))
+				Expect(b.EgressBurst).To(BeNil())
+				Expect(req.Container.Linux.NetworkBandwidth.EgressRate.GetValue()).To(Equal(uint64(5_000_000)))
+			},
+		}),
+
+		Entry("conflicting network bandwidth", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxNetworkIngressBandwidth(10_000_000, 0) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxNetworkIngressBandwidth(20_000_000, 0) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("invalid network bandwidth", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxNetworkBandwidth(&api.LinuxNetworkBandwidth{EgressBurst: api.UInt64(uint64(1024))})
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("scheduling hints", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetSchedulingHintNUMANode(1)
+					a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_FULL_CORES)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetSchedulingHintExclusiveCores(true) }),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				hints := rsp.Adjust.SchedulingHints
+				node, ok := hints.PreferredNUMANode()
+				Expect(ok).To(BeTrue())
+				Expect(node).To(Equal(uint32(1)))
+				Expect(hints.SmtPolicy).To(Equal(api.SMTPolicy_SMT_POLICY_FULL_CORES))
+				Expect(hints.WantsExclusiveCores()).To(BeTrue())
+			},
+		}),
+
+		Entry("conflicting scheduling hint SMT policies", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_FULL_CORES)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_SINGLE_THREAD)
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting scheduling hint preferred NUMA nodes", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetSchedulingHintNUMANode(0) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetSchedulingHintNUMANode(1) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("invalid scheduling hint SMT policy", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetSchedulingHintSMTPolicy(api.SMTPolicy(42)) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("exclusive cores with shared SMT siblings", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_SHARED)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetSchedulingHintExclusiveCores(true) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("exclusive cores with shared SMT siblings of a single plugin", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetSchedulingHintSMTPolicy(api.SMTPolicy_SMT_POLICY_SHARED)
+					a.SetSchedulingHintExclusiveCores(true)
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("preferred NUMA node among topology hint NUMA nodes", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetTopologyHintNUMANodes(0, 1) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetSchedulingHintNUMANode(1) }),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.SchedulingHints.PreferredNumaNode.GetValue()).To(Equal(uint32(1)))
+			},
+		}),
+
+		Entry("preferred NUMA node not among topology hint NUMA nodes", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetSchedulingHintNUMANode(2) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetTopologyHintNUMANodes(0, 1) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("user and groups", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetUser(1000)
+					a.AddAdditionalGid(10)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.SetGroup(1000)
+					a.AddAdditionalGid(10)
+					a.AddAdditionalGid(20)
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.User.Uid.GetValue()).To(Equal(uint32(1000)))
+				Expect(rsp.Adjust.User.Gid.GetValue()).To(Equal(uint32(1000)))
+				Expect(rsp.Adjust.User.AdditionalGids).To(Equal([]uint32{10, 20}))
+				Expect(req.Container.User.Uid.GetValue()).To(Equal(uint32(1000)))
+				Expect(req.Container.User.AdditionalGids).To(Equal([]uint32{10, 20}))
+			},
+		}),
+
+		Entry("conflicting users", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetUser(1000) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetUser(2000) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting groups", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetGroup(1000) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetGroup(2000) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("non-root user with root rejected", createCase{
+			options: []merge.Option{
+				merge.WithUserPolicy(&merge.UserPolicy{RejectRoot: true}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetUser(1000)
+					a.SetGroup(1000)
+				}),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.User.Uid.GetValue()).To(Equal(uint32(1000)))
+			},
+		}),
+
+		Entry("root user with root rejected", createCase{
+			options: []merge.Option{
+				merge.WithUserPolicy(&merge.UserPolicy{RejectRoot: true}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetUser(0) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("root additional group with root rejected", createCase{
+			options: []merge.Option{
+				merge.WithUserPolicy(&merge.UserPolicy{RejectRoot: true}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAdditionalGid(0) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("user with user changes rejected", createCase{
+			options: []merge.Option{
+				merge.WithUserPolicy(&merge.UserPolicy{RejectChanges: true}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetUser(1000) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("resources from different plugins", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.SetLinuxCPUShares(512)
+					a.SetLinuxCPUSetCPUs("0-1")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Shares.GetValue()).To(Equal(uint64(512)))
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("0-1"))
+				Expect(req.Container.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+				Expect(req.Container.Linux.Resources.Cpu.Cpus).To(Equal("0-1"))
+			},
+		}),
+
+		Entry("conflicting memory limits", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting cpusets", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("1") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting hugepage limits", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting hugepage limits with different page size spellings", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddLinuxHugepageLimit("2MB", 1024)
+					a.Linux.Resources.HugepageLimits[0].PageSize = "2MiB"
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddLinuxHugepageLimit("2MB", 2048)
+					a.Linux.Resources.HugepageLimits[0].PageSize = "2048kB"
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("normalized hugepage page sizes", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddLinuxHugepageLimit("2MiB", 1024)
+					a.AddLinuxHugepageLimit("2048kB", 2048)
+					a.Linux.Resources.HugepageLimits = append(a.Linux.Resources.HugepageLimits,
+						&api.HugepageLimit{PageSize: "1GiB", Limit: 1 << 30})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits).To(HaveLen(2))
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits[0].PageSize).To(Equal("2MB"))
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits[0].Limit).To(Equal(uint64(2048)))
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits[1].PageSize).To(Equal("1GB"))
+			},
+		}),
+
+		Entry("invalid hugepage page size", createCase{
+			options: []merge.Option{
+				merge.WithHugepageSizes([]string{"2MB", "1GB"}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2iB", 1024) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("unvalidated invalid hugepage page size", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2M", 1024) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits).To(HaveLen(1))
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits[0].PageSize).To(Equal("2M"))
+			},
+		}),
+
+		Entry("unsupported hugepage page size", createCase{
+			options: []merge.Option{
+				merge.WithHugepageSizes([]string{"2MB", "1GB"}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("1GiB", 1<<30) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("64KB", 1024) }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p2"),
+		}),
+
+		Entry("conflicting unified resources", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxUnified("memory.high", "1024") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddLinuxUnified("memory.high", "2048") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting cgroups paths", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCgroupsPath("/a") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCgroupsPath("/b") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting OOM score adjustments", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { v := 100; a.SetLinuxOomScoreAdj(&v) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { v := 200; a.SetLinuxOomScoreAdj(&v) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("rlimits of different types", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddRlimit("RLIMIT_NOFILE", 1024, 512) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddRlimit("RLIMIT_NPROC", 256, 128) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Rlimits).To(HaveLen(2))
+				Expect(req.Container.Rlimits).To(HaveLen(2))
+			},
+		}),
+
+		Entry("conflicting rlimits", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddRlimit("RLIMIT_NOFILE", 1024, 512) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddRlimit("RLIMIT_NOFILE", 2048, 512) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("topology hints", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetTopologyHintNUMANodes(0, 1) }),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddDeviceTopologyHint(&api.DeviceTopologyHint{Device: "/dev/dev1", NumaNodes: []uint32{1}})
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.TopologyHints.NumaNodes).To(Equal([]uint32{0, 1}))
+				Expect(rsp.Adjust.TopologyHints.Devices).To(HaveLen(1))
+			},
+		}),
+
+		Entry("conflicting NUMA node topology hints", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetTopologyHintNUMANodes(0) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetTopologyHintNUMANodes(1) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicting device topology hints", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddDeviceTopologyHint(&api.DeviceTopologyHint{Device: "/dev/dev1", NumaNodes: []uint32{0}})
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddDeviceTopologyHint(&api.DeviceTopologyHint{Device: "/dev/dev1", NumaNodes: []uint32{1}})
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("device topology hint without a device", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddDeviceTopologyHint(&api.DeviceTopologyHint{NumaNodes: []uint32{0}})
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("allowed handler annotations", createCase{
+			options: []merge.Option{
+				merge.WithHandlerAnnotationPrefixes([]string{"io.katacontainers."}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddHandlerAnnotation("io.katacontainers.config.hypervisor.kernel_params", "quiet")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.HandlerAnnotations).To(Equal(map[string]string{
+					"io.katacontainers.config.hypervisor.kernel_params": "quiet",
+				}))
+			},
+		}),
+
+		Entry("handler annotations not allowed by prefix", createCase{
+			options: []merge.Option{
+				merge.WithHandlerAnnotationPrefixes([]string{"io.katacontainers."}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddHandlerAnnotation("io.other.key", "value")
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("conflicting handler annotations", createCase{
+			options: []merge.Option{
+				merge.WithHandlerAnnotationPrefixes([]string{"io.katacontainers."}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddHandlerAnnotation("io.katacontainers.key", "value1")
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddHandlerAnnotation("io.katacontainers.key", "value2")
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("Windows resources", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetWindowsMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetWindowsCPUCount(2) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Windows.Resources.Memory.Limit.GetValue()).To(Equal(uint64(1024)))
+				Expect(rsp.Adjust.Windows.Resources.Cpu.Count.GetValue()).To(Equal(uint64(2)))
+				Expect(req.Container.Windows.Resources.Memory.Limit.GetValue()).To(Equal(uint64(1024)))
+			},
+		}),
+
+		Entry("conflicting Windows resources", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetWindowsMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetWindowsMemoryLimit(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("capabilities in different sets", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddCapability("NET_ADMIN", api.CapabilityBounding)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.AddCapability("NET_ADMIN", api.CapabilityEffective)
+					a.DropCapability("SYS_ADMIN", api.CapabilityBounding)
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				caps := rsp.Adjust.Linux.Capabilities
+				Expect(caps.Bounding).To(Equal([]string{
+					"CAP_NET_ADMIN",
+					api.MarkForRemoval("CAP_SYS_ADMIN"),
+				}))
+				Expect(caps.Effective).To(Equal([]string{"CAP_NET_ADMIN"}))
+				Expect(req.Container.Linux.Capabilities.Bounding).To(Equal([]string{"CAP_NET_ADMIN"}))
+			},
+		}),
+
+		Entry("conflicting capabilities", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddCapability("NET_ADMIN", api.CapabilityBounding)
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.DropCapability("NET_ADMIN", api.CapabilityBounding)
+				}),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("annotation key exceeding limits", createCase{
+			options: []merge.Option{
+				merge.WithAnnotationLimits(&api.AnnotationLimits{MaxKeyLength: 4}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("toolong", "value") }),
+			},
+			rejection: rejected(merge.AnnotationLimitRule, "p1"),
+		}),
+
+		Entry("annotation value exceeding limits", createCase{
+			options: []merge.Option{
+				merge.WithAnnotationLimits(&api.AnnotationLimits{MaxValueLength: 4}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "toolong") }),
+			},
+			rejection: rejected(merge.AnnotationLimitRule, "p1"),
+		}),
+
+		Entry("annotation value truncated to limits", createCase{
+			options: []merge.Option{
+				merge.WithAnnotationLimits(&api.AnnotationLimits{
+					MaxValueLength: 4,
+					Policy:         api.AnnotationLimitPolicy_ANNOTATION_LIMIT_TRUNCATE,
+				}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "toolong") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(HaveKeyWithValue("key", "tool"))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("key", "tool"))
+			},
+		}),
+
+		Entry("annotations exceeding count limit", createCase{
+			options: []merge.Option{
+				merge.WithAnnotationLimits(&api.AnnotationLimits{MaxCount: 1}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key1", "value") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddAnnotation("key2", "value") }),
+			},
+			rejection: rejected(merge.AnnotationLimitRule, "p2"),
+		}),
+
+		Entry("cpuset within topology", createCase{
+			options: []merge.Option{
+				merge.WithTopology(topology),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxCPUSetCPUs("0-1,3")
+					a.SetLinuxCPUSetMems("0")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("0-1,3"))
+				Expect(req.Container.Linux.Resources.Cpu.Mems).To(Equal("0"))
+			},
+		}),
+
+		Entry("cpuset CPUs outside topology", createCase{
+			options: []merge.Option{
+				merge.WithTopology(topology),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("2-5") }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("cpuset memory nodes outside topology", createCase{
+			options: []merge.Option{
+				merge.WithTopology(topology),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetMems("1") }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("malformed cpuset CPUs", createCase{
+			options: []merge.Option{
+				merge.WithTopology(topology),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("3-1") }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "p1"),
+		}),
+
+		Entry("cpuset outside topology without validation", createCase{
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("2-5") }),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("2-5"))
+			},
+		}),
+
+		Entry("unsupported adjustments kept", createCase{
+			pod: &api.PodSandbox{
+				Id:             "pod0",
+				Name:           "pod0",
+				RuntimeHandler: "vm",
+				RuntimeHandlerCapabilities: &api.RuntimeHandlerCapabilities{
+					UnsupportedAdjustments: []string{api.MountsAdjustment},
+				},
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Mounts).To(HaveLen(1))
+			},
+		}),
+
+		Entry("unsupported adjustments dropped", createCase{
+			options: []merge.Option{
+				merge.WithDroppedUnsupportedAdjustments(),
+			},
+			pod: &api.PodSandbox{
+				Id:             "pod0",
+				Name:           "pod0",
+				RuntimeHandler: "vm",
+				RuntimeHandlerCapabilities: &api.RuntimeHandlerCapabilities{
+					UnsupportedAdjustments: []string{api.MountsAdjustment},
+				},
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.AddMount(mount("/mnt", "/host/mnt"))
+					a.AddEnv("BAR", "bar")
+				}),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Mounts).To(BeEmpty())
+				Expect(envKeys(rsp.Adjust.Env)).To(Equal([]string{"BAR=bar"}))
+			},
+		}),
+
+		Entry("adjustments allowed by plugin policies", createCase{
+			options: []merge.Option{
+				merge.WithAdjustmentPolicy("foo", &merge.AdjustmentPolicy{
+					Allow: []string{api.EnvAdjustment, api.AnnotationsAdjustment},
+				}),
+				merge.WithAdjustmentPolicy("10-bar", &merge.AdjustmentPolicy{
+					Deny: []string{api.HooksAdjustment},
+				}),
+			},
+			replies: []reply{
+				adjust("00-foo", func(a *api.ContainerAdjustment) {
+					a.AddEnv("FOO", "foo")
+					a.AddAnnotation("foo", "foo")
+				}),
+				adjust("10-bar", func(a *api.ContainerAdjustment) {
+					a.AddDevice(&api.LinuxDevice{Path: "/dev/bar", Type: "c", Major: 1, Minor: 3})
+				}),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(envKeys(rsp.Adjust.Env)).To(Equal([]string{"FOO=foo"}))
+				Expect(rsp.Adjust.Linux.Devices).To(HaveLen(1))
+			},
+		}),
+
+		Entry("adjustments outside the allowlist of a plugin", createCase{
+			options: []merge.Option{
+				merge.WithAdjustmentPolicy("foo", &merge.AdjustmentPolicy{
+					Allow: []string{api.EnvAdjustment, api.AnnotationsAdjustment},
+				}),
+			},
+			replies: []reply{
+				adjust("00-foo", func(a *api.ContainerAdjustment) {
+					a.AddEnv("FOO", "foo")
+					a.AddDevice(&api.LinuxDevice{Path: "/dev/foo", Type: "c", Major: 1, Minor: 3})
+				}),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "00-foo"),
+		}),
+
+		Entry("adjustments on the denylist of a plugin", createCase{
+			options: []merge.Option{
+				merge.WithAdjustmentPolicy("foo", &merge.AdjustmentPolicy{
+					Deny: []string{api.HooksAdjustment},
+				}),
+				merge.WithAdjustmentPolicy("00-foo", &merge.AdjustmentPolicy{
+					Deny: []string{api.EnvAdjustment},
+				}),
+			},
+			replies: []reply{
+				adjust("00-foo", func(a *api.ContainerAdjustment) { a.AddEnv("FOO", "foo") }),
+			},
+			rejection: rejected(merge.InvalidAdjustmentRule, "00-foo"),
+		}),
+
+		Entry("updates of other containers", createCase{
+			replies: []reply{
+				createUpdate("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(1024)
+				})),
+				createUpdate("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUShares(512)
+				})),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(1))
+				Expect(rsp.Update[0].ContainerId).To(Equal("ctr1"))
+				Expect(rsp.Update[0].Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+				Expect(rsp.Update[0].Linux.Resources.Cpu.Shares.GetValue()).To(Equal(uint64(512)))
+			},
+		}),
+
+		Entry("conflicting updates of other containers", createCase{
+			replies: []reply{
+				createUpdate("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(1024)
+				})),
+				createUpdate("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("update of the container being created", createCase{
+			replies: []reply{
+				createUpdate("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(1024)
+				})),
+			},
+			rejection: rejected(merge.InvalidUpdateRule, "p1"),
+		}),
+
+		Entry("memory limit conflicts resolved by taking the maximum", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, takeMax),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(4096) }),
+				adjust("p3", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+				Expect(req.Container.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+			},
+		}),
+
+		Entry("hugepage limit conflicts resolved by taking the maximum", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, takeMax),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 4096) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits).To(HaveLen(1))
+				Expect(rsp.Adjust.Linux.Resources.HugepageLimits[0].Limit).To(Equal(uint64(4096)))
+			},
+		}),
+
+		Entry("annotation conflicts resolved by keeping the current value", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.AnnotationsClass, always(merge.KeepCurrent)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value2") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Annotations).To(Equal(map[string]string{"key": "value1"}))
+				Expect(req.Container.Annotations).To(HaveKeyWithValue("key", "value1"))
+			},
+		}),
+
+		Entry("mount conflicts resolved by using the proposed value", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.MountsClass, always(merge.UseProposed)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt2")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(mountDestinations(rsp.Adjust.Mounts)).To(Equal([]string{"/mnt:/host/mnt2"}))
+				Expect(mountDestinations(req.Container.Mounts)).To(Equal([]string{
+					"/data:/host/data",
+					"/mnt:/host/mnt2",
+				}))
+			},
+		}),
+
+		Entry("mount conflicts resolved by keeping the current value", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.MountsClass, always(merge.KeepCurrent)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt1")) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddMount(mount("/mnt", "/host/mnt2")) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(mountDestinations(rsp.Adjust.Mounts)).To(Equal([]string{"/mnt:/host/mnt1"}))
+				Expect(mountDestinations(req.Container.Mounts)).To(Equal([]string{
+					"/data:/host/data",
+					"/mnt:/host/mnt1",
+				}))
+			},
+		}),
+
+		Entry("conflicts rejected by a resolver", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.AnnotationsClass, always(merge.RejectConflict)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddAnnotation("key", "value2") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicts outside the field class of a resolver", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.AnnotationsClass, always(merge.UseProposed)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("memory limit conflicts resolved by the maximum value strategy", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.MaxValue()),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(4096) }),
+				adjust("p3", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+				Expect(req.Container.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+			},
+		}),
+
+		Entry("CPU shares conflicts resolved by the minimum value strategy", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.MinValue()),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUShares(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUShares(512) }),
+				adjust("p3", func(a *api.ContainerAdjustment) { a.SetLinuxCPUShares(2048) }),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Shares.GetValue()).To(Equal(uint64(512)))
+			},
+		}),
+
+		Entry("non-numeric conflicts rejected by the maximum value strategy", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.MaxValue()),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("2-3") }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("cpuset conflicts merged into their union", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.CPUSetUnion()),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-1") }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("4") }),
+				adjust("p3", func(a *api.ContainerAdjustment) {
+					a.SetLinuxCPUSetCPUs("2")
+					a.SetLinuxCPUSetMems("1")
+				}),
+				adjust("p4", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetMems("0") }),
+			},
+			check: func(req *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("0-2,4"))
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Mems).To(Equal("0-1"))
+				Expect(req.Container.Linux.Resources.Cpu.Cpus).To(Equal("0-2,4"))
+			},
+		}),
+
+		Entry("conflicts resolved by plugin priority", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.PluginPriority("p2", "p1")),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+				adjust("p3", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(4096) }),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(2048)))
+			},
+		}),
+
+		Entry("conflicts of plugins without priority", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.PluginPriority("p3")),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("conflicts resolved by per-field strategies", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.ByField(map[string]merge.ConflictResolver{
+					"linux.resources.memory.limit": merge.MaxValue(),
+					"linux.resources.cpu.cpus":     merge.CPUSetUnion(),
+				})),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxMemoryLimit(1024)
+					a.SetLinuxCPUSetCPUs("0")
+				}),
+				adjust("p2", func(a *api.ContainerAdjustment) {
+					a.SetLinuxMemoryLimit(2048)
+					a.SetLinuxCPUSetCPUs("1")
+				}),
+			},
+			check: func(_ *api.CreateContainerRequest, rsp *api.CreateContainerResponse) {
+				Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(2048)))
+				Expect(rsp.Adjust.Linux.Resources.Cpu.Cpus).To(Equal("0-1"))
+			},
+		}),
+
+		Entry("conflicts outside the fields of per-field strategies", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.ByField(map[string]merge.ConflictResolver{
+					"linux.resources.memory.limit": merge.MaxValue(),
+				})),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUShares(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUShares(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("merged conflicts of parameters which can't be merged", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, always(merge.UseMerged)),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.AddLinuxHugepageLimit("2MB", 4096) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("merged conflicts with a merged value of the wrong type", createCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, func(c *merge.Conflict) merge.Resolution {
+					c.Merged = 4096
+					return merge.UseMerged
+				}),
+			},
+			replies: []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+				adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+	)
+
+	It("passes conflict details to resolvers", func() {
+		var conflicts []*merge.Conflict
+
+		result := merge.NewCreateContainerResult(createRequest(nil),
+			merge.WithConflictResolver(merge.ResourcesClass, func(c *merge.Conflict) merge.Resolution {
+				conflicts = append(conflicts, c)
+				return merge.KeepCurrent
+			}),
+		)
+		Expect(apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("0-1") }),
+			adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxCPUSetCPUs("2-3") }),
+		})).To(Succeed())
+
+		Expect(conflicts).To(HaveLen(1))
+		Expect(*conflicts[0]).To(Equal(merge.Conflict{
+			Class:     merge.ResourcesClass,
+			Field:     "linux.resources.cpu.cpus",
+			Subject:   "CPU pinning",
+			Container: "ctr0",
+			Owner:     "p1",
+			Plugin:    "p2",
+			Current:   "0-1",
+			Proposed:  "2-3",
+		}))
+	})
+
+	It("describes rejected conflicts in structured form", func() {
+		result := merge.NewCreateContainerResult(createRequest(nil))
+		err := apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(1024) }),
+			adjust("p2", func(a *api.ContainerAdjustment) { a.SetLinuxMemoryLimit(2048) }),
+		})
+		Expect(err).To(HaveOccurred())
+
+		c, ok := merge.AsConflict(err)
+		Expect(ok).To(BeTrue())
+		Expect(*c).To(Equal(merge.Conflict{
+			Class:     merge.ResourcesClass,
+			Field:     "linux.resources.memory.limit",
+			Subject:   "memory limit",
+			Container: "ctr0",
+			Owner:     "p1",
+			Plugin:    "p2",
+			Current:   int64(1024),
+			Proposed:  int64(2048),
+		}))
+
+		result = merge.NewCreateContainerResult(createRequest(nil))
+		err = apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnv("FOO", "bar") }),
+			adjust("p2", func(a *api.ContainerAdjustment) { a.AddEnv("FOO", "baz") }),
+		})
+		c, ok = merge.AsConflict(fmt.Errorf("failed to create container: %w", err))
+		Expect(ok).To(BeTrue())
+		Expect(c.Class).To(Equal(merge.FieldClass(api.EnvAdjustment)))
+		Expect(c.Field).To(Equal("env[FOO]"))
+		Expect(c.Owner).To(Equal("p1"))
+		Expect(c.Plugin).To(Equal("p2"))
+		Expect(proto.Equal(c.Current.(*api.KeyValue), &api.KeyValue{Key: "FOO", Value: "bar"})).To(BeTrue())
+		Expect(proto.Equal(c.Proposed.(*api.KeyValue), &api.KeyValue{Key: "FOO", Value: "baz"})).To(BeTrue())
+
+		result = merge.NewCreateContainerResult(createRequest(nil))
+		err = apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) { a.AddEnvFile("/etc/env", false) }),
+		})
+		_, ok = merge.AsConflict(err)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("rejects responses of invalid type", func() {
+		result := merge.NewCreateContainerResult(createRequest(nil))
+		err := result.Apply(&api.Empty{}, "p1")
+		Expect(err).To(HaveOccurred())
+
+		var rejErr *merge.RejectedError
+		Expect(errors.As(err, &rejErr)).To(BeFalse())
+	})
+
+	It("rejects changing protected AppArmor profiles", func() {
+		var (
+			options = []merge.Option{
+				merge.WithProtectedApparmorProfiles([]string{"cri-containerd.apparmor.d"}),
+			}
+			replies = []reply{
+				adjust("p1", func(a *api.ContainerAdjustment) {
+					a.SetLinuxApparmorProfile("unconfined")
+				}),
+			}
+		)
+
+		req := createRequest(nil)
+		req.Container.Linux = &api.LinuxContainer{ApparmorProfile: "cri-containerd.apparmor.d"}
+		result := merge.NewCreateContainerResult(req, options...)
+		checkRejection(apply(result, replies), rejected(merge.InvalidAdjustmentRule, "p1"))
+
+		req = createRequest(nil)
+		req.Container.Linux = &api.LinuxContainer{ApparmorProfile: "custom"}
+		result = merge.NewCreateContainerResult(req, options...)
+		Expect(apply(result, replies)).To(Succeed())
+		Expect(req.Container.Linux.ApparmorProfile).To(Equal("unconfined"))
+	})
+})
+
+var _ = Describe("Removal markers", func() {
+	It("are set both explicitly and by a legacy prefix by adjustments", func() {
+		a := &api.ContainerAdjustment{}
+		a.RemoveMount("/mnt")
+		a.RemoveEnv("FOO")
+		a.RemoveDevice("/dev/foo")
+
+		m := a.Mounts[0]
+		Expect(m.Remove).To(BeTrue())
+		Expect(m.Destination).To(Equal("-/mnt"))
+		Expect(m.StripRemovalMarker()).To(Equal(&api.Mount{Destination: "/mnt"}))
+
+		e := a.Env[0]
+		Expect(e.Remove).To(BeTrue())
+		Expect(e.Key).To(Equal("-FOO"))
+		Expect(e.StripRemovalMarker()).To(Equal(&api.KeyValue{Key: "FOO"}))
+
+		d := a.Linux.Devices[0]
+		Expect(d.Remove).To(BeTrue())
+		Expect(d.Path).To(Equal("-/dev/foo"))
+		Expect(d.StripRemovalMarker()).To(Equal(&api.LinuxDevice{Path: "/dev/foo"}))
+	})
+
+	It("are honored in either form", func() {
+		for _, m := range []*api.Mount{
+			{Destination: "-/mnt"},
+			{Destination: "/mnt", Remove: true},
+		} {
+			key, marked := m.IsMarkedForRemoval()
+			Expect(marked).To(BeTrue())
+			Expect(key).To(Equal("/mnt"))
+		}
+		for _, e := range []*api.KeyValue{
+			{Key: "-FOO"},
+			{Key: "FOO", Remove: true},
+		} {
+			key, marked := e.IsMarkedForRemoval()
+			Expect(marked).To(BeTrue())
+			Expect(key).To(Equal("FOO"))
+		}
+		for _, d := range []*api.LinuxDevice{
+			{Path: "-/dev/foo"},
+			{Path: "/dev/foo", Remove: true},
+		} {
+			key, marked := d.IsMarkedForRemoval()
+			Expect(marked).To(BeTrue())
+			Expect(key).To(Equal("/dev/foo"))
+		}
+
+		key, marked := (&api.Mount{Destination: "/mnt"}).IsMarkedForRemoval()
+		Expect(marked).To(BeFalse())
+		Expect(key).To(Equal("/mnt"))
+		Expect(api.StripRemovalMarker("-foo")).To(Equal("foo"))
+		Expect(api.StripRemovalMarker("foo")).To(Equal("foo"))
+	})
+})
+
+var _ = Describe("Adjustment preview", func() {
+	It("reports conflicts without changing the result", func() {
+		req := createRequest(nil)
+		result := merge.NewCreateContainerResult(req)
+		Expect(apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) {
+				a.SetLinuxMemoryLimit(1024)
+				a.AddEnv("BAR", "bar")
+			}),
+		})).To(Succeed())
+
+		candidate := &api.ContainerAdjustment{}
+		candidate.SetLinuxMemoryLimit(2048)
+		candidate.AddMount(mount("/mnt", "/host/mnt"))
+
+		conflicts, err := result.Preview(candidate, "p2")
+		Expect(err).To(BeNil())
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Subject).To(Equal("memory limit"))
+		Expect(conflicts[0].Owner).To(Equal("p1"))
+		Expect(conflicts[0].Plugin).To(Equal("p2"))
+
+		candidate = &api.ContainerAdjustment{}
+		candidate.AddEnv("BAR", "xyzzy")
+		conflicts, err = result.Preview(candidate, "p2")
+		Expect(err).To(BeNil())
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Subject).To(Equal("env BAR"))
+
+		rsp := result.CreateContainerResponse()
+		Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+		Expect(rsp.Adjust.Mounts).To(BeEmpty())
+		Expect(mountDestinations(req.Container.Mounts)).To(Equal([]string{"/data:/host/data"}))
+
+		candidate = &api.ContainerAdjustment{}
+		candidate.SetLinuxMemoryLimit(2048)
+		conflicts, err = result.Preview(candidate, "p2", merge.WithConflictResolver(merge.ResourcesClass,
+			always(merge.UseProposed)))
+		Expect(err).To(BeNil())
+		Expect(conflicts).To(BeEmpty())
+		Expect(rsp.Adjust.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+	})
+})
+
+var _ = Describe("Adjustment ownership", func() {
+	It("reports the owners of adjusted parameters and the collected adjustment", func() {
+		req := createRequest(nil)
+		result := merge.NewCreateContainerResult(req)
+		Expect(result.Owners(req.Container.Id)).To(BeEmpty())
+
+		Expect(apply(result, []reply{
+			adjust("p1", func(a *api.ContainerAdjustment) {
+				a.SetLinuxMemoryLimit(1024)
+				a.AddEnv("BAR", "bar")
+			}),
+			adjust("p2", func(a *api.ContainerAdjustment) {
+				a.AddMount(mount("/mnt", "/host/mnt"))
+				a.AddAnnotation("key", "value")
+			}),
+		})).To(Succeed())
+
+		Expect(result.Owners(req.Container.Id)).To(Equal(map[string]string{
+			"linux.resources.memory.limit": "p1",
+			"env[BAR]":                     "p1",
+			"mounts[/mnt]":                 "p2",
+			"annotations[key]":             "p2",
+		}))
+		Expect(result.Owners("no-such-container")).To(BeNil())
+
+		collected := result.Adjustment()
+		Expect(collected.Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+		Expect(collected.Annotations).To(Equal(map[string]string{"key": "value"}))
+
+		collected.Annotations["key"] = "changed"
+		Expect(result.CreateContainerResponse().Adjust.Annotations["key"]).To(Equal("value"))
+	})
+})
+
+var _ = Describe("UpdateContainer result", func() {
+	DescribeTable("collecting plugin responses",
+		func(tc updateCase) {
+			req := updateRequest()
+			result := merge.NewUpdateContainerResult(req, tc.options...)
+
+			err := apply(result, tc.replies)
+			checkRejection(err, tc.rejection)
+			if err == nil && tc.check != nil {
+				tc.check(req, result.UpdateContainerResponse())
+			}
+		},
+
+		Entry("update of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(1))
+				Expect(rsp.Update[0].ContainerId).To(Equal("ctr0"))
+				Expect(rsp.Update[0].Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(2048)))
+				Expect(req.LinuxResources.Memory.Limit.GetValue()).To(Equal(int64(2048)))
+			},
+		}),
+
+		Entry("device cgroup rules of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rw")
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.RemoveLinuxDeviceCgroupRule("c", &major, &minor)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(deviceRules(req.LinuxResources.Devices)).To(Equal([]string{"remove c 1:3"}))
+				Expect(req.LinuxResources.ToOCI().Devices).To(BeEmpty())
+			},
+		}),
+
+		Entry("conflicting device cgroup rules of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "rw")
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.AddLinuxDeviceCgroupRule(true, "c", &major, &minor, "r")
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("network bandwidth of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxNetworkIngressBandwidth(10_000_000, 0)
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxNetworkEgressBandwidth(5_000_000, 65536)
+				})),
+			},
+			check: func(_ *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(1))
+				b := rsp.Update[0].Linux.NetworkBandwidth
+				Expect(b.IngressRate.GetValue()).To(Equal(uint64(10_000_000)))
+				Expect(b.EgressRate.GetValue()).To(Equal(uint64(5_000_000)))
+				Expect(b.EgressBurst.GetValue()).To(Equal(uint64(65536)))
+			},
+		}),
+
+		Entry("conflicting network bandwidth of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxNetworkEgressBandwidth(5_000_000, 0)
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxNetworkEgressBandwidth(1_000_000, 0)
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("invalid network bandwidth of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxNetworkIngressBandwidth(0, 0)
+				})),
+			},
+			rejection: rejected(merge.InvalidUpdateRule, "p1"),
+		}),
+
+		Entry("requested container updated last", updateCase{
+			replies: []reply{
+				update("p1",
+					containerUpdate("ctr0", func(u *api.ContainerUpdate) { u.SetLinuxCPUShares(512) }),
+					containerUpdate("ctr1", func(u *api.ContainerUpdate) { u.SetLinuxCPUShares(256) }),
+				),
+				update("p2", containerUpdate("ctr2", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUShares(128)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				ids := []string{}
+				for _, u := range rsp.Update {
+					ids = append(ids, u.ContainerId)
+				}
+				Expect(ids).To(Equal([]string{"ctr1", "ctr2", "ctr0"}))
+				Expect(rsp.Update[2].Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(1024)))
+				Expect(rsp.Update[2].Linux.Resources.Cpu.Shares.GetValue()).To(Equal(uint64(512)))
+			},
+		}),
+
+		Entry("OOM score update of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					v := 500
+					u.SetLinuxOomScoreAdj(&v)
+					u.AddLinuxUnified("memory.oom.group", "1")
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(1))
+				Expect(rsp.Update[0].Linux.OomScoreAdj.GetValue()).To(Equal(int64(500)))
+				Expect(rsp.Update[0].Linux.Resources.Unified).To(Equal(map[string]string{
+					"memory.oom.group": "1",
+				}))
+			},
+		}),
+
+		Entry("cpuset update outside topology", updateCase{
+			options: []merge.Option{
+				merge.WithTopology(topology),
+			},
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("4")
+				})),
+			},
+			rejection: rejected(merge.InvalidUpdateRule, "p1"),
+		}),
+
+		Entry("cpuset updates merged into their union", updateCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, merge.CPUSetUnion()),
+			},
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("0-1")
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("3")
+				})),
+			},
+			check: func(_ *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(1))
+				Expect(rsp.Update[0].Linux.Resources.Cpu.Cpus).To(Equal("0-1,3"))
+			},
+		}),
+
+		Entry("hugepage update with unsupported page size", updateCase{
+			options: []merge.Option{
+				merge.WithHugepageSizes([]string{"2048kB"}),
+			},
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.AddLinuxHugepageLimit("2MiB", 1024)
+				})),
+				update("p2", containerUpdate("ctr2", func(u *api.ContainerUpdate) {
+					u.AddLinuxHugepageLimit("1GB", 1024)
+				})),
+			},
+			rejection: rejected(merge.InvalidUpdateRule, "p2"),
+		}),
+
+		Entry("conflicting OOM score updates", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					v := 500
+					u.SetLinuxOomScoreAdj(&v)
+				})),
+				update("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					v := -500
+					u.SetLinuxOomScoreAdj(&v)
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("ignored failed OOM score update with resources", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					v := 500
+					u.SetLinuxOomScoreAdj(&v)
+					u.SetIgnoreFailure()
+				})),
+				update("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					v := -500
+					u.SetLinuxOomScoreAdj(&v)
+					u.AddLinuxUnified("memory.oom.group", "1")
+					u.SetIgnoreFailure()
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update[0].ContainerId).To(Equal("ctr1"))
+				Expect(rsp.Update[0].Linux.OomScoreAdj.GetValue()).To(Equal(int64(500)))
+				Expect(rsp.Update[0].Linux.Resources.Unified).To(BeEmpty())
+			},
+		}),
+
+		Entry("conflicting updates of the requested container", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(4096)
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("updates of the same parameter of different containers", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+				})),
+				update("p2", containerUpdate("ctr2", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(4096)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(3))
+			},
+		}),
+
+		Entry("annotation updates", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.AddAnnotation("key1", "value1")
+				})),
+				update("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.AddAnnotation("key2", "value2")
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update[0].Annotations).To(Equal(map[string]string{
+					"key1": "value1",
+					"key2": "value2",
+				}))
+			},
+		}),
+
+		Entry("conflicting annotation updates", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.AddAnnotation("key", "value1")
+				})),
+				update("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.AddAnnotation("key", "value2")
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+
+		Entry("update conflicts resolved by taking the maximum", updateCase{
+			options: []merge.Option{
+				merge.WithConflictResolver(merge.ResourcesClass, takeMax),
+			},
+			replies: []reply{
+				update("p1", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(4096)
+				})),
+				update("p2", containerUpdate("ctr0", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update[0].Linux.Resources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+				Expect(req.LinuxResources.Memory.Limit.GetValue()).To(Equal(int64(4096)))
+			},
+		}),
+
+		Entry("failures ignored only if all plugins allow it", updateCase{
+			replies: []reply{
+				update("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxMemoryLimit(2048)
+					u.SetIgnoreFailure()
+				})),
+				update("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUShares(512)
+				})),
+			},
+			check: func(req *api.UpdateContainerRequest, rsp *api.UpdateContainerResponse) {
+				Expect(rsp.Update[0].IgnoreFailure).To(BeFalse())
+			},
+		}),
+	)
+})
+
+var _ = Describe("StopContainer result", func() {
+	DescribeTable("collecting plugin responses",
+		func(tc stopCase) {
+			result := merge.NewStopContainerResult()
+
+			err := apply(result, tc.replies)
+			checkRejection(err, tc.rejection)
+			if err == nil && tc.check != nil {
+				tc.check(result.StopContainerResponse())
+			}
+		},
+
+		Entry("no updates", stopCase{
+			replies: []reply{
+				{plugin: "p1", response: (*api.StopContainerResponse)(nil)},
+			},
+			check: func(rsp *api.StopContainerResponse) {
+				Expect(rsp.Update).To(BeEmpty())
+			},
+		}),
+
+		Entry("updates of other containers", stopCase{
+			replies: []reply{
+				stopUpdate("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("0-3")
+				})),
+				stopUpdate("p2", containerUpdate("ctr2", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("0-3")
+				})),
+			},
+			check: func(rsp *api.StopContainerResponse) {
+				Expect(rsp.Update).To(HaveLen(2))
+				Expect(rsp.Update[0].Linux.Resources.Cpu.Cpus).To(Equal("0-3"))
+			},
+		}),
+
+		Entry("conflicting updates", stopCase{
+			replies: []reply{
+				stopUpdate("p1", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("0-3")
+				})),
+				stopUpdate("p2", containerUpdate("ctr1", func(u *api.ContainerUpdate) {
+					u.SetLinuxCPUSetCPUs("4-7")
+				})),
+			},
+			rejection: conflict("p2", "p1"),
+		}),
+	)
+})
@@ -0,0 +1,180 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+// adjustments are the names of all container adjustments.
+var adjustments = []string{
+	api.AnnotationsAdjustment,
+	api.MountsAdjustment,
+	api.EnvAdjustment,
+	api.HooksAdjustment,
+	api.DevicesAdjustment,
+	api.ResourcesAdjustment,
+	api.CgroupsPathAdjustment,
+	api.OomScoreAdjAdjustment,
+	api.RlimitsAdjustment,
+	api.CDIDevicesAdjustment,
+	api.TopologyHintsAdjustment,
+	api.HandlerAnnotationsAdjustment,
+	api.WindowsResourcesAdjustment,
+	api.CapabilitiesAdjustment,
+	api.EnvFilesAdjustment,
+	api.UserAdjustment,
+	api.ApparmorProfileAdjustment,
+	api.SeccompPolicyAdjustment,
+	api.CPUAffinityAdjustment,
+	api.SchedulingHintsAdjustment,
+	api.NetworkBandwidthAdjustment,
+}
+
+// AdjustmentPolicy restricts the container adjustments a plugin can make.
+// Adjustments are named as by the api.*Adjustment constants, for instance
+// "env" or "devices".
+type AdjustmentPolicy struct {
+	// Allow lists the only adjustments the plugin can make, if set.
+	Allow []string
+	// Deny lists adjustments the plugin can't make.
+	Deny []string
+}
+
+// Validate checks that the policy only names known adjustments.
+func (p *AdjustmentPolicy) Validate() error {
+	for _, names := range [][]string{p.Allow, p.Deny} {
+		for _, name := range names {
+			if !isAdjustment(name) {
+				return fmt.Errorf("invalid adjustment policy, unknown adjustment %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// Allows returns true if the policy allows the given adjustment.
+func (p *AdjustmentPolicy) Allows(adjustment string) bool {
+	if p == nil {
+		return true
+	}
+	if len(p.Allow) > 0 && !contains(p.Allow, adjustment) {
+		return false
+	}
+	return !contains(p.Deny, adjustment)
+}
+
+// WithAdjustmentPolicy returns an option to restrict the adjustments of the
+// given plugin, by full (idx-name) or base name, using the policy. A policy
+// for the full name of a plugin takes precedence over one for its base name.
+func WithAdjustmentPolicy(plugin string, policy *AdjustmentPolicy) Option {
+	return func(r *Result) {
+		if r.policies == nil {
+			r.policies = map[string]*AdjustmentPolicy{}
+		}
+		r.policies[plugin] = policy
+	}
+}
+
+// checkAdjustmentPolicy rejects adjustments the policy of the plugin doesn't
+// allow.
+func (r *Result) checkAdjustmentPolicy(rpl *api.ContainerAdjustment, plugin string) error {
+	policy, ok := r.policies[plugin]
+	if !ok {
+		if _, base, split := strings.Cut(plugin, "-"); split {
+			policy = r.policies[base]
+		}
+	}
+	if policy == nil {
+		return nil
+	}
+
+	for _, adjustment := range adjustments {
+		if rpl.HasAdjustment(adjustment) && !policy.Allows(adjustment) {
+			return rejected(plugin, InvalidAdjustmentRule, adjustment,
+				"%s adjustment not allowed for plugin", adjustment)
+		}
+	}
+
+	return nil
+}
+
+func isAdjustment(name string) bool {
+	return contains(adjustments, name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UserPolicy restricts how plugins can adjust the user and groups of
+// containers. Plugins can use this to enforce non-root execution, while
+// the runtime uses it to keep plugins from escalating privileges.
+type UserPolicy struct {
+	// RejectChanges rejects all changes to the user and groups.
+	RejectChanges bool
+	// RejectRoot rejects setting the user or any group to root (ID 0).
+	RejectRoot bool
+}
+
+// WithUserPolicy returns an option to restrict the user and group
+// adjustments of plugins using the policy. By default all are allowed.
+func WithUserPolicy(policy *UserPolicy) Option {
+	return func(r *Result) {
+		r.userPolicy = policy
+	}
+}
+
+// checkUser rejects user and group adjustments the user policy doesn't
+// allow.
+func (r *Result) checkUser(user *api.User, plugin string) error {
+	policy := r.userPolicy
+	if policy == nil {
+		return nil
+	}
+
+	if policy.RejectChanges {
+		return rejected(plugin, InvalidAdjustmentRule, api.UserAdjustment,
+			"user and group adjustments not allowed")
+	}
+
+	if policy.RejectRoot {
+		if uid := user.GetUid(); uid != nil && uid.Value == 0 {
+			return rejected(plugin, InvalidAdjustmentRule, api.UserAdjustment,
+				"setting root user not allowed")
+		}
+		if gid := user.GetGid(); gid != nil && gid.Value == 0 {
+			return rejected(plugin, InvalidAdjustmentRule, api.UserAdjustment,
+				"setting root group not allowed")
+		}
+		if slices.Contains(user.GetAdditionalGids(), 0) {
+			return rejected(plugin, InvalidAdjustmentRule, api.UserAdjustment,
+				"adding root group not allowed")
+		}
+	}
+
+	return nil
+}
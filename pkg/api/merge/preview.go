@@ -0,0 +1,122 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/containerd/nri/pkg/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// Preview simulates collecting the given adjustment of a plugin, without
+// changing the Result. It returns the conflicts the adjustment would cause
+// with the adjustments collected from other plugins, unless they would be
+// resolved by a conflict resolver, and any other error collecting it would
+// fail with. Conflicts outside the annotations, mounts and resources field
+// classes end the simulation, so only the first of these is returned. The
+// given options are applied to the simulation only, for instance to use
+// conflict resolvers without side effects.
+func (r *Result) Preview(adjust *api.ContainerAdjustment, plugin string, options ...Option) ([]*Conflict, error) {
+	if r.request.create == nil {
+		return nil, fmt.Errorf("cannot preview adjustment without container creation")
+	}
+
+	if adjust == nil {
+		return []*Conflict{}, nil
+	}
+
+	p := r.copy()
+	for _, o := range options {
+		o(p)
+	}
+
+	var (
+		id        = p.request.create.Container.Id
+		conflicts = []*Conflict{}
+		resolvers = p.resolvers
+	)
+
+	// collect conflicts which would be rejected, then carry on without them
+	p.resolvers = map[FieldClass]ConflictResolver{}
+	for _, class := range []FieldClass{AnnotationsClass, MountsClass, ResourcesClass} {
+		resolver := resolvers[class]
+		p.resolvers[class] = func(c *Conflict) Resolution {
+			if resolver != nil {
+				if resolution := resolver(c); resolution != RejectConflict {
+					return resolution
+				}
+			}
+			conflicts = append(conflicts, c)
+			return KeepCurrent
+		}
+	}
+
+	err := p.adjust(proto.Clone(adjust).(*api.ContainerAdjustment), plugin)
+
+	if c, ok := AsConflict(err); ok {
+		if c.Container == "" {
+			c.Container = id
+		}
+		conflicts = append(conflicts, c)
+		err = nil
+	}
+
+	return conflicts, err
+}
+
+// copy returns a copy of a container creation Result for previewing.
+func (r *Result) copy() *Result {
+	c := *r
+	c.preview = true
+	c.request = resultRequest{
+		create: proto.Clone(r.request.create).(*api.CreateContainerRequest),
+	}
+	initCreateRequest(c.request.create)
+	c.reply = resultReply{
+		adjust: initAdjustment(proto.Clone(r.reply.adjust).(*api.ContainerAdjustment)),
+		update: r.reply.update,
+	}
+	c.owners = resultOwners{}
+	for id, o := range r.owners {
+		c.owners[id] = o.copy()
+	}
+	c.resolvers = maps.Clone(r.resolvers)
+
+	return &c
+}
+
+// copy returns a copy of the owners.
+func (o *owners) copy() *owners {
+	c := *o
+	c.annotations = maps.Clone(o.annotations)
+	c.handlerAnnotations = maps.Clone(o.handlerAnnotations)
+	c.mounts = maps.Clone(o.mounts)
+	c.devices = maps.Clone(o.devices)
+	c.deviceCgroupRules = maps.Clone(o.deviceCgroupRules)
+	c.cdiDevices = maps.Clone(o.cdiDevices)
+	c.env = maps.Clone(o.env)
+	c.envFiles = maps.Clone(o.envFiles)
+	c.hugepageLimits = maps.Clone(o.hugepageLimits)
+	c.unified = maps.Clone(o.unified)
+	c.rlimits = maps.Clone(o.rlimits)
+	c.deviceTopologyHints = maps.Clone(o.deviceTopologyHints)
+	c.capabilities = maps.Clone(o.capabilities)
+	c.networkBandwidth = maps.Clone(o.networkBandwidth)
+	return &c
+}
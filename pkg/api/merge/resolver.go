@@ -0,0 +1,191 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"fmt"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+// FieldClass is a class of container parameters with a common conflict
+// resolution strategy.
+type FieldClass string
+
+const (
+	// AnnotationsClass is the class of container annotations.
+	AnnotationsClass FieldClass = api.AnnotationsAdjustment
+	// MountsClass is the class of container mounts.
+	MountsClass FieldClass = api.MountsAdjustment
+	// ResourcesClass is the class of Linux container resources.
+	ResourcesClass FieldClass = api.ResourcesAdjustment
+)
+
+// Conflict describes a plugin trying to set a container parameter which
+// is already owned by another plugin.
+type Conflict struct {
+	// Class of the conflicting parameter. Outside the resolvable classes
+	// this is the name of the adjustment of the parameter, for instance
+	// "devices".
+	Class FieldClass
+	// Field is the path of the conflicting parameter in the adjustment or
+	// update, for instance "linux.resources.memory.limit". Keys of maps
+	// and lists are given in brackets, for instance "annotations[key]".
+	Field string
+	// Subject is the conflicting parameter, for instance "memory limit".
+	Subject string
+	// Container is the ID of the container the parameter belongs to.
+	Container string
+	// Owner is the plugin which first set the parameter.
+	Owner string
+	// Plugin is the plugin trying to set the parameter.
+	Plugin string
+	// Current is the current value of the parameter, or the current item
+	// for parameters which are items of a list, like devices. It is nil if
+	// the value is not known.
+	Current interface{}
+	// Proposed is the value or item the plugin tries to set.
+	Proposed interface{}
+	// Merged is the value to set instead of the current and proposed ones,
+	// set by resolvers which resolve the conflict with UseMerged. It must
+	// have the same type as the proposed value.
+	Merged interface{}
+}
+
+// Resolution is the outcome of resolving a Conflict.
+type Resolution int
+
+const (
+	// RejectConflict rejects the change as a conflict. This is the default
+	// outcome for conflicts without a registered resolver.
+	RejectConflict Resolution = iota
+	// KeepCurrent keeps the current value and ignores the change.
+	KeepCurrent
+	// UseProposed replaces the current value with the proposed one.
+	UseProposed
+	// UseMerged replaces the current value with the Merged value of the
+	// conflict, for instance the union of two cpusets. It is supported for
+	// memory and CPU resource parameters. Conflicts of other parameters
+	// resolved with UseMerged are rejected.
+	UseMerged
+)
+
+// ConflictResolver decides the outcome of a Conflict. The values of the
+// conflict are the Go values of the parameter: a string for annotations,
+// an *api.Mount for mounts, an *api.LinuxDeviceCgroup for device cgroup
+// rules, and an int64, uint64, bool or string for the other resource
+// parameters.
+type ConflictResolver func(*Conflict) Resolution
+
+// WithConflictResolver returns an option to resolve conflicts within the
+// given field class using the given resolver, instead of rejecting them.
+// The plugin which first set a parameter stays its owner, regardless of
+// the outcome.
+func WithConflictResolver(class FieldClass, resolver ConflictResolver) Option {
+	return func(r *Result) {
+		if r.resolvers == nil {
+			r.resolvers = map[FieldClass]ConflictResolver{}
+		}
+		r.resolvers[class] = resolver
+	}
+}
+
+// resolve checks the outcome of claiming a parameter. It returns true if
+// the proposed value should be applied, and an error if the change should
+// be rejected. Conflicts are passed to the resolver of the field class.
+func (r *Result) resolve(err error, class FieldClass, id string, current, proposed interface{}) (bool, error) {
+	resolution, c, err := r.resolveConflict(err, class, id, current, proposed)
+	if resolution == UseMerged {
+		if r.preview {
+			return false, nil
+		}
+		log.Warnf(noCtx, "can't merge conflicting %s of plugins %q and %q of container %s",
+			c.Subject, c.Owner, c.Plugin, id)
+		return false, err
+	}
+	return resolution == UseProposed, err
+}
+
+// resolveValue checks the outcome of claiming a parameter, like resolve,
+// returning the value to apply. This is the proposed value, or the merged
+// one if a resolver merged the conflicting values.
+func resolveValue[T any](r *Result, err error, class FieldClass, id string, current, proposed T) (T, bool, error) {
+	resolution, c, err := r.resolveConflict(err, class, id, current, proposed)
+	if resolution != UseMerged {
+		return proposed, resolution != KeepCurrent && err == nil, err
+	}
+
+	merged, ok := c.Merged.(T)
+	if !ok {
+		return proposed, false, fmt.Errorf("invalid merged value %v (%T) of %s of container %s, expected %T: %w",
+			c.Merged, c.Merged, c.Subject, id, proposed, err)
+	}
+
+	if !r.preview {
+		log.Infof(noCtx, "resolved conflict of plugins %q and %q on %s of container %s, used merged value %v",
+			c.Owner, c.Plugin, c.Subject, id, merged)
+	}
+	return merged, true, nil
+}
+
+// resolveConflict passes the conflict of claiming a parameter, if any, to
+// the resolver of the field class. It returns the resolution, the resolved
+// conflict and, for rejected or merged conflicts, the error to reject the
+// change with if merging fails. Changes without a conflict are resolved with
+// UseProposed.
+func (r *Result) resolveConflict(err error, class FieldClass, id string, current, proposed interface{}) (Resolution, *Conflict, error) {
+	if err == nil {
+		return UseProposed, nil, nil
+	}
+
+	err = conflictDetails(err, class, id, current, proposed)
+
+	resolver, ok := r.resolvers[class]
+	if !ok {
+		return RejectConflict, nil, err
+	}
+
+	details, ok := AsConflict(err)
+	if !ok {
+		return RejectConflict, nil, err
+	}
+
+	c := *details
+	resolution := resolver(&c)
+	if r.preview && resolution != RejectConflict {
+		if resolution == UseMerged {
+			return resolution, &c, err
+		}
+		return resolution, &c, nil
+	}
+
+	switch resolution {
+	case KeepCurrent:
+		log.Infof(noCtx, "resolved conflict of plugins %q and %q on %s of container %s, kept current value",
+			c.Owner, c.Plugin, c.Subject, id)
+		return KeepCurrent, &c, nil
+	case UseProposed:
+		log.Infof(noCtx, "resolved conflict of plugins %q and %q on %s of container %s, used proposed value",
+			c.Owner, c.Plugin, c.Subject, id)
+		return UseProposed, &c, nil
+	case UseMerged:
+		return UseMerged, &c, err
+	}
+
+	return RejectConflict, &c, err
+}
@@ -0,0 +1,150 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package merge
+
+import (
+	"github.com/containerd/nri/pkg/api"
+)
+
+// MaxValue returns a conflict resolver which keeps the larger of two
+// conflicting numeric values, for instance the largest of all memory limits
+// set by plugins. Conflicts of other parameters are rejected.
+func MaxValue() ConflictResolver {
+	return func(c *Conflict) Resolution {
+		return compareValues(c, func(cmp int) bool { return cmp < 0 })
+	}
+}
+
+// MinValue returns a conflict resolver which keeps the smaller of two
+// conflicting numeric values. Conflicts of other parameters are rejected.
+func MinValue() ConflictResolver {
+	return func(c *Conflict) Resolution {
+		return compareValues(c, func(cmp int) bool { return cmp > 0 })
+	}
+}
+
+// CPUSetUnion returns a conflict resolver which merges conflicting cpuset
+// CPUs or memory nodes into their union. Conflicts of other parameters, and
+// of malformed cpusets, are rejected.
+func CPUSetUnion() ConflictResolver {
+	return func(c *Conflict) Resolution {
+		switch c.Field {
+		case "linux.resources.cpu.cpus", "linux.resources.cpu.mems":
+		default:
+			return RejectConflict
+		}
+
+		current, ok1 := c.Current.(string)
+		proposed, ok2 := c.Proposed.(string)
+		if !ok1 || !ok2 {
+			return RejectConflict
+		}
+
+		union, err := api.ParseCPUSet(current)
+		if err != nil {
+			return RejectConflict
+		}
+		other, err := api.ParseCPUSet(proposed)
+		if err != nil {
+			return RejectConflict
+		}
+		for id := range other {
+			union[id] = struct{}{}
+		}
+
+		c.Merged = union.String()
+		return UseMerged
+	}
+}
+
+// PluginPriority returns a conflict resolver which lets the plugin with
+// the higher priority override the value set by the other one. Plugins are
+// given in decreasing order of priority, by full (idx-name) or base name.
+// Plugins not listed have the lowest priority. Conflicts of plugins with
+// the same priority are rejected.
+func PluginPriority(plugins ...string) ConflictResolver {
+	rank := func(plugin string) int {
+		_, base, _ := api.ParsePluginName(plugin)
+		for i, name := range plugins {
+			if name == plugin || (base != "" && name == base) {
+				return i
+			}
+		}
+		return len(plugins)
+	}
+
+	return func(c *Conflict) Resolution {
+		owner, plugin := rank(c.Owner), rank(c.Plugin)
+		switch {
+		case plugin < owner:
+			return UseProposed
+		case plugin > owner:
+			return KeepCurrent
+		}
+		return RejectConflict
+	}
+}
+
+// ByField returns a conflict resolver which resolves conflicts using the
+// resolver for the field of the conflict, for instance MaxValue for
+// "linux.resources.memory.limit". Conflicts of other fields are rejected.
+func ByField(resolvers map[string]ConflictResolver) ConflictResolver {
+	return func(c *Conflict) Resolution {
+		if resolver, ok := resolvers[c.Field]; ok && resolver != nil {
+			return resolver(c)
+		}
+		return RejectConflict
+	}
+}
+
+// compareValues resolves a conflict of numeric values, using the proposed
+// value if the comparison of the current and proposed values is preferred.
+func compareValues(c *Conflict, useProposed func(cmp int) bool) Resolution {
+	var cmp int
+
+	switch current := c.Current.(type) {
+	case int64:
+		proposed, ok := c.Proposed.(int64)
+		if !ok {
+			return RejectConflict
+		}
+		cmp = compare(current, proposed)
+	case uint64:
+		proposed, ok := c.Proposed.(uint64)
+		if !ok {
+			return RejectConflict
+		}
+		cmp = compare(current, proposed)
+	default:
+		return RejectConflict
+	}
+
+	if useProposed(cmp) {
+		return UseProposed
+	}
+	return KeepCurrent
+}
+
+func compare[T int64 | uint64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -17,6 +17,8 @@
 package api
 
 import (
+	"fmt"
+	"path"
 	"sort"
 
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
@@ -27,6 +29,18 @@ const (
 	SELinuxRelabel = "relabel"
 )
 
+// mountPropagations are the valid mount propagation modes.
+var mountPropagations = map[string]struct{}{
+	"private":     {},
+	"rprivate":    {},
+	"shared":      {},
+	"rshared":     {},
+	"slave":       {},
+	"rslave":      {},
+	"unbindable":  {},
+	"runbindable": {},
+}
+
 // FromOCIMounts returns a Mount slice for an OCI runtime Spec.
 func FromOCIMounts(o []rspec.Mount) []*Mount {
 	var mounts []*Mount
@@ -36,19 +50,60 @@ func FromOCIMounts(o []rspec.Mount) []*Mount {
 			Type:        m.Type,
 			Source:      m.Source,
 			Options:     DupStringSlice(m.Options),
+			UidMappings: FromOCILinuxIDMappings(m.UIDMappings),
+			GidMappings: FromOCILinuxIDMappings(m.GIDMappings),
 		})
 	}
 	return mounts
 }
 
-// ToOCI returns a Mount for an OCI runtime Spec.
+// FromOCILinuxIDMappings returns ID mappings for an OCI runtime Spec.
+func FromOCILinuxIDMappings(o []rspec.LinuxIDMapping) []*LinuxIDMapping {
+	var mappings []*LinuxIDMapping
+	for _, m := range o {
+		mappings = append(mappings, &LinuxIDMapping{
+			ContainerId: m.ContainerID,
+			HostId:      m.HostID,
+			Size:        m.Size,
+		})
+	}
+	return mappings
+}
+
+// ToOCILinuxIDMappings returns ID mappings for an OCI runtime Spec.
+func ToOCILinuxIDMappings(mappings []*LinuxIDMapping) []rspec.LinuxIDMapping {
+	var o []rspec.LinuxIDMapping
+	for _, m := range mappings {
+		o = append(o, rspec.LinuxIDMapping{
+			ContainerID: m.GetContainerId(),
+			HostID:      m.GetHostId(),
+			Size:        m.GetSize(),
+		})
+	}
+	return o
+}
+
+// ToOCI returns a Mount for an OCI runtime Spec. The propagation mode of
+// the mount replaces any propagation mode among its options.
 func (m *Mount) ToOCI(propagationQuery *string) rspec.Mount {
 	o := rspec.Mount{
 		Destination: m.Destination,
 		Type:        m.Type,
 		Source:      m.Source,
+		UIDMappings: ToOCILinuxIDMappings(m.UidMappings),
+		GIDMappings: ToOCILinuxIDMappings(m.GidMappings),
 	}
-	for _, opt := range m.Options {
+	options := m.Options
+	if m.Propagation != "" {
+		options = nil
+		for _, opt := range m.Options {
+			if _, ok := mountPropagations[opt]; !ok {
+				options = append(options, opt)
+			}
+		}
+		options = append(options, m.Propagation)
+	}
+	for _, opt := range options {
 		o.Options = append(o.Options, opt)
 		if propagationQuery != nil && (opt == "rprivate" || opt == "rshared" || opt == "rslave") {
 			*propagationQuery = opt
@@ -57,13 +112,36 @@ func (m *Mount) ToOCI(propagationQuery *string) rspec.Mount {
 	return o
 }
 
+// Validate the propagation mode and ID mappings of the mount.
+func (m *Mount) Validate() error {
+	if m.Propagation != "" {
+		if _, ok := mountPropagations[m.Propagation]; !ok {
+			return fmt.Errorf("invalid propagation %q of mount %s", m.Propagation, m.Destination)
+		}
+	}
+	if (len(m.UidMappings) == 0) != (len(m.GidMappings) == 0) {
+		return fmt.Errorf("idmapped mount %s needs both UID and GID mappings", m.Destination)
+	}
+	for _, mappings := range [][]*LinuxIDMapping{m.UidMappings, m.GidMappings} {
+		for _, id := range mappings {
+			if id.GetSize() == 0 {
+				return fmt.Errorf("invalid empty ID mapping of mount %s", m.Destination)
+			}
+		}
+	}
+	return nil
+}
+
 // Cmp returns true if the mounts are equal.
 func (m *Mount) Cmp(v *Mount) bool {
 	if v == nil {
 		return false
 	}
 	if m.Destination != v.Destination || m.Type != v.Type || m.Source != v.Source ||
-		len(m.Options) != len(v.Options) {
+		m.Propagation != v.Propagation || len(m.Options) != len(v.Options) {
+		return false
+	}
+	if !cmpIDMappings(m.UidMappings, v.UidMappings) || !cmpIDMappings(m.GidMappings, v.GidMappings) {
 		return false
 	}
 
@@ -81,8 +159,109 @@ func (m *Mount) Cmp(v *Mount) bool {
 	return true
 }
 
-// IsMarkedForRemoval checks if a Mount is marked for removal.
+// IsMarkedForRemoval checks if a Mount is marked for removal, either by
+// its Remove field or by a legacy '-' prefix of its destination. It also
+// returns the destination stripped of any removal marker.
 func (m *Mount) IsMarkedForRemoval() (string, bool) {
-	key, marked := IsMarkedForRemoval(m.Destination)
-	return key, marked
+	key, marked := IsMarkedForRemoval(m.GetDestination())
+	return key, marked || m.GetRemove()
+}
+
+// StripRemovalMarker returns a copy of the Mount with any removal marker
+// stripped from it.
+func (m *Mount) StripRemovalMarker() *Mount {
+	if m == nil {
+		return nil
+	}
+	return &Mount{
+		Destination: StripRemovalMarker(m.Destination),
+		Type:        m.Type,
+		Source:      m.Source,
+		Options:     DupStringSlice(m.Options),
+		Propagation: m.Propagation,
+		UidMappings: dupIDMappings(m.UidMappings),
+		GidMappings: dupIDMappings(m.GidMappings),
+	}
+}
+
+// CompleteMountFromContainer completes a mount which only specifies its
+// destination, and optionally its type, using the existing mount of the
+// container with the same destination. This lets a plugin remount an
+// existing volume, for instance one backed by a PV, without knowing where
+// the runtime has put it on the host. The source, and any unset type,
+// options or ID mappings, are taken from the existing mount. A mount with a
+// source, or one marked for removal, is returned as such. It is an error if
+// the container has no mount with the same destination and a matching type.
+func CompleteMountFromContainer(m *Mount, ctr *Container) (*Mount, error) {
+	if m == nil || m.Source != "" {
+		return m, nil
+	}
+	if _, marked := m.IsMarkedForRemoval(); marked {
+		return m, nil
+	}
+	if m.Destination == "" {
+		return nil, fmt.Errorf("can't complete mount without source and destination")
+	}
+
+	dst := path.Clean(m.Destination)
+	mounts := ctr.GetMounts()
+	// look for the last such mount, which shadows any earlier ones
+	for i := len(mounts) - 1; i >= 0; i-- {
+		c := mounts[i]
+		if path.Clean(c.GetDestination()) != dst {
+			continue
+		}
+		if m.Type != "" && c.GetType() != "" && m.Type != c.GetType() {
+			continue
+		}
+
+		completed := &Mount{
+			Destination: m.Destination,
+			Type:        m.Type,
+			Source:      c.GetSource(),
+			Options:     DupStringSlice(m.Options),
+			Propagation: m.Propagation,
+			UidMappings: dupIDMappings(m.UidMappings),
+			GidMappings: dupIDMappings(m.GidMappings),
+		}
+		if completed.Type == "" {
+			completed.Type = c.GetType()
+		}
+		if completed.Options == nil {
+			completed.Options = DupStringSlice(c.GetOptions())
+		}
+		if completed.UidMappings == nil && completed.GidMappings == nil {
+			completed.UidMappings = dupIDMappings(c.GetUidMappings())
+			completed.GidMappings = dupIDMappings(c.GetGidMappings())
+		}
+		return completed, nil
+	}
+
+	return nil, fmt.Errorf("container %s has no mount %s to complete mount from",
+		ctr.GetName(), m.Destination)
+}
+
+func cmpIDMappings(a, b []*LinuxIDMapping) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].GetContainerId() != b[i].GetContainerId() || a[i].GetHostId() != b[i].GetHostId() ||
+			a[i].GetSize() != b[i].GetSize() {
+			return false
+		}
+	}
+	return true
+}
+
+func dupIDMappings(mappings []*LinuxIDMapping) []*LinuxIDMapping {
+	var dup []*LinuxIDMapping
+	for _, m := range mappings {
+		dup = append(dup, &LinuxIDMapping{
+			ContainerId: m.GetContainerId(),
+			HostId:      m.GetHostId(),
+			Size:        m.GetSize(),
+		})
+	}
+	return dup
 }
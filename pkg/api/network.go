@@ -0,0 +1,73 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// NetworkNamespacePath returns the path of the network namespace of the
+// pod. If the runtime provides no pod network, it falls back to the path
+// of the network namespace among the Linux namespaces of the pod, if any.
+func (p *PodSandbox) NetworkNamespacePath() string {
+	if path := p.GetNetwork().GetNamespacePath(); path != "" {
+		return path
+	}
+	for _, ns := range p.GetLinux().GetNamespaces() {
+		if ns.Type == string(rspec.NetworkNamespace) {
+			return ns.Path
+		}
+	}
+	return ""
+}
+
+// GetInterface returns the pod network interface with the given name, or
+// nil if there is no such interface.
+func (n *PodNetwork) GetInterface(name string) *PodNetworkInterface {
+	for _, i := range n.GetInterfaces() {
+		if i.Name == name {
+			return i
+		}
+	}
+	return nil
+}
+
+// HardwareAddr returns the parsed MAC address of the interface.
+func (i *PodNetworkInterface) HardwareAddr() (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(i.GetMac())
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC address of interface %s: %w", i.GetName(), err)
+	}
+	return mac, nil
+}
+
+// Prefixes returns the parsed addresses of the interface.
+func (i *PodNetworkInterface) Prefixes() ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, a := range i.GetAddresses() {
+		p, err := netip.ParsePrefix(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of interface %s: %w", i.GetName(), err)
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes, nil
+}
@@ -146,6 +146,13 @@ func UInt32(v interface{}) *OptionalUInt32 {
 	var value uint32
 
 	switch o := v.(type) {
+	case uint16:
+		value = uint32(o)
+	case *uint16:
+		if o == nil {
+			return nil
+		}
+		value = uint32(*o)
 	case uint32:
 		value = o
 	case *uint32:
@@ -30,6 +30,17 @@ const (
 	PluginNameEnvVar = "NRI_PLUGIN_NAME"
 	// PluginIdxEnvVar is used to inform NRI-launched plugins about their ID.
 	PluginIdxEnvVar = "NRI_PLUGIN_IDX"
+	// PluginAuthTokenEnvVar is used to pass plugins a registration token.
+	PluginAuthTokenEnvVar = "NRI_PLUGIN_AUTH_TOKEN"
+	// SchemaVersion is the version of the payload schema. Versions are
+	// cumulative: a peer with a given version knows all fields and events
+	// introduced up to it. The version is bumped for additions a peer may
+	// need to detect. Fields added without a bump are optional, and peers
+	// must treat them as unset by older peers. New events are always gated
+	// by a bump. Peers exchange their schema version during registration.
+	// Fields unknown to a peer with an older schema version are preserved,
+	// not dropped, when passed through it.
+	SchemaVersion = 20
 )
 
 // ParsePluginName parses the (file)name of a plugin into an index and a base.
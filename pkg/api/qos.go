@@ -0,0 +1,177 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// QOSClass returns the Kubernetes QoS class of the pod. If the runtime
+// provides no QoS class, it is taken from the cgroup parent of the pod, or
+// computed from the container resource specs of the pod if there are any.
+func (p *PodSandbox) QOSClass() QOSClass {
+	spec := p.GetResourceSpec()
+	if class := spec.GetQosClass(); class != QOSClass_QOS_CLASS_UNKNOWN {
+		return class
+	}
+	if class := QOSClassFromCgroupParent(p.GetLinux().GetCgroupParent()); class != QOSClass_QOS_CLASS_UNKNOWN {
+		return class
+	}
+	if len(spec.GetContainers()) > 0 {
+		return PodQOSClass(spec.GetContainers())
+	}
+	return QOSClass_QOS_CLASS_UNKNOWN
+}
+
+// GetContainer returns the resource spec of the container with the given
+// name, or nil if there is no such container.
+func (s *PodResourceSpec) GetContainer(name string) *ContainerResourceSpec {
+	for _, c := range s.GetContainers() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// PodQOSClass returns the Kubernetes QoS class of a pod with the given
+// container resource specs, following the rules of the kubelet. Unset
+// requests are taken to be equal to the corresponding limits.
+func PodQOSClass(containers []*ContainerResourceSpec) QOSClass {
+	var (
+		specified  bool
+		guaranteed = len(containers) > 0
+	)
+
+	for _, c := range containers {
+		requests, limits := c.GetRequests(), c.GetLimits()
+		for _, amount := range []*OptionalInt64{
+			requests.GetCpu(), requests.GetMemory(), limits.GetCpu(), limits.GetMemory(),
+		} {
+			if amount.GetValue() > 0 {
+				specified = true
+			}
+		}
+
+		cpu, memory := limits.GetCpu().GetValue(), limits.GetMemory().GetValue()
+		if cpu <= 0 || memory <= 0 {
+			guaranteed = false
+			continue
+		}
+		if r := requests.GetCpu(); r != nil && r.GetValue() != cpu {
+			guaranteed = false
+		}
+		if r := requests.GetMemory(); r != nil && r.GetValue() != memory {
+			guaranteed = false
+		}
+	}
+
+	switch {
+	case !specified:
+		return QOSClass_QOS_CLASS_BEST_EFFORT
+	case guaranteed:
+		return QOSClass_QOS_CLASS_GUARANTEED
+	default:
+		return QOSClass_QOS_CLASS_BURSTABLE
+	}
+}
+
+// QOSClassFromCgroupParent returns the Kubernetes QoS class of a pod from
+// the cgroup parent assigned to it by the kubelet, using either the systemd
+// or the cgroupfs cgroup driver. It returns QOS_CLASS_UNKNOWN if the cgroup
+// parent is not one of a kubelet-managed pod.
+func QOSClassFromCgroupParent(cgroupParent string) QOSClass {
+	if base := path.Base(cgroupParent); strings.HasSuffix(base, ".slice") {
+		switch {
+		case strings.HasPrefix(base, "kubepods-besteffort-pod"):
+			return QOSClass_QOS_CLASS_BEST_EFFORT
+		case strings.HasPrefix(base, "kubepods-burstable-pod"):
+			return QOSClass_QOS_CLASS_BURSTABLE
+		case strings.HasPrefix(base, "kubepods-pod"):
+			return QOSClass_QOS_CLASS_GUARANTEED
+		}
+		return QOSClass_QOS_CLASS_UNKNOWN
+	}
+
+	dirs := strings.Split(strings.Trim(cgroupParent, "/"), "/")
+	for i := 0; i < len(dirs)-1; i++ {
+		if dirs[i] != "kubepods" {
+			continue
+		}
+		switch next := dirs[i+1]; {
+		case next == "besteffort":
+			return QOSClass_QOS_CLASS_BEST_EFFORT
+		case next == "burstable":
+			return QOSClass_QOS_CLASS_BURSTABLE
+		case strings.HasPrefix(next, "pod"):
+			return QOSClass_QOS_CLASS_GUARANTEED
+		}
+	}
+
+	return QOSClass_QOS_CLASS_UNKNOWN
+}
+
+// FromCRIContainerResources returns the resource spec of a container with
+// the given name, derived from its CRI resources. This reverses the kubelet
+// conversion of the container requests and limits to CRI resources. The
+// memory request is only available if the kubelet sets it as memory.min.
+func FromCRIContainerResources(name string, r *cri.LinuxContainerResources) *ContainerResourceSpec {
+	if r == nil {
+		return nil
+	}
+
+	const (
+		// minShares is the CPU shares the kubelet sets for no CPU request.
+		minShares = 2
+		// sharesPerCPU is the CPU shares the kubelet sets per CPU requested.
+		sharesPerCPU = 1024
+	)
+
+	var (
+		spec     = &ContainerResourceSpec{Name: name}
+		requests = &ResourceList{}
+		limits   = &ResourceList{}
+	)
+
+	if r.CpuShares > minShares {
+		requests.Cpu = Int64((r.CpuShares*1000 + sharesPerCPU - 1) / sharesPerCPU)
+	}
+	if r.CpuQuota > 0 && r.CpuPeriod > 0 {
+		limits.Cpu = Int64(r.CpuQuota * 1000 / r.CpuPeriod)
+	}
+	if r.MemoryLimitInBytes > 0 {
+		limits.Memory = Int64(r.MemoryLimitInBytes)
+	}
+	if v, ok := r.Unified["memory.min"]; ok {
+		if memory, err := strconv.ParseInt(v, 10, 64); err == nil && memory > 0 {
+			requests.Memory = Int64(memory)
+		}
+	}
+
+	if requests.Cpu != nil || requests.Memory != nil {
+		spec.Requests = requests
+	}
+	if limits.Cpu != nil || limits.Memory != nil {
+		spec.Limits = limits
+	}
+
+	return spec
+}
@@ -159,6 +159,9 @@ func (r *LinuxResources) ToOCI() *rspec.LinuxResources {
 		}
 	}
 	for _, d := range r.Devices {
+		if d.GetRemove() {
+			continue
+		}
 		o.Devices = append(o.Devices, rspec.LinuxDeviceCgroup{
 			Allow:  d.Allow,
 			Type:   d.Type,
@@ -208,7 +211,7 @@ func (r *LinuxResources) ToCRI(oomScoreAdj int64) *cri.LinuxContainerResources {
 	return o
 }
 
-// Copy creates a copy of the resources.
+// Copy creates a copy of the resources. Any unknown fields are preserved.
 func (r *LinuxResources) Copy() *LinuxResources {
 	if r == nil {
 		return nil
@@ -225,6 +228,7 @@ func (r *LinuxResources) Copy() *LinuxResources {
 			DisableOomKiller: Bool(r.Memory.GetDisableOomKiller()),
 			UseHierarchy:     Bool(r.Memory.GetUseHierarchy()),
 		}
+		o.Memory.unknownFields = copyUnknownFields(r.Memory.unknownFields)
 	}
 	if r.Cpu != nil {
 		o.Cpu = &LinuxCPU{
@@ -236,11 +240,13 @@ func (r *LinuxResources) Copy() *LinuxResources {
 			Cpus:            r.Cpu.GetCpus(),
 			Mems:            r.Cpu.GetMems(),
 		}
+		o.Cpu.unknownFields = copyUnknownFields(r.Cpu.unknownFields)
 	}
 	for _, l := range r.HugepageLimits {
 		o.HugepageLimits = append(o.HugepageLimits, &HugepageLimit{
-			PageSize: l.PageSize,
-			Limit:    l.Limit,
+			PageSize:      l.PageSize,
+			Limit:         l.Limit,
+			unknownFields: copyUnknownFields(l.unknownFields),
 		})
 	}
 	if len(r.Unified) != 0 {
@@ -249,13 +255,26 @@ func (r *LinuxResources) Copy() *LinuxResources {
 			o.Unified[k] = v
 		}
 	}
+	for _, d := range r.Devices {
+		o.Devices = append(o.Devices, d.Copy())
+	}
 	if r.Pids != nil {
 		o.Pids = &LinuxPids{
-			Limit: r.Pids.Limit,
+			Limit:         r.Pids.Limit,
+			unknownFields: copyUnknownFields(r.Pids.unknownFields),
 		}
 	}
 	o.BlockioClass = String(r.BlockioClass)
 	o.RdtClass = String(r.RdtClass)
+	o.unknownFields = copyUnknownFields(r.unknownFields)
 
 	return o
 }
+
+// copyUnknownFields copies fields unknown to our payload schema version.
+func copyUnknownFields(u []byte) []byte {
+	if len(u) == 0 {
+		return nil
+	}
+	return append([]byte(nil), u...)
+}
@@ -0,0 +1,57 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+)
+
+// IsEmpty returns true if no scheduling hints are set.
+func (h *SchedulingHints) IsEmpty() bool {
+	return h.GetPreferredNumaNode() == nil &&
+		h.GetSmtPolicy() == SMTPolicy_SMT_POLICY_UNSPECIFIED &&
+		h.GetExclusiveCores() == nil
+}
+
+// PreferredNUMANode returns the preferred NUMA node of the hints, and
+// whether one is set.
+func (h *SchedulingHints) PreferredNUMANode() (uint32, bool) {
+	if node := h.GetPreferredNumaNode(); node != nil {
+		return node.GetValue(), true
+	}
+	return 0, false
+}
+
+// WantsExclusiveCores returns true if the hints ask for cores which are
+// not shared with other containers.
+func (h *SchedulingHints) WantsExclusiveCores() bool {
+	return h.GetExclusiveCores().GetValue()
+}
+
+// SharesSMTSiblings returns true if the hints allow sharing the SMT
+// siblings of the CPUs of the container with other containers.
+func (h *SchedulingHints) SharesSMTSiblings() bool {
+	return h.GetSmtPolicy() == SMTPolicy_SMT_POLICY_SHARED
+}
+
+// Validate checks that the hints only use known SMT policies.
+func (h *SchedulingHints) Validate() error {
+	if _, ok := SMTPolicy_name[int32(h.GetSmtPolicy())]; !ok {
+		return fmt.Errorf("unknown SMT policy %d", h.GetSmtPolicy())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// FromCRISecurityProfile returns a security profile from a CRI one.
+func FromCRISecurityProfile(o *cri.SecurityProfile) *SecurityProfile {
+	if o == nil {
+		return nil
+	}
+	return &SecurityProfile{
+		ProfileType:  SecurityProfile_ProfileType(o.ProfileType),
+		LocalhostRef: o.LocalhostRef,
+	}
+}
+
+// FromOCILinuxSeccomp returns a seccomp policy from an OCI runtime Spec.
+func FromOCILinuxSeccomp(o *rspec.LinuxSeccomp) *LinuxSeccomp {
+	if o == nil {
+		return nil
+	}
+
+	s := &LinuxSeccomp{
+		DefaultAction:    string(o.DefaultAction),
+		DefaultErrno:     errnoFromOCI(o.DefaultErrnoRet),
+		ListenerPath:     o.ListenerPath,
+		ListenerMetadata: o.ListenerMetadata,
+	}
+	for _, arch := range o.Architectures {
+		s.Architectures = append(s.Architectures, string(arch))
+	}
+	for _, flag := range o.Flags {
+		s.Flags = append(s.Flags, string(flag))
+	}
+	for _, sc := range o.Syscalls {
+		syscall := &LinuxSyscall{
+			Names:    DupStringSlice(sc.Names),
+			Action:   string(sc.Action),
+			ErrnoRet: errnoFromOCI(sc.ErrnoRet),
+		}
+		for _, arg := range sc.Args {
+			syscall.Args = append(syscall.Args, &LinuxSeccompArg{
+				Index:    uint32(arg.Index),
+				Value:    arg.Value,
+				ValueTwo: arg.ValueTwo,
+				Op:       string(arg.Op),
+			})
+		}
+		s.Syscalls = append(s.Syscalls, syscall)
+	}
+
+	return s
+}
+
+// ToOCI returns the seccomp policy for an OCI runtime Spec.
+func (s *LinuxSeccomp) ToOCI() *rspec.LinuxSeccomp {
+	if s == nil {
+		return nil
+	}
+
+	o := &rspec.LinuxSeccomp{
+		DefaultAction:    rspec.LinuxSeccompAction(s.DefaultAction),
+		DefaultErrnoRet:  errnoToOCI(s.DefaultErrno),
+		ListenerPath:     s.ListenerPath,
+		ListenerMetadata: s.ListenerMetadata,
+	}
+	for _, arch := range s.Architectures {
+		o.Architectures = append(o.Architectures, rspec.Arch(arch))
+	}
+	for _, flag := range s.Flags {
+		o.Flags = append(o.Flags, rspec.LinuxSeccompFlag(flag))
+	}
+	for _, sc := range s.Syscalls {
+		syscall := rspec.LinuxSyscall{
+			Names:    DupStringSlice(sc.Names),
+			Action:   rspec.LinuxSeccompAction(sc.Action),
+			ErrnoRet: errnoToOCI(sc.ErrnoRet),
+		}
+		for _, arg := range sc.Args {
+			syscall.Args = append(syscall.Args, rspec.LinuxSeccompArg{
+				Index:    uint(arg.Index),
+				Value:    arg.Value,
+				ValueTwo: arg.ValueTwo,
+				Op:       rspec.LinuxSeccompOperator(arg.Op),
+			})
+		}
+		o.Syscalls = append(o.Syscalls, syscall)
+	}
+
+	return o
+}
+
+func errnoFromOCI(errno *uint) *OptionalUInt32 {
+	if errno == nil {
+		return nil
+	}
+	return &OptionalUInt32{Value: uint32(*errno)}
+}
+
+func errnoToOCI(errno *OptionalUInt32) *uint {
+	if errno == nil {
+		return nil
+	}
+	v := uint(errno.Value)
+	return &v
+}
@@ -0,0 +1,57 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+// Matches returns true if the selector matches a pod with the given UID
+// and labels.
+func (s *ContainerSelector) Matches(uid string, labels map[string]string) bool {
+	if s == nil {
+		return false
+	}
+	if s.PodUid != "" && s.PodUid != uid {
+		return false
+	}
+	for k, v := range s.Labels {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the filter matches the container in the given
+// pod. A nil filter matches any container.
+func (f *ContainerFilter) Matches(pod *PodSandbox, ctr *Container) bool {
+	if f == nil {
+		return true
+	}
+	if f.PodSandboxId != "" && f.PodSandboxId != ctr.GetPodSandboxId() {
+		return false
+	}
+	if f.Selector != nil && !f.Selector.Matches(pod.GetUid(), pod.GetLabels()) {
+		return false
+	}
+	if len(f.States) == 0 {
+		return true
+	}
+	for _, state := range f.States {
+		if state == ctr.GetState() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// v1 CPU times in cpuacct.stat are in USER_HZ, which is 100 on Linux.
+	userHZ = 100
+)
+
+// ReadContainerStats reads the resource usage of a container from the
+// cgroup filesystem mounted at root, given the OCI Spec cgroups path of
+// the container. Both cgroup v1 and v2 are supported. Statistics which
+// are not available are left unset.
+func ReadContainerStats(root, cgroupsPath string) (*ContainerStats, error) {
+	if root == "" {
+		root = DefaultCgroupRoot
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return readCgroup2Stats(root, cgroupsPath)
+	}
+
+	return readCgroup1Stats(root, cgroupsPath)
+}
+
+func readCgroup2Stats(root, cgroupsPath string) (*ContainerStats, error) {
+	dir, err := CgroupFSPath(root, cgroupsPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read container stats: %w", err)
+	}
+
+	stats := &ContainerStats{}
+
+	if usage, ok := readUint(dir, "memory.current"); ok {
+		stats.Memory = &MemoryStats{Usage: usage}
+		stats.Memory.MaxUsage, _ = readUint(dir, "memory.peak")
+		stats.Memory.Limit, _ = readUint(dir, "memory.max")
+	}
+
+	if kv, ok := readKeyValues(dir, "cpu.stat"); ok {
+		stats.Cpu = &CPUStats{
+			UsageUsec:     kv["usage_usec"],
+			UserUsec:      kv["user_usec"],
+			SystemUsec:    kv["system_usec"],
+			NrPeriods:     kv["nr_periods"],
+			NrThrottled:   kv["nr_throttled"],
+			ThrottledUsec: kv["throttled_usec"],
+		}
+	}
+
+	stats.MemoryPressure = readPressure(dir, "memory.pressure")
+	stats.CpuPressure = readPressure(dir, "cpu.pressure")
+	stats.IoPressure = readPressure(dir, "io.pressure")
+
+	return stats, nil
+}
+
+func readCgroup1Stats(root, cgroupsPath string) (*ContainerStats, error) {
+	var (
+		stats = &ContainerStats{}
+		found bool
+	)
+
+	if dir, err := CgroupFSPath(filepath.Join(root, "memory"), cgroupsPath); err != nil {
+		return nil, err
+	} else if usage, ok := readUint(dir, "memory.usage_in_bytes"); ok {
+		found = true
+		stats.Memory = &MemoryStats{Usage: usage}
+		stats.Memory.MaxUsage, _ = readUint(dir, "memory.max_usage_in_bytes")
+		stats.Memory.Limit, _ = readUint(dir, "memory.limit_in_bytes")
+		// the unlimited v1 limit is the largest page aligned int64
+		if stats.Memory.Limit >= 1<<62 {
+			stats.Memory.Limit = 0
+		}
+	}
+
+	if dir, err := CgroupFSPath(filepath.Join(root, "cpuacct"), cgroupsPath); err != nil {
+		return nil, err
+	} else if usage, ok := readUint(dir, "cpuacct.usage"); ok {
+		found = true
+		stats.Cpu = &CPUStats{UsageUsec: usage / 1000}
+		if kv, ok := readKeyValues(dir, "cpuacct.stat"); ok {
+			stats.Cpu.UserUsec = kv["user"] * 1000000 / userHZ
+			stats.Cpu.SystemUsec = kv["system"] * 1000000 / userHZ
+		}
+	}
+
+	if dir, err := CgroupFSPath(filepath.Join(root, "cpu"), cgroupsPath); err != nil {
+		return nil, err
+	} else if kv, ok := readKeyValues(dir, "cpu.stat"); ok {
+		found = true
+		if stats.Cpu == nil {
+			stats.Cpu = &CPUStats{}
+		}
+		stats.Cpu.NrPeriods = kv["nr_periods"]
+		stats.Cpu.NrThrottled = kv["nr_throttled"]
+		stats.Cpu.ThrottledUsec = kv["throttled_time"] / 1000
+	}
+
+	if !found {
+		return nil, fmt.Errorf("failed to read container stats, no cgroup found for %q",
+			cgroupsPath)
+	}
+
+	return stats, nil
+}
+
+// readUint reads a single unsigned integer from a cgroup file. The value
+// "max" is read as zero.
+func readUint(dir, file string) (uint64, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, true
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// readKeyValues reads a flat keyed cgroup file, like cpu.stat.
+func readKeyValues(dir, file string) (map[string]uint64, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return nil, false
+	}
+
+	kv := map[string]uint64{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			kv[fields[0]] = v
+		}
+	}
+
+	return kv, true
+}
+
+// readPressure reads a cgroup v2 pressure stall information file.
+func readPressure(dir, file string) *PSIStats {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return nil
+	}
+
+	psi := &PSIStats{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		d := &PSIData{}
+		for _, f := range fields[1:] {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "avg10":
+				d.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				d.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				d.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				d.Total, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+		switch fields[0] {
+		case "some":
+			psi.Some = d
+		case "full":
+			psi.Full = d
+		}
+	}
+
+	return psi
+}
@@ -25,4 +25,7 @@ const (
 	DefaultPluginRegistrationTimeout = 5 * time.Second
 	// DefaultPluginRequestTimeout is the default timeout for plugins to handle a request.
 	DefaultPluginRequestTimeout = 2 * time.Second
+	// DefaultKeepaliveMisses is the default number of consecutive keepalives
+	// which can be missed before a connection is considered dead.
+	DefaultKeepaliveMisses = 3
 )
@@ -0,0 +1,182 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sysfsOnlineCPUs lists the online CPUs of the host.
+	sysfsOnlineCPUs = "/sys/devices/system/cpu/online"
+	// sysfsOnlineNodes lists the online memory (NUMA) nodes of the host.
+	sysfsOnlineNodes = "/sys/devices/system/node/online"
+	// maxCPUSetIDs caps the number of IDs a parsed set can list, well above
+	// the number of CPUs supported by Linux, to bound parsing hostile input.
+	maxCPUSetIDs = 1 << 16
+)
+
+// CPUSet is a set of CPU or memory node IDs.
+type CPUSet map[int]struct{}
+
+// ParseCPUSet parses a set of CPU or memory node IDs in the Linux list
+// format used for cpuset.cpus and cpuset.mems, for instance 0-3,8,10-11.
+func ParseCPUSet(value string) (CPUSet, error) {
+	set := CPUSet{}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return set, nil
+	}
+
+	count := 0
+	for _, item := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid cpuset %q: invalid ID %q", value, lo)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(hi)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid cpuset %q: invalid range %q", value, item)
+			}
+		}
+		if count += last - first + 1; count > maxCPUSetIDs || count <= 0 {
+			return nil, fmt.Errorf("invalid cpuset %q: too many IDs", value)
+		}
+		for id := first; id <= last; id++ {
+			set[id] = struct{}{}
+		}
+	}
+
+	return set, nil
+}
+
+// Contains returns true if the set contains all IDs of the other set.
+func (s CPUSet) Contains(o CPUSet) bool {
+	for id := range o {
+		if _, ok := s[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Difference returns the IDs of the set which are not in the other set.
+func (s CPUSet) Difference(o CPUSet) CPUSet {
+	diff := CPUSet{}
+	for id := range s {
+		if _, ok := o[id]; !ok {
+			diff[id] = struct{}{}
+		}
+	}
+	return diff
+}
+
+// String returns the set in the Linux list format.
+func (s CPUSet) String() string {
+	ids := make([]int, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var items []string
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+		if i == j {
+			items = append(items, strconv.Itoa(ids[i]))
+		} else {
+			items = append(items, strconv.Itoa(ids[i])+"-"+strconv.Itoa(ids[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// Topology is the set of online CPUs and memory nodes of a host, used
+// to validate the cpuset adjustments and updates of plugins.
+type Topology struct {
+	// CPUs is the set of online CPUs.
+	CPUs CPUSet
+	// Mems is the set of online memory (NUMA) nodes.
+	Mems CPUSet
+}
+
+// HostTopology returns the topology of the host, read from sysfs.
+func HostTopology() (*Topology, error) {
+	return ReadTopology("/")
+}
+
+// ReadTopology returns the topology of a host with its sysfs mounted
+// under the given root directory.
+func ReadTopology(root string) (*Topology, error) {
+	cpus, err := readCPUSet(filepath.Join(root, sysfsOnlineCPUs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read online CPUs: %w", err)
+	}
+	mems, err := readCPUSet(filepath.Join(root, sysfsOnlineNodes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read online memory nodes: %w", err)
+	}
+	return &Topology{
+		CPUs: cpus,
+		Mems: mems,
+	}, nil
+}
+
+func readCPUSet(path string) (CPUSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCPUSet(string(data))
+}
+
+// CheckCPUs checks that a cpuset.cpus value is valid and lists online CPUs.
+func (t *Topology) CheckCPUs(cpus string) error {
+	return checkCPUSet("CPUs", cpus, t.CPUs)
+}
+
+// CheckMems checks that a cpuset.mems value is valid and lists online
+// memory nodes.
+func (t *Topology) CheckMems(mems string) error {
+	return checkCPUSet("memory nodes", mems, t.Mems)
+}
+
+func checkCPUSet(kind, value string, online CPUSet) error {
+	set, err := ParseCPUSet(value)
+	if err != nil {
+		return err
+	}
+	if !online.Contains(set) {
+		return fmt.Errorf("%s %s not online (online %s: %s)",
+			kind, set.Difference(online), kind, online)
+	}
+	return nil
+}
@@ -22,6 +22,23 @@ func (u *ContainerUpdate) SetContainerId(id string) {
 	u.ContainerId = id
 }
 
+// SetSelectorPodUID selects the containers of the pod with the given UID
+// to update, instead of a single container.
+func (u *ContainerUpdate) SetSelectorPodUID(uid string) {
+	u.initSelector()
+	u.Selector.PodUid = uid
+}
+
+// AddSelectorLabel selects the containers of pods with the given label
+// to update, instead of a single container.
+func (u *ContainerUpdate) AddSelectorLabel(key, value string) {
+	u.initSelector()
+	if u.Selector.Labels == nil {
+		u.Selector.Labels = make(map[string]string)
+	}
+	u.Selector.Labels[key] = value
+}
+
 // SetLinuxMemoryLimit records setting the memory limit for a container.
 func (u *ContainerUpdate) SetLinuxMemoryLimit(value int64) {
 	u.initLinuxResourcesMemory()
@@ -119,12 +136,39 @@ func (u *ContainerUpdate) SetLinuxPidLimits(value int64) {
 }
 
 // AddLinuxHugepageLimit records adding a hugepage limit for a container.
+// The page size is normalized, so 2MiB and 2048kB both become 2MB. Any
+// earlier limit for the same page size is replaced.
 func (u *ContainerUpdate) AddLinuxHugepageLimit(pageSize string, value uint64) {
 	u.initLinuxResources()
-	u.Linux.Resources.HugepageLimits = append(u.Linux.Resources.HugepageLimits,
-		&HugepageLimit{
-			PageSize: pageSize,
-			Limit:    value,
+	u.Linux.Resources.HugepageLimits = addHugepageLimit(u.Linux.Resources.HugepageLimits,
+		pageSize, value)
+}
+
+// AddLinuxDeviceCgroupRule records the addition of a device cgroup rule
+// for a container. A nil major or minor number matches all numbers. Any
+// earlier rule for the same device type and numbers is replaced.
+func (u *ContainerUpdate) AddLinuxDeviceCgroupRule(allow bool, typ string, major, minor *int64, access string) {
+	u.initLinuxResources()
+	u.Linux.Resources.Devices = append(u.Linux.Resources.Devices,
+		&LinuxDeviceCgroup{
+			Allow:  allow,
+			Type:   typ,
+			Major:  Int64(major),
+			Minor:  Int64(minor),
+			Access: access,
+		})
+}
+
+// RemoveLinuxDeviceCgroupRule records the removal of the device cgroup rules
+// for the given device type and numbers from an already created container.
+func (u *ContainerUpdate) RemoveLinuxDeviceCgroupRule(typ string, major, minor *int64) {
+	u.initLinuxResources()
+	u.Linux.Resources.Devices = append(u.Linux.Resources.Devices,
+		&LinuxDeviceCgroup{
+			Type:   typ,
+			Major:  Int64(major),
+			Minor:  Int64(minor),
+			Remove: true,
 		})
 }
 
@@ -146,6 +190,56 @@ func (u *ContainerUpdate) AddLinuxUnified(key, value string) {
 	u.Linux.Resources.Unified[key] = value
 }
 
+// SetLinuxOomScoreAdj records setting the kernel's Out-Of-Memory (OOM) killer
+// score for an already created container.
+func (u *ContainerUpdate) SetLinuxOomScoreAdj(value *int) {
+	u.initLinux()
+	u.Linux.OomScoreAdj = Int(value)
+}
+
+// SetLinuxNetworkBandwidth records setting the network bandwidth limits
+// for an already created container.
+func (u *ContainerUpdate) SetLinuxNetworkBandwidth(b *LinuxNetworkBandwidth) {
+	u.initLinux()
+	u.Linux.NetworkBandwidth = b
+}
+
+// SetLinuxNetworkIngressBandwidth records setting the ingress bandwidth rate,
+// in bits per second, and burst, in bytes, for an already created container.
+// A zero burst is left unset.
+func (u *ContainerUpdate) SetLinuxNetworkIngressBandwidth(rate, burst uint64) {
+	u.initLinuxNetworkBandwidth()
+	u.Linux.NetworkBandwidth.IngressRate = UInt64(rate)
+	if burst != 0 {
+		u.Linux.NetworkBandwidth.IngressBurst = UInt64(burst)
+	}
+}
+
+// SetLinuxNetworkEgressBandwidth records setting the egress bandwidth rate,
+// in bits per second, and burst, in bytes, for an already created container.
+// A zero burst is left unset.
+func (u *ContainerUpdate) SetLinuxNetworkEgressBandwidth(rate, burst uint64) {
+	u.initLinuxNetworkBandwidth()
+	u.Linux.NetworkBandwidth.EgressRate = UInt64(rate)
+	if burst != 0 {
+		u.Linux.NetworkBandwidth.EgressBurst = UInt64(burst)
+	}
+}
+
+// AddAnnotation records the addition of the annotation key=value
+// to an already created container.
+func (u *ContainerUpdate) AddAnnotation(key, value string) {
+	u.initAnnotations()
+	u.Annotations[key] = value
+}
+
+// RemoveAnnotation records the removal of the annotation for the given
+// key from an already created container.
+func (u *ContainerUpdate) RemoveAnnotation(key string) {
+	u.initAnnotations()
+	u.Annotations[MarkForRemoval(key)] = ""
+}
+
 // SetIgnoreFailure marks an Update as ignored for failures.
 // Such updates will not prevent the related container operation
 // from succeeding if the update fails.
@@ -157,12 +251,31 @@ func (u *ContainerUpdate) SetIgnoreFailure() {
 // Initializing a container update.
 //
 
+func (u *ContainerUpdate) initAnnotations() {
+	if u.Annotations == nil {
+		u.Annotations = make(map[string]string)
+	}
+}
+
+func (u *ContainerUpdate) initSelector() {
+	if u.Selector == nil {
+		u.Selector = &ContainerSelector{}
+	}
+}
+
 func (u *ContainerUpdate) initLinux() {
 	if u.Linux == nil {
 		u.Linux = &LinuxContainerUpdate{}
 	}
 }
 
+func (u *ContainerUpdate) initLinuxNetworkBandwidth() {
+	u.initLinux()
+	if u.Linux.NetworkBandwidth == nil {
+		u.Linux.NetworkBandwidth = &LinuxNetworkBandwidth{}
+	}
+}
+
 func (u *ContainerUpdate) initLinuxResources() {
 	u.initLinux()
 	if u.Linux.Resources == nil {
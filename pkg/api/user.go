@@ -0,0 +1,47 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// FromOCIUser returns the user and group identity of a process from an OCI
+// runtime Spec.
+func FromOCIUser(o rspec.User) *User {
+	return &User{
+		Uid:            UInt32(o.UID),
+		Gid:            UInt32(o.GID),
+		AdditionalGids: DupUint32Slice(o.AdditionalGids),
+	}
+}
+
+// ToOCI returns the user and group identity for an OCI runtime Spec.
+func (u *User) ToOCI() rspec.User {
+	o := rspec.User{}
+	if u == nil {
+		return o
+	}
+	if uid := u.GetUid(); uid != nil {
+		o.UID = uid.Value
+	}
+	if gid := u.GetGid(); gid != nil {
+		o.GID = gid.Value
+	}
+	o.AdditionalGids = DupUint32Slice(u.AdditionalGids)
+	return o
+}
@@ -0,0 +1,75 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// vetoMarker marks the message of a veto error.
+	vetoMarker = "container start vetoed: "
+)
+
+// VetoError is returned by plugins to veto starting a container.
+type VetoError struct {
+	// Reason is a human-readable explanation of the veto.
+	Reason string
+}
+
+// Veto returns an error for vetoing the start of a container. Plugins return
+// it from their PostCreateContainer or StartContainer handler to ask the
+// runtime to destroy the container before it runs. Runtimes only honor the
+// vetoes of plugins they allow to veto container starts. Other errors of
+// plugins never veto container starts. Plugins dispatching events concurrently
+// can't veto, since their events are acknowledged before they are handled.
+func Veto(format string, args ...interface{}) error {
+	return &VetoError{
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error returns the error message of the veto.
+func (e *VetoError) Error() string {
+	return vetoMarker + e.Reason
+}
+
+// GRPCStatus returns the gRPC status corresponding to the veto.
+func (e *VetoError) GRPCStatus() *status.Status {
+	return status.New(codes.Aborted, e.Error())
+}
+
+// IsVeto checks if an error, either returned by a plugin or received from
+// one, is a veto. If it is, it also returns the reason for the veto.
+func IsVeto(err error) (string, bool) {
+	var veto *VetoError
+	if errors.As(err, &veto) {
+		return veto.Reason, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return "", false
+	}
+	_, reason, found := strings.Cut(st.Message(), vetoMarker)
+	return reason, found
+}
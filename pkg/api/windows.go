@@ -0,0 +1,92 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api
+
+import (
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// FromOCIWindows returns Windows container parameters from an OCI runtime Spec.
+func FromOCIWindows(o *rspec.Windows) *WindowsContainer {
+	if o == nil {
+		return nil
+	}
+	w := &WindowsContainer{
+		Resources: FromOCIWindowsResources(o.Resources),
+	}
+	if h := o.HyperV; h != nil {
+		w.Hyperv = &WindowsHyperV{
+			UtilityVmPath: h.UtilityVMPath,
+		}
+	}
+	return w
+}
+
+// FromOCIWindowsResources returns Windows resources from an OCI runtime Spec.
+func FromOCIWindowsResources(o *rspec.WindowsResources) *WindowsResources {
+	if o == nil {
+		return nil
+	}
+	w := &WindowsResources{}
+	if m := o.Memory; m != nil {
+		w.Memory = &WindowsMemory{
+			Limit: UInt64(m.Limit),
+		}
+	}
+	if c := o.CPU; c != nil {
+		w.Cpu = &WindowsCPU{
+			Count:   UInt64(c.Count),
+			Shares:  UInt32(c.Shares),
+			Maximum: UInt32(c.Maximum),
+		}
+	}
+	return w
+}
+
+// IsHyperV returns true if the container runs with Hyper-V isolation.
+func (w *WindowsContainer) IsHyperV() bool {
+	return w.GetHyperv() != nil
+}
+
+// ToOCI returns Windows resources for an OCI runtime Spec.
+func (r *WindowsResources) ToOCI() *rspec.WindowsResources {
+	if r == nil {
+		return nil
+	}
+	o := &rspec.WindowsResources{}
+	if m := r.Memory; m != nil {
+		o.Memory = &rspec.WindowsMemoryResources{
+			Limit: m.GetLimit().Get(),
+		}
+	}
+	if c := r.Cpu; c != nil {
+		o.CPU = &rspec.WindowsCPUResources{
+			Count:   c.GetCount().Get(),
+			Shares:  uint16Ptr(c.GetShares().Get()),
+			Maximum: uint16Ptr(c.GetMaximum().Get()),
+		}
+	}
+	return o
+}
+
+func uint16Ptr(v *uint32) *uint16 {
+	if v == nil {
+		return nil
+	}
+	u := uint16(*v)
+	return &u
+}
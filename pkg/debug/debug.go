@@ -0,0 +1,273 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package debug implements the optional debug listener of NRI plugins and
+// the runtime. The listener serves pprof profiles at /debug/pprof/ and a
+// JSON status page at /debug/status, listing connections and their status,
+// pending requests and the last errors. Users of the listener can serve
+// extra endpoints of their own.
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/ttrpc"
+)
+
+const (
+	// MaxErrors is the number of last errors kept for the status page.
+	MaxErrors = 16
+)
+
+// Status is the status reported on the status page.
+type Status struct {
+	// Connections lists the active connections.
+	Connections []string `json:"connections"`
+	// ConnectionStatus is the reported status of connections, if any.
+	ConnectionStatus map[string]string `json:"connectionStatus,omitempty"`
+	// PendingRequests lists the requests in progress.
+	PendingRequests []*Request `json:"pendingRequests"`
+	// LastErrors lists the last failed requests.
+	LastErrors []*Request `json:"lastErrors"`
+}
+
+// Request is a pending or a failed request.
+type Request struct {
+	// Method is the method of the request.
+	Method string `json:"method"`
+	// Started is the time the request was started.
+	Started time.Time `json:"started"`
+	// Error is the error of a failed request.
+	Error string `json:"error,omitempty"`
+}
+
+// Tracker tracks connections, pending and failed requests.
+type Tracker struct {
+	sync.Mutex
+	conns   map[string]int
+	status  map[string]string
+	next    uint64
+	pending map[uint64]*Request
+	errors  []*Request
+}
+
+// Connected records a new connection.
+func (t *Tracker) Connected(conn string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.conns == nil {
+		t.conns = make(map[string]int)
+	}
+	t.conns[conn]++
+}
+
+// Disconnected records a closed connection.
+func (t *Tracker) Disconnected(conn string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.conns[conn] <= 1 {
+		delete(t.conns, conn)
+		delete(t.status, conn)
+		return
+	}
+	t.conns[conn]--
+}
+
+// SetStatus records the reported status of a connection.
+func (t *Tracker) SetStatus(conn, status string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.conns[conn]; !ok {
+		return
+	}
+	if t.status == nil {
+		t.status = make(map[string]string)
+	}
+	t.status[conn] = status
+}
+
+// Begin tracking a request. The returned function must be called with
+// the result of the request once it is done.
+func (t *Tracker) Begin(method string) func(error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.pending == nil {
+		t.pending = make(map[uint64]*Request)
+	}
+
+	id := t.next
+	t.next++
+	req := &Request{
+		Method:  method,
+		Started: time.Now(),
+	}
+	t.pending[id] = req
+
+	return func(err error) {
+		t.Lock()
+		defer t.Unlock()
+
+		delete(t.pending, id)
+		if err == nil {
+			return
+		}
+
+		if len(t.errors) >= MaxErrors {
+			t.errors = t.errors[1:]
+		}
+		t.errors = append(t.errors, &Request{
+			Method:  req.Method,
+			Started: req.Started,
+			Error:   err.Error(),
+		})
+	}
+}
+
+// Status returns the connections, pending requests and last errors.
+func (t *Tracker) Status() *Status {
+	t.Lock()
+	defer t.Unlock()
+
+	s := &Status{
+		Connections:     make([]string, 0, len(t.conns)),
+		PendingRequests: make([]*Request, 0, len(t.pending)),
+		LastErrors:      make([]*Request, 0, len(t.errors)),
+	}
+	for conn, cnt := range t.conns {
+		for i := 0; i < cnt; i++ {
+			s.Connections = append(s.Connections, conn)
+		}
+	}
+	sort.Strings(s.Connections)
+	if len(t.status) > 0 {
+		s.ConnectionStatus = make(map[string]string, len(t.status))
+		for conn, status := range t.status {
+			s.ConnectionStatus[conn] = status
+		}
+	}
+	for _, req := range t.pending {
+		r := *req
+		s.PendingRequests = append(s.PendingRequests, &r)
+	}
+	sort.Slice(s.PendingRequests, func(i, j int) bool {
+		return s.PendingRequests[i].Started.Before(s.PendingRequests[j].Started)
+	})
+	for _, req := range t.errors {
+		r := *req
+		s.LastErrors = append(s.LastErrors, &r)
+	}
+
+	return s
+}
+
+// ServerInterceptor returns a ttrpc server interceptor for tracking requests.
+func (t *Tracker) ServerInterceptor() ttrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, unmarshal ttrpc.Unmarshaler, info *ttrpc.UnaryServerInfo, method ttrpc.Method) (interface{}, error) {
+		done := t.Begin(info.FullMethod)
+		rpl, err := method(ctx, unmarshal)
+		done(err)
+		return rpl, err
+	}
+}
+
+// ClientInterceptor returns a ttrpc client interceptor for tracking requests.
+func (t *Tracker) ClientInterceptor() ttrpc.UnaryClientInterceptor {
+	return func(ctx context.Context, req *ttrpc.Request, rpl *ttrpc.Response, info *ttrpc.UnaryClientInfo, invoker ttrpc.Invoker) error {
+		done := t.Begin(info.FullMethod)
+		err := invoker(ctx, req, rpl)
+		done(err)
+		return err
+	}
+}
+
+// ListenOption is an option for the debug listener.
+type ListenOption func(*http.ServeMux)
+
+// WithHandler returns an option to serve an extra endpoint using handler.
+func WithHandler(pattern string, handler http.Handler) ListenOption {
+	return func(mux *http.ServeMux) {
+		mux.Handle(pattern, handler)
+	}
+}
+
+// Server is a debug listener.
+type Server struct {
+	l   net.Listener
+	srv *http.Server
+}
+
+// Listen starts serving pprof profiles and the status page of the tracker
+// on the given address.
+func Listen(addr string, t *Tracker, opts ...ListenOption) (*Server, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug listener on %q: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(t.Status()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	for _, o := range opts {
+		o(mux)
+	}
+
+	s := &Server{
+		l: l,
+		srv: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+
+	go func() {
+		_ = s.srv.Serve(l)
+	}()
+
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.l.Addr()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
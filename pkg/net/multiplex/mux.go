@@ -22,8 +22,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"sync"
-	"syscall"
 	"time"
 
 	nrinet "github.com/containerd/nri/pkg/net"
@@ -41,12 +41,15 @@ import (
 // Writing to a connection is fully synchronous. The caller can safely
 // reuse the buffer once the call returns. Reading from a connection
 // returns the oldest demultiplexed buffer for the connection, blocking
-// if the connections incoming queue is empty. If any incoming queue is
-// ever overflown the underlying trunk and all multiplexed connections
-// are closed and an error is recorded. This error is later returned by
-// any subsequent read from any connection. All connections of the Mux
-// have the same fixed incoming queue length which can be configured
-// using the WithReadQueueLength Option during Mux creation.
+// if the connections incoming queue is empty. If the buffer does not fit
+// the read, the rest of it is returned by subsequent reads, so streaming
+// protocols like HTTP/2 can also be used over a connection. If any
+// incoming queue is ever overflown the underlying trunk and all
+// multiplexed connections are closed and an error is recorded. This
+// error is later returned by any subsequent read from any connection.
+// All connections of the Mux have the same fixed incoming queue length
+// which can be configured using the WithReadQueueLength Option during
+// Mux creation.
 //
 // The Mux interface also provides functions that emulate net.Dial and
 // net.Listen for a connection. Usually these can be used for passing
@@ -147,6 +150,8 @@ type conn struct {
 	id        ConnID
 	mux       *mux
 	readC     chan []byte
+	readLock  sync.Mutex
+	pending   []byte
 	closeOnce sync.Once
 	doneC     chan error
 }
@@ -385,6 +390,15 @@ func (c *conn) Read(buf []byte) (int, error) {
 		ok  bool
 	)
 
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+
+	if len(c.pending) > 0 {
+		n := copy(buf, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+
 	select {
 	case err, ok = <-c.doneC:
 		if !ok || err == nil {
@@ -395,13 +409,11 @@ func (c *conn) Read(buf []byte) (int, error) {
 		if !ok {
 			return 0, c.mux.error()
 		}
-		if cap(buf) < len(msg) {
-			return 0, syscall.ENOMEM
-		}
 	}
 
-	copy(buf, msg)
-	return len(msg), nil
+	n := copy(buf, msg)
+	c.pending = msg[n:]
+	return n, nil
 }
 
 // Write writes the given data to the multiplexed connection.
@@ -434,14 +446,14 @@ func (c *conn) close() error {
 	return nil
 }
 
-// LocalAddr is the unimplemented stub for the corresponding net.Conn function.
+// LocalAddr returns the ConnID of the connection as its address.
 func (c *conn) LocalAddr() net.Addr {
-	return nil
+	return connAddr(c.id)
 }
 
-// RemoteAddr is the unimplemented stub for the corresponding net.Conn function.
+// RemoteAddr returns the ConnID of the connection as its address.
 func (c *conn) RemoteAddr() net.Addr {
-	return nil
+	return connAddr(c.id)
 }
 
 // SetDeadline is the unimplemented stub for the corresponding net.Conn function.
@@ -458,3 +470,17 @@ func (c *conn) SetReadDeadline(_ time.Time) error {
 func (c *conn) SetWriteDeadline(_ time.Time) error {
 	return nil
 }
+
+// connAddr is the address of a multiplexed connection. Some packages, for
+// instance gRPC, insist on connections and listeners having an address.
+type connAddr ConnID
+
+// Network returns the name of the network of the address.
+func (a connAddr) Network() string {
+	return "mux"
+}
+
+// String returns the string form of the address.
+func (a connAddr) String() string {
+	return "mux:" + strconv.FormatUint(uint64(a), 10)
+}
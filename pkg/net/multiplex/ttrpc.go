@@ -23,6 +23,14 @@ const (
 	RuntimeServiceConn
 )
 
+// SubPluginConns returns the mux connection IDs for the NRI plugin and
+// runtime services of the sub-plugin with the given number, hosted on the
+// connection of another plugin. Sub-plugins are numbered starting from 1.
+// Number 0 gives the connection IDs of the hosting plugin itself.
+func SubPluginConns(n int) (ConnID, ConnID) {
+	return PluginServiceConn + ConnID(2*n), RuntimeServiceConn + ConnID(2*n)
+}
+
 const (
 	ttrpcMessageHeaderLength = 10
 	ttrpcMessageLengthMax    = 4 << 20
@@ -0,0 +1,200 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package cli provides the common command line flags and bootstrapping of
+// NRI plugins, so that all plugins can be deployed in the same way.
+package cli
+
+import (
+	"expvar"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// TextLogFormat is the name of the plain text log format.
+	TextLogFormat = "text"
+	// JSONLogFormat is the name of the JSON log format.
+	JSONLogFormat = "json"
+)
+
+// Options are the common command line options of NRI plugins.
+type Options struct {
+	// Name is the plugin name to register with.
+	Name string
+	// Idx is the plugin index to register with.
+	Idx string
+	// SocketPath is the NRI socket path to connect to.
+	SocketPath string
+	// LogLevel is the logging level.
+	LogLevel string
+	// LogFormat is the logging format, text or json.
+	LogFormat string
+	// MetricsAddr is the address to serve metrics on, if any.
+	MetricsAddr string
+	// DebugAddr is the address to serve debug endpoints on, if any.
+	DebugAddr string
+	// ConfigFile is the plugin configuration file, if any.
+	ConfigFile string
+	// SelfTest runs a self-test of the plugin instead of connecting to NRI.
+	SelfTest bool
+	// Reconnect makes the plugin reconnect to NRI if the connection is lost.
+	Reconnect bool
+}
+
+// NewOptions returns the common options with their default values.
+func NewOptions() *Options {
+	return &Options{
+		LogLevel:  logrus.InfoLevel.String(),
+		LogFormat: TextLogFormat,
+	}
+}
+
+// AddFlags registers the common options as flags in the given flag set.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Name, "name", o.Name, "plugin name to register to NRI")
+	fs.StringVar(&o.Idx, "idx", o.Idx, "plugin index to register to NRI")
+	fs.StringVar(&o.SocketPath, "socket-path", o.SocketPath, "NRI socket path to connect to")
+	fs.StringVar(&o.LogLevel, "log-level", o.LogLevel, "logging level (debug, info, warn, error)")
+	fs.StringVar(&o.LogFormat, "log-format", o.LogFormat, "logging format (text, json)")
+	fs.StringVar(&o.MetricsAddr, "metrics-addr", o.MetricsAddr, "address to serve metrics on, disabled if empty")
+	fs.StringVar(&o.DebugAddr, "debug-addr", o.DebugAddr, "address to serve pprof and status on, disabled if empty")
+	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile, "plugin configuration file")
+	fs.BoolVar(&o.SelfTest, "nri-self-test", o.SelfTest, "run a self-test of the plugin, then exit")
+	fs.BoolVar(&o.Reconnect, "reconnect", o.Reconnect, "reconnect to NRI if the connection is lost")
+}
+
+// Parse registers the common options with the default flag set then
+// parses the command line. Plugin-specific flags can be registered before
+// calling Parse.
+func Parse() *Options {
+	o := NewOptions()
+	o.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+// Setup configures the given logger, starts serving metrics if enabled,
+// and returns the stub options corresponding to the common options.
+func (o *Options) Setup(log *logrus.Logger) ([]stub.Option, error) {
+	if err := o.SetupLogger(log); err != nil {
+		return nil, err
+	}
+	if err := o.StartMetrics(); err != nil {
+		return nil, err
+	}
+	return o.StubOptions(), nil
+}
+
+// SetupLogger configures the level and format of the given logger.
+func (o *Options) SetupLogger(log *logrus.Logger) error {
+	level, err := logrus.ParseLevel(o.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", o.LogLevel, err)
+	}
+	log.SetLevel(level)
+
+	switch o.LogFormat {
+	case TextLogFormat, "":
+		log.SetFormatter(&logrus.TextFormatter{
+			PadLevelText: true,
+		})
+	case JSONLogFormat:
+		log.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid log format %q", o.LogFormat)
+	}
+
+	return nil
+}
+
+// StartMetrics starts serving metrics if a metrics address is set. Metrics
+// are published in JSON using the expvar package at /debug/vars.
+func (o *Options) StartMetrics() error {
+	if o.MetricsAddr == "" {
+		return nil
+	}
+
+	l, err := net.Listen("tcp", o.MetricsAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %q: %w", o.MetricsAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(l); err != nil {
+			logrus.StandardLogger().Errorf("failed to serve metrics: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// StubOptions returns the stub options corresponding to the common options.
+func (o *Options) StubOptions() []stub.Option {
+	var opts []stub.Option
+
+	if o.Name != "" {
+		opts = append(opts, stub.WithPluginName(o.Name))
+	}
+	if o.Idx != "" {
+		opts = append(opts, stub.WithPluginIdx(o.Idx))
+	}
+	if o.SocketPath != "" {
+		opts = append(opts, stub.WithSocketPath(o.SocketPath))
+	}
+	if o.DebugAddr != "" {
+		opts = append(opts, stub.WithDebugListener(o.DebugAddr))
+	}
+	if o.SelfTest {
+		opts = append(opts, stub.WithSelfTest(""))
+	}
+	if o.Reconnect {
+		opts = append(opts, stub.WithReconnect(stub.ReconnectPolicy{}))
+	}
+
+	return opts
+}
+
+// ReadConfig returns the contents of the configuration file, or nil if
+// no configuration file is set.
+func (o *Options) ReadConfig() ([]byte, error) {
+	if o.ConfigFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(o.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %q: %w", o.ConfigFile, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,154 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cli_test
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
+	"github.com/containerd/nri/pkg/stub"
+
+	require "github.com/stretchr/testify/require"
+)
+
+func parse(args ...string) (*cli.Options, error) {
+	fs := flag.NewFlagSet("plugin", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	o := cli.NewOptions()
+	o.AddFlags(fs)
+	return o, fs.Parse(args)
+}
+
+func TestParseFlags(t *testing.T) {
+	o, err := parse()
+	require.NoError(t, err)
+	require.Equal(t, &cli.Options{LogLevel: "info", LogFormat: cli.TextLogFormat}, o)
+
+	o, err = parse(
+		"-name", "test",
+		"-idx", "05",
+		"-socket-path", "/run/nri/test.sock",
+		"-log-level", "debug",
+		"-log-format", "json",
+		"-metrics-addr", "localhost:9090",
+		"-debug-addr", "localhost:6060",
+		"-config", "/etc/nri/test.yaml",
+		"-nri-self-test",
+		"-reconnect",
+	)
+	require.NoError(t, err)
+	require.Equal(t, &cli.Options{
+		Name:        "test",
+		Idx:         "05",
+		SocketPath:  "/run/nri/test.sock",
+		LogLevel:    "debug",
+		LogFormat:   cli.JSONLogFormat,
+		MetricsAddr: "localhost:9090",
+		DebugAddr:   "localhost:6060",
+		ConfigFile:  "/etc/nri/test.yaml",
+		SelfTest:    true,
+		Reconnect:   true,
+	}, o)
+
+	_, err = parse("-no-such-flag")
+	require.Error(t, err)
+}
+
+func TestSetupLogger(t *testing.T) {
+	log := logrus.New()
+
+	o, err := parse("-log-level", "warn", "-log-format", "json")
+	require.NoError(t, err)
+	require.NoError(t, o.SetupLogger(log))
+	require.Equal(t, logrus.WarnLevel, log.GetLevel())
+	require.IsType(t, &logrus.JSONFormatter{}, log.Formatter)
+
+	o, err = parse("-log-level", "loud")
+	require.NoError(t, err)
+	require.Error(t, o.SetupLogger(log))
+
+	o, err = parse("-log-format", "xml")
+	require.NoError(t, err)
+	require.Error(t, o.SetupLogger(log))
+}
+
+func TestReadConfig(t *testing.T) {
+	o, err := parse()
+	require.NoError(t, err)
+	data, err := o.ReadConfig()
+	require.NoError(t, err)
+	require.Nil(t, data, "configuration without a configuration file")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value\n"), 0o644))
+	o, err = parse("-config", path)
+	require.NoError(t, err)
+	data, err = o.ReadConfig()
+	require.NoError(t, err)
+	require.Equal(t, "key: value\n", string(data))
+
+	o, err = parse("-config", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	_, err = o.ReadConfig()
+	require.Error(t, err)
+}
+
+type plugin struct {
+	created bool
+}
+
+func (p *plugin) CreateContainer(context.Context, *api.PodSandbox, *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.created = true
+	return nil, nil, nil
+}
+
+func TestStubOptions(t *testing.T) {
+	o, err := parse()
+	require.NoError(t, err)
+	require.Empty(t, o.StubOptions())
+
+	o, err = parse("-name", "test", "-idx", "05")
+	require.NoError(t, err)
+	s, err := stub.New(&plugin{}, o.StubOptions()...)
+	require.NoError(t, err)
+	require.Equal(t, "05-test", s.(interface{ Name() string }).Name())
+
+	socket := filepath.Join(t.TempDir(), "nri.sock")
+	o, err = parse("-name", "test", "-idx", "05", "-socket-path", socket)
+	require.NoError(t, err)
+	s, err = stub.New(&plugin{}, o.StubOptions()...)
+	require.NoError(t, err)
+	err = s.Start(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), socket)
+
+	p := &plugin{}
+	o, err = parse("-name", "test", "-idx", "05", "-socket-path", socket, "-nri-self-test")
+	require.NoError(t, err)
+	s, err = stub.New(p, o.StubOptions()...)
+	require.NoError(t, err)
+	require.NoError(t, s.Run(context.Background()), "self-test instead of connecting")
+	require.True(t, p.created)
+}
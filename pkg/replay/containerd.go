@@ -0,0 +1,167 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+var (
+	logMsgRe = regexp.MustCompile(`msg="((?:[^"\\]|\\.)*)"`)
+
+	runPodRe = regexp.MustCompile(`^RunPodSandbox for &PodSandboxMetadata\{Name:(.*?),Uid:(.*?),` +
+		`Namespace:(.*?),Attempt:\d+,?\} returns sandbox id "([^"]+)"`)
+	stopPodRe   = regexp.MustCompile(`^StopPodSandbox for "([^"]+)" returns successfully`)
+	removePodRe = regexp.MustCompile(`^RemovePodSandbox (?:for )?"([^"]+)" returns successfully`)
+	createCtrRe = regexp.MustCompile(`^CreateContainer within sandbox "([^"]+)" for ` +
+		`&ContainerMetadata\{Name:(.*?),Attempt:\d+,?\} returns container id "([^"]+)"`)
+	startCtrRe  = regexp.MustCompile(`^StartContainer for "([^"]+)" returns successfully`)
+	updateReqRe = regexp.MustCompile(`^UpdateContainerResources for "([^"]+)" with Linux: (&LinuxContainerResources\{.*)`)
+	updateCtrRe = regexp.MustCompile(`^UpdateContainerResources for "([^"]+)" returns successfully`)
+	stopCtrRe   = regexp.MustCompile(`^StopContainer for "([^"]+)" returns successfully`)
+	removeCtrRe = regexp.MustCompile(`^RemoveContainer for "([^"]+)" returns successfully`)
+	resFieldRe  = regexp.MustCompile(`\b(CpuPeriod|CpuQuota|CpuShares|MemoryLimitInBytes|OomScoreAdj|` +
+		`CpusetCpus|CpusetMems|MemorySwapLimitInBytes):([^,}]*)`)
+)
+
+// ParseContainerdLog converts the CRI calls logged by containerd to NRI
+// events. It understands the messages the CRI plugin of containerd logs at
+// the info and debug levels, in text or JSON log format, and ignores any
+// other lines. Only the metadata containerd logs is known of pods and
+// containers. Calls for pods and containers created before the log starts
+// are ignored.
+func ParseContainerdLog(r io.Reader) ([]*Step, error) {
+	var (
+		c       = newConverter()
+		scanner = newScanner(r)
+		updates = map[string]*api.LinuxResources{}
+	)
+
+	for scanner.Scan() {
+		msg, ok := logMessage(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		if m := runPodRe.FindStringSubmatch(msg); m != nil {
+			c.runPodSandbox(&api.PodSandbox{
+				Id:        m[4],
+				Name:      m[1],
+				Uid:       m[2],
+				Namespace: m[3],
+			})
+		} else if m := stopPodRe.FindStringSubmatch(msg); m != nil {
+			c.stopPodSandbox(m[1])
+		} else if m := removePodRe.FindStringSubmatch(msg); m != nil {
+			c.removePodSandbox(m[1])
+		} else if m := createCtrRe.FindStringSubmatch(msg); m != nil {
+			c.createContainer(&api.Container{
+				Id:           m[3],
+				PodSandboxId: m[1],
+				Name:         m[2],
+			})
+		} else if m := startCtrRe.FindStringSubmatch(msg); m != nil {
+			c.startContainer(m[1])
+		} else if m := updateReqRe.FindStringSubmatch(msg); m != nil {
+			updates[m[1]] = parseLinuxResources(m[2])
+		} else if m := updateCtrRe.FindStringSubmatch(msg); m != nil {
+			c.updateContainer(m[1], updates[m[1]])
+			delete(updates, m[1])
+		} else if m := stopCtrRe.FindStringSubmatch(msg); m != nil {
+			c.stopContainer(m[1])
+		} else if m := removeCtrRe.FindStringSubmatch(msg); m != nil {
+			c.removeContainer(m[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read containerd log: %w", err)
+	}
+
+	return c.steps, nil
+}
+
+// logMessage extracts the message from a containerd log line.
+func logMessage(line string) (string, bool) {
+	if strings.HasPrefix(line, "{") {
+		entry := struct {
+			Msg string `json:"msg"`
+		}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Msg == "" {
+			return "", false
+		}
+		return entry.Msg, true
+	}
+
+	m := logMsgRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	msg, err := strconv.Unquote(`"` + m[1] + `"`)
+	if err != nil {
+		return "", false
+	}
+	return msg, true
+}
+
+// parseLinuxResources parses the scalar fields of logged CRI container
+// resources.
+func parseLinuxResources(s string) *api.LinuxResources {
+	res := &cri.LinuxContainerResources{}
+	for _, m := range resFieldRe.FindAllStringSubmatch(s, -1) {
+		var (
+			field = m[1]
+			value = m[2]
+		)
+		switch field {
+		case "CpusetCpus":
+			res.CpusetCpus = value
+			continue
+		case "CpusetMems":
+			res.CpusetMems = value
+			continue
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch field {
+		case "CpuPeriod":
+			res.CpuPeriod = v
+		case "CpuQuota":
+			res.CpuQuota = v
+		case "CpuShares":
+			res.CpuShares = v
+		case "MemoryLimitInBytes":
+			res.MemoryLimitInBytes = v
+		case "OomScoreAdj":
+			res.OomScoreAdj = v
+		case "MemorySwapLimitInBytes":
+			res.MemorySwapLimitInBytes = v
+		}
+	}
+	return api.FromCRILinuxResources(res)
+}
@@ -0,0 +1,155 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package replay
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+// converter converts successful CRI calls to the NRI events a runtime
+// relays for them. Calls for pods and containers created before the
+// trace starts are ignored, since their state is unknown.
+type converter struct {
+	pods  map[string]*api.PodSandbox
+	ctrs  map[string]*api.Container
+	order []string
+	steps []*Step
+}
+
+func newConverter() *converter {
+	return &converter{
+		pods: map[string]*api.PodSandbox{},
+		ctrs: map[string]*api.Container{},
+	}
+}
+
+// emit adds a step with a snapshot of the pod and container.
+func (c *converter) emit(event api.Event, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) {
+	s := &Step{
+		Event: event,
+		Pod:   proto.Clone(pod).(*api.PodSandbox),
+	}
+	if ctr != nil {
+		s.Container = proto.Clone(ctr).(*api.Container)
+	}
+	if res != nil {
+		s.Resources = proto.Clone(res).(*api.LinuxResources)
+	}
+	c.steps = append(c.steps, s)
+}
+
+func (c *converter) runPodSandbox(pod *api.PodSandbox) {
+	if pod.Id == "" {
+		return
+	}
+	c.pods[pod.Id] = pod
+	c.emit(api.Event_RUN_POD_SANDBOX, pod, nil, nil)
+}
+
+func (c *converter) stopPodSandbox(id string) {
+	pod, ok := c.pods[id]
+	if !ok {
+		return
+	}
+	// the runtime stops any running containers of the pod first
+	for _, ctrID := range c.order {
+		if ctr := c.ctrs[ctrID]; ctr.PodSandboxId == id && ctr.State == api.ContainerState_CONTAINER_RUNNING {
+			c.stopContainer(ctrID)
+		}
+	}
+	c.emit(api.Event_STOP_POD_SANDBOX, pod, nil, nil)
+}
+
+func (c *converter) removePodSandbox(id string) {
+	pod, ok := c.pods[id]
+	if !ok {
+		return
+	}
+	// the runtime removes any remaining containers of the pod first
+	for _, ctrID := range append([]string{}, c.order...) {
+		if ctr := c.ctrs[ctrID]; ctr.PodSandboxId == id {
+			c.removeContainer(ctrID)
+		}
+	}
+	c.emit(api.Event_REMOVE_POD_SANDBOX, pod, nil, nil)
+	delete(c.pods, id)
+}
+
+func (c *converter) createContainer(ctr *api.Container) {
+	pod, ok := c.pods[ctr.PodSandboxId]
+	if !ok || ctr.Id == "" {
+		return
+	}
+	ctr.State = api.ContainerState_CONTAINER_CREATED
+	c.ctrs[ctr.Id] = ctr
+	c.order = append(c.order, ctr.Id)
+	c.emit(api.Event_CREATE_CONTAINER, pod, ctr, nil)
+	c.emit(api.Event_POST_CREATE_CONTAINER, pod, ctr, nil)
+}
+
+func (c *converter) startContainer(id string) {
+	ctr, ok := c.ctrs[id]
+	if !ok {
+		return
+	}
+	pod := c.pods[ctr.PodSandboxId]
+	ctr.State = api.ContainerState_CONTAINER_RUNNING
+	c.emit(api.Event_START_CONTAINER, pod, ctr, nil)
+	c.emit(api.Event_POST_START_CONTAINER, pod, ctr, nil)
+}
+
+func (c *converter) updateContainer(id string, res *api.LinuxResources) {
+	ctr, ok := c.ctrs[id]
+	if !ok || res == nil {
+		return
+	}
+	pod := c.pods[ctr.PodSandboxId]
+	c.emit(api.Event_UPDATE_CONTAINER, pod, ctr, res)
+	if ctr.Linux == nil {
+		ctr.Linux = &api.LinuxContainer{}
+	}
+	ctr.Linux.Resources = res
+	c.emit(api.Event_POST_UPDATE_CONTAINER, pod, ctr, nil)
+}
+
+func (c *converter) stopContainer(id string) {
+	ctr, ok := c.ctrs[id]
+	if !ok || ctr.State == api.ContainerState_CONTAINER_STOPPED {
+		return
+	}
+	pod := c.pods[ctr.PodSandboxId]
+	ctr.State = api.ContainerState_CONTAINER_STOPPED
+	c.emit(api.Event_STOP_CONTAINER, pod, ctr, nil)
+}
+
+func (c *converter) removeContainer(id string) {
+	ctr, ok := c.ctrs[id]
+	if !ok {
+		return
+	}
+	pod := c.pods[ctr.PodSandboxId]
+	c.emit(api.Event_REMOVE_CONTAINER, pod, ctr, nil)
+	delete(c.ctrs, id)
+	for i, ctrID := range c.order {
+		if ctrID == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
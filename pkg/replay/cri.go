@@ -0,0 +1,224 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+// CRICall is a single recorded CRI call, as dumped, for instance, by a gRPC
+// interceptor on the kubelet or runtime side of the CRI connection. Request
+// and Response are the JSON encoding of the CRI request and response.
+type CRICall struct {
+	// Method is the CRI method called, either with its full gRPC name, like
+	// /runtime.v1.RuntimeService/RunPodSandbox, or just RunPodSandbox.
+	Method string `json:"method"`
+	// Request is the request of the call.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Response is the response of the call.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error is the error the call failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ParseCRITrace converts recorded CRI calls, one CRICall per line in JSON,
+// to NRI events. Failed calls and calls other than the ones of the pod and
+// container lifecycle are ignored. Calls for pods and containers created
+// before the trace starts are ignored. Empty lines and lines starting with
+// '#' are skipped.
+func ParseCRITrace(r io.Reader) ([]*Step, error) {
+	var (
+		c       = newConverter()
+		scanner = newScanner(r)
+		lineNum = 0
+	)
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		call := &CRICall{}
+		if err := json.Unmarshal([]byte(line), call); err != nil {
+			return nil, fmt.Errorf("line %d: invalid CRI call: %w", lineNum, err)
+		}
+		if call.Error != "" {
+			continue
+		}
+		if err := c.convertCall(call); err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", lineNum, call.Method, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CRI trace: %w", err)
+	}
+
+	return c.steps, nil
+}
+
+// convertCall converts a single successful CRI call.
+func (c *converter) convertCall(call *CRICall) error {
+	switch path.Base(call.Method) {
+	case "RunPodSandbox":
+		var (
+			req = &cri.RunPodSandboxRequest{}
+			rpl = &cri.RunPodSandboxResponse{}
+		)
+		if err := unmarshalCall(call, req, rpl); err != nil {
+			return err
+		}
+		c.runPodSandbox(podFromCRI(rpl.PodSandboxId, req))
+
+	case "StopPodSandbox":
+		req := &cri.StopPodSandboxRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.stopPodSandbox(req.PodSandboxId)
+
+	case "RemovePodSandbox":
+		req := &cri.RemovePodSandboxRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.removePodSandbox(req.PodSandboxId)
+
+	case "CreateContainer":
+		var (
+			req = &cri.CreateContainerRequest{}
+			rpl = &cri.CreateContainerResponse{}
+		)
+		if err := unmarshalCall(call, req, rpl); err != nil {
+			return err
+		}
+		c.createContainer(containerFromCRI(rpl.ContainerId, req))
+
+	case "StartContainer":
+		req := &cri.StartContainerRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.startContainer(req.ContainerId)
+
+	case "UpdateContainerResources":
+		req := &cri.UpdateContainerResourcesRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.updateContainer(req.ContainerId, api.FromCRILinuxResources(req.Linux))
+
+	case "StopContainer":
+		req := &cri.StopContainerRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.stopContainer(req.ContainerId)
+
+	case "RemoveContainer":
+		req := &cri.RemoveContainerRequest{}
+		if err := unmarshalCall(call, req, nil); err != nil {
+			return err
+		}
+		c.removeContainer(req.ContainerId)
+	}
+
+	return nil
+}
+
+func unmarshalCall(call *CRICall, req, rpl interface{}) error {
+	if len(call.Request) > 0 {
+		if err := json.Unmarshal(call.Request, req); err != nil {
+			return fmt.Errorf("invalid request: %w", err)
+		}
+	}
+	if rpl != nil && len(call.Response) > 0 {
+		if err := json.Unmarshal(call.Response, rpl); err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+	}
+	return nil
+}
+
+// podFromCRI returns the NRI pod for a CRI RunPodSandbox request.
+func podFromCRI(id string, req *cri.RunPodSandboxRequest) *api.PodSandbox {
+	cfg := req.GetConfig()
+	pod := &api.PodSandbox{
+		Id:             id,
+		Name:           cfg.GetMetadata().GetName(),
+		Uid:            cfg.GetMetadata().GetUid(),
+		Namespace:      cfg.GetMetadata().GetNamespace(),
+		Labels:         cfg.GetLabels(),
+		Annotations:    cfg.GetAnnotations(),
+		RuntimeHandler: req.GetRuntimeHandler(),
+	}
+	if linux := cfg.GetLinux(); linux != nil {
+		pod.Linux = &api.LinuxPodSandbox{
+			CgroupParent: linux.CgroupParent,
+			PodOverhead:  api.FromCRILinuxResources(linux.Overhead),
+			PodResources: api.FromCRILinuxResources(linux.Resources),
+		}
+	}
+	return pod
+}
+
+// containerFromCRI returns the NRI container for a CRI CreateContainer
+// request.
+func containerFromCRI(id string, req *cri.CreateContainerRequest) *api.Container {
+	cfg := req.GetConfig()
+	ctr := &api.Container{
+		Id:           id,
+		PodSandboxId: req.GetPodSandboxId(),
+		Name:         cfg.GetMetadata().GetName(),
+		Labels:       cfg.GetLabels(),
+		Annotations:  cfg.GetAnnotations(),
+		Args:         append(append([]string{}, cfg.GetCommand()...), cfg.GetArgs()...),
+	}
+	for _, kv := range cfg.GetEnvs() {
+		ctr.Env = append(ctr.Env, kv.Key+"="+kv.Value)
+	}
+	for _, m := range cfg.GetMounts() {
+		options := []string{"rbind"}
+		if m.Readonly {
+			options = append(options, "ro")
+		} else {
+			options = append(options, "rw")
+		}
+		ctr.Mounts = append(ctr.Mounts, &api.Mount{
+			Destination: m.ContainerPath,
+			Source:      m.HostPath,
+			Type:        "bind",
+			Options:     options,
+		})
+	}
+	if res := cfg.GetLinux().GetResources(); res != nil {
+		ctr.Linux = &api.LinuxContainer{
+			Resources: api.FromCRILinuxResources(res),
+		}
+	}
+	return ctr
+}
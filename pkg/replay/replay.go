@@ -0,0 +1,308 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/log"
+)
+
+const (
+	// RuntimeName is the runtime name the replayer presents to plugins.
+	RuntimeName = "nri-replay"
+	// RuntimeVersion is the runtime version the replayer presents to plugins.
+	RuntimeVersion = "0.1.0"
+)
+
+// Result is the outcome of replaying a single step.
+type Result struct {
+	// Step is the replayed step.
+	Step *Step
+	// Adjust is the combined adjustment of plugins for CREATE_CONTAINER.
+	Adjust *api.ContainerAdjustment
+	// Update is the combined container updates of plugins.
+	Update []*api.ContainerUpdate
+	// Evict is the combined container evictions of plugins.
+	Evict []*api.ContainerEviction
+	// Err is the error relaying the step to plugins failed with.
+	Err error
+}
+
+// resultJSON is the JSON encoding of a Result.
+type resultJSON struct {
+	Step   *Step             `json:"step"`
+	Adjust json.RawMessage   `json:"adjust,omitempty"`
+	Update []json.RawMessage `json:"update,omitempty"`
+	Evict  []json.RawMessage `json:"evict,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// MarshalJSON marshals a result to JSON.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var (
+		j   = &resultJSON{Step: r.Step}
+		err error
+	)
+
+	if j.Adjust, err = marshalMessage(r.Adjust); err != nil {
+		return nil, err
+	}
+	for _, u := range r.Update {
+		data, err := marshalMessage(u)
+		if err != nil {
+			return nil, err
+		}
+		j.Update = append(j.Update, data)
+	}
+	for _, e := range r.Evict {
+		data, err := marshalMessage(e)
+		if err != nil {
+			return nil, err
+		}
+		j.Evict = append(j.Evict, data)
+	}
+	if r.Err != nil {
+		j.Error = r.Err.Error()
+	}
+
+	return json.Marshal(j)
+}
+
+// Replayer simulates a runtime, replaying steps against the plugins which
+// connect to it. Adjustments and updates requested by plugins are recorded
+// in the results, but they are not applied to the pods and containers of
+// subsequent steps, which are replayed as recorded.
+type Replayer struct {
+	sync.Mutex
+	r           *nri.Adaptation
+	pods        map[string]*api.PodSandbox
+	ctrs        map[string]*api.Container
+	unsolicited []*api.ContainerUpdate
+}
+
+// New creates a replayer, with an NRI runtime adaptation created with the
+// given options. Use nri.WithSocketPath to let plugins under test connect to
+// a socket other than the default one, or nri.WithPluginPath to launch them.
+func New(opts ...nri.Option) (*Replayer, error) {
+	r := &Replayer{
+		pods: map[string]*api.PodSandbox{},
+		ctrs: map[string]*api.Container{},
+	}
+
+	adaptation, err := nri.New(RuntimeName, RuntimeVersion, r.syncPlugin, r.updateContainers, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replayer: %w", err)
+	}
+	r.r = adaptation
+
+	return r, nil
+}
+
+// Adaptation returns the NRI runtime adaptation of the replayer.
+func (r *Replayer) Adaptation() *nri.Adaptation {
+	return r.r
+}
+
+// Start starts the replayer, letting plugins connect to it.
+func (r *Replayer) Start() error {
+	return r.r.Start()
+}
+
+// Stop stops the replayer.
+func (r *Replayer) Stop() {
+	r.r.Stop()
+}
+
+// WaitForPlugins waits until at least the given number of plugins have
+// registered and are ready.
+func (r *Replayer) WaitForPlugins(ctx context.Context, count int) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		ready := 0
+		for _, status := range r.r.PluginStatus() {
+			if status.Ready {
+				ready++
+			}
+		}
+		if ready >= count {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d of %d plugins ready: %w", ready, count, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Replay replays the given steps, in order, against the connected plugins.
+// Steps which fail are recorded in their result and replaying continues
+// with the next step, like a runtime would carry on with other pods and
+// containers.
+func (r *Replayer) Replay(ctx context.Context, steps []*Step) []*Result {
+	results := make([]*Result, 0, len(steps))
+	for _, s := range steps {
+		res := r.replayStep(ctx, s)
+		if res.Err != nil {
+			log.Warnf(ctx, "replaying %s failed: %v", s, res.Err)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// Unsolicited returns the unsolicited container updates requested by plugins
+// so far, including the ones requested during plugin synchronization.
+func (r *Replayer) Unsolicited() []*api.ContainerUpdate {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*api.ContainerUpdate{}, r.unsolicited...)
+}
+
+func (r *Replayer) replayStep(ctx context.Context, s *Step) *Result {
+	var (
+		res = &Result{Step: s}
+		pod = clonePod(s.Pod)
+		ctr = cloneContainer(s.Container)
+		evt = &nri.StateChangeEvent{Event: s.Event, Pod: pod, Container: ctr}
+	)
+
+	if pod == nil {
+		res.Err = fmt.Errorf("invalid step %s, no pod", s.Event)
+		return res
+	}
+	if ctr == nil && isContainerEvent(s.Event) {
+		res.Err = fmt.Errorf("invalid step %s, no container", s.Event)
+		return res
+	}
+
+	r.track(s)
+
+	switch s.Event {
+	case api.Event_CREATE_CONTAINER:
+		rpl, err := r.r.CreateContainer(ctx, &nri.CreateContainerRequest{Pod: pod, Container: ctr})
+		res.Adjust, res.Update, res.Evict, res.Err = rpl.GetAdjust(), rpl.GetUpdate(), rpl.GetEvict(), err
+	case api.Event_UPDATE_CONTAINER:
+		rpl, err := r.r.UpdateContainer(ctx, &nri.UpdateContainerRequest{
+			Pod:            pod,
+			Container:      ctr,
+			LinuxResources: proto.Clone(s.Resources).(*api.LinuxResources),
+		})
+		res.Update, res.Evict, res.Err = rpl.GetUpdate(), rpl.GetEvict(), err
+	case api.Event_STOP_CONTAINER:
+		rpl, err := r.r.StopContainer(ctx, &nri.StopContainerRequest{Pod: pod, Container: ctr})
+		res.Update, res.Err = rpl.GetUpdate(), err
+	default:
+		res.Err = r.r.StateChange(ctx, evt)
+	}
+
+	return res
+}
+
+// track tracks the pods and containers of replayed steps, for synchronizing
+// plugins which connect while steps are replayed.
+func (r *Replayer) track(s *Step) {
+	r.Lock()
+	defer r.Unlock()
+
+	switch s.Event {
+	case api.Event_RUN_POD_SANDBOX, api.Event_STOP_POD_SANDBOX:
+		r.pods[s.Pod.Id] = s.Pod
+	case api.Event_REMOVE_POD_SANDBOX:
+		delete(r.pods, s.Pod.Id)
+	case api.Event_REMOVE_CONTAINER:
+		delete(r.ctrs, s.Container.Id)
+	default:
+		if s.Container != nil {
+			r.ctrs[s.Container.Id] = s.Container
+		}
+	}
+}
+
+// syncPlugin synchronizes a plugin with the tracked pods and containers.
+func (r *Replayer) syncPlugin(ctx context.Context, cb nri.SyncCB) error {
+	r.Lock()
+	var (
+		pods = make([]*api.PodSandbox, 0, len(r.pods))
+		ctrs = make([]*api.Container, 0, len(r.ctrs))
+	)
+	for _, pod := range r.pods {
+		pods = append(pods, clonePod(pod))
+	}
+	for _, ctr := range r.ctrs {
+		ctrs = append(ctrs, cloneContainer(ctr))
+	}
+	r.Unlock()
+
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Id < pods[j].Id })
+	sort.Slice(ctrs, func(i, j int) bool { return ctrs[i].Id < ctrs[j].Id })
+
+	updates, err := cb(ctx, pods, ctrs)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	r.unsolicited = append(r.unsolicited, updates...)
+	r.Unlock()
+
+	return nil
+}
+
+// updateContainers records unsolicited container updates requested by
+// plugins. All updates are reported successful.
+func (r *Replayer) updateContainers(_ context.Context, updates []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+	r.Lock()
+	defer r.Unlock()
+	r.unsolicited = append(r.unsolicited, updates...)
+	return nil, nil
+}
+
+func isContainerEvent(event api.Event) bool {
+	switch event {
+	case api.Event_RUN_POD_SANDBOX, api.Event_STOP_POD_SANDBOX, api.Event_REMOVE_POD_SANDBOX:
+		return false
+	}
+	return true
+}
+
+func clonePod(pod *api.PodSandbox) *api.PodSandbox {
+	if pod == nil {
+		return nil
+	}
+	return proto.Clone(pod).(*api.PodSandbox)
+}
+
+func cloneContainer(ctr *api.Container) *api.Container {
+	if ctr == nil {
+		return nil
+	}
+	return proto.Clone(ctr).(*api.Container)
+}
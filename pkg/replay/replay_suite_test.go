@@ -0,0 +1,247 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package replay_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/replay"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+func TestReplay(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Replay Suite")
+}
+
+const containerdLog = `
+time="2024-01-01T00:00:00Z" level=info msg="RunPodSandbox for &PodSandboxMetadata{Name:nginx,Uid:uid0,Namespace:default,Attempt:0,}"
+time="2024-01-01T00:00:01Z" level=info msg="RunPodSandbox for &PodSandboxMetadata{Name:nginx,Uid:uid0,Namespace:default,Attempt:0,} returns sandbox id \"pod0\""
+time="2024-01-01T00:00:02Z" level=info msg="CreateContainer within sandbox \"pod0\" for &ContainerMetadata{Name:nginx,Attempt:0,} returns container id \"ctr0\""
+time="2024-01-01T00:00:03Z" level=info msg="StartContainer for \"ctr0\" returns successfully"
+time="2024-01-01T00:00:04Z" level=info msg="StartContainer for \"unknown\" returns successfully"
+{"level":"debug","msg":"UpdateContainerResources for \"ctr0\" with Linux: &LinuxContainerResources{CpuPeriod:100000,CpuQuota:50000,CpuShares:512,MemoryLimitInBytes:1073741824,OomScoreAdj:0,CpusetCpus:0-1,CpusetMems:,HugepageLimits:[]*HugepageLimit{},Unified:map[string]string{},MemorySwapLimitInBytes:0,} Windows: nil","time":"2024-01-01T00:00:05Z"}
+{"level":"info","msg":"UpdateContainerResources for \"ctr0\" returns successfully","time":"2024-01-01T00:00:06Z"}
+time="2024-01-01T00:00:07Z" level=info msg="StopPodSandbox for \"pod0\" returns successfully"
+time="2024-01-01T00:00:08Z" level=info msg="RemovePodSandbox \"pod0\" returns successfully"
+`
+
+const criTrace = `
+# recorded CRI calls
+{"method":"/runtime.v1.RuntimeService/RunPodSandbox","request":{"config":{"metadata":{"name":"web","uid":"uid1","namespace":"prod"},"labels":{"app":"web"}},"runtime_handler":"runc"},"response":{"pod_sandbox_id":"pod1"}}
+{"method":"CreateContainer","request":{"pod_sandbox_id":"pod1","config":{"metadata":{"name":"app"},"command":["/bin/app"],"args":["-v"],"envs":[{"key":"FOO","value":"bar"}],"linux":{"resources":{"cpu_shares":1024}}}},"response":{"container_id":"ctr1"}}
+{"method":"CreateContainer","request":{"pod_sandbox_id":"pod1","config":{"metadata":{"name":"failed"}}},"error":"rpc error: code = Unknown"}
+{"method":"StartContainer","request":{"container_id":"ctr1"}}
+{"method":"ListContainers","request":{}}
+{"method":"StopContainer","request":{"container_id":"ctr1","timeout":30}}
+{"method":"RemoveContainer","request":{"container_id":"ctr1"}}
+`
+
+func events(steps []*replay.Step) []string {
+	var names []string
+	for _, s := range steps {
+		names = append(names, s.Event.String())
+	}
+	return names
+}
+
+var _ = Describe("Trace conversion", func() {
+	It("should convert containerd logs", func() {
+		steps, err := replay.ParseContainerdLog(strings.NewReader(containerdLog))
+		Expect(err).To(BeNil())
+		Expect(events(steps)).To(Equal([]string{
+			"RUN_POD_SANDBOX",
+			"CREATE_CONTAINER",
+			"POST_CREATE_CONTAINER",
+			"START_CONTAINER",
+			"POST_START_CONTAINER",
+			"UPDATE_CONTAINER",
+			"POST_UPDATE_CONTAINER",
+			"STOP_CONTAINER",
+			"STOP_POD_SANDBOX",
+			"REMOVE_CONTAINER",
+			"REMOVE_POD_SANDBOX",
+		}))
+
+		Expect(steps[0].Pod.Id).To(Equal("pod0"))
+		Expect(steps[0].Pod.Name).To(Equal("nginx"))
+		Expect(steps[0].Pod.Uid).To(Equal("uid0"))
+		Expect(steps[0].Pod.Namespace).To(Equal("default"))
+		Expect(steps[1].Container.Id).To(Equal("ctr0"))
+		Expect(steps[1].Container.Name).To(Equal("nginx"))
+		Expect(steps[1].Container.State).To(Equal(api.ContainerState_CONTAINER_CREATED))
+		Expect(steps[3].Container.State).To(Equal(api.ContainerState_CONTAINER_RUNNING))
+		Expect(steps[5].Resources.Cpu.Shares.GetValue()).To(Equal(uint64(512)))
+		Expect(steps[5].Resources.Cpu.Quota.GetValue()).To(Equal(int64(50000)))
+		Expect(steps[5].Resources.Cpu.Cpus).To(Equal("0-1"))
+		Expect(steps[5].Resources.Memory.Limit.GetValue()).To(Equal(int64(1 << 30)))
+		Expect(steps[6].Container.Linux.Resources.Cpu.Cpus).To(Equal("0-1"))
+		Expect(steps[7].Container.State).To(Equal(api.ContainerState_CONTAINER_STOPPED))
+	})
+
+	It("should convert CRI call traces", func() {
+		steps, err := replay.ParseCRITrace(strings.NewReader(criTrace))
+		Expect(err).To(BeNil())
+		Expect(events(steps)).To(Equal([]string{
+			"RUN_POD_SANDBOX",
+			"CREATE_CONTAINER",
+			"POST_CREATE_CONTAINER",
+			"START_CONTAINER",
+			"POST_START_CONTAINER",
+			"STOP_CONTAINER",
+			"REMOVE_CONTAINER",
+		}))
+
+		pod := steps[0].Pod
+		Expect(pod.Id).To(Equal("pod1"))
+		Expect(pod.Namespace).To(Equal("prod"))
+		Expect(pod.Labels).To(Equal(map[string]string{"app": "web"}))
+		Expect(pod.RuntimeHandler).To(Equal("runc"))
+
+		ctr := steps[1].Container
+		Expect(ctr.Id).To(Equal("ctr1"))
+		Expect(ctr.PodSandboxId).To(Equal("pod1"))
+		Expect(ctr.Args).To(Equal([]string{"/bin/app", "-v"}))
+		Expect(ctr.Env).To(Equal([]string{"FOO=bar"}))
+		Expect(ctr.Linux.Resources.Cpu.Shares.GetValue()).To(Equal(uint64(1024)))
+	})
+
+	It("should reject malformed CRI call traces", func() {
+		_, err := replay.ParseCRITrace(strings.NewReader("{\"method\":"))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should read back written steps", func() {
+		steps, err := replay.ParseCRITrace(strings.NewReader(criTrace))
+		Expect(err).To(BeNil())
+
+		buf := &bytes.Buffer{}
+		Expect(replay.WriteSteps(buf, steps)).To(Succeed())
+
+		read, err := replay.ReadSteps(buf)
+		Expect(err).To(BeNil())
+		Expect(read).To(HaveLen(len(steps)))
+		for i := range steps {
+			Expect(read[i].Event).To(Equal(steps[i].Event))
+			Expect(proto.Equal(read[i].Pod, steps[i].Pod)).To(BeTrue())
+			Expect(proto.Equal(read[i].Container, steps[i].Container)).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("Replay", func() {
+	var (
+		dir    string
+		socket string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "nri-replay-test-")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		socket = filepath.Join(dir, "nri.sock")
+	})
+
+	It("should replay converted events against plugins", func() {
+		steps, err := replay.ParseContainerdLog(strings.NewReader(containerdLog))
+		Expect(err).To(BeNil())
+
+		r, err := replay.New(
+			nri.WithSocketPath(socket),
+			nri.WithPluginPath(filepath.Join(dir, "plugins")),
+		)
+		Expect(err).To(BeNil())
+		Expect(r.Start()).To(Succeed())
+		defer r.Stop()
+
+		p := &plugin{}
+		s, err := stub.New(p,
+			stub.WithPluginName("test"),
+			stub.WithPluginIdx("00"),
+			stub.WithSocketPath(socket),
+			stub.WithOnClose(func() {}),
+		)
+		Expect(err).To(BeNil())
+		Expect(s.Start(context.Background())).To(Succeed())
+		defer s.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		Expect(r.WaitForPlugins(ctx, 1)).To(Succeed())
+
+		results := r.Replay(context.Background(), steps)
+		Expect(results).To(HaveLen(len(steps)))
+		for _, res := range results {
+			Expect(res.Err).To(BeNil(), res.Step.String())
+		}
+		Expect(results[1].Adjust.Env).To(ContainElement(&api.KeyValue{Key: "REPLAYED", Value: "true"}))
+
+		Eventually(p.Events, time.Second).Should(Equal([]string{
+			"CREATE_CONTAINER",
+			"UPDATE_CONTAINER",
+			"STOP_CONTAINER",
+		}))
+	})
+})
+
+type plugin struct {
+	sync.Mutex
+	events []string
+}
+
+func (p *plugin) record(event api.Event) {
+	p.Lock()
+	defer p.Unlock()
+	p.events = append(p.events, event.String())
+}
+
+func (p *plugin) Events() []string {
+	p.Lock()
+	defer p.Unlock()
+	return append([]string{}, p.events...)
+}
+
+func (p *plugin) CreateContainer(_ context.Context, _ *api.PodSandbox, _ *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.record(api.Event_CREATE_CONTAINER)
+	a := &api.ContainerAdjustment{}
+	a.AddEnv("REPLAYED", "true")
+	return a, nil, nil
+}
+
+func (p *plugin) UpdateContainer(_ context.Context, _ *api.PodSandbox, _ *api.Container, _ *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.record(api.Event_UPDATE_CONTAINER)
+	return nil, nil
+}
+
+func (p *plugin) StopContainer(_ context.Context, _ *api.PodSandbox, _ *api.Container) ([]*api.ContainerUpdate, error) {
+	p.record(api.Event_STOP_CONTAINER)
+	return nil, nil
+}
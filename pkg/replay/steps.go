@@ -0,0 +1,178 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package replay simulates the lifecycle of pods and containers recorded in
+// CRI call traces. Traces, containerd debug logs or JSON CRI call records,
+// are converted to the sequence of NRI events a runtime would have relayed
+// to plugins. The events can be saved, and replayed against plugins under
+// test, letting plugin authors validate their plugins against the exact
+// workloads of their production clusters.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+// Step is a single NRI event of a simulated lifecycle.
+type Step struct {
+	// Event is the NRI event or request relayed to plugins.
+	Event api.Event
+	// Pod is the pod of the event.
+	Pod *api.PodSandbox
+	// Container is the container of the event, nil for pod events.
+	Container *api.Container
+	// Resources are the updated resources for UPDATE_CONTAINER.
+	Resources *api.LinuxResources
+}
+
+// String returns a short description of the step.
+func (s *Step) String() string {
+	switch {
+	case s.Container != nil:
+		return fmt.Sprintf("%s %s/%s/%s", s.Event, s.Pod.GetNamespace(), s.Pod.GetName(),
+			s.Container.Name)
+	case s.Pod != nil:
+		return fmt.Sprintf("%s %s/%s", s.Event, s.Pod.Namespace, s.Pod.Name)
+	}
+	return s.Event.String()
+}
+
+// stepJSON is the JSON encoding of a Step.
+type stepJSON struct {
+	Event     string          `json:"event"`
+	Pod       json.RawMessage `json:"pod,omitempty"`
+	Container json.RawMessage `json:"container,omitempty"`
+	Resources json.RawMessage `json:"resources,omitempty"`
+}
+
+// MarshalJSON marshals a step to JSON.
+func (s *Step) MarshalJSON() ([]byte, error) {
+	var (
+		j   = &stepJSON{Event: s.Event.String()}
+		err error
+	)
+
+	if j.Pod, err = marshalMessage(s.Pod); err != nil {
+		return nil, err
+	}
+	if j.Container, err = marshalMessage(s.Container); err != nil {
+		return nil, err
+	}
+	if j.Resources, err = marshalMessage(s.Resources); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON unmarshals a step from JSON.
+func (s *Step) UnmarshalJSON(data []byte) error {
+	j := &stepJSON{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return err
+	}
+
+	evt, ok := api.Event_value[strings.ToUpper(j.Event)]
+	if !ok {
+		return fmt.Errorf("invalid event %q", j.Event)
+	}
+
+	*s = Step{Event: api.Event(evt)}
+	if len(j.Pod) > 0 {
+		s.Pod = &api.PodSandbox{}
+		if err := protojson.Unmarshal(j.Pod, s.Pod); err != nil {
+			return fmt.Errorf("invalid pod in %s: %w", j.Event, err)
+		}
+	}
+	if len(j.Container) > 0 {
+		s.Container = &api.Container{}
+		if err := protojson.Unmarshal(j.Container, s.Container); err != nil {
+			return fmt.Errorf("invalid container in %s: %w", j.Event, err)
+		}
+	}
+	if len(j.Resources) > 0 {
+		s.Resources = &api.LinuxResources{}
+		if err := protojson.Unmarshal(j.Resources, s.Resources); err != nil {
+			return fmt.Errorf("invalid resources in %s: %w", j.Event, err)
+		}
+	}
+
+	return nil
+}
+
+func marshalMessage(m proto.Message) (json.RawMessage, error) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	return protojson.Marshal(m)
+}
+
+// WriteSteps writes steps as JSON, one step per line.
+func WriteSteps(w io.Writer, steps []*Step) error {
+	enc := json.NewEncoder(w)
+	for _, s := range steps {
+		if err := enc.Encode(s); err != nil {
+			return fmt.Errorf("failed to write step %s: %w", s, err)
+		}
+	}
+	return nil
+}
+
+// ReadSteps reads steps written by WriteSteps. Empty lines and lines
+// starting with '#' are ignored.
+func ReadSteps(r io.Reader) ([]*Step, error) {
+	var (
+		steps   []*Step
+		scanner = newScanner(r)
+		lineNum = 0
+	)
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s := &Step{}
+		if err := json.Unmarshal([]byte(line), s); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		steps = append(steps, s)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read steps: %w", err)
+	}
+
+	return steps, nil
+}
+
+// newScanner returns a line scanner which tolerates long lines, as found
+// in debug logs and traces.
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return scanner
+}
@@ -0,0 +1,130 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+)
+
+// RunPodSandbox relays the corresponding event to plugins.
+func (i *Integration) RunPodSandbox(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.RunPodSandbox(ctx, evt)
+	}
+	return nil
+}
+
+// StopPodSandbox relays the corresponding event to plugins.
+func (i *Integration) StopPodSandbox(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.StopPodSandbox(ctx, evt)
+	}
+	return nil
+}
+
+// RemovePodSandbox relays the corresponding event to plugins.
+func (i *Integration) RemovePodSandbox(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.RemovePodSandbox(ctx, evt)
+	}
+	return nil
+}
+
+// CreateContainer relays the corresponding request to plugins. If NRI is
+// disabled, it returns an empty response.
+func (i *Integration) CreateContainer(ctx context.Context, req *nri.CreateContainerRequest) (*nri.CreateContainerResponse, error) {
+	if r := i.Adaptation(); r != nil {
+		return r.CreateContainer(ctx, req)
+	}
+	return &nri.CreateContainerResponse{}, nil
+}
+
+// PostCreateContainer relays the corresponding event to plugins.
+func (i *Integration) PostCreateContainer(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.PostCreateContainer(ctx, evt)
+	}
+	return nil
+}
+
+// StartContainer relays the corresponding event to plugins.
+func (i *Integration) StartContainer(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.StartContainer(ctx, evt)
+	}
+	return nil
+}
+
+// PostStartContainer relays the corresponding event to plugins.
+func (i *Integration) PostStartContainer(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.PostStartContainer(ctx, evt)
+	}
+	return nil
+}
+
+// UpdateContainer relays the corresponding request to plugins. If NRI is
+// disabled, it returns an empty response.
+func (i *Integration) UpdateContainer(ctx context.Context, req *nri.UpdateContainerRequest) (*nri.UpdateContainerResponse, error) {
+	if r := i.Adaptation(); r != nil {
+		return r.UpdateContainer(ctx, req)
+	}
+	return &nri.UpdateContainerResponse{}, nil
+}
+
+// PostUpdateContainer relays the corresponding event to plugins.
+func (i *Integration) PostUpdateContainer(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.PostUpdateContainer(ctx, evt)
+	}
+	return nil
+}
+
+// ContainerDied relays the corresponding event to plugins.
+func (i *Integration) ContainerDied(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.ContainerDied(ctx, evt)
+	}
+	return nil
+}
+
+// CreateContainerRollback relays the corresponding event to plugins.
+func (i *Integration) CreateContainerRollback(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.CreateContainerRollback(ctx, evt)
+	}
+	return nil
+}
+
+// StopContainer relays the corresponding request to plugins. If NRI is
+// disabled, it returns an empty response.
+func (i *Integration) StopContainer(ctx context.Context, req *nri.StopContainerRequest) (*nri.StopContainerResponse, error) {
+	if r := i.Adaptation(); r != nil {
+		return r.StopContainer(ctx, req)
+	}
+	return &nri.StopContainerResponse{}, nil
+}
+
+// RemoveContainer relays the corresponding event to plugins.
+func (i *Integration) RemoveContainer(ctx context.Context, evt *nri.StateChangeEvent) error {
+	if r := i.Adaptation(); r != nil {
+		return r.RemoveContainer(ctx, evt)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package integration helps runtimes integrate NRI. It wraps the runtime
+// adaptation with the configuration, lifecycle and event relaying which
+// runtimes otherwise all implement themselves. Runtimes create an Integration
+// from their NRI configuration, start it once their runtime service is up,
+// relay pod and container events through it, and reload it when their
+// configuration changes. All functions of a disabled Integration are no-ops.
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/log"
+)
+
+// Config is the NRI configuration of a runtime.
+type Config struct {
+	// Disable NRI.
+	Disable bool `json:"disable" toml:"disable"`
+	// SocketPath is the path of the socket external plugins connect to.
+	SocketPath string `json:"socket_path" toml:"socket_path"`
+	// PluginPath is the directory of pre-installed plugins to launch.
+	PluginPath string `json:"plugin_path" toml:"plugin_path"`
+	// PluginConfigPath is the directory of pre-installed plugin configuration.
+	PluginConfigPath string `json:"plugin_config_path" toml:"plugin_config_path"`
+	// PluginRegistrationTimeout is the timeout for plugins to register.
+	PluginRegistrationTimeout time.Duration `json:"plugin_registration_timeout" toml:"plugin_registration_timeout"`
+	// PluginRequestTimeout is the timeout for plugins to handle a request.
+	PluginRequestTimeout time.Duration `json:"plugin_request_timeout" toml:"plugin_request_timeout"`
+	// DisableConnections disables connections from external plugins.
+	DisableConnections bool `json:"disable_connections" toml:"disable_connections"`
+}
+
+// DefaultConfig returns the default NRI configuration, with NRI enabled.
+func DefaultConfig() *Config {
+	return &Config{
+		SocketPath:                nri.DefaultSocketPath,
+		PluginPath:                nri.DefaultPluginPath,
+		PluginConfigPath:          nri.DefaultPluginConfigPath,
+		PluginRegistrationTimeout: nri.DefaultPluginRegistrationTimeout,
+		PluginRequestTimeout:      nri.DefaultPluginRequestTimeout,
+	}
+}
+
+// Validate the configuration.
+func (c *Config) Validate() error {
+	if c.Disable {
+		return nil
+	}
+	if c.PluginRegistrationTimeout < 0 {
+		return fmt.Errorf("invalid plugin registration timeout %s", c.PluginRegistrationTimeout)
+	}
+	if c.PluginRequestTimeout < 0 {
+		return fmt.Errorf("invalid plugin request timeout %s", c.PluginRequestTimeout)
+	}
+	if c.DisableConnections && c.PluginPath == "" {
+		return errors.New("no plugin path and external connections disabled")
+	}
+	return nil
+}
+
+// Options returns the runtime adaptation options for the configuration.
+func (c *Config) Options() []nri.Option {
+	var opts []nri.Option
+
+	if c.SocketPath != "" {
+		opts = append(opts, nri.WithSocketPath(c.SocketPath))
+	}
+	if c.PluginPath != "" {
+		opts = append(opts, nri.WithPluginPath(c.PluginPath))
+	}
+	if c.PluginConfigPath != "" {
+		opts = append(opts, nri.WithPluginConfigPath(c.PluginConfigPath))
+	}
+	if c.DisableConnections {
+		opts = append(opts, nri.WithDisabledExternalConnections())
+	}
+
+	return opts
+}
+
+// ConfigureTimeouts sets the plugin timeouts of the configuration. Unset
+// timeouts are reset to their default. The timeouts are global, shared by
+// all runtime adaptations in the process.
+func (c *Config) ConfigureTimeouts() {
+	registration, request := c.PluginRegistrationTimeout, c.PluginRequestTimeout
+	if registration == 0 {
+		registration = nri.DefaultPluginRegistrationTimeout
+	}
+	if request == 0 {
+		request = nri.DefaultPluginRequestTimeout
+	}
+	nri.SetPluginRegistrationTimeout(registration)
+	nri.SetPluginRequestTimeout(request)
+}
+
+// Integration of NRI into a runtime.
+type Integration struct {
+	sync.Mutex
+	name     string
+	version  string
+	cfg      Config
+	options  []nri.Option
+	syncFn   nri.SyncFn
+	updateFn nri.UpdateFn
+	nri      *nri.Adaptation
+}
+
+// Option to apply to an Integration.
+type Option func(*Integration) error
+
+// WithAdaptationOptions returns an option to pass extra options to the
+// runtime adaptation, in addition to the ones from the configuration.
+func WithAdaptationOptions(opts ...nri.Option) Option {
+	return func(i *Integration) error {
+		i.options = append(i.options, opts...)
+		return nil
+	}
+}
+
+// WithMetricsRecorder returns an option to record metrics of the runtime
+// adaptation using the given recorder.
+func WithMetricsRecorder(m nri.MetricsRecorder) Option {
+	return func(i *Integration) error {
+		if m == nil {
+			return errors.New("invalid (nil) metrics recorder")
+		}
+		i.options = append(i.options, nri.WithMetricsRecorder(m))
+		return nil
+	}
+}
+
+// New creates an NRI integration for the runtime with the given name and
+// version, using the given configuration. A nil configuration is taken as
+// the default one.
+func New(name, version string, cfg *Config, opts ...Option) (*Integration, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid NRI configuration: %w", err)
+	}
+
+	i := &Integration{
+		name:    name,
+		version: version,
+		cfg:     *cfg,
+	}
+
+	for _, o := range opts {
+		if err := o(i); err != nil {
+			return nil, fmt.Errorf("failed to apply NRI integration option: %w", err)
+		}
+	}
+
+	return i, nil
+}
+
+// IsEnabled returns true if NRI is enabled in the configuration.
+func (i *Integration) IsEnabled() bool {
+	i.Lock()
+	defer i.Unlock()
+	return !i.cfg.Disable
+}
+
+// Start NRI, once the runtime is ready to synchronize plugins and to apply
+// their updates using the given functions.
+func (i *Integration) Start(syncFn nri.SyncFn, updateFn nri.UpdateFn) error {
+	i.Lock()
+	defer i.Unlock()
+
+	if i.nri != nil {
+		return errors.New("NRI already started")
+	}
+
+	i.syncFn = syncFn
+	i.updateFn = updateFn
+
+	return i.start()
+}
+
+// Stop NRI, disconnecting all plugins.
+func (i *Integration) Stop() {
+	i.Lock()
+	defer i.Unlock()
+	i.stop()
+}
+
+// Reload NRI with the given configuration. If the configuration changed,
+// NRI is restarted with it, and plugins need to reconnect. If NRI has not
+// been started, only the configuration is updated.
+func (i *Integration) Reload(cfg *Config) error {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid NRI configuration: %w", err)
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	if *cfg == i.cfg {
+		return nil
+	}
+
+	log.Infof(context.Background(), "NRI configuration changed, reloading...")
+
+	started := i.nri != nil
+	i.stop()
+	i.cfg = *cfg
+
+	if !started {
+		return nil
+	}
+
+	return i.start()
+}
+
+// Adaptation returns the runtime adaptation, or nil if NRI is disabled
+// or not started.
+func (i *Integration) Adaptation() *nri.Adaptation {
+	i.Lock()
+	defer i.Unlock()
+	return i.nri
+}
+
+func (i *Integration) start() error {
+	if i.cfg.Disable {
+		log.Infof(context.Background(), "NRI is disabled")
+		return nil
+	}
+	if i.syncFn == nil || i.updateFn == nil {
+		return errors.New("NRI started without sync or update function")
+	}
+
+	i.cfg.ConfigureTimeouts()
+
+	r, err := nri.New(i.name, i.version, i.syncFn, i.updateFn,
+		append(i.cfg.Options(), i.options...)...)
+	if err != nil {
+		return fmt.Errorf("failed to create NRI: %w", err)
+	}
+
+	if err := r.Start(); err != nil {
+		r.Stop()
+		return fmt.Errorf("failed to start NRI: %w", err)
+	}
+
+	i.nri = r
+	return nil
+}
+
+func (i *Integration) stop() {
+	if i.nri != nil {
+		i.nri.Stop()
+		i.nri = nil
+	}
+}
@@ -0,0 +1,160 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package integration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+	integration "github.com/containerd/nri/pkg/runtime-integration"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+func TestIntegration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Runtime Integration Suite")
+}
+
+var _ = Describe("Integration", func() {
+	var (
+		ctx = context.Background()
+		dir string
+		pod = &api.PodSandbox{Id: "pod0", Name: "pod0", Uid: "uid0"}
+		ctr = &api.Container{Id: "ctr0", PodSandboxId: "pod0", Name: "ctr0"}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "nri-integration-test-")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+	})
+
+	config := func(socket string) *integration.Config {
+		cfg := integration.DefaultConfig()
+		cfg.SocketPath = filepath.Join(dir, socket)
+		cfg.PluginPath = filepath.Join(dir, "plugins")
+		cfg.PluginConfigPath = filepath.Join(dir, "conf.d")
+		return cfg
+	}
+
+	syncFn := func(ctx context.Context, cb nri.SyncCB) error {
+		_, err := cb(ctx, nil, nil)
+		return err
+	}
+	updateFn := func(context.Context, []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+		return nil, nil
+	}
+
+	It("should relay nothing if disabled", func() {
+		cfg := config("nri.sock")
+		cfg.Disable = true
+
+		i, err := integration.New("runtime", "v0", cfg)
+		Expect(err).To(BeNil())
+		Expect(i.IsEnabled()).To(BeFalse())
+		Expect(i.Start(syncFn, updateFn)).To(Succeed())
+		Expect(i.Adaptation()).To(BeNil())
+		Expect(cfg.SocketPath).ToNot(BeAnExistingFile())
+
+		Expect(i.RunPodSandbox(ctx, &api.StateChangeEvent{Pod: pod})).To(Succeed())
+		rpl, err := i.CreateContainer(ctx, &api.CreateContainerRequest{Pod: pod, Container: ctr})
+		Expect(err).To(BeNil())
+		Expect(rpl).To(Equal(&api.CreateContainerResponse{}))
+		i.Stop()
+	})
+
+	It("should relay events to plugins", func() {
+		cfg := config("nri.sock")
+
+		i, err := integration.New("runtime", "v0", cfg)
+		Expect(err).To(BeNil())
+		Expect(i.Start(syncFn, updateFn)).To(Succeed())
+		defer i.Stop()
+
+		p := startPlugin(cfg.SocketPath)
+		defer p.Stop()
+		Eventually(func() []*nri.PluginStatus {
+			return i.Adaptation().PluginStatus()
+		}, 5*time.Second).Should(HaveLen(1))
+
+		Expect(i.RunPodSandbox(ctx, &api.StateChangeEvent{Pod: pod})).To(Succeed())
+		rpl, err := i.CreateContainer(ctx, &api.CreateContainerRequest{Pod: pod, Container: ctr})
+		Expect(err).To(BeNil())
+		Expect(rpl.Adjust.Annotations).To(HaveKeyWithValue("integration", "test"))
+	})
+
+	It("should restart on configuration changes", func() {
+		cfg := config("nri.sock")
+
+		i, err := integration.New("runtime", "v0", cfg)
+		Expect(err).To(BeNil())
+		Expect(i.Start(syncFn, updateFn)).To(Succeed())
+		defer i.Stop()
+
+		r := i.Adaptation()
+		Expect(i.Reload(config("nri.sock"))).To(Succeed())
+		Expect(i.Adaptation()).To(BeIdenticalTo(r))
+
+		changed := config("nri-changed.sock")
+		Expect(i.Reload(changed)).To(Succeed())
+		Expect(i.Adaptation()).ToNot(BeIdenticalTo(r))
+		Expect(changed.SocketPath).To(BeAnExistingFile())
+
+		changed.Disable = true
+		Expect(i.Reload(changed)).To(Succeed())
+		Expect(i.Adaptation()).To(BeNil())
+	})
+
+	It("should reject invalid configuration", func() {
+		cfg := config("nri.sock")
+		cfg.PluginRequestTimeout = -time.Second
+		_, err := integration.New("runtime", "v0", cfg)
+		Expect(err).ToNot(BeNil())
+
+		_, err = integration.New("runtime", "v0", nil, integration.WithMetricsRecorder(nil))
+		Expect(err).ToNot(BeNil())
+	})
+})
+
+type plugin struct{}
+
+func (*plugin) CreateContainer(_ context.Context, _ *api.PodSandbox, _ *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	a := &api.ContainerAdjustment{}
+	a.AddAnnotation("integration", "test")
+	return a, nil, nil
+}
+
+func startPlugin(socket string) stub.Stub {
+	s, err := stub.New(&plugin{},
+		stub.WithPluginName("test"),
+		stub.WithPluginIdx("00"),
+		stub.WithSocketPath(socket),
+		stub.WithOnClose(func() {}),
+	)
+	Expect(err).To(BeNil())
+	Expect(s.Start(context.Background())).To(Succeed())
+	return s
+}
@@ -117,6 +117,12 @@ func (g *Generator) Adjust(adjust *nri.ContainerAdjustment) error {
 	g.AdjustDevices(adjust.GetLinux().GetDevices())
 	g.AdjustCgroupsPath(adjust.GetLinux().GetCgroupsPath())
 	g.AdjustOomScoreAdj(adjust.GetLinux().GetOomScoreAdj())
+	g.AdjustApparmorProfile(adjust.GetLinux().GetApparmorProfile())
+	g.AdjustSeccompPolicy(adjust.GetLinux().GetSeccompPolicy())
+	if err := g.AdjustCapabilities(adjust.GetLinux().GetCapabilities()); err != nil {
+		return fmt.Errorf("failed to adjust capabilities in OCI Spec: %w", err)
+	}
+	g.AdjustUser(adjust.GetUser())
 
 	resources := adjust.GetLinux().GetResources()
 	if err := g.AdjustResources(resources); err != nil {
@@ -128,6 +134,7 @@ func (g *Generator) Adjust(adjust *nri.ContainerAdjustment) error {
 	if err := g.AdjustRdtClass(resources.GetRdtClass().Get()); err != nil {
 		return err
 	}
+	g.AdjustWindowsResources(adjust.GetWindows().GetResources())
 
 	if err := g.AdjustMounts(adjust.GetMounts()); err != nil {
 		return err
@@ -144,7 +151,7 @@ func (g *Generator) AdjustEnv(env []*nri.KeyValue) {
 	mod := map[string]*nri.KeyValue{}
 
 	for _, e := range env {
-		key, _ := nri.IsMarkedForRemoval(e.Key)
+		key, _ := e.IsMarkedForRemoval()
 		mod[key] = e
 	}
 
@@ -268,6 +275,14 @@ func (g *Generator) AdjustResources(r *nri.LinuxResources) error {
 	if v := r.GetPids(); v != nil {
 		g.SetLinuxResourcesPidsLimit(v.GetLimit())
 	}
+	for _, d := range r.Devices {
+		key, marked := d.IsMarkedForRemoval()
+		g.removeDeviceCgroupRule(key)
+		if marked {
+			continue
+		}
+		g.AddLinuxResourcesDevice(d.Allow, d.Type, d.Major.Get(), d.Minor.Get(), d.Access)
+	}
 	if g.checkResources != nil {
 		if err := g.checkResources(g.Config.Linux.Resources); err != nil {
 			return fmt.Errorf("failed to adjust resources in OCI Spec: %w", err)
@@ -277,6 +292,52 @@ func (g *Generator) AdjustResources(r *nri.LinuxResources) error {
 	return nil
 }
 
+// removeDeviceCgroupRule removes the device cgroup rules with the given key.
+func (g *Generator) removeDeviceCgroupRule(key string) {
+	if g.Config.Linux.Resources == nil {
+		return
+	}
+	kept := []rspec.LinuxDeviceCgroup{}
+	for _, d := range g.Config.Linux.Resources.Devices {
+		rule := &nri.LinuxDeviceCgroup{
+			Type:  d.Type,
+			Major: nri.Int64(d.Major),
+			Minor: nri.Int64(d.Minor),
+		}
+		if rule.Key() != key {
+			kept = append(kept, d)
+		}
+	}
+	g.Config.Linux.Resources.Devices = kept
+}
+
+// AdjustWindowsResources adjusts the (Windows) resources in the OCI Spec.
+func (g *Generator) AdjustWindowsResources(r *nri.WindowsResources) {
+	if r == nil {
+		return
+	}
+
+	if l := r.GetMemory().GetLimit(); l != nil {
+		g.SetWindowsResourcesMemoryLimit(l.GetValue())
+	}
+	if c := r.GetCpu(); c != nil {
+		g.initConfigWindowsResourcesCPU()
+		cpu := g.Config.Windows.Resources.CPU
+		if v := c.GetCount(); v != nil {
+			count := v.GetValue()
+			cpu.Count = &count
+		}
+		if v := c.GetShares(); v != nil {
+			shares := uint16(v.GetValue())
+			cpu.Shares = &shares
+		}
+		if v := c.GetMaximum(); v != nil {
+			maximum := uint16(v.GetValue())
+			cpu.Maximum = &maximum
+		}
+	}
+}
+
 // AdjustBlockIOClass adjusts the block I/O class in the OCI Spec.
 func (g *Generator) AdjustBlockIOClass(blockIOClass *string) error {
 	if blockIOClass == nil || g.resolveBlockIO == nil {
@@ -332,6 +393,76 @@ func (g *Generator) AdjustOomScoreAdj(score *nri.OptionalInt) {
 	}
 }
 
+// AdjustApparmorProfile adjusts the AppArmor profile of the process in the OCI Spec.
+func (g *Generator) AdjustApparmorProfile(profile string) {
+	if profile != "" {
+		g.SetProcessApparmorProfile(profile)
+	}
+}
+
+// AdjustSeccompPolicy adjusts the seccomp policy of the process in the OCI Spec.
+func (g *Generator) AdjustSeccompPolicy(policy *nri.LinuxSeccomp) {
+	if policy != nil {
+		g.initConfigLinux()
+		g.Config.Linux.Seccomp = policy.ToOCI()
+	}
+}
+
+// AdjustCapabilities adjusts the process capabilities in the OCI Spec.
+func (g *Generator) AdjustCapabilities(caps *nri.LinuxCapabilities) error {
+	if caps == nil {
+		return nil
+	}
+
+	for _, set := range nri.CapabilitySets {
+		add, drop := g.capabilityAdjusters(set)
+		for _, c := range caps.Get(set) {
+			var err error
+			if name, marked := nri.IsMarkedForRemoval(c); marked {
+				err = drop(name)
+			} else {
+				err = add(name)
+			}
+			if err != nil {
+				return fmt.Errorf("%s capability %q: %w", set, nri.ClearRemovalMarker(c), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) capabilityAdjusters(set nri.CapabilitySet) (add, drop func(string) error) {
+	switch set {
+	case nri.CapabilityBounding:
+		return g.AddProcessCapabilityBounding, g.DropProcessCapabilityBounding
+	case nri.CapabilityEffective:
+		return g.AddProcessCapabilityEffective, g.DropProcessCapabilityEffective
+	case nri.CapabilityPermitted:
+		return g.AddProcessCapabilityPermitted, g.DropProcessCapabilityPermitted
+	case nri.CapabilityInheritable:
+		return g.AddProcessCapabilityInheritable, g.DropProcessCapabilityInheritable
+	}
+	return g.AddProcessCapabilityAmbient, g.DropProcessCapabilityAmbient
+}
+
+// AdjustUser adjusts the user and groups of the process in the OCI Spec.
+func (g *Generator) AdjustUser(user *nri.User) {
+	if user == nil {
+		return
+	}
+
+	if uid := user.GetUid(); uid != nil {
+		g.SetProcessUID(uid.GetValue())
+	}
+	if gid := user.GetGid(); gid != nil {
+		g.SetProcessGID(gid.GetValue())
+	}
+	for _, gid := range user.GetAdditionalGids() {
+		g.AddProcessAdditionalGid(gid)
+	}
+}
+
 // AdjustDevices adjusts the (Linux) devices in the OCI Spec.
 func (g *Generator) AdjustDevices(devices []*nri.LinuxDevice) {
 	for _, d := range devices {
@@ -545,3 +676,16 @@ func (g *Generator) initConfigLinuxResources() {
 		g.Config.Linux.Resources = &rspec.LinuxResources{}
 	}
 }
+
+func (g *Generator) initConfigWindowsResourcesCPU() {
+	g.initConfig()
+	if g.Config.Windows == nil {
+		g.Config.Windows = &rspec.Windows{}
+	}
+	if g.Config.Windows.Resources == nil {
+		g.Config.Windows.Resources = &rspec.WindowsResources{}
+	}
+	if g.Config.Windows.Resources.CPU == nil {
+		g.Config.Windows.Resources.CPU = &rspec.WindowsCPUResources{}
+	}
+}
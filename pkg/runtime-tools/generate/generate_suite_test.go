@@ -322,6 +322,195 @@ var _ = Describe("Adjustment", func() {
 		})
 	})
 
+	When("has device cgroup rules", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec = makeSpec(withDeviceCgroupRules(
+					rspec.LinuxDeviceCgroup{Allow: false, Access: "rwm"},
+					rspec.LinuxDeviceCgroup{Allow: true, Type: "c", Major: Int64(1), Minor: Int64(3), Access: "rwm"},
+				))
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.RemoveLinuxDeviceCgroupRule("c", Int64(1), Int64(3))
+			adjust.AddLinuxDeviceCgroupRule(true, "c", Int64(10), nil, "rw")
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withDeviceCgroupRules(
+				rspec.LinuxDeviceCgroup{Allow: false, Access: "rwm"},
+				rspec.LinuxDeviceCgroup{Allow: true, Type: "c", Major: Int64(10), Access: "rw"},
+			))))
+		})
+	})
+
+	When("has Windows resources", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec   = makeSpec()
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.SetWindowsMemoryLimit(1 << 30)
+			adjust.SetWindowsCPUCount(2)
+			adjust.SetWindowsCPUMaximum(5000)
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withWindowsResources(1<<30, 2, 5000))))
+		})
+	})
+
+	When("has capabilities", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec = makeSpec(withCapabilities(&rspec.LinuxCapabilities{
+					Bounding:  []string{"CAP_CHOWN", "CAP_NET_RAW"},
+					Effective: []string{"CAP_CHOWN", "CAP_NET_RAW"},
+					Permitted: []string{"CAP_CHOWN", "CAP_NET_RAW"},
+				}))
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.DropCapability("net_raw")
+			adjust.AddCapability("CAP_SYS_ADMIN")
+			adjust.AddCapability("CAP_KILL", api.CapabilityAmbient)
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withCapabilities(&rspec.LinuxCapabilities{
+				Bounding:  []string{"CAP_CHOWN", "CAP_SYS_ADMIN"},
+				Effective: []string{"CAP_CHOWN", "CAP_SYS_ADMIN"},
+				Permitted: []string{"CAP_CHOWN", "CAP_SYS_ADMIN"},
+				Ambient:   []string{"CAP_KILL"},
+			}))))
+		})
+
+		It("rejects invalid capabilities", func() {
+			var (
+				spec   = makeSpec()
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.AddCapability("no_such_capability")
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).ToNot(Succeed())
+		})
+	})
+
+	When("has AppArmor profile", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec   = makeSpec(withApparmorProfile("runtime/default"))
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.SetLinuxApparmorProfile("custom")
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withApparmorProfile("custom"))))
+		})
+	})
+
+	When("has seccomp policy", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec   = makeSpec()
+				adjust = &api.ContainerAdjustment{}
+				errno  = uint(1)
+				policy = &rspec.LinuxSeccomp{
+					DefaultAction: rspec.ActErrno,
+					Architectures: []rspec.Arch{rspec.ArchX86_64},
+					Syscalls: []rspec.LinuxSyscall{
+						{
+							Names:  []string{"read", "write"},
+							Action: rspec.ActAllow,
+						},
+						{
+							Names:    []string{"personality"},
+							Action:   rspec.ActErrno,
+							ErrnoRet: &errno,
+							Args: []rspec.LinuxSeccompArg{
+								{Index: 0, Value: 0xffffffff, Op: rspec.OpNotEqual},
+							},
+						},
+					},
+				}
+			)
+
+			adjust.SetLinuxSeccompPolicy(api.FromOCILinuxSeccomp(policy))
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withSeccompPolicy(policy))))
+		})
+	})
+
+	When("has user", func() {
+		It("adjusts Spec correctly", func() {
+			var (
+				spec = makeSpec(withUser(rspec.User{
+					UID:            0,
+					GID:            0,
+					AdditionalGids: []uint32{10},
+				}))
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.SetUser(1000)
+			adjust.SetGroup(1000)
+			adjust.AddAdditionalGid(10)
+			adjust.AddAdditionalGid(20)
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withUser(rspec.User{
+				UID:            1000,
+				GID:            1000,
+				AdditionalGids: []uint32{10, 20},
+			}))))
+		})
+
+		It("leaves unset IDs intact", func() {
+			var (
+				spec   = makeSpec(withUser(rspec.User{UID: 1000, GID: 1000}))
+				adjust = &api.ContainerAdjustment{}
+			)
+
+			adjust.SetGroup(2000)
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(withUser(rspec.User{UID: 1000, GID: 2000}))))
+		})
+	})
+
 	When("has mounts", func() {
 		It("it sorts the Spec mount slice", func() {
 			var (
@@ -374,6 +563,49 @@ var _ = Describe("Adjustment", func() {
 				}),
 			)))
 		})
+
+		It("keeps the propagation and ID mappings of mounts", func() {
+			var (
+				spec     = makeSpec()
+				adjust   = &api.ContainerAdjustment{}
+				mappings = []*api.LinuxIDMapping{
+					{ContainerId: 0, HostId: 100000, Size: 65536},
+				}
+			)
+
+			adjust.AddMountWithIDMapping(
+				&api.Mount{
+					Destination: "/data",
+					Source:      "/host/data",
+					Type:        "bind",
+					Options:     []string{"rbind", "rprivate", "ro"},
+					Propagation: "private",
+				},
+				mappings, mappings,
+			)
+
+			rg := &rgen.Generator{Config: spec}
+			xg := xgen.SpecGenerator(rg)
+
+			Expect(xg).ToNot(BeNil())
+			Expect(xg.Adjust(adjust)).To(Succeed())
+			Expect(spec).To(Equal(makeSpec(
+				withMounts([]rspec.Mount{
+					{
+						Destination: "/data",
+						Source:      "/host/data",
+						Type:        "bind",
+						Options:     []string{"rbind", "ro", "private"},
+						UIDMappings: []rspec.LinuxIDMapping{
+							{ContainerID: 0, HostID: 100000, Size: 65536},
+						},
+						GIDMappings: []rspec.LinuxIDMapping{
+							{ContainerID: 0, HostID: 100000, Size: 65536},
+						},
+					},
+				}),
+			)))
+		})
 	})
 })
 
@@ -507,6 +739,18 @@ func withPidsLimit(v int64) specOption {
 	}
 }
 
+func withDeviceCgroupRules(rules ...rspec.LinuxDeviceCgroup) specOption {
+	return func(spec *rspec.Spec) {
+		if spec.Linux == nil {
+			spec.Linux = &rspec.Linux{}
+		}
+		if spec.Linux.Resources == nil {
+			spec.Linux.Resources = &rspec.LinuxResources{}
+		}
+		spec.Linux.Resources.Devices = rules
+	}
+}
+
 func withMounts(mounts []rspec.Mount) specOption {
 	return func(spec *rspec.Spec) {
 		spec.Mounts = append(spec.Mounts, mounts...)
@@ -526,6 +770,58 @@ func withRlimit(typ string, hard, soft uint64) specOption {
 	}
 }
 
+func withWindowsResources(limit, count uint64, maximum uint16) specOption {
+	return func(spec *rspec.Spec) {
+		spec.Windows = &rspec.Windows{
+			Resources: &rspec.WindowsResources{
+				Memory: &rspec.WindowsMemoryResources{
+					Limit: &limit,
+				},
+				CPU: &rspec.WindowsCPUResources{
+					Count:   &count,
+					Maximum: &maximum,
+				},
+			},
+		}
+	}
+}
+
+func withCapabilities(caps *rspec.LinuxCapabilities) specOption {
+	return func(spec *rspec.Spec) {
+		if spec.Process == nil {
+			return
+		}
+		spec.Process.Capabilities = caps
+	}
+}
+
+func withApparmorProfile(profile string) specOption {
+	return func(spec *rspec.Spec) {
+		if spec.Process == nil {
+			return
+		}
+		spec.Process.ApparmorProfile = profile
+	}
+}
+
+func withSeccompPolicy(policy *rspec.LinuxSeccomp) specOption {
+	return func(spec *rspec.Spec) {
+		if spec.Linux == nil {
+			spec.Linux = &rspec.Linux{}
+		}
+		spec.Linux.Seccomp = policy
+	}
+}
+
+func withUser(user rspec.User) specOption {
+	return func(spec *rspec.Spec) {
+		if spec.Process == nil {
+			return
+		}
+		spec.Process.User = user
+	}
+}
+
 func makeSpec(options ...specOption) *rspec.Spec {
 	spec := &rspec.Spec{
 		Process: &rspec.Process{},
@@ -0,0 +1,45 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"time"
+)
+
+type budgetKey struct{}
+
+// TimeBudget returns the time left for the runtime operation which the
+// request being handled is part of. Plugins can use this to skip optional
+// work when the budget runs low. The returned bool is false if the runtime
+// did not pass a budget, for instance because it has no deadline for the
+// operation. The context must be the one passed to the request handler.
+func TimeBudget(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Value(budgetKey{}).(time.Time)
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// withTimeBudget returns a context carrying the given time budget.
+func withTimeBudget(ctx context.Context, budget int64) context.Context {
+	if budget <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, time.Now().Add(time.Duration(budget)))
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// WithConfigInto decodes the plugin configuration into the given value,
+// which must be a non-nil pointer. The configuration is decoded as YAML,
+// or JSON, before the Configure handler of the plugin is called, and again
+// whenever the runtime passes updated configuration to the plugin. Fields
+// missing from the configuration keep the values cfg had when the option
+// was applied. Decoding errors fail the configuration.
+func WithConfigInto(cfg interface{}) Option {
+	return func(s *stub) error {
+		v := reflect.ValueOf(cfg)
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			return fmt.Errorf("invalid configuration target %T, need non-nil pointer", cfg)
+		}
+		defaults, err := json.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to save configuration defaults: %w", err)
+		}
+		s.config = cfg
+		s.cfgDefaults = defaults
+		return nil
+	}
+}
+
+// decodeConfig decodes the given configuration into the configuration of
+// the plugin, if the plugin asked for this using WithConfigInto. It returns
+// a function for restoring the previous configuration.
+func (stub *stub) decodeConfig(config string) (func(), error) {
+	if stub.config == nil {
+		return func() {}, nil
+	}
+
+	var (
+		target = reflect.ValueOf(stub.config).Elem()
+		prev   = reflect.New(target.Type()).Elem()
+		cfg    = reflect.New(target.Type())
+	)
+
+	if err := json.Unmarshal(stub.cfgDefaults, cfg.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to restore configuration defaults: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(config), cfg.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	prev.Set(target)
+	target.Set(cfg.Elem())
+
+	return func() { target.Set(prev) }, nil
+}
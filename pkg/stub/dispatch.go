@@ -0,0 +1,234 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+// WithConcurrentDispatch returns an option to handle pod and container
+// events concurrently, using at most the given number of workers. Events
+// of any single container are handled in order, and so are events of any
+// single pod, with respect to both each other and the events of containers
+// of the pod. Events of different containers are handled concurrently.
+//
+// Without this option, the plugin handles one event at a time, and the
+// runtime waits for the plugin to handle an event before it proceeds. With
+// this option, the stub acknowledges state change events immediately and
+// handles them in the background. Errors returned by their handlers are
+// logged, since they can't be returned to the runtime any more. Requests
+// which need a response, like CreateContainer, and the PostCreateContainer
+// and StartContainer events, which can veto starting the container, are
+// still handled before responding, in order with the events of the pod and
+// container involved. Synchronize and Shutdown are handled once all events
+// received before them are. The handlers of the plugin must be safe for
+// concurrent use.
+func WithConcurrentDispatch(workers int) Option {
+	return func(s *stub) error {
+		if workers < 1 {
+			return fmt.Errorf("invalid number of dispatch workers %d", workers)
+		}
+		s.dispatcher = newDispatcher(workers)
+		return nil
+	}
+}
+
+// dispatcher runs tasks concurrently across pods and containers, preserving
+// the order of tasks for any single pod or container.
+type dispatcher struct {
+	sync.Mutex
+	workers chan struct{}
+	pods    map[string]*podTasks
+}
+
+// podTasks tracks the last tasks submitted for a pod and its containers.
+type podTasks struct {
+	pod  chan struct{}
+	ctrs map[string]chan struct{}
+}
+
+func newDispatcher(workers int) *dispatcher {
+	return &dispatcher{
+		workers: make(chan struct{}, workers),
+		pods:    make(map[string]*podTasks),
+	}
+}
+
+// submit a task for the given pod or, if ctrID is set, for a container of the
+// pod. A container task runs once earlier tasks of the container and the pod
+// are done. A pod task runs once all earlier tasks of the pod and any of its
+// containers are done. The returned channel is closed once the task is done.
+func (d *dispatcher) submit(podID, ctrID string, task func()) <-chan struct{} {
+	var (
+		done = make(chan struct{})
+		deps []chan struct{}
+	)
+
+	d.Lock()
+	p, ok := d.pods[podID]
+	if !ok {
+		p = &podTasks{ctrs: make(map[string]chan struct{})}
+		d.pods[podID] = p
+	}
+	if p.pod != nil {
+		deps = append(deps, p.pod)
+	}
+	if ctrID != "" {
+		if c, ok := p.ctrs[ctrID]; ok {
+			deps = append(deps, c)
+		}
+		p.ctrs[ctrID] = done
+	} else {
+		for _, c := range p.ctrs {
+			deps = append(deps, c)
+		}
+		p.pod = done
+		p.ctrs = make(map[string]chan struct{})
+	}
+	d.Unlock()
+
+	go func() {
+		defer close(done)
+
+		for _, c := range deps {
+			<-c
+		}
+
+		d.workers <- struct{}{}
+		task()
+		<-d.workers
+
+		d.forget(p, podID, ctrID, done)
+	}()
+
+	return done
+}
+
+// wait until all tasks submitted so far are done, or the context is done.
+func (d *dispatcher) wait(ctx context.Context) error {
+	var pending []chan struct{}
+
+	d.Lock()
+	for _, p := range d.pods {
+		if p.pod != nil {
+			pending = append(pending, p.pod)
+		}
+		for _, c := range p.ctrs {
+			pending = append(pending, c)
+		}
+	}
+	d.Unlock()
+
+	for _, c := range pending {
+		select {
+		case <-c:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// forget a finished task, unless other tasks have been submitted after it.
+func (d *dispatcher) forget(p *podTasks, podID, ctrID string, done chan struct{}) {
+	d.Lock()
+	defer d.Unlock()
+
+	if ctrID != "" {
+		if p.ctrs[ctrID] == done {
+			delete(p.ctrs, ctrID)
+		}
+	} else if p.pod == done {
+		p.pod = nil
+	}
+
+	if p.pod == nil && len(p.ctrs) == 0 && d.pods[podID] == p {
+		delete(d.pods, podID)
+	}
+}
+
+// dispatchEvent handles a state change event, in the background if events
+// are dispatched concurrently.
+func (stub *stub) dispatchEvent(ctx context.Context, evt *api.StateChangeEvent) error {
+	if stub.dispatcher == nil {
+		return stub.stateChange(ctx, evt)
+	}
+
+	// events which can veto starting the container are handled like requests
+	switch evt.Event {
+	case api.Event_POST_CREATE_CONTAINER, api.Event_START_CONTAINER:
+		var err error
+		if derr := stub.dispatchRequest(ctx, evt.Container, func() {
+			err = stub.stateChange(ctx, evt)
+		}); derr != nil {
+			return derr
+		}
+		return err
+	}
+
+	// the event is acknowledged before it is handled, don't let the request
+	// context get canceled under the handler
+	ctx = context.WithoutCancel(ctx)
+	podID, ctrID := evt.GetPod().GetId(), evt.GetContainer().GetId()
+	if ctrID != "" {
+		podID = evt.GetContainer().GetPodSandboxId()
+	}
+
+	stub.dispatcher.submit(podID, ctrID, func() {
+		if err := stub.stateChange(ctx, evt); err != nil {
+			log.Errorf(ctx, "Failed to handle event %s: %v", evt.Event, err)
+		}
+	})
+
+	return nil
+}
+
+// dispatchRequest handles a container request, in order with any events of
+// the container and its pod if events are dispatched concurrently.
+func (stub *stub) dispatchRequest(ctx context.Context, ctr *api.Container, handler func()) error {
+	if stub.dispatcher == nil {
+		handler()
+		return nil
+	}
+
+	done := stub.dispatcher.submit(ctr.GetPodSandboxId(), ctr.GetId(), func() {
+		// skip requests the runtime has given up on while waiting
+		if ctx.Err() == nil {
+			handler()
+		}
+	})
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainEvents waits until all events dispatched so far have been handled.
+func (stub *stub) drainEvents(ctx context.Context) error {
+	if stub.dispatcher == nil {
+		return nil
+	}
+	return stub.dispatcher.wait(ctx)
+}
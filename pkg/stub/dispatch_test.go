@@ -0,0 +1,237 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+
+	require "github.com/stretchr/testify/require"
+)
+
+// taskLog records the order in which dispatched tasks run.
+type taskLog struct {
+	sync.Mutex
+	names []string
+}
+
+func (l *taskLog) task(name string, wait <-chan struct{}) func() {
+	return func() {
+		if wait != nil {
+			<-wait
+		}
+		l.Lock()
+		defer l.Unlock()
+		l.names = append(l.names, name)
+	}
+}
+
+func (l *taskLog) get() []string {
+	l.Lock()
+	defer l.Unlock()
+	return append([]string{}, l.names...)
+}
+
+func waitDone(t *testing.T, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for dispatched task")
+	}
+}
+
+func TestDispatchOrdersContainerTasks(t *testing.T) {
+	var (
+		d       = newDispatcher(4)
+		l       = &taskLog{}
+		release = make(chan struct{})
+	)
+
+	d.submit("pod0", "ctr0", l.task("ctr0-1", release))
+	d.submit("pod0", "ctr0", l.task("ctr0-2", nil))
+	other := d.submit("pod0", "ctr1", l.task("ctr1-1", nil))
+
+	// tasks of other containers are not held up by ctr0
+	waitDone(t, other)
+	require.Equal(t, []string{"ctr1-1"}, l.get())
+
+	close(release)
+	last := d.submit("pod0", "ctr0", l.task("ctr0-3", nil))
+	waitDone(t, last)
+	require.Equal(t, []string{"ctr1-1", "ctr0-1", "ctr0-2", "ctr0-3"}, l.get())
+}
+
+func TestDispatchOrdersPodTasks(t *testing.T) {
+	var (
+		d       = newDispatcher(4)
+		l       = &taskLog{}
+		release = make(chan struct{})
+	)
+
+	d.submit("pod0", "ctr0", l.task("ctr0-1", release))
+	d.submit("pod0", "", l.task("pod0", nil))
+	d.submit("pod0", "ctr1", l.task("ctr1-1", nil))
+	other := d.submit("pod1", "ctr2", l.task("ctr2-1", nil))
+
+	// the pod task waits for ctr0, later container tasks for the pod task,
+	// tasks of other pods for neither
+	waitDone(t, other)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, []string{"ctr2-1"}, l.get())
+
+	close(release)
+	require.NoError(t, d.wait(context.Background()))
+	require.Equal(t, []string{"ctr2-1", "ctr0-1", "pod0", "ctr1-1"}, l.get())
+	require.Empty(t, d.pods, "finished tasks are forgotten")
+}
+
+func TestDispatchWorkerBound(t *testing.T) {
+	const workers = 2
+
+	var (
+		d       = newDispatcher(workers)
+		release = make(chan struct{})
+		running atomic.Int32
+		peak    atomic.Int32
+		started = make(chan struct{}, 8)
+	)
+
+	for _, id := range []string{"ctr0", "ctr1", "ctr2", "ctr3", "ctr4"} {
+		d.submit("pod0", id, func() {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			started <- struct{}{}
+			<-release
+			running.Add(-1)
+		})
+	}
+
+	for i := 0; i < workers; i++ {
+		<-started
+	}
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, started, 0, "more tasks running than workers")
+
+	close(release)
+	require.NoError(t, d.wait(context.Background()))
+	require.Equal(t, int32(workers), peak.Load())
+}
+
+func TestDispatchCancelledRequest(t *testing.T) {
+	var (
+		s       = &stub{dispatcher: newDispatcher(4)}
+		release = make(chan struct{})
+		ctr     = &api.Container{Id: "ctr0", PodSandboxId: "pod0"}
+		called  = false
+	)
+
+	s.dispatcher.submit("pod0", "ctr0", func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := s.dispatchRequest(ctx, ctr, func() { called = true })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, s.dispatcher.wait(context.Background()))
+	require.False(t, called, "handler of cancelled request called")
+}
+
+type vetoPlugin struct {
+	release chan struct{}
+	handled atomic.Bool
+}
+
+func (p *vetoPlugin) PostCreateContainer(context.Context, *api.PodSandbox, *api.Container) error {
+	return api.Veto("not on my watch")
+}
+
+func (p *vetoPlugin) StartContainer(context.Context, *api.PodSandbox, *api.Container) error {
+	return nil
+}
+
+func (p *vetoPlugin) PostStartContainer(context.Context, *api.PodSandbox, *api.Container) error {
+	<-p.release
+	p.handled.Store(true)
+	return api.Veto("too late")
+}
+
+func TestDispatchPropagatesVetoes(t *testing.T) {
+	var (
+		ctx = context.Background()
+		pod = &api.PodSandbox{Id: "pod0"}
+		ctr = &api.Container{Id: "ctr0", PodSandboxId: "pod0"}
+		p   = &vetoPlugin{release: make(chan struct{})}
+	)
+
+	s, err := New(p, WithPluginName("test"), WithPluginIdx("00"), WithConcurrentDispatch(2))
+	require.NoError(t, err)
+	stub := s.(*stub)
+
+	_, err = stub.StateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_POST_CREATE_CONTAINER,
+		Pod:       pod,
+		Container: ctr,
+	})
+	reason, ok := api.IsVeto(err)
+	require.True(t, ok, "veto of PostCreateContainer not returned")
+	require.Equal(t, "not on my watch", reason)
+
+	_, err = stub.StateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_START_CONTAINER,
+		Pod:       pod,
+		Container: ctr,
+	})
+	require.NoError(t, err)
+
+	// other events are acknowledged before they are handled
+	_, err = stub.StateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_POST_START_CONTAINER,
+		Pod:       pod,
+		Container: ctr,
+	})
+	require.NoError(t, err)
+	require.False(t, p.handled.Load())
+
+	// synchronization waits for them
+	var syncErr error
+	syncDone := make(chan struct{})
+	go func() {
+		defer close(syncDone)
+		_, syncErr = stub.Synchronize(ctx, &api.SynchronizeRequest{})
+	}()
+	select {
+	case <-syncDone:
+		require.FailNow(t, "Synchronize did not wait for pending events")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(p.release)
+	waitDone(t, syncDone)
+	require.NoError(t, syncErr)
+	require.True(t, p.handled.Load())
+}
@@ -0,0 +1,122 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdnet "net"
+	"sync/atomic"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/net/multiplex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WithGRPCTransport makes the plugin talk to the runtime using gRPC instead
+// of ttrpc. The runtime accepts gRPC plugins on a separate socket, which the
+// plugin needs to connect to, for instance using WithSocketPath. Options set
+// using WithTTRPCOptions are ignored for gRPC.
+func WithGRPCTransport() Option {
+	return func(s *stub) error {
+		s.grpc = true
+		return nil
+	}
+}
+
+// usesGRPC returns true if the plugin talks to the runtime using gRPC.
+// Sub-plugins use the transport of their host.
+func (stub *stub) usesGRPC() bool {
+	if stub.host != nil {
+		return stub.host.grpc
+	}
+	return stub.grpc
+}
+
+// connectGRPC sets up the gRPC server and client of the plugin on the given
+// multiplexed connections.
+func (stub *stub) connectGRPC(ctx context.Context, rpcm multiplex.Mux, rpcl stdnet.Listener, runtimeID multiplex.ConnID) error {
+	conn, err := rpcm.Open(runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to multiplex gRPC client connection: %w", err)
+	}
+
+	// The multiplexed connection can't be reestablished once lost, so only
+	// hand it out for the first connection attempt.
+	dialed := &atomic.Bool{}
+	dialer := func(context.Context, string) (stdnet.Conn, error) {
+		if dialed.Swap(true) {
+			return nil, errors.New("runtime connection closed")
+		}
+		return conn, nil
+	}
+
+	grpcc, err := grpc.Dial("passthrough:///nri-runtime",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+
+	grpcs := grpc.NewServer()
+	api.RegisterPluginGRPCService(grpcs, stub)
+
+	stub.srvErrC = make(chan error, 1)
+	stub.cfgErrC = make(chan error, 1)
+
+	go func(l stdnet.Listener, doneC chan struct{}, srvErrC chan error) {
+		srvErrC <- grpcs.Serve(l)
+		close(doneC)
+	}(rpcl, stub.doneC, stub.srvErrC)
+
+	go stub.watchGRPCConn(grpcc)
+
+	stub.grpcs = grpcs
+	stub.grpcc = grpcc
+
+	stub.runtime = api.NewRuntimeGRPCClient(grpcc)
+
+	return nil
+}
+
+// watchGRPCConn waits for the gRPC connection to the runtime to go down.
+func (stub *stub) watchGRPCConn(grpcc *grpc.ClientConn) {
+	var (
+		ctx   = context.Background()
+		ready bool
+	)
+
+	for state := grpcc.GetState(); ; state = grpcc.GetState() {
+		switch state {
+		case connectivity.Ready:
+			ready = true
+		case connectivity.Idle:
+			if ready {
+				stub.connClosed()
+				return
+			}
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			stub.connClosed()
+			return
+		}
+		grpcc.WaitForStateChange(ctx, state)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/nri/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithKeepalive makes the plugin send keepalives to the runtime with the
+// given interval. If the runtime fails to acknowledge the given number of
+// consecutive keepalives, the connection is considered dead and closed, so
+// OnClose is called promptly, for instance after a runtime crash leaves the
+// connection half-open. The runtime in turn detaches the plugin if it stops
+// sending keepalives. If the number of misses is 0, the default
+// api.DefaultKeepaliveMisses is used. Without this option, the plugin sends
+// keepalives with the interval requested by the runtime, if any.
+func WithKeepalive(interval time.Duration, misses int) Option {
+	return func(s *stub) error {
+		if interval < time.Millisecond {
+			return fmt.Errorf("invalid keepalive interval %s", interval)
+		}
+		if misses < 0 {
+			return fmt.Errorf("invalid keepalive miss threshold %d", misses)
+		}
+		s.kaIntv = interval
+		s.kaMisses = misses
+		return nil
+	}
+}
+
+// startKeepalive starts sending keepalives to the runtime, if enabled by
+// the plugin or requested by the runtime. Sub-plugins share the connection
+// of their host, so only the host sends keepalives. The caller must hold
+// the lock.
+func (stub *stub) startKeepalive() {
+	interval := stub.kaIntv
+	if interval == 0 {
+		interval = stub.rtKaIntv
+	}
+	if interval <= 0 || stub.host != nil || stub.kaStop != nil {
+		return
+	}
+
+	misses := stub.kaMisses
+	if misses == 0 {
+		misses = api.DefaultKeepaliveMisses
+	}
+
+	stopC := make(chan struct{})
+	stub.kaStop = stopC
+
+	go stub.keepalive(stub.runtime, interval, misses, stopC)
+}
+
+// stopKeepalive stops sending keepalives. The caller must hold the lock.
+func (stub *stub) stopKeepalive() {
+	if stub.kaStop != nil {
+		close(stub.kaStop)
+		stub.kaStop = nil
+	}
+}
+
+// keepalive sends keepalives to the runtime until stopped, or until the
+// runtime misses too many of them. Runtimes which don't know about
+// keepalives reject them, which still proves the connection alive.
+func (stub *stub) keepalive(runtime api.RuntimeService, interval time.Duration, misses int, stopC chan struct{}) {
+	var (
+		req    = &api.KeepaliveRequest{Interval: interval.Milliseconds()}
+		ticker = time.NewTicker(interval)
+		failed = 0
+	)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		_, err := runtime.Keepalive(ctx, req)
+		cancel()
+
+		if err == nil || status.Code(err) == codes.Unimplemented {
+			failed = 0
+		} else {
+			failed++
+			log.Warnf(noCtx, "Keepalive to runtime failed (%d/%d): %v", failed, misses, err)
+			if failed >= misses {
+				log.Errorf(noCtx, "Runtime missed %d consecutive keepalives, closing connection", failed)
+				stub.dropConnection(stopC)
+				return
+			}
+		}
+
+		select {
+		case <-stopC:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dropConnection closes the connection to the runtime, unless keepalives
+// have been stopped in the meantime. Closing the connection triggers the
+// usual handling of a lost connection.
+func (stub *stub) dropConnection(stopC chan struct{}) {
+	stub.Lock()
+	if stub.kaStop != stopC {
+		stub.Unlock()
+		return
+	}
+	rpcm := stub.rpcm
+	stub.Unlock()
+
+	if rpcm != nil {
+		rpcm.Close()
+	}
+}
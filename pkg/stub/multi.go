@@ -0,0 +1,155 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// MultiStub serves several plugins from a single process. Each plugin has
+// its own identity and event subscriptions, but they are started, run and
+// stopped together. The plugins either have a connection to NRI each, or
+// share a single connection.
+type MultiStub struct {
+	stubs []*stub
+}
+
+// NewMulti creates a MultiStub for the given stubs, which must have been
+// created by New with distinct plugin names and indices.
+func NewMulti(stubs ...Stub) (*MultiStub, error) {
+	if len(stubs) == 0 {
+		return nil, fmt.Errorf("stub: no plugins for MultiStub")
+	}
+
+	m := &MultiStub{}
+	names := map[string]struct{}{}
+	for _, s := range stubs {
+		stub, ok := s.(*stub)
+		if !ok {
+			return nil, fmt.Errorf("stub: MultiStub needs stubs created by New, got %T", s)
+		}
+		if _, ok := names[stub.Name()]; ok {
+			return nil, fmt.Errorf("stub: duplicate plugin %s in MultiStub", stub.Name())
+		}
+		names[stub.Name()] = struct{}{}
+		m.stubs = append(m.stubs, stub)
+	}
+
+	return m, nil
+}
+
+// NewShared creates a MultiStub for the given stubs, which serves them over
+// a single connection to NRI. The first stub owns the connection and hosts
+// the rest as sub-plugins, which NRI treats as distinct plugins for ordering,
+// validation and attribution. Only the connection options of the first stub
+// are used. The sub-plugins share the lifetime of the connection.
+func NewShared(stubs ...Stub) (*MultiStub, error) {
+	m, err := NewMulti(stubs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stub := range m.stubs {
+		if stub.host != nil || len(stub.subs) > 0 {
+			return nil, fmt.Errorf("stub: plugin %s already shares a connection", stub.Name())
+		}
+		if stub.reconnect != nil {
+			return nil, fmt.Errorf("stub: plugin %s reconnects, unsupported with a shared connection", stub.Name())
+		}
+	}
+
+	host := m.stubs[0]
+	for i, sub := range m.stubs[1:] {
+		sub.host = host
+		sub.subNum = i + 1
+		host.subs = append(host.subs, sub)
+	}
+
+	return m, nil
+}
+
+// Start all plugins. If any plugin fails to start, the already started
+// ones are stopped.
+func (m *MultiStub) Start(ctx context.Context) error {
+	for i, stub := range m.stubs {
+		if err := stub.Start(ctx); err != nil {
+			for _, started := range m.stubs[:i] {
+				started.Stop()
+			}
+			return fmt.Errorf("failed to start plugin %s: %w", stub.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Stop all plugins, in the reverse order of starting them.
+func (m *MultiStub) Stop() {
+	for i := len(m.stubs) - 1; i >= 0; i-- {
+		m.stubs[i].Stop()
+	}
+}
+
+// Wait for all plugins to stop, should be called after Start() or Run().
+func (m *MultiStub) Wait() {
+	for _, stub := range m.stubs {
+		stub.Wait()
+	}
+}
+
+// Run all plugins. Start event processing then wait for an error or any
+// plugin getting stopped, then stop all plugins. If any of the plugins is
+// set up for a self-test, run the self-test of all plugins instead.
+func (m *MultiStub) Run(ctx context.Context) error {
+	for _, stub := range m.stubs {
+		if stub.selfTest {
+			return m.SelfTest(ctx)
+		}
+	}
+
+	if err := m.Start(ctx); err != nil {
+		return err
+	}
+
+	errC := make(chan error, len(m.stubs))
+	for _, s := range m.stubs {
+		go func(s *stub) {
+			if err := s.waitExit(); err != nil {
+				errC <- fmt.Errorf("plugin %s: %w", s.Name(), err)
+				return
+			}
+			errC <- nil
+		}(s)
+	}
+
+	err := <-errC
+	m.Stop()
+
+	return err
+}
+
+// SelfTest runs the self-test of all plugins.
+func (m *MultiStub) SelfTest(ctx context.Context) error {
+	var errs []error
+	for _, stub := range m.stubs {
+		if err := stub.SelfTest(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", stub.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
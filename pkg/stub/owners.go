@@ -0,0 +1,59 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+type (
+	ownersKey    struct{}
+	collectedKey struct{}
+)
+
+// AdjustmentOwners returns the plugins owning the container parameters
+// adjusted by the plugins preceding this one in the CreateContainer request
+// being handled, by parameter path, for instance "linux.resources.cpu.shares".
+// It returns nil if no parameters are owned yet, or if the runtime did not
+// pass ownership. The context must be the one passed to the request handler.
+func AdjustmentOwners(ctx context.Context) map[string]string {
+	owners, _ := ctx.Value(ownersKey{}).(map[string]string)
+	return owners
+}
+
+// CollectedAdjustment returns the adjustment collected from the plugins
+// preceding this one in the CreateContainer request being handled. It returns
+// nil if the runtime did not pass the adjustment. The context must be the one
+// passed to the request handler.
+func CollectedAdjustment(ctx context.Context) *api.ContainerAdjustment {
+	adjust, _ := ctx.Value(collectedKey{}).(*api.ContainerAdjustment)
+	return adjust
+}
+
+// withCollectedAdjustment returns a context carrying the given adjustment
+// owners and collected adjustment.
+func withCollectedAdjustment(ctx context.Context, owners map[string]string, adjust *api.ContainerAdjustment) context.Context {
+	if len(owners) > 0 {
+		ctx = context.WithValue(ctx, ownersKey{}, owners)
+	}
+	if adjust != nil {
+		ctx = context.WithValue(ctx, collectedKey{}, adjust)
+	}
+	return ctx
+}
@@ -0,0 +1,170 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultReconnectInitialBackoff is the default delay before the first
+	// attempt to reconnect to the runtime.
+	DefaultReconnectInitialBackoff = 500 * time.Millisecond
+	// DefaultReconnectMaxBackoff is the default cap on the delay between
+	// attempts to reconnect to the runtime.
+	DefaultReconnectMaxBackoff = 30 * time.Second
+)
+
+var (
+	// errStopped is returned by attempts to reconnect a stopped plugin.
+	errStopped = errors.New("plugin stopped")
+)
+
+// ReconnectPolicy controls how the plugin reconnects to the runtime once
+// its connection is lost, for instance because the runtime was restarted.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first attempt to reconnect.
+	// It is doubled after each failed attempt. If 0, the default
+	// DefaultReconnectInitialBackoff is used.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. If 0, the default
+	// DefaultReconnectMaxBackoff is used.
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of consecutive failed attempts after which
+	// the plugin gives up reconnecting. If 0, attempts are unlimited.
+	MaxAttempts int
+}
+
+// WithReconnect makes the plugin reconnect to the runtime once its
+// connection is lost, instead of calling OnClose. The plugin re-dials the
+// runtime socket with exponential backoff, then registers again. The runtime
+// configures and synchronizes the plugin as usual, so the plugin gets its
+// Configure and Synchronize handlers invoked once its state is established
+// again. OnClose is only called if the plugin gives up reconnecting. Run
+// and Wait return once the plugin is stopped or gives up reconnecting.
+// Plugins only reconnect if they dialed the runtime socket themselves, and
+// not over a connection shared with other plugins.
+func WithReconnect(policy ReconnectPolicy) Option {
+	return func(s *stub) error {
+		if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.MaxAttempts < 0 {
+			return fmt.Errorf("invalid reconnect policy %+v", policy)
+		}
+		if policy.InitialBackoff == 0 {
+			policy.InitialBackoff = DefaultReconnectInitialBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = DefaultReconnectMaxBackoff
+		}
+		if policy.MaxBackoff < policy.InitialBackoff {
+			policy.MaxBackoff = policy.InitialBackoff
+		}
+		s.reconnect = &policy
+		return nil
+	}
+}
+
+// canReconnect returns true if the plugin should try to reconnect to the
+// runtime after losing its connection. The caller must hold the lock.
+func (stub *stub) canReconnect() bool {
+	return stub.reconnect != nil && !stub.stopped && stub.dialed &&
+		stub.host == nil && len(stub.subs) == 0
+}
+
+// reconnectLoop tries to reconnect to the runtime according to the reconnect
+// policy, until it succeeds, the plugin is stopped, or it gives up.
+func (stub *stub) reconnectLoop(stopC chan struct{}) {
+	var (
+		policy  = stub.reconnect
+		backoff = policy.InitialBackoff
+	)
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-stopC:
+			return
+		case <-time.After(backoff):
+		}
+
+		log.Infof(noCtx, "Reconnecting plugin %s to runtime (attempt %d)...", stub.Name(), attempt)
+
+		err := stub.restart()
+		if err == nil {
+			log.Infof(noCtx, "Plugin %s reconnected to runtime", stub.Name())
+			return
+		}
+		if errors.Is(err, errStopped) {
+			return
+		}
+
+		log.Warnf(noCtx, "Failed to reconnect plugin %s to runtime: %v", stub.Name(), err)
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			stub.giveUpReconnect(fmt.Errorf("failed to reconnect to runtime after %d attempts: %w",
+				attempt, err))
+			return
+		}
+
+		if backoff *= 2; backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// restart reconnects the plugin to the runtime, unless it has been stopped.
+func (stub *stub) restart() error {
+	stub.Lock()
+	defer stub.Unlock()
+
+	if stub.stopped {
+		return errStopped
+	}
+
+	if err := stub.start(context.Background()); err != nil {
+		// drop any connection dialed by the failed attempt
+		stub.conn = nil
+		return err
+	}
+
+	stub.reconnStop = nil
+	return nil
+}
+
+// giveUpReconnect stops reconnecting, letting Run and Wait return with the
+// given error, then calls OnClose.
+func (stub *stub) giveUpReconnect(err error) {
+	log.Errorf(noCtx, "Plugin %s giving up reconnecting: %v", stub.Name(), err)
+
+	stub.Lock()
+	stub.reconnStop = nil
+	stub.exit(err)
+	stub.Unlock()
+
+	stub.closed()
+}
+
+// exit lets Run and Wait of a reconnecting plugin return with the given
+// error. The caller must hold the lock.
+func (stub *stub) exit(err error) {
+	if stub.exitC != nil {
+		stub.exitErr = err
+		close(stub.exitC)
+		stub.exitC = nil
+	}
+}
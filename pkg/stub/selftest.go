@@ -0,0 +1,320 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const (
+	// SelfTestRuntime is the runtime name passed to Configure in self-test.
+	SelfTestRuntime = "nri-self-test"
+	// SelfTestVersion is the runtime version passed to Configure in self-test.
+	SelfTestVersion = "v0.0.0"
+)
+
+// SelfTest exercises the plugin handlers against generated fixtures.
+func (stub *stub) SelfTest(ctx context.Context) error {
+	log.Infof(ctx, "Running self-test of plugin %s...", stub.Name())
+
+	events, err := stub.configure(ctx, stub.testConfig, SelfTestRuntime, SelfTestVersion)
+	if err != nil {
+		return fmt.Errorf("self-test: failed to configure plugin: %w", err)
+	}
+	if events == 0 {
+		return fmt.Errorf("self-test: plugin subscribed to no events")
+	}
+
+	pod, ctr := selfTestPod(), selfTestContainer()
+
+	if handler := stub.handlers.Synchronize; handler != nil {
+		update, err := handler(ctx, []*api.PodSandbox{pod}, []*api.Container{ctr})
+		if err != nil {
+			return fmt.Errorf("self-test: Synchronize failed: %w", err)
+		}
+		if err := checkUpdates(update); err != nil {
+			return fmt.Errorf("self-test: Synchronize: %w", err)
+		}
+	}
+
+	ctr = selfTestContainer()
+	ctr.Id = "nri-self-test-ctr1"
+	ctr.Name = "ctr1"
+	ctr.State = api.ContainerState_CONTAINER_UNKNOWN
+
+	for _, e := range []api.Event{
+		api.Event_RUN_POD_SANDBOX,
+		api.Event_CREATE_CONTAINER,
+		api.Event_POST_CREATE_CONTAINER,
+		api.Event_START_CONTAINER,
+		api.Event_POST_START_CONTAINER,
+		api.Event_UPDATE_CONTAINER,
+		api.Event_POST_UPDATE_CONTAINER,
+		api.Event_CONTAINER_DIED,
+		api.Event_STOP_CONTAINER,
+		api.Event_REMOVE_CONTAINER,
+		api.Event_STOP_POD_SANDBOX,
+		api.Event_REMOVE_POD_SANDBOX,
+	} {
+		if !events.IsSet(e) {
+			continue
+		}
+
+		if e == api.Event_CONTAINER_DIED {
+			ctr.State = api.ContainerState_CONTAINER_STOPPED
+			ctr.SetExit(&api.ContainerExit{Code: 137, Signal: 9, OOMKilled: true})
+		}
+
+		ctr.EventSequence++
+		if err := stub.selfTestEvent(ctx, e, pod, ctr); err != nil {
+			return fmt.Errorf("self-test: %s: %w", e, err)
+		}
+
+		switch e {
+		case api.Event_CREATE_CONTAINER:
+			ctr.State = api.ContainerState_CONTAINER_CREATED
+		case api.Event_START_CONTAINER:
+			ctr.State = api.ContainerState_CONTAINER_RUNNING
+		case api.Event_STOP_CONTAINER:
+			ctr.State = api.ContainerState_CONTAINER_STOPPED
+		}
+	}
+
+	if events.IsSet(api.Event_CREATE_CONTAINER_ROLLBACK) {
+		if err := stub.selfTestRollback(ctx, events, pod); err != nil {
+			return fmt.Errorf("self-test: %s: %w", api.Event_CREATE_CONTAINER_ROLLBACK, err)
+		}
+	}
+
+	log.Infof(ctx, "Self-test of plugin %s passed", stub.Name())
+
+	return nil
+}
+
+// selfTestEvent relays an event to the plugin. State change events are passed
+// directly to their handlers, since the errors of events dispatched
+// concurrently would not be returned.
+func (stub *stub) selfTestEvent(ctx context.Context, e api.Event, pod *api.PodSandbox, ctr *api.Container) error {
+	switch e {
+	case api.Event_CREATE_CONTAINER:
+		rpl, err := stub.CreateContainer(ctx, &api.CreateContainerRequest{Pod: pod, Container: ctr})
+		if err != nil {
+			return err
+		}
+		if err := checkAdjustment(rpl.GetAdjust()); err != nil {
+			return err
+		}
+		return checkUpdates(rpl.GetUpdate())
+
+	case api.Event_UPDATE_CONTAINER:
+		rpl, err := stub.UpdateContainer(ctx, &api.UpdateContainerRequest{
+			Pod:            pod,
+			Container:      ctr,
+			LinuxResources: ctr.GetLinux().GetResources(),
+		})
+		if err != nil {
+			return err
+		}
+		return checkUpdates(rpl.GetUpdate())
+
+	case api.Event_STOP_CONTAINER:
+		rpl, err := stub.StopContainer(ctx, &api.StopContainerRequest{Pod: pod, Container: ctr})
+		if err != nil {
+			return err
+		}
+		return checkUpdates(rpl.GetUpdate())
+
+	case api.Event_RUN_POD_SANDBOX, api.Event_STOP_POD_SANDBOX, api.Event_REMOVE_POD_SANDBOX:
+		return stub.stateChange(ctx, &api.StateChangeEvent{Event: e, Pod: pod})
+	}
+
+	return stub.stateChange(ctx, &api.StateChangeEvent{Event: e, Pod: pod, Container: ctr})
+}
+
+// selfTestRollback creates a container, then rolls its creation back with
+// the collected adjustment, as if the runtime failed to create it.
+func (stub *stub) selfTestRollback(ctx context.Context, events api.EventMask, pod *api.PodSandbox) error {
+	ctr := selfTestContainer()
+	ctr.Id = "nri-self-test-ctr2"
+	ctr.Name = "ctr2"
+	ctr.State = api.ContainerState_CONTAINER_UNKNOWN
+
+	var adjust *api.ContainerAdjustment
+	if events.IsSet(api.Event_CREATE_CONTAINER) {
+		ctr.EventSequence++
+		rpl, err := stub.CreateContainer(ctx, &api.CreateContainerRequest{Pod: pod, Container: ctr})
+		if err != nil {
+			return fmt.Errorf("CreateContainer failed: %w", err)
+		}
+		adjust = rpl.GetAdjust()
+	}
+
+	ctr.EventSequence++
+	return stub.stateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_CREATE_CONTAINER_ROLLBACK,
+		Pod:       pod,
+		Container: ctr,
+		Adjust:    adjust,
+		Error:     "self-test: simulated container creation failure",
+	})
+}
+
+// checkAdjustment checks that a container adjustment is well-formed.
+func checkAdjustment(a *api.ContainerAdjustment) error {
+	if a == nil {
+		return nil
+	}
+
+	if _, err := proto.Marshal(a); err != nil {
+		return fmt.Errorf("failed to marshal adjustment: %w", err)
+	}
+
+	for k := range a.GetAnnotations() {
+		if k == "" {
+			return fmt.Errorf("adjustment with empty annotation key")
+		}
+	}
+	for _, m := range a.GetMounts() {
+		if m.GetDestination() == "" {
+			return fmt.Errorf("adjustment with mount without destination")
+		}
+	}
+	for _, e := range a.GetEnv() {
+		if e.GetKey() == "" {
+			return fmt.Errorf("adjustment with empty environment variable name")
+		}
+	}
+	for _, d := range a.GetLinux().GetDevices() {
+		if d.GetPath() == "" {
+			return fmt.Errorf("adjustment with device without path")
+		}
+		if _, removed := d.IsMarkedForRemoval(); removed {
+			continue
+		}
+		switch d.GetType() {
+		case "c", "b", "u", "p":
+		default:
+			return fmt.Errorf("adjustment with device %q of invalid type %q",
+				d.GetPath(), d.GetType())
+		}
+	}
+	for _, r := range a.GetRlimits() {
+		if r.GetType() == "" {
+			return fmt.Errorf("adjustment with rlimit without type")
+		}
+	}
+	for _, d := range a.GetCDIDevices() {
+		if d.GetName() == "" {
+			return fmt.Errorf("adjustment with CDI device without name")
+		}
+	}
+	for _, f := range a.GetEnvFiles() {
+		if f.GetPath() == "" {
+			return fmt.Errorf("adjustment with environment file without path")
+		}
+	}
+	if a.GetUser() != nil && !a.HasAdjustment(api.UserAdjustment) {
+		return fmt.Errorf("adjustment with empty user")
+	}
+	for _, h := range a.GetTopologyHints().GetDevices() {
+		if h.GetDevice() == "" {
+			return fmt.Errorf("adjustment with topology hint without device")
+		}
+	}
+	if err := a.GetSchedulingHints().Validate(); err != nil {
+		return fmt.Errorf("adjustment with invalid scheduling hints: %w", err)
+	}
+
+	return nil
+}
+
+// checkUpdates checks that container updates are well-formed.
+func checkUpdates(updates []*api.ContainerUpdate) error {
+	for _, u := range updates {
+		if u.GetContainerId() == "" {
+			return fmt.Errorf("update without container ID")
+		}
+		if _, err := proto.Marshal(u); err != nil {
+			return fmt.Errorf("failed to marshal update of container %q: %w",
+				u.GetContainerId(), err)
+		}
+	}
+	return nil
+}
+
+func selfTestPod() *api.PodSandbox {
+	return &api.PodSandbox{
+		Id:             "nri-self-test-pod",
+		Name:           "nri-self-test",
+		Uid:            "00000000-0000-0000-0000-000000000000",
+		Namespace:      "default",
+		RuntimeHandler: "runc",
+		Labels: map[string]string{
+			"app": "nri-self-test",
+		},
+		Annotations: map[string]string{
+			"nri.io/self-test": "true",
+		},
+		Linux: &api.LinuxPodSandbox{
+			CgroupParent: "/kubepods/besteffort/pod00000000",
+			CgroupsPath:  "/kubepods/besteffort/pod00000000",
+		},
+	}
+}
+
+func selfTestContainer() *api.Container {
+	return &api.Container{
+		Id:           "nri-self-test-ctr0",
+		PodSandboxId: "nri-self-test-pod",
+		Name:         "ctr0",
+		State:        api.ContainerState_CONTAINER_RUNNING,
+		Labels: map[string]string{
+			"app": "nri-self-test",
+		},
+		Annotations: map[string]string{
+			"nri.io/self-test": "true",
+		},
+		Args: []string{"/bin/sh", "-c", "sleep inf"},
+		Env:  []string{"PATH=/usr/local/bin:/usr/bin:/bin"},
+		Mounts: []*api.Mount{
+			{
+				Destination: "/proc",
+				Type:        "proc",
+				Source:      "proc",
+				Options:     []string{"nosuid", "noexec", "nodev"},
+			},
+		},
+		Linux: &api.LinuxContainer{
+			Resources: &api.LinuxResources{
+				Cpu: &api.LinuxCPU{
+					Shares: api.UInt64(1024),
+					Quota:  api.Int64(100000),
+					Period: api.UInt64(100000),
+				},
+				Memory: &api.LinuxMemory{
+					Limit: api.Int64(128 * 1024 * 1024),
+				},
+			},
+			CgroupsPath: "/kubepods/besteffort/pod00000000/nri-self-test-ctr0",
+		},
+	}
+}
@@ -0,0 +1,191 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/containerd/nri/pkg/api"
+
+	require "github.com/stretchr/testify/require"
+)
+
+// testPlugin is a plugin with overridable handlers for self-tests.
+type testPlugin struct {
+	events []api.Event
+	create func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error)
+	update func(*api.Container) ([]*api.ContainerUpdate, error)
+	start  func(*api.Container) error
+	stop   func(*api.PodSandbox) error
+}
+
+func (p *testPlugin) CreateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.events = append(p.events, api.Event_CREATE_CONTAINER)
+	if p.create != nil {
+		return p.create(ctr)
+	}
+	adjust := &api.ContainerAdjustment{}
+	adjust.AddEnv("NRI_SELF_TEST", "true")
+	adjust.AddDevice(&api.LinuxDevice{Path: "/dev/null", Type: "c", Major: 1, Minor: 3})
+	return adjust, nil, nil
+}
+
+func (p *testPlugin) UpdateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container, _ *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.events = append(p.events, api.Event_UPDATE_CONTAINER)
+	if p.update != nil {
+		return p.update(ctr)
+	}
+	u := &api.ContainerUpdate{}
+	u.SetContainerId(ctr.Id)
+	u.SetLinuxCPUShares(512)
+	return []*api.ContainerUpdate{u}, nil
+}
+
+func (p *testPlugin) StartContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.events = append(p.events, api.Event_START_CONTAINER)
+	if p.start != nil {
+		return p.start(ctr)
+	}
+	return nil
+}
+
+func (p *testPlugin) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.events = append(p.events, api.Event_STOP_POD_SANDBOX)
+	if p.stop != nil {
+		return p.stop(pod)
+	}
+	return nil
+}
+
+func newSelfTestStub(t *testing.T, p *testPlugin, opts ...Option) Stub {
+	s, err := New(p, append([]Option{WithPluginName("test"), WithPluginIdx("00")}, opts...)...)
+	require.NoError(t, err, "New()")
+	return s
+}
+
+func TestSelfTestPasses(t *testing.T) {
+	p := &testPlugin{}
+	s := newSelfTestStub(t, p)
+
+	require.NoError(t, s.(SelfTester).SelfTest(context.Background()))
+	require.Equal(t, []api.Event{
+		api.Event_CREATE_CONTAINER,
+		api.Event_START_CONTAINER,
+		api.Event_UPDATE_CONTAINER,
+		api.Event_STOP_POD_SANDBOX,
+	}, p.events)
+}
+
+func TestSelfTestRejectsMalformedResponses(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		create func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error)
+		update func(*api.Container) ([]*api.ContainerUpdate, error)
+		err    string
+	}{
+		{
+			name: "empty environment variable name",
+			create: func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+				adjust := &api.ContainerAdjustment{}
+				adjust.AddEnv("", "value")
+				return adjust, nil, nil
+			},
+			err: "empty environment variable name",
+		},
+		{
+			name: "device of invalid type",
+			create: func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+				adjust := &api.ContainerAdjustment{}
+				adjust.AddDevice(&api.LinuxDevice{Path: "/dev/foo", Type: "x"})
+				return adjust, nil, nil
+			},
+			err: `device "/dev/foo" of invalid type "x"`,
+		},
+		{
+			name: "update without container ID in CreateContainer",
+			create: func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+				u := &api.ContainerUpdate{}
+				u.SetLinuxCPUShares(512)
+				return nil, []*api.ContainerUpdate{u}, nil
+			},
+			err: "update without container ID",
+		},
+		{
+			name: "update without container ID in UpdateContainer",
+			update: func(*api.Container) ([]*api.ContainerUpdate, error) {
+				u := &api.ContainerUpdate{}
+				u.SetLinuxCPUShares(512)
+				return []*api.ContainerUpdate{u}, nil
+			},
+			err: "UPDATE_CONTAINER: update without container ID",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSelfTestStub(t, &testPlugin{create: tc.create, update: tc.update})
+			err := s.(SelfTester).SelfTest(context.Background())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestSelfTestFailsOnHandlerErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		p    *testPlugin
+		err  string
+	}{
+		{
+			name: "CreateContainer",
+			p: &testPlugin{
+				create: func(*api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+					return nil, nil, fmt.Errorf("out of devices")
+				},
+			},
+			err: "CREATE_CONTAINER: out of devices",
+		},
+		{
+			name: "StartContainer",
+			p: &testPlugin{
+				start: func(*api.Container) error { return fmt.Errorf("failed to start") },
+			},
+			err: "START_CONTAINER: failed to start",
+		},
+		{
+			name: "StopPodSandbox",
+			p: &testPlugin{
+				stop: func(*api.PodSandbox) error { return fmt.Errorf("failed to stop") },
+			},
+			err: "STOP_POD_SANDBOX: failed to stop",
+		},
+	} {
+		for _, opts := range [][]Option{nil, {WithConcurrentDispatch(2)}} {
+			name := tc.name
+			if opts != nil {
+				name += " with concurrent dispatch"
+			}
+			t.Run(name, func(t *testing.T) {
+				s := newSelfTestStub(t, tc.p, opts...)
+				err := s.(SelfTester).SelfTest(context.Background())
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			})
+		}
+	}
+}
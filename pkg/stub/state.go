@@ -0,0 +1,187 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StateInterface handles checkpointing the state of the plugin.
+type StateInterface interface {
+	// SaveState returns the state of the plugin to checkpoint.
+	SaveState(context.Context) ([]byte, error)
+	// LoadState restores the state of the plugin from a checkpoint.
+	LoadState(context.Context, []byte) error
+}
+
+// WithStateStore checkpoints the state of the plugin in the given directory.
+// The plugin must implement StateInterface. The stub restores the state of
+// the plugin before it is first synchronized, or when its subscription is
+// restored, and checkpoints it after synchronization, on shutdown and when
+// the plugin is stopped. Plugins can also checkpoint their state at any time
+// using Checkpoint. Checkpoints are written atomically, so a crash leaves
+// either the previous or the new checkpoint in place.
+func WithStateStore(dir string) Option {
+	return func(s *stub) error {
+		if dir == "" {
+			return fmt.Errorf("invalid (empty) state store directory")
+		}
+		s.state = &stateStore{dir: dir}
+		return nil
+	}
+}
+
+// stateStore tracks the checkpointed state of the plugin.
+type stateStore struct {
+	sync.Mutex
+	dir    string
+	loaded bool
+}
+
+// checkStateStore checks that the plugin can use the state store, if any.
+func (stub *stub) checkStateStore() error {
+	if stub.state == nil {
+		return nil
+	}
+	if stub.handlers.SaveState == nil || stub.handlers.LoadState == nil {
+		return fmt.Errorf("plugin %T uses a state store but does not implement StateInterface",
+			stub.plugin)
+	}
+	return nil
+}
+
+// statePath returns the path of the checkpoint of the plugin.
+func (stub *stub) statePath() string {
+	return filepath.Join(stub.state.dir, stub.Name()+".state")
+}
+
+// loadState restores the state of the plugin from its checkpoint, unless
+// it has already been restored.
+func (stub *stub) loadState(ctx context.Context) error {
+	if stub.state == nil {
+		return nil
+	}
+
+	stub.state.Lock()
+	defer stub.state.Unlock()
+
+	if stub.state.loaded {
+		return nil
+	}
+
+	path := stub.statePath()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Infof(ctx, "No checkpointed state for plugin %s", stub.Name())
+	case err != nil:
+		return fmt.Errorf("failed to read plugin state %q: %w", path, err)
+	default:
+		if err := stub.handlers.LoadState(ctx, data); err != nil {
+			return fmt.Errorf("failed to restore plugin state %q: %w", path, err)
+		}
+		log.Infof(ctx, "Restored checkpointed state for plugin %s", stub.Name())
+	}
+
+	stub.state.loaded = true
+	return nil
+}
+
+// saveState checkpoints the state of the plugin. The state is not saved
+// before it has been restored, to avoid overwriting the checkpoint with
+// the initial state of the plugin.
+func (stub *stub) saveState(ctx context.Context) error {
+	if stub.state == nil {
+		return nil
+	}
+
+	stub.state.Lock()
+	defer stub.state.Unlock()
+
+	if !stub.state.loaded {
+		return nil
+	}
+
+	data, err := stub.handlers.SaveState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get plugin state: %w", err)
+	}
+
+	path := stub.statePath()
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save plugin state %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// Checkpoint the state of the plugin.
+func (stub *stub) Checkpoint(ctx context.Context) error {
+	if stub.state == nil {
+		return fmt.Errorf("plugin %s has no state store", stub.Name())
+	}
+	return stub.saveState(ctx)
+}
+
+// writeFileAtomic writes a file by renaming a synced temporary file over it.
+func writeFileAtomic(path string, data []byte) (retErr error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return nil
+	}
+	defer d.Close()
+
+	// Sync the directory for the rename to survive a crash. Failing to
+	// do so is not fatal, the file itself is already complete.
+	d.Sync()
+
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+type statsKey struct{}
+
+// ContainerStats returns the resource usage of the container which the
+// UpdateContainer or StopContainer request being handled is for. It returns
+// nil if the runtime did not pass the resource usage. The context must be
+// the one passed to the request handler.
+func ContainerStats(ctx context.Context) *api.ContainerStats {
+	stats, _ := ctx.Value(statsKey{}).(*api.ContainerStats)
+	return stats
+}
+
+// withContainerStats returns a context carrying the given container stats.
+func withContainerStats(ctx context.Context, stats *api.ContainerStats) context.Context {
+	if stats == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, statsKey{}, stats)
+}
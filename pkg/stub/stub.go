@@ -28,10 +28,12 @@ import (
 	"time"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/debug"
 	nrilog "github.com/containerd/nri/pkg/log"
 	"github.com/containerd/nri/pkg/net"
 	"github.com/containerd/nri/pkg/net/multiplex"
 	"github.com/containerd/ttrpc"
+	"google.golang.org/grpc"
 )
 
 // Plugin can implement a number of interfaces related to Pod and Container
@@ -135,6 +137,50 @@ type PostUpdateContainerInterface interface {
 	PostUpdateContainer(context.Context, *api.PodSandbox, *api.Container) error
 }
 
+// ContainerDiedInterface handles ContainerDied API events.
+type ContainerDiedInterface interface {
+	// ContainerDied relays a ContainerDied event to the plugin. It is sent
+	// once the runtime observes the death of a container, before it gets
+	// stopped, with the exit information of the container, including the
+	// exit code, the terminating signal and whether it was OOM-killed.
+	ContainerDied(context.Context, *api.PodSandbox, *api.Container) error
+}
+
+// CreateContainerRollbackInterface handles CreateContainerRollback API events.
+type CreateContainerRollbackInterface interface {
+	// CreateContainerRollback relays a CreateContainerRollback event to the
+	// plugin. It is sent if the runtime fails to create a container after
+	// the adjustments of plugins were collected for it, with the collected
+	// adjustment and the error the runtime failed with. Plugins can use it
+	// to release any resources they have reserved for the container.
+	CreateContainerRollback(context.Context, *api.PodSandbox, *api.Container, *api.ContainerAdjustment, string) error
+}
+
+// SetLeadershipInterface handles SetLeadership API requests.
+type SetLeadershipInterface interface {
+	// SetLeadership notifies the plugin about its role among replicated
+	// instances when leader election is enabled. Only the leader receives
+	// CreateContainer, UpdateContainer and StopContainer requests.
+	SetLeadership(ctx context.Context, leader bool) error
+}
+
+// PingInterface handles Ping API requests.
+type PingInterface interface {
+	// Ping is called when the runtime checks the liveness of the plugin.
+	// Returning an error reports the plugin unhealthy. Repeated failures
+	// get the plugin detached by the runtime.
+	Ping(context.Context) error
+}
+
+// ReconfigurePluginInterface handles ReconfigurePlugin API requests.
+type ReconfigurePluginInterface interface {
+	// ReconfigurePlugin is called when the runtime passes updated
+	// configuration to the plugin. If the plugin uses WithConfigInto, the
+	// updated configuration is already decoded when this is called. If an
+	// error is returned, the previous decoded configuration is restored.
+	ReconfigurePlugin(ctx context.Context, config string) error
+}
+
 // Stub is the interface the stub provides for the plugin implementation.
 type Stub interface {
 	// Run starts the plugin then waits for the plugin service to exit, either due to a
@@ -162,11 +208,88 @@ type Stub interface {
 	RequestTimeout() time.Duration
 }
 
+// The interfaces below are implemented by stubs in addition to Stub. Use a
+// type assertion on the Stub returned by New to get to them.
+var (
+	_ StatusReporter      = &stub{}
+	_ AdjustmentPreviewer = &stub{}
+	_ StateQuerier        = &stub{}
+	_ RuntimeSettings     = &stub{}
+	_ SelfTester          = &stub{}
+	_ Checkpointer        = &stub{}
+)
+
+// StatusReporter reports the readiness of the plugin to the runtime.
+type StatusReporter interface {
+	// ReportStatus reports the readiness of the plugin to the runtime.
+	ReportStatus(ready bool, message string) error
+}
+
+// AdjustmentPreviewer checks adjustments for conflicts before they are made.
+type AdjustmentPreviewer interface {
+	// PreviewAdjustment simulates merging the given adjustment with the ones
+	// of other plugins for a container being created, returning the conflicts
+	// it would cause. It can only be called while handling the CreateContainer
+	// request of the container.
+	PreviewAdjustment(ctx context.Context, containerID string, adjust *api.ContainerAdjustment) (*api.PreviewAdjustmentResponse, error)
+}
+
+// StateQuerier queries the pods and containers known to the runtime.
+type StateQuerier interface {
+	// GetPods returns the pods currently known to the runtime, for instance
+	// to verify or rebuild the state cached by the plugin without having to
+	// reconnect. Pods filtered out for the plugin are not returned.
+	GetPods(ctx context.Context) ([]*api.PodSandbox, error)
+
+	// GetContainers returns the containers currently known to the runtime
+	// which match the given filter, or all of them if the filter is nil.
+	// Containers in pods filtered out for the plugin are not returned.
+	GetContainers(ctx context.Context, filter *api.ContainerFilter) ([]*api.Container, error)
+}
+
+// RuntimeSettings provides the settings received from the runtime in the
+// Configure request.
+type RuntimeSettings interface {
+	// AnnotationLimits returns the limits the runtime enforces on annotations
+	// injected by the plugin, as received in the Configure request, if any.
+	// Plugins can use these to check or truncate annotations themselves.
+	AnnotationLimits() *api.AnnotationLimits
+
+	// RuntimeDefaults returns the defaults the runtime applies to containers,
+	// as received in the Configure request, if any. Plugins can use these to
+	// compute adjustments relative to the actual defaults of the runtime.
+	RuntimeDefaults() *api.RuntimeDefaults
+}
+
+// SelfTester exercises the plugin locally.
+type SelfTester interface {
+	// SelfTest exercises the plugin locally, without connecting to NRI.
+	// It configures the plugin, then passes generated pods and containers
+	// to the handlers of all events the plugin subscribes to, checking
+	// that any requested adjustments and updates are well-formed.
+	SelfTest(context.Context) error
+}
+
+// Checkpointer saves the state of the plugin.
+type Checkpointer interface {
+	// Checkpoint saves the state of the plugin in the state store set up
+	// using WithStateStore. The state is only saved once it has been
+	// restored from the store.
+	Checkpoint(context.Context) error
+}
+
 const (
 	// DefaultRegistrationTimeout is the default plugin registration timeout.
 	DefaultRegistrationTimeout = api.DefaultPluginRegistrationTimeout
 	// DefaultRequestTimeout is the default plugin request processing timeout.
 	DefaultRequestTimeout = api.DefaultPluginRequestTimeout
+
+	// containerDiedSchemaVersion is the first payload schema version of
+	// runtimes which accept subscriptions to ContainerDied events.
+	containerDiedSchemaVersion = 18
+	// createContainerRollbackSchemaVersion is the first payload schema version
+	// of runtimes which accept subscriptions to CreateContainerRollback events.
+	createContainerRollbackSchemaVersion = 19
 )
 
 var (
@@ -241,6 +364,86 @@ func WithDialer(d func(string) (stdnet.Conn, error)) Option {
 	}
 }
 
+// WithLeaderElection enables leader election among plugin instances which
+// register with the same name and index. Only one of the instances, the
+// leader, receives requests which can adjust or update containers. The
+// rest of the instances are on standby and only receive notifications.
+// When the leader disconnects, one of the standby instances is promoted.
+func WithLeaderElection() Option {
+	return func(s *stub) error {
+		s.election = true
+		return nil
+	}
+}
+
+// WithSubscriptionRestore lets the runtime restore the previous subscription
+// of the plugin if it reconnects within the grace period configured for the
+// runtime. If the subscription is restored, the plugin is not configured or
+// synchronized again, so its Configure and Synchronize handlers are not
+// called. This is useful for plugins which can retain or recover their state
+// by other means, for instance during a hot-swap of the plugin binary.
+func WithSubscriptionRestore() Option {
+	return func(s *stub) error {
+		s.restore = true
+		return nil
+	}
+}
+
+// WithAuthToken sets the token the plugin presents when it registers with
+// the runtime. Runtimes can require a token to restrict who may attach
+// plugins. By default the token is taken from the NRI_PLUGIN_AUTH_TOKEN
+// environment variable, if it is set.
+func WithAuthToken(token string) Option {
+	return func(s *stub) error {
+		s.authToken = token
+		return nil
+	}
+}
+
+// WithStatusReporting lets the plugin report its readiness to the runtime
+// using ReportStatus. Such a plugin is considered not ready until it first
+// reports to be ready, for instance once it has loaded its policy. The
+// runtime can be configured to hold requests to the plugin until then.
+func WithStatusReporting() Option {
+	return func(s *stub) error {
+		s.readiness = true
+		return nil
+	}
+}
+
+// WithEventFilter sets a filter for the pods to receive events for. The
+// runtime relays pod and container events to the plugin only if the pod
+// matches the filter, and synchronizes the plugin only with matching pods
+// and their containers. This reduces the load on plugins which only care
+// about a few namespaces or labeled pods.
+func WithEventFilter(filter *api.EventFilter) Option {
+	return func(s *stub) error {
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+		s.filter = filter
+		return nil
+	}
+}
+
+// WithRunAfter sets plugins, by full (idx-name) or base name, which the
+// plugin needs to be invoked after, regardless of their plugin indices.
+func WithRunAfter(plugins ...string) Option {
+	return func(s *stub) error {
+		s.runAfter = append(s.runAfter, plugins...)
+		return nil
+	}
+}
+
+// WithRunBefore sets plugins, by full (idx-name) or base name, which the
+// plugin needs to be invoked before, regardless of their plugin indices.
+func WithRunBefore(plugins ...string) Option {
+	return func(s *stub) error {
+		s.runBefore = append(s.runBefore, plugins...)
+		return nil
+	}
+}
+
 // WithTTRPCOptions sets extra client and server options to use for ttrpc .
 func WithTTRPCOptions(clientOpts []ttrpc.ClientOpts, serverOpts []ttrpc.ServerOpt) Option {
 	return func(s *stub) error {
@@ -250,33 +453,92 @@ func WithTTRPCOptions(clientOpts []ttrpc.ClientOpts, serverOpts []ttrpc.ServerOp
 	}
 }
 
+// WithDebugListener starts serving pprof profiles and a status page of the
+// plugin on the given address. The status page lists the connection to the
+// runtime, pending requests and the last failed requests.
+func WithDebugListener(addr string) Option {
+	return func(s *stub) error {
+		s.debugAddr = addr
+		return nil
+	}
+}
+
+// WithSelfTest makes Run perform a self-test instead of connecting to NRI.
+// The given configuration is passed to the plugin's Configure handler.
+// The outcome of the self-test is returned by Run, so the plugin exits
+// with an error if the self-test fails. This is useful as a startup or
+// readiness probe for plugins deployed as DaemonSets.
+func WithSelfTest(config string) Option {
+	return func(s *stub) error {
+		s.selfTest = true
+		s.testConfig = config
+		return nil
+	}
+}
+
 // stub implements Stub.
 type stub struct {
 	sync.Mutex
-	plugin     interface{}
-	handlers   handlers
-	events     api.EventMask
-	name       string
-	idx        string
-	socketPath string
-	dialer     func(string) (stdnet.Conn, error)
-	conn       stdnet.Conn
-	onClose    func()
-	serverOpts []ttrpc.ServerOpt
-	clientOpts []ttrpc.ClientOpts
-	rpcm       multiplex.Mux
-	rpcl       stdnet.Listener
-	rpcs       *ttrpc.Server
-	rpcc       *ttrpc.Client
-	runtime    api.RuntimeService
-	started    bool
-	doneC      chan struct{}
-	srvErrC    chan error
-	cfgErrC    chan error
-	syncReq    *api.SynchronizeRequest
+	plugin      interface{}
+	handlers    handlers
+	events      api.EventMask
+	name        string
+	idx         string
+	election    bool
+	restore     bool
+	socketPath  string
+	readiness   bool
+	authToken   string
+	dialer      func(string) (stdnet.Conn, error)
+	conn        stdnet.Conn
+	onClose     func()
+	serverOpts  []ttrpc.ServerOpt
+	clientOpts  []ttrpc.ClientOpts
+	rpcm        multiplex.Mux
+	rpcl        stdnet.Listener
+	rpcs        *ttrpc.Server
+	rpcc        *ttrpc.Client
+	grpc        bool
+	grpcs       *grpc.Server
+	grpcc       *grpc.ClientConn
+	runtime     api.RuntimeService
+	started     bool
+	doneC       chan struct{}
+	srvErrC     chan error
+	cfgErrC     chan error
+	syncReq     *api.SynchronizeRequest
+	syncToken   string
+	syncChunks  uint64
+	kaIntv      time.Duration
+	kaMisses    int
+	rtKaIntv    time.Duration
+	kaStop      chan struct{}
+	reconnect   *ReconnectPolicy
+	dialed      bool
+	stopped     bool
+	reconnStop  chan struct{}
+	exitC       chan struct{}
+	exitErr     error
+	debugAddr   string
+	debugTrk    *debug.Tracker
+	debugSrv    *debug.Server
+	selfTest    bool
+	testConfig  string
+	config      interface{}
+	cfgDefaults []byte
+	dispatcher  *dispatcher
+	filter      *api.EventFilter
+	runAfter    []string
+	runBefore   []string
+	host        *stub
+	subNum      int
+	subs        []*stub
+	state       *stateStore
 
 	registrationTimeout time.Duration
 	requestTimeout      time.Duration
+	annotationLimits    *api.AnnotationLimits
+	runtimeDefaults     *api.RuntimeDefaults
 }
 
 // Handlers for NRI plugin event and request.
@@ -295,6 +557,14 @@ type handlers struct {
 	PostCreateContainer func(context.Context, *api.PodSandbox, *api.Container) error
 	PostStartContainer  func(context.Context, *api.PodSandbox, *api.Container) error
 	PostUpdateContainer func(context.Context, *api.PodSandbox, *api.Container) error
+	ContainerDied       func(context.Context, *api.PodSandbox, *api.Container) error
+	SetLeadership       func(context.Context, bool) error
+	Ping                func(context.Context) error
+	ReconfigurePlugin   func(context.Context, string) error
+	SaveState           func(context.Context) ([]byte, error)
+	LoadState           func(context.Context, []byte) error
+
+	CreateContainerRollback func(context.Context, *api.PodSandbox, *api.Container, *api.ContainerAdjustment, string) error
 }
 
 // New creates a stub with the given plugin and options.
@@ -303,6 +573,7 @@ func New(p interface{}, opts ...Option) (Stub, error) {
 		plugin:     p,
 		name:       os.Getenv(api.PluginNameEnvVar),
 		idx:        os.Getenv(api.PluginIdxEnvVar),
+		authToken:  os.Getenv(api.PluginAuthTokenEnvVar),
 		socketPath: api.DefaultSocketPath,
 		dialer:     func(p string) (stdnet.Conn, error) { return stdnet.Dial("unix", p) },
 
@@ -320,6 +591,18 @@ func New(p interface{}, opts ...Option) (Stub, error) {
 		return nil, err
 	}
 
+	if err := stub.checkStateStore(); err != nil {
+		return nil, err
+	}
+
+	if stub.debugAddr != "" {
+		stub.debugTrk = &debug.Tracker{}
+		stub.serverOpts = append(stub.serverOpts,
+			ttrpc.WithChainUnaryServerInterceptor(stub.debugTrk.ServerInterceptor()))
+		stub.clientOpts = append(stub.clientOpts,
+			ttrpc.WithChainUnaryClientInterceptor(stub.debugTrk.ClientInterceptor()))
+	}
+
 	if err := stub.ensureIdentity(); err != nil {
 		return nil, err
 	}
@@ -331,29 +614,46 @@ func New(p interface{}, opts ...Option) (Stub, error) {
 }
 
 // Start event processing, register to NRI and wait for getting configured.
-func (stub *stub) Start(ctx context.Context) (retErr error) {
+func (stub *stub) Start(ctx context.Context) error {
 	stub.Lock()
 	defer stub.Unlock()
 
+	stub.stopped = false
+	return stub.start(ctx)
+}
+
+// start connects to NRI, registers and waits for getting configured. The
+// caller must hold the lock.
+func (stub *stub) start(ctx context.Context) (retErr error) {
 	if stub.isStarted() {
 		return fmt.Errorf("stub already started")
 	}
 	stub.doneC = make(chan struct{})
 
-	err := stub.connect()
+	if stub.debugTrk != nil && stub.debugSrv == nil {
+		srv, err := debug.Listen(stub.debugAddr, stub.debugTrk)
+		if err != nil {
+			return err
+		}
+		log.Infof(noCtx, "Serving debug endpoints on %s...", srv.Addr())
+		stub.debugSrv = srv
+	}
+
+	rpcm, err := stub.multiplex()
 	if err != nil {
 		return err
 	}
-
-	rpcm := multiplex.Multiplex(stub.conn)
 	defer func() {
 		if retErr != nil {
-			rpcm.Close()
+			if stub.host == nil {
+				rpcm.Close()
+			}
 			stub.rpcm = nil
 		}
 	}()
 
-	rpcl, err := rpcm.Listen(multiplex.PluginServiceConn)
+	pluginID, runtimeID := multiplex.SubPluginConns(stub.subNum)
+	rpcl, err := rpcm.Listen(pluginID)
 	if err != nil {
 		return err
 	}
@@ -364,6 +664,45 @@ func (stub *stub) Start(ctx context.Context) (retErr error) {
 		}
 	}()
 
+	if stub.usesGRPC() {
+		err = stub.connectGRPC(ctx, rpcm, rpcl, runtimeID)
+	} else {
+		err = stub.connectTTRPC(ctx, rpcm, rpcl, runtimeID)
+	}
+	if err != nil {
+		return err
+	}
+
+	stub.rpcm = rpcm
+	stub.rpcl = rpcl
+
+	if err = stub.register(ctx); err != nil {
+		stub.close()
+		return err
+	}
+
+	if err = <-stub.cfgErrC; err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "Started plugin %s...", stub.Name())
+
+	if stub.debugTrk != nil {
+		stub.debugTrk.Connected(stub.connName())
+	}
+
+	stub.started = true
+	stub.startKeepalive()
+	if stub.reconnect != nil && stub.exitC == nil {
+		stub.exitC = make(chan struct{})
+		stub.exitErr = nil
+	}
+	return nil
+}
+
+// connectTTRPC sets up the ttrpc server and client of the plugin on the
+// given multiplexed connections.
+func (stub *stub) connectTTRPC(ctx context.Context, rpcm multiplex.Mux, rpcl stdnet.Listener, runtimeID multiplex.ConnID) (retErr error) {
 	rpcs, err := ttrpc.NewServer(stub.serverOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create ttrpc server: %w", err)
@@ -377,7 +716,7 @@ func (stub *stub) Start(ctx context.Context) (retErr error) {
 
 	api.RegisterPluginService(rpcs, stub)
 
-	conn, err := rpcm.Open(multiplex.RuntimeServiceConn)
+	conn, err := rpcm.Open(runtimeID)
 	if err != nil {
 		return fmt.Errorf("failed to multiplex ttrpc client connection: %w", err)
 	}
@@ -403,25 +742,11 @@ func (stub *stub) Start(ctx context.Context) (retErr error) {
 		close(doneC)
 	}(rpcl, stub.doneC, stub.srvErrC)
 
-	stub.rpcm = rpcm
-	stub.rpcl = rpcl
 	stub.rpcs = rpcs
 	stub.rpcc = rpcc
 
 	stub.runtime = api.NewRuntimeClient(rpcc)
 
-	if err = stub.register(ctx); err != nil {
-		stub.close()
-		return err
-	}
-
-	if err = <-stub.cfgErrC; err != nil {
-		return err
-	}
-
-	log.Infof(ctx, "Started plugin %s...", stub.Name())
-
-	stub.started = true
 	return nil
 }
 
@@ -429,9 +754,24 @@ func (stub *stub) Start(ctx context.Context) (retErr error) {
 func (stub *stub) Stop() {
 	log.Infof(noCtx, "Stopping plugin %s...", stub.Name())
 
+	if err := stub.saveState(noCtx); err != nil {
+		log.Errorf(noCtx, "Failed to checkpoint state of plugin %s: %v", stub.Name(), err)
+	}
+
 	stub.Lock()
 	defer stub.Unlock()
+	stub.stopped = true
+	if stub.reconnStop != nil {
+		close(stub.reconnStop)
+		stub.reconnStop = nil
+	}
 	stub.close()
+	stub.exit(nil)
+
+	if stub.debugSrv != nil {
+		stub.debugSrv.Close()
+		stub.debugSrv = nil
+	}
 }
 
 // IsStarted returns true if the plugin has been started either by Start() or by Run().
@@ -452,6 +792,8 @@ func (stub *stub) close() {
 		return
 	}
 
+	stub.stopKeepalive()
+
 	if stub.rpcl != nil {
 		stub.rpcl.Close()
 	}
@@ -461,27 +803,59 @@ func (stub *stub) close() {
 	if stub.rpcc != nil {
 		stub.rpcc.Close()
 	}
-	if stub.rpcm != nil {
+	if stub.grpcs != nil {
+		stub.grpcs.Stop()
+	}
+	if stub.grpcc != nil {
+		stub.grpcc.Close()
+	}
+	if stub.rpcm != nil && stub.host == nil {
 		stub.rpcm.Close()
 	}
 	if stub.srvErrC != nil {
 		<-stub.doneC
 	}
+	if stub.debugTrk != nil {
+		stub.debugTrk.Disconnected(stub.connName())
+	}
 
 	stub.started = false
 	stub.conn = nil
 	stub.syncReq = nil
+	stub.syncToken = ""
 }
 
 // Run the plugin. Start event processing then wait for an error or getting stopped.
 func (stub *stub) Run(ctx context.Context) error {
 	var err error
 
+	if stub.selfTest {
+		return stub.SelfTest(ctx)
+	}
+
 	if err = stub.Start(ctx); err != nil {
 		return err
 	}
 
-	err = <-stub.srvErrC
+	return stub.waitExit()
+}
+
+// waitExit waits for the plugin to stop, either due to a critical error or
+// an explicit call to Stop(). Plugins which reconnect to the runtime stop
+// once they give up reconnecting.
+func (stub *stub) waitExit() error {
+	stub.Lock()
+	exitC, srvErrC := stub.exitC, stub.srvErrC
+	stub.Unlock()
+
+	if exitC != nil {
+		<-exitC
+		stub.Lock()
+		defer stub.Unlock()
+		return stub.exitErr
+	}
+
+	err := <-srvErrC
 	if err == ttrpc.ErrServerClosed {
 		return nil
 	}
@@ -491,6 +865,15 @@ func (stub *stub) Run(ctx context.Context) error {
 
 // Wait for the plugin to stop, should be called after Start() or Run().
 func (stub *stub) Wait() {
+	stub.Lock()
+	exitC := stub.exitC
+	stub.Unlock()
+
+	if exitC != nil {
+		<-exitC
+		return
+	}
+
 	if stub.IsStarted() {
 		<-stub.doneC
 	}
@@ -509,6 +892,14 @@ func (stub *stub) RequestTimeout() time.Duration {
 	return stub.requestTimeout
 }
 
+func (stub *stub) AnnotationLimits() *api.AnnotationLimits {
+	return stub.annotationLimits
+}
+
+func (stub *stub) RuntimeDefaults() *api.RuntimeDefaults {
+	return stub.runtimeDefaults
+}
+
 // Connect the plugin to NRI.
 func (stub *stub) connect() error {
 	if stub.conn != nil {
@@ -539,10 +930,40 @@ func (stub *stub) connect() error {
 	}
 
 	stub.conn = conn
+	stub.dialed = true
 
 	return nil
 }
 
+// multiplex returns the multiplexer for the connection to NRI. Sub-plugins
+// use the multiplexer of their hosting plugin, which must have been started.
+func (stub *stub) multiplex() (multiplex.Mux, error) {
+	if host := stub.host; host != nil {
+		host.Lock()
+		defer host.Unlock()
+		if host.rpcm == nil {
+			return nil, fmt.Errorf("hosting plugin %s not started", host.Name())
+		}
+		return host.rpcm, nil
+	}
+
+	if err := stub.connect(); err != nil {
+		return nil, err
+	}
+
+	return multiplex.Multiplex(stub.conn), nil
+}
+
+// connName returns the name of the connection to NRI for debugging.
+func (stub *stub) connName() string {
+	if stub.conn != nil && stub.conn.RemoteAddr() != nil {
+		if addr := stub.conn.RemoteAddr().String(); addr != "" {
+			return "runtime@" + addr
+		}
+	}
+	return "runtime"
+}
+
 // Register the plugin with NRI.
 func (stub *stub) register(ctx context.Context) error {
 	log.Infof(ctx, "Registering plugin %s...", stub.Name())
@@ -551,21 +972,52 @@ func (stub *stub) register(ctx context.Context) error {
 	defer cancel()
 
 	req := &api.RegisterPluginRequest{
-		PluginName: stub.name,
-		PluginIdx:  stub.idx,
-	}
-	if _, err := stub.runtime.RegisterPlugin(ctx, req); err != nil {
+		PluginName:          stub.name,
+		PluginIdx:           stub.idx,
+		LeaderElection:      stub.election,
+		RestoreSubscription: stub.restore,
+		SchemaVersion:       api.SchemaVersion,
+		ReportsStatus:       stub.readiness,
+		SubPlugins:          uint32(len(stub.subs)),
+		AuthToken:           stub.authToken,
+	}
+	rpl, err := stub.runtime.RegisterPlugin(ctx, req)
+	if err != nil {
 		return fmt.Errorf("failed to register with NRI/Runtime: %w", err)
 	}
 
+	if rpl.GetSubscriptionRestored() {
+		log.Infof(ctx, "Plugin %s reconnected with restored subscription", stub.Name())
+		stub.cfgErrC <- stub.loadState(ctx)
+	}
+
 	return nil
 }
 
 // Handle a lost connection.
 func (stub *stub) connClosed() {
 	stub.Lock()
+	if stub.reconnStop != nil {
+		// failed reconnection attempt, retried by the reconnect loop
+		stub.Unlock()
+		return
+	}
 	stub.close()
+	if stub.canReconnect() {
+		stopC := make(chan struct{})
+		stub.reconnStop = stopC
+		stub.Unlock()
+		log.Warnf(noCtx, "Plugin %s lost connection to runtime", stub.Name())
+		go stub.reconnectLoop(stopC)
+		return
+	}
 	stub.Unlock()
+
+	stub.closed()
+}
+
+// closed notifies the plugin about its connection being closed for good.
+func (stub *stub) closed() {
 	if stub.onClose != nil {
 		stub.onClose()
 		return
@@ -595,6 +1047,63 @@ func (stub *stub) UpdateContainers(update []*api.ContainerUpdate) ([]*api.Contai
 	return nil, err
 }
 
+// ReportStatus reports the readiness of the plugin to the runtime.
+func (stub *stub) ReportStatus(ready bool, message string) error {
+	if stub.runtime == nil {
+		return ErrNoService
+	}
+
+	ctx := context.Background()
+	req := &api.ReportStatusRequest{
+		Ready:   ready,
+		Message: message,
+	}
+	_, err := stub.runtime.ReportStatus(ctx, req)
+	return err
+}
+
+// PreviewAdjustment simulates merging an adjustment for a container being created.
+func (stub *stub) PreviewAdjustment(ctx context.Context, containerID string, adjust *api.ContainerAdjustment) (*api.PreviewAdjustmentResponse, error) {
+	if stub.runtime == nil {
+		return nil, ErrNoService
+	}
+
+	req := &api.PreviewAdjustmentRequest{
+		ContainerId: containerID,
+		Adjust:      adjust,
+	}
+	return stub.runtime.PreviewAdjustment(ctx, req)
+}
+
+// GetPods returns the pods currently known to the runtime.
+func (stub *stub) GetPods(ctx context.Context) ([]*api.PodSandbox, error) {
+	if stub.runtime == nil {
+		return nil, ErrNoService
+	}
+
+	rpl, err := stub.runtime.GetPods(ctx, &api.GetPodsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return rpl.Pods, nil
+}
+
+// GetContainers returns the containers currently known to the runtime.
+func (stub *stub) GetContainers(ctx context.Context, filter *api.ContainerFilter) ([]*api.Container, error) {
+	if stub.runtime == nil {
+		return nil, ErrNoService
+	}
+
+	req := &api.GetContainersRequest{
+		Filter: filter,
+	}
+	rpl, err := stub.runtime.GetContainers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rpl.Containers, nil
+}
+
 // Configure the plugin.
 func (stub *stub) Configure(ctx context.Context, req *api.ConfigureRequest) (rpl *api.ConfigureResponse, retErr error) {
 	var (
@@ -607,45 +1116,92 @@ func (stub *stub) Configure(ctx context.Context, req *api.ConfigureRequest) (rpl
 
 	stub.registrationTimeout = time.Duration(req.RegistrationTimeout * int64(time.Millisecond))
 	stub.requestTimeout = time.Duration(req.RequestTimeout * int64(time.Millisecond))
+	stub.annotationLimits = req.AnnotationLimits
+	stub.runtimeDefaults = req.RuntimeDefaults
+	stub.rtKaIntv = time.Duration(req.KeepaliveInterval * int64(time.Millisecond))
+
+	if req.SchemaVersion > api.SchemaVersion {
+		log.Infof(ctx, "Runtime uses newer payload schema version %d (> %d)",
+			req.SchemaVersion, api.SchemaVersion)
+	}
 
 	defer func() {
 		stub.cfgErrC <- retErr
 	}()
 
-	if handler := stub.handlers.Configure; handler == nil {
-		events = stub.events
-	} else {
-		events, err = handler(ctx, req.Config, req.RuntimeName, req.RuntimeVersion)
-		if err != nil {
-			log.Errorf(ctx, "Plugin configuration failed: %v", err)
-			return nil, err
-		}
-
-		if events == 0 {
-			events = stub.events
-		}
-
-		// Only allow plugins to subscribe to events they can handle.
-		if extra := events & ^stub.events; extra != 0 {
-			log.Errorf(ctx, "Plugin subscribed for unhandled events %s (0x%x)",
-				extra.PrettyString(), extra)
-			return nil, fmt.Errorf("internal error: unhandled events %s (0x%x)",
-				extra.PrettyString(), extra)
-		}
+	events, err = stub.configure(ctx, req.Config, req.RuntimeName, req.RuntimeVersion)
+	if err != nil {
+		return nil, err
+	}
 
-		log.Infof(ctx, "Subscribing plugin %s (%s) for events %s", stub.Name(),
-			filepath.Base(os.Args[0]), events.PrettyString())
+	// Runtimes predating ContainerDied events reject subscriptions to them.
+	if req.SchemaVersion < containerDiedSchemaVersion && events.IsSet(api.Event_CONTAINER_DIED) {
+		log.Infof(ctx, "Runtime does not support ContainerDied events, not subscribing to them")
+		events.Clear(api.Event_CONTAINER_DIED)
+	}
+	// Likewise for CreateContainerRollback events.
+	if req.SchemaVersion < createContainerRollbackSchemaVersion && events.IsSet(api.Event_CREATE_CONTAINER_ROLLBACK) {
+		log.Infof(ctx, "Runtime does not support CreateContainerRollback events, not subscribing to them")
+		events.Clear(api.Event_CREATE_CONTAINER_ROLLBACK)
 	}
 
 	return &api.ConfigureResponse{
-		Events: int32(events),
+		Events:    int32(events),
+		Filter:    stub.filter,
+		RunAfter:  stub.runAfter,
+		RunBefore: stub.runBefore,
 	}, nil
 }
 
+// configure the plugin, returning the events it subscribes to.
+func (stub *stub) configure(ctx context.Context, config, runtime, version string) (api.EventMask, error) {
+	if _, err := stub.decodeConfig(config); err != nil {
+		log.Errorf(ctx, "Plugin configuration failed: %v", err)
+		return 0, err
+	}
+
+	handler := stub.handlers.Configure
+	if handler == nil {
+		return stub.events, nil
+	}
+
+	events, err := handler(ctx, config, runtime, version)
+	if err != nil {
+		log.Errorf(ctx, "Plugin configuration failed: %v", err)
+		return 0, err
+	}
+
+	if events == 0 {
+		events = stub.events
+	}
+
+	// Only allow plugins to subscribe to events they can handle.
+	if extra := events & ^stub.events; extra != 0 {
+		log.Errorf(ctx, "Plugin subscribed for unhandled events %s (0x%x)",
+			extra.PrettyString(), extra)
+		return 0, fmt.Errorf("internal error: unhandled events %s (0x%x)",
+			extra.PrettyString(), extra)
+	}
+
+	log.Infof(ctx, "Subscribing plugin %s (%s) for events %s", stub.Name(),
+		filepath.Base(os.Args[0]), events.PrettyString())
+
+	return events, nil
+}
+
 // Synchronize the state of the plugin with the runtime.
 func (stub *stub) Synchronize(ctx context.Context, req *api.SynchronizeRequest) (*api.SynchronizeResponse, error) {
+	if err := stub.drainEvents(ctx); err != nil {
+		return nil, err
+	}
+
 	handler := stub.handlers.Synchronize
 	if handler == nil {
+		if !req.More {
+			if err := stub.loadState(ctx); err != nil {
+				return nil, err
+			}
+		}
 		return &api.SynchronizeResponse{More: req.More}, nil
 	}
 
@@ -663,6 +1219,10 @@ func (stub *stub) collectSync(req *api.SynchronizeRequest) (*api.SynchronizeResp
 	log.Debugf(noCtx, "collecting sync req with %d pods, %d containers...",
 		len(req.Pods), len(req.Containers))
 
+	if err := stub.checkSyncContinuation(req); err != nil {
+		return nil, err
+	}
+
 	if stub.syncReq == nil {
 		stub.syncReq = req
 	} else {
@@ -670,15 +1230,24 @@ func (stub *stub) collectSync(req *api.SynchronizeRequest) (*api.SynchronizeResp
 		stub.syncReq.Containers = append(stub.syncReq.Containers, req.Containers...)
 	}
 
-	return &api.SynchronizeResponse{More: req.More}, nil
+	stub.syncChunks++
+	stub.syncToken = "sync-" + strconv.FormatUint(stub.syncChunks, 10)
+
+	return &api.SynchronizeResponse{More: req.More, Continuation: stub.syncToken}, nil
 }
 
 func (stub *stub) deliverSync(ctx context.Context, req *api.SynchronizeRequest) (*api.SynchronizeResponse, error) {
 	stub.Lock()
+	err := stub.checkSyncContinuation(req)
 	syncReq := stub.syncReq
 	stub.syncReq = nil
+	stub.syncToken = ""
 	stub.Unlock()
 
+	if err != nil {
+		return nil, err
+	}
+
 	if syncReq == nil {
 		syncReq = req
 	} else {
@@ -686,19 +1255,52 @@ func (stub *stub) deliverSync(ctx context.Context, req *api.SynchronizeRequest)
 		syncReq.Containers = append(syncReq.Containers, req.Containers...)
 	}
 
+	if err := stub.loadState(ctx); err != nil {
+		return nil, err
+	}
+
 	update, err := stub.handlers.Synchronize(ctx, syncReq.Pods, syncReq.Containers)
+	if err == nil {
+		if err := stub.saveState(ctx); err != nil {
+			log.Errorf(ctx, "Failed to checkpoint state of plugin %s: %v", stub.Name(), err)
+		}
+	}
 	return &api.SynchronizeResponse{
 		Update: update,
 		More:   false,
 	}, err
 }
 
+// checkSyncContinuation checks that a chunk of a synchronization carries the
+// continuation token of the previous chunk. Runtimes which don't support
+// continuation tokens send chunks without one, which are not checked. The
+// chunks collected so far are dropped if the check fails.
+func (stub *stub) checkSyncContinuation(req *api.SynchronizeRequest) error {
+	if req.Continuation == "" || req.Continuation == stub.syncToken {
+		return nil
+	}
+
+	err := fmt.Errorf("unexpected sync continuation %q, expected %q",
+		req.Continuation, stub.syncToken)
+	stub.syncReq = nil
+	stub.syncToken = ""
+
+	return err
+}
+
 // Shutdown the plugin.
 func (stub *stub) Shutdown(ctx context.Context, _ *api.ShutdownRequest) (*api.ShutdownResponse, error) {
+	if err := stub.drainEvents(ctx); err != nil {
+		log.Errorf(ctx, "Failed to wait for pending events of plugin %s: %v", stub.Name(), err)
+	}
+
 	handler := stub.handlers.Shutdown
 	if handler != nil {
 		handler(ctx)
 	}
+	if err := stub.saveState(ctx); err != nil {
+		log.Errorf(ctx, "Failed to checkpoint state of plugin %s: %v", stub.Name(), err)
+	}
 	return &api.ShutdownResponse{}, nil
 }
 
@@ -708,7 +1310,20 @@ func (stub *stub) CreateContainer(ctx context.Context, req *api.CreateContainerR
 	if handler == nil {
 		return nil, nil
 	}
-	adjust, update, err := handler(ctx, req.Pod, req.Container)
+	ctx = withTimeBudget(ctx, req.TimeBudget)
+	ctx = withCollectedAdjustment(ctx, req.Owners, req.Adjust)
+
+	var (
+		adjust *api.ContainerAdjustment
+		update []*api.ContainerUpdate
+		err    error
+	)
+	if derr := stub.dispatchRequest(ctx, req.Container, func() {
+		adjust, update, err = handler(ctx, req.Pod, req.Container)
+	}); derr != nil {
+		return nil, derr
+	}
+
 	return &api.CreateContainerResponse{
 		Adjust: adjust,
 		Update: update,
@@ -721,7 +1336,19 @@ func (stub *stub) UpdateContainer(ctx context.Context, req *api.UpdateContainerR
 	if handler == nil {
 		return nil, nil
 	}
-	update, err := handler(ctx, req.Pod, req.Container, req.LinuxResources)
+	ctx = withTimeBudget(ctx, req.TimeBudget)
+	ctx = withContainerStats(ctx, req.Stats)
+
+	var (
+		update []*api.ContainerUpdate
+		err    error
+	)
+	if derr := stub.dispatchRequest(ctx, req.Container, func() {
+		update, err = handler(ctx, req.Pod, req.Container, req.LinuxResources)
+	}); derr != nil {
+		return nil, derr
+	}
+
 	return &api.UpdateContainerResponse{
 		Update: update,
 	}, err
@@ -733,7 +1360,19 @@ func (stub *stub) StopContainer(ctx context.Context, req *api.StopContainerReque
 	if handler == nil {
 		return nil, nil
 	}
-	update, err := handler(ctx, req.Pod, req.Container)
+	ctx = withTimeBudget(ctx, req.TimeBudget)
+	ctx = withContainerStats(ctx, req.Stats)
+
+	var (
+		update []*api.ContainerUpdate
+		err    error
+	)
+	if derr := stub.dispatchRequest(ctx, req.Container, func() {
+		update, err = handler(ctx, req.Pod, req.Container)
+	}); derr != nil {
+		return nil, derr
+	}
+
 	return &api.StopContainerResponse{
 		Update: update,
 	}, err
@@ -741,7 +1380,15 @@ func (stub *stub) StopContainer(ctx context.Context, req *api.StopContainerReque
 
 // StateChange event handler.
 func (stub *stub) StateChange(ctx context.Context, evt *api.StateChangeEvent) (*api.Empty, error) {
+	ctx = withTimeBudget(ctx, evt.TimeBudget)
+	err := stub.dispatchEvent(ctx, evt)
+	return &api.StateChangeResponse{}, err
+}
+
+// stateChange calls the handler of a state change event.
+func (stub *stub) stateChange(ctx context.Context, evt *api.StateChangeEvent) error {
 	var err error
+
 	switch evt.Event {
 	case api.Event_RUN_POD_SANDBOX:
 		if handler := stub.handlers.RunPodSandbox; handler != nil {
@@ -771,13 +1418,73 @@ func (stub *stub) StateChange(ctx context.Context, evt *api.StateChangeEvent) (*
 		if handler := stub.handlers.PostUpdateContainer; handler != nil {
 			err = handler(ctx, evt.Pod, evt.Container)
 		}
+	case api.Event_CONTAINER_DIED:
+		if handler := stub.handlers.ContainerDied; handler != nil {
+			err = handler(ctx, evt.Pod, evt.Container)
+		}
+	case api.Event_CREATE_CONTAINER_ROLLBACK:
+		if handler := stub.handlers.CreateContainerRollback; handler != nil {
+			err = handler(ctx, evt.Pod, evt.Container, evt.Adjust, evt.Error)
+		}
 	case api.Event_REMOVE_CONTAINER:
 		if handler := stub.handlers.RemoveContainer; handler != nil {
 			err = handler(ctx, evt.Pod, evt.Container)
 		}
 	}
 
-	return &api.StateChangeResponse{}, err
+	return err
+}
+
+// SetLeadership request handler.
+func (stub *stub) SetLeadership(ctx context.Context, req *api.SetLeadershipRequest) (*api.Empty, error) {
+	if req.Leader {
+		log.Infof(ctx, "Plugin %s elected as leader", stub.Name())
+	} else {
+		log.Infof(ctx, "Plugin %s on standby", stub.Name())
+	}
+
+	var err error
+	if handler := stub.handlers.SetLeadership; handler != nil {
+		err = handler(ctx, req.Leader)
+	}
+
+	return &api.Empty{}, err
+}
+
+// Ping request handler.
+func (stub *stub) Ping(ctx context.Context, _ *api.Empty) (*api.Empty, error) {
+	var err error
+	if handler := stub.handlers.Ping; handler != nil {
+		err = handler(ctx)
+	}
+
+	return &api.Empty{}, err
+}
+
+// ReconfigurePlugin request handler.
+func (stub *stub) ReconfigurePlugin(ctx context.Context, req *api.ReconfigurePluginRequest) (*api.Empty, error) {
+	handler := stub.handlers.ReconfigurePlugin
+	if handler == nil && stub.config == nil {
+		return nil, fmt.Errorf("plugin %s does not support reconfiguration", stub.Name())
+	}
+
+	log.Infof(ctx, "Reconfiguring plugin %s...", stub.Name())
+
+	restore, err := stub.decodeConfig(req.Config)
+	if err != nil {
+		log.Errorf(ctx, "Plugin reconfiguration failed: %v", err)
+		return nil, err
+	}
+
+	if handler != nil {
+		if err := handler(ctx, req.Config); err != nil {
+			log.Errorf(ctx, "Plugin reconfiguration failed: %v", err)
+			restore()
+			return nil, err
+		}
+	}
+
+	return &api.Empty{}, nil
 }
 
 // ensureIdentity sets plugin index and name from the binary if those are unset.
@@ -813,6 +1520,19 @@ func (stub *stub) setupHandlers() error {
 	if plugin, ok := stub.plugin.(ShutdownInterface); ok {
 		stub.handlers.Shutdown = plugin.Shutdown
 	}
+	if plugin, ok := stub.plugin.(SetLeadershipInterface); ok {
+		stub.handlers.SetLeadership = plugin.SetLeadership
+	}
+	if plugin, ok := stub.plugin.(PingInterface); ok {
+		stub.handlers.Ping = plugin.Ping
+	}
+	if plugin, ok := stub.plugin.(ReconfigurePluginInterface); ok {
+		stub.handlers.ReconfigurePlugin = plugin.ReconfigurePlugin
+	}
+	if plugin, ok := stub.plugin.(StateInterface); ok {
+		stub.handlers.SaveState = plugin.SaveState
+		stub.handlers.LoadState = plugin.LoadState
+	}
 
 	if plugin, ok := stub.plugin.(RunPodInterface); ok {
 		stub.handlers.RunPodSandbox = plugin.RunPodSandbox
@@ -858,6 +1578,14 @@ func (stub *stub) setupHandlers() error {
 		stub.handlers.PostUpdateContainer = plugin.PostUpdateContainer
 		stub.events.Set(api.Event_POST_UPDATE_CONTAINER)
 	}
+	if plugin, ok := stub.plugin.(ContainerDiedInterface); ok {
+		stub.handlers.ContainerDied = plugin.ContainerDied
+		stub.events.Set(api.Event_CONTAINER_DIED)
+	}
+	if plugin, ok := stub.plugin.(CreateContainerRollbackInterface); ok {
+		stub.handlers.CreateContainerRollback = plugin.CreateContainerRollback
+		stub.events.Set(api.Event_CREATE_CONTAINER_ROLLBACK)
+	}
 
 	if stub.events == 0 {
 		return fmt.Errorf("internal error: plugin %T does not implement any NRI request handlers",
@@ -0,0 +1,90 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/nri/pkg/api"
+
+	require "github.com/stretchr/testify/require"
+)
+
+// cleanupPlugin releases what it reserved for containers when they are removed.
+type cleanupPlugin struct {
+	reserved  map[string]bool
+	cancelled []string
+}
+
+func (p *cleanupPlugin) CreateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.reserved[ctr.Id] = true
+	return nil, nil, nil
+}
+
+func (p *cleanupPlugin) RemoveContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	if ctr.Exit().IsCancelled() {
+		p.cancelled = append(p.cancelled, ctr.Id)
+	}
+	delete(p.reserved, ctr.Id)
+	return nil
+}
+
+func TestCancelledCreationIsRemoval(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		pod  = &api.PodSandbox{Id: "pod0"}
+		ctr0 = &api.Container{Id: "ctr0", PodSandboxId: "pod0"}
+		ctr1 = &api.Container{Id: "ctr1", PodSandboxId: "pod0"}
+		p    = &cleanupPlugin{reserved: map[string]bool{}}
+	)
+
+	s, err := New(p, WithPluginName("test"), WithPluginIdx("00"))
+	require.NoError(t, err)
+	stub := s.(*stub)
+
+	for _, ctr := range []*api.Container{ctr0, ctr1} {
+		_, err = stub.CreateContainer(ctx, &api.CreateContainerRequest{Pod: pod, Container: ctr})
+		require.NoError(t, err)
+	}
+	require.Equal(t, map[string]bool{"ctr0": true, "ctr1": true}, p.reserved)
+
+	// a cancelled creation arrives as a removal with the cancelled exit reason
+	cancelled := &api.Container{Id: "ctr0", PodSandboxId: "pod0"}
+	cancelled.SetExit(&api.ContainerExit{
+		Code:   api.UnknownExitCode,
+		Reason: api.ExitReasonCancelled,
+	})
+	_, err = stub.StateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_REMOVE_CONTAINER,
+		Pod:       pod,
+		Container: cancelled,
+	})
+	require.NoError(t, err)
+
+	removed := &api.Container{Id: "ctr1", PodSandboxId: "pod0"}
+	removed.SetExit(&api.ContainerExit{Code: 0})
+	_, err = stub.StateChange(ctx, &api.StateChangeEvent{
+		Event:     api.Event_REMOVE_CONTAINER,
+		Pod:       pod,
+		Container: removed,
+	})
+	require.NoError(t, err)
+
+	require.Empty(t, p.reserved)
+	require.Equal(t, []string{"ctr0"}, p.cancelled)
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -42,10 +43,21 @@ const (
 var (
 	log     *logrus.Logger
 	verbose bool
+	cfg     config
+	_       = stub.ConfigureInterface(&plugin{})
 )
 
+// our configuration
+type config struct {
+	// DeviceTemplates are named devices annotations can refer to.
+	DeviceTemplates map[string]device `json:"deviceTemplates"`
+	// MountTemplates are named mounts annotations can refer to.
+	MountTemplates map[string]mount `json:"mountTemplates"`
+}
+
 // an annotated device
 type device struct {
+	Template string `json:"template,omitempty"`
 	Path     string `json:"path"`
 	Type     string `json:"type"`
 	Major    int64  `json:"major"`
@@ -57,6 +69,7 @@ type device struct {
 
 // an annotated mount
 type mount struct {
+	Template    string   `json:"template,omitempty"`
 	Source      string   `json:"source"`
 	Destination string   `json:"destination"`
 	Type        string   `json:"type"`
@@ -68,6 +81,42 @@ type plugin struct {
 	stub stub.Stub
 }
 
+// Configure handles plugin configuration.
+func (p *plugin) Configure(_ context.Context, config, runtime, version string) (stub.EventMask, error) {
+	log.Infof("got configuration data %q from runtime %s %s", config, runtime, version)
+	if config == "" {
+		return 0, nil
+	}
+
+	if err := parseConfig([]byte(config)); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// parseConfig parses and takes into use the given configuration.
+func parseConfig(data []byte) error {
+	c := config{}
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	for name := range c.DeviceTemplates {
+		if _, err := c.resolveDevice(device{Template: name}); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	for name := range c.MountTemplates {
+		if _, err := c.resolveMount(mount{Template: name}); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	cfg = c
+	return nil
+}
+
 // CreateContainer handles container creation requests.
 func (p *plugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
 	if verbose {
@@ -134,6 +183,14 @@ func parseDevices(ctr string, annotations map[string]string) ([]device, error) {
 		return nil, fmt.Errorf("invalid device annotation %q: %w", string(annotation), err)
 	}
 
+	for i, d := range devices {
+		resolved, err := cfg.resolveDevice(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device annotation %q: %w", string(annotation), err)
+		}
+		devices[i] = resolved
+	}
+
 	return devices, nil
 }
 
@@ -199,10 +256,15 @@ func injectMounts(pod *api.PodSandbox, ctr *api.Container, a *api.ContainerAdjus
 	}
 
 	for _, m := range mounts {
-		a.AddMount(m.toNRI())
+		// mounts without a source remount an existing mount of the container
+		mnt, err := api.CompleteMountFromContainer(m.toNRI(), ctr)
+		if err != nil {
+			return fmt.Errorf("%s: invalid mount annotation: %w", containerName(pod, ctr), err)
+		}
+		a.AddMount(mnt)
 		if !verbose {
 			log.Infof("%s: injected mount %q -> %q...", containerName(pod, ctr),
-				m.Source, m.Destination)
+				mnt.Source, mnt.Destination)
 		}
 	}
 
@@ -223,6 +285,14 @@ func parseMounts(ctr string, annotations map[string]string) ([]mount, error) {
 		return nil, fmt.Errorf("invalid mount annotation %q: %w", string(annotation), err)
 	}
 
+	for i, m := range mounts {
+		resolved, err := cfg.resolveMount(m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mount annotation %q: %w", string(annotation), err)
+		}
+		mounts[i] = resolved
+	}
+
 	return mounts, nil
 }
 
@@ -240,6 +310,87 @@ func getAnnotation(annotations map[string]string, mainKey, ctr string) []byte {
 	return nil
 }
 
+// Resolve a device, applying any referenced template with overrides.
+func (c *config) resolveDevice(d device) (device, error) {
+	seen := map[string]bool{}
+	for d.Template != "" {
+		name := d.Template
+		if seen[name] {
+			return device{}, fmt.Errorf("device template %q inherits from itself", name)
+		}
+		seen[name] = true
+
+		t, ok := c.DeviceTemplates[name]
+		if !ok {
+			return device{}, fmt.Errorf("unknown device template %q", name)
+		}
+		d = t.override(d)
+	}
+	return d, nil
+}
+
+// Override the fields of a device template with those set in the given device.
+func (d device) override(o device) device {
+	if o.Path != "" {
+		d.Path = o.Path
+	}
+	if o.Type != "" {
+		d.Type = o.Type
+	}
+	if o.Major != 0 {
+		d.Major = o.Major
+	}
+	if o.Minor != 0 {
+		d.Minor = o.Minor
+	}
+	if o.FileMode != 0 {
+		d.FileMode = o.FileMode
+	}
+	if o.UID != 0 {
+		d.UID = o.UID
+	}
+	if o.GID != 0 {
+		d.GID = o.GID
+	}
+	return d
+}
+
+// Resolve a mount, applying any referenced template with overrides.
+func (c *config) resolveMount(m mount) (mount, error) {
+	seen := map[string]bool{}
+	for m.Template != "" {
+		name := m.Template
+		if seen[name] {
+			return mount{}, fmt.Errorf("mount template %q inherits from itself", name)
+		}
+		seen[name] = true
+
+		t, ok := c.MountTemplates[name]
+		if !ok {
+			return mount{}, fmt.Errorf("unknown mount template %q", name)
+		}
+		m = t.override(m)
+	}
+	return m, nil
+}
+
+// Override the fields of a mount template with those set in the given mount.
+func (m mount) override(o mount) mount {
+	if o.Source != "" {
+		m.Source = o.Source
+	}
+	if o.Destination != "" {
+		m.Destination = o.Destination
+	}
+	if o.Type != "" {
+		m.Type = o.Type
+	}
+	if o.Options != nil {
+		m.Options = o.Options
+	}
+	return m
+}
+
 // Convert a device to the NRI API representation.
 func (d *device) toNRI() *api.LinuxDevice {
 	apiDev := &api.LinuxDevice{
@@ -315,27 +466,26 @@ func dump(args ...interface{}) {
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		opts       []stub.Option
-		err        error
+		opts []stub.Option
+		err  error
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
 	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
-	flag.Parse()
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
 
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
+	data, err := options.ReadConfig()
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
+	if data != nil {
+		if err := parseConfig(data); err != nil {
+			log.Fatalf("failed to load configuration file %q: %v", options.ConfigFile, err)
+		}
 	}
 
 	p := &plugin{}
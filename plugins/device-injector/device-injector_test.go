@@ -17,9 +17,13 @@
 package main
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/api"
 )
 
 func TestParseDevices(t *testing.T) {
@@ -265,3 +269,261 @@ func TestParseMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplates(t *testing.T) {
+	type testCase struct {
+		name        string
+		config      string
+		annotations map[string]string
+		devices     []device
+		mounts      []mount
+		configErr   bool
+		parseErr    bool
+	}
+
+	const templates = `
+deviceTemplates:
+  test-null:
+    path: /dev/test-null
+    type: c
+    major: 1
+    minor: 3
+  owned-null:
+    template: test-null
+    uid: 1000
+    gid: 1000
+mountTemplates:
+  cache:
+    source: /var/cache/shared
+    destination: /cache
+    type: bind
+    options:
+      - bind
+      - ro
+`
+
+	for _, tc := range []*testCase{
+		{
+			name:   "device and mount templates",
+			config: templates,
+			annotations: map[string]string{
+				"devices.nri.io/container.ctr0": `
+- template: test-null
+`,
+				"mounts.nri.io/container.ctr0": `
+- template: cache
+`,
+			},
+			devices: []device{
+				{
+					Path:  "/dev/test-null",
+					Type:  "c",
+					Major: 1,
+					Minor: 3,
+				},
+			},
+			mounts: []mount{
+				{
+					Source:      "/var/cache/shared",
+					Destination: "/cache",
+					Type:        "bind",
+					Options: []string{
+						"bind",
+						"ro",
+					},
+				},
+			},
+		},
+		{
+			name:   "templates with overrides",
+			config: templates,
+			annotations: map[string]string{
+				"devices.nri.io/container.ctr0": `
+- template: test-null
+  path: /dev/other-null
+  file_mode: 0666
+`,
+				"mounts.nri.io/container.ctr0": `
+- template: cache
+  destination: /data/cache
+`,
+			},
+			devices: []device{
+				{
+					Path:     "/dev/other-null",
+					Type:     "c",
+					Major:    1,
+					Minor:    3,
+					FileMode: 0666,
+				},
+			},
+			mounts: []mount{
+				{
+					Source:      "/var/cache/shared",
+					Destination: "/data/cache",
+					Type:        "bind",
+					Options: []string{
+						"bind",
+						"ro",
+					},
+				},
+			},
+		},
+		{
+			name:   "inherited template",
+			config: templates,
+			annotations: map[string]string{
+				"devices.nri.io/container.ctr0": `
+- template: owned-null
+  gid: 2000
+`,
+			},
+			devices: []device{
+				{
+					Path:  "/dev/test-null",
+					Type:  "c",
+					Major: 1,
+					Minor: 3,
+					UID:   1000,
+					GID:   2000,
+				},
+			},
+		},
+		{
+			name:   "unknown template",
+			config: templates,
+			annotations: map[string]string{
+				"devices.nri.io/container.ctr0": `
+- template: zero
+`,
+			},
+			parseErr: true,
+		},
+		{
+			name: "recursive template",
+			config: `
+deviceTemplates:
+  loop:
+    template: loop
+`,
+			configErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() { cfg = config{} }()
+
+			err := parseConfig([]byte(tc.config))
+			if tc.configErr {
+				require.NotNil(t, err, "expected configuration error")
+				return
+			}
+			require.Nil(t, err, "configuration error")
+
+			devices, err := parseDevices("ctr0", tc.annotations)
+			if tc.parseErr {
+				require.NotNil(t, err, "expected device parsing error")
+				return
+			}
+			require.Nil(t, err, "device parsing error")
+			require.Equal(t, tc.devices, devices, "parsed devices")
+
+			mounts, err := parseMounts("ctr0", tc.annotations)
+			require.Nil(t, err, "mount parsing error")
+			require.Equal(t, tc.mounts, mounts, "parsed mounts")
+		})
+	}
+}
+
+func TestCompleteMounts(t *testing.T) {
+	type testCase struct {
+		name       string
+		annotation string
+		result     []string
+		fail       bool
+	}
+
+	log = logrus.StandardLogger()
+
+	pod := &api.PodSandbox{Name: "pod0"}
+	ctr := &api.Container{
+		Name: "ctr0",
+		Mounts: []*api.Mount{
+			{
+				Source:      "/var/lib/kubelet/pods/uid0/volumes/kubernetes.io~csi/pv0/mount",
+				Destination: "/data",
+				Type:        "bind",
+				Options:     []string{"rbind", "rprivate", "rw"},
+			},
+		},
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "mount with only a destination",
+			annotation: `
+- destination: /data
+`,
+			result: []string{
+				"/var/lib/kubelet/pods/uid0/volumes/kubernetes.io~csi/pv0/mount:/data:bind:rbind,rprivate,rw",
+			},
+		},
+		{
+			name: "mount with a destination, type and options",
+			annotation: `
+- destination: /data/
+  type: bind
+  options:
+    - rbind
+    - rshared
+`,
+			result: []string{
+				"/var/lib/kubelet/pods/uid0/volumes/kubernetes.io~csi/pv0/mount:/data/:bind:rbind,rshared",
+			},
+		},
+		{
+			name: "mount with a source",
+			annotation: `
+- source: /foo
+  destination: /data
+  type: bind
+`,
+			result: []string{
+				"/foo:/data:bind:",
+			},
+		},
+		{
+			name: "mount with no matching destination",
+			annotation: `
+- destination: /other
+`,
+			fail: true,
+		},
+		{
+			name: "mount with no matching type",
+			annotation: `
+- destination: /data
+  type: tmpfs
+`,
+			fail: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod.Annotations = map[string]string{
+				"mounts.nri.io/container.ctr0": tc.annotation,
+			}
+			adjust := &api.ContainerAdjustment{}
+			err := injectMounts(pod, ctr, adjust)
+			if tc.fail {
+				require.NotNil(t, err, "expected mount completion error")
+				return
+			}
+			require.Nil(t, err, "mount completion error")
+
+			var mounts []string
+			for _, m := range adjust.Mounts {
+				mounts = append(mounts, m.Source+":"+m.Destination+":"+m.Type+":"+strings.Join(m.Options, ","))
+			}
+			require.Equal(t, tc.result, mounts, "completed mounts")
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -332,9 +333,9 @@ func (p *plugin) printYamlDiff(apifunc string, obj string, origValue interface{}
 	}
 }
 
-func startPlugin(wg *sync.WaitGroup, pluginName string, pluginIdx int) {
+func startPlugin(wg *sync.WaitGroup, pluginName string, pluginIdx int, commonOpts []stub.Option) {
 	var (
-		opts []stub.Option
+		opts = append([]stub.Option{}, commonOpts...)
 		err  error
 	)
 
@@ -370,9 +371,6 @@ func startPlugin(wg *sync.WaitGroup, pluginName string, pluginIdx int) {
 
 func main() {
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
 	flag.StringVar(&cfg.LogFile, "log-file", "", "logfile name, if logging to a file")
 	flag.IntVar(&cfg.VerboseLevel, "verbose-level", 0,
@@ -387,7 +385,18 @@ func main() {
 			"indices 45, 50 and 80. Note that this plugin will install itself to index 0 and 99\n"+
 			"if this parameter is not given.")
 	flag.BoolVar(&cfg.Yaml, "yaml", false, "Print the diff in yaml")
-	flag.Parse()
+	options := cli.Parse()
+
+	// The index of each differ instance comes from -indices, so -idx,
+	// although accepted, gets overridden for all instances.
+	commonOpts, err := options.Setup(log)
+	if err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
+	pluginName := options.Name
+	if pluginName == "" {
+		pluginName = "Differ"
+	}
 
 	if cfg.LogFile != "" {
 		f, err := os.OpenFile(cfg.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
@@ -427,7 +436,13 @@ func main() {
 
 		wg.Add(1)
 
-		go startPlugin(wg, "Differ", idx)
+		// Instances rely on being invoked in index order, so self-test
+		// them one by one.
+		if options.SelfTest {
+			startPlugin(wg, pluginName, idx, commonOpts)
+		} else {
+			go startPlugin(wg, pluginName, idx, commonOpts)
+		}
 	}
 
 	entry := indices[prevIndex]
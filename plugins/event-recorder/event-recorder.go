@@ -0,0 +1,408 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// value redacted data is replaced with
+	redacted = "<redacted>"
+)
+
+var (
+	log = logrus.StandardLogger()
+)
+
+// our configuration
+type config struct {
+	// Events are the events to record, all of them by default.
+	Events []string `json:"events"`
+	// Redact is the data to redact from recorded events.
+	Redact *redaction `json:"redact"`
+}
+
+// redaction is the data to redact from recorded pods and containers. Keys
+// and names are matched against patterns, which are either exact, or match
+// any key with the given prefix if they end in '*'.
+type redaction struct {
+	// Annotations are the pod and container annotations to redact.
+	Annotations []string `json:"annotations"`
+	// Labels are the pod and container labels to redact.
+	Labels []string `json:"labels"`
+	// Env are the container environment variables to redact.
+	Env []string `json:"env"`
+	// Args redacts the command line arguments of containers.
+	Args bool `json:"args"`
+}
+
+// defaultRedaction redacts the values of all environment variables, which
+// are a common way to pass credentials to containers.
+var defaultRedaction = &redaction{
+	Env: []string{"*"},
+}
+
+// record is a single recorded event.
+type record struct {
+	// Seq is the sequence number of the record, assigned by the ring.
+	Seq uint64 `json:"seq"`
+	// Time the event was received.
+	Time time.Time `json:"time"`
+	// Event is the name of the event.
+	Event string `json:"event"`
+	// Namespace, Pod, Container and ContainerID identify the pod and
+	// container of the event, if any.
+	Namespace   string `json:"namespace,omitempty"`
+	Pod         string `json:"pod,omitempty"`
+	Container   string `json:"container,omitempty"`
+	ContainerID string `json:"containerId,omitempty"`
+	// Data is the redacted data of the event.
+	Data *eventData `json:"data,omitempty"`
+}
+
+// eventData is the data of an event, marshaled using the protobuf JSON mapping.
+type eventData struct {
+	Pod        json.RawMessage   `json:"pod,omitempty"`
+	Container  json.RawMessage   `json:"container,omitempty"`
+	Resources  json.RawMessage   `json:"resources,omitempty"`
+	Pods       []json.RawMessage `json:"pods,omitempty"`
+	Containers []json.RawMessage `json:"containers,omitempty"`
+}
+
+// our event recorder plugin
+type plugin struct {
+	sync.Mutex
+	stub   stub.Stub
+	ring   *ring
+	mask   stub.EventMask
+	redact *redaction
+}
+
+func newPlugin(r *ring) *plugin {
+	return &plugin{
+		ring:   r,
+		redact: defaultRedaction,
+	}
+}
+
+// Configure handles plugin configuration.
+func (p *plugin) Configure(_ context.Context, config, runtime, version string) (stub.EventMask, error) {
+	log.Infof("got configuration data %q from runtime %s %s", config, runtime, version)
+	if config != "" {
+		if err := p.setConfig([]byte(config)); err != nil {
+			return 0, err
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	return p.mask, nil
+}
+
+// setConfig parses and takes the given configuration into use.
+func (p *plugin) setConfig(data []byte) error {
+	mask, redact, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.mask = mask
+	p.redact = redact
+
+	return nil
+}
+
+func parseConfig(data []byte) (stub.EventMask, *redaction, error) {
+	cfg := config{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return 0, nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	mask, err := api.ParseEventMask(cfg.Events...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	redact := cfg.Redact
+	if redact == nil {
+		redact = defaultRedaction
+	}
+
+	return mask, redact, nil
+}
+
+func (p *plugin) Synchronize(_ context.Context, pods []*api.PodSandbox, containers []*api.Container) ([]*api.ContainerUpdate, error) {
+	data := &eventData{}
+	for _, pod := range pods {
+		data.Pods = append(data.Pods, marshal(p.redactPod(pod)))
+	}
+	for _, ctr := range containers {
+		data.Containers = append(data.Containers, marshal(p.redactContainer(ctr)))
+	}
+	p.record(&record{Event: "Synchronize", Data: data})
+	return nil, nil
+}
+
+func (p *plugin) Shutdown(_ context.Context) {
+	p.record(&record{Event: "Shutdown"})
+}
+
+func (p *plugin) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.recordEvent("RunPodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.recordEvent("StopPodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.recordEvent("RemovePodSandbox", pod, nil, nil)
+	return nil
+}
+
+func (p *plugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.recordEvent("CreateContainer", pod, ctr, nil)
+	return nil, nil, nil
+}
+
+func (p *plugin) PostCreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("PostCreateContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) StartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("StartContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) PostStartContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("PostStartContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) UpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.recordEvent("UpdateContainer", pod, ctr, res)
+	return nil, nil
+}
+
+func (p *plugin) PostUpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("PostUpdateContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) StopContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+	p.recordEvent("StopContainer", pod, ctr, nil)
+	return nil, nil
+}
+
+func (p *plugin) RemoveContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("RemoveContainer", pod, ctr, nil)
+	return nil
+}
+
+func (p *plugin) ContainerDied(_ context.Context, pod *api.PodSandbox, ctr *api.Container) error {
+	p.recordEvent("ContainerDied", pod, ctr, nil)
+	return nil
+}
+
+// recordEvent records a pod or container lifecycle event.
+func (p *plugin) recordEvent(event string, pod *api.PodSandbox, ctr *api.Container, res *api.LinuxResources) {
+	rec := &record{
+		Event:       event,
+		Namespace:   pod.GetNamespace(),
+		Pod:         pod.GetName(),
+		Container:   ctr.GetName(),
+		ContainerID: ctr.GetId(),
+		Data: &eventData{
+			Pod:       marshal(p.redactPod(pod)),
+			Container: marshal(p.redactContainer(ctr)),
+			Resources: marshal(res),
+		},
+	}
+	p.record(rec)
+}
+
+// record an event in the ring. Failures are logged but otherwise ignored,
+// so that we never interfere with the lifecycle of pods and containers.
+func (p *plugin) record(rec *record) {
+	rec.Time = time.Now()
+	if err := p.ring.append(rec); err != nil {
+		log.Errorf("failed to record %s event: %v", rec.Event, err)
+	}
+}
+
+// redactPod returns a copy of the pod with data redacted.
+func (p *plugin) redactPod(pod *api.PodSandbox) *api.PodSandbox {
+	if pod == nil {
+		return nil
+	}
+
+	p.Lock()
+	r := p.redact
+	p.Unlock()
+
+	pod = proto.Clone(pod).(*api.PodSandbox)
+	redactMap(pod.Annotations, r.Annotations)
+	redactMap(pod.Labels, r.Labels)
+
+	return pod
+}
+
+// redactContainer returns a copy of the container with data redacted.
+func (p *plugin) redactContainer(ctr *api.Container) *api.Container {
+	if ctr == nil {
+		return nil
+	}
+
+	p.Lock()
+	r := p.redact
+	p.Unlock()
+
+	ctr = proto.Clone(ctr).(*api.Container)
+	redactMap(ctr.Annotations, r.Annotations)
+	redactMap(ctr.Labels, r.Labels)
+	for i, env := range ctr.Env {
+		name, _, _ := strings.Cut(env, "=")
+		if matchAny(r.Env, name) {
+			ctr.Env[i] = name + "=" + redacted
+		}
+	}
+	if r.Args {
+		for i := range ctr.Args {
+			ctr.Args[i] = redacted
+		}
+	}
+
+	return ctr
+}
+
+// redactMap redacts the values of the map with keys matching any pattern.
+func redactMap(m map[string]string, patterns []string) {
+	for k := range m {
+		if matchAny(patterns, k) {
+			m[k] = redacted
+		}
+	}
+}
+
+// matchAny checks if a key matches any of the given patterns.
+func matchAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// marshal a message using the protobuf JSON mapping, or nil for a nil message.
+func marshal(m proto.Message) json.RawMessage {
+	if !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		log.Errorf("failed to marshal %T: %v", m, err)
+		return nil
+	}
+	return data
+}
+
+func main() {
+	var (
+		dir     string
+		maxSize int64
+		socket  string
+		events  string
+		opts    []stub.Option
+		err     error
+	)
+
+	flag.StringVar(&dir, "dir", "/var/lib/nri/event-recorder", "directory to record events in")
+	flag.Int64Var(&maxSize, "max-size", 64<<20, "maximum size of recorded events, in bytes")
+	flag.StringVar(&socket, "socket", "/var/run/nri/event-recorder.sock", "unix socket to serve recorded events on, empty to disable")
+	flag.StringVar(&events, "events", "all", "comma-separated list of events to record")
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
+
+	r, err := openRing(dir, maxSize)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer r.close()
+
+	p := newPlugin(r)
+	if p.mask, err = api.ParseEventMask(events); err != nil {
+		log.Fatalf("failed to parse events: %v", err)
+	}
+
+	data, err := options.ReadConfig()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if data != nil {
+		if err := p.setConfig(data); err != nil {
+			log.Fatalf("failed to load configuration file %q: %v", options.ConfigFile, err)
+		}
+	}
+
+	if socket != "" {
+		srv, err := startServer(socket, r)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		defer srv.stop()
+	}
+
+	if p.stub, err = stub.New(p, opts...); err != nil {
+		log.Fatalf("failed to create plugin stub: %v", err)
+	}
+
+	err = p.stub.Run(context.Background())
+	if err != nil {
+		log.Errorf("plugin exited with error %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,299 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+func readAll(t *testing.T, r *ring) []*record {
+	var records []*record
+	require.NoError(t, r.read(func(rec *record) bool {
+		records = append(records, rec)
+		return true
+	}))
+	return records
+}
+
+func ringSize(t *testing.T, dir string) int64 {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	size := int64(0)
+	for _, e := range entries {
+		info, err := e.Info()
+		require.NoError(t, err)
+		size += info.Size()
+	}
+	return size
+}
+
+func TestRing(t *testing.T) {
+	const (
+		maxSize = 8 * 1024
+		count   = 1000
+	)
+
+	dir := t.TempDir()
+	r, err := openRing(dir, maxSize)
+	require.NoError(t, err)
+
+	for i := 0; i < count; i++ {
+		require.NoError(t, r.append(&record{Event: "RunPodSandbox", Pod: fmt.Sprintf("pod%d", i)}))
+	}
+
+	records := readAll(t, r)
+	require.NotEmpty(t, records)
+	require.Less(t, len(records), count, "old records should have been dropped")
+	for i, rec := range records {
+		require.Equal(t, uint64(count-len(records)+i+1), rec.Seq)
+	}
+	require.Equal(t, fmt.Sprintf("pod%d", count-1), records[len(records)-1].Pod)
+	require.LessOrEqual(t, ringSize(t, dir), int64(maxSize))
+	require.NoError(t, r.close())
+
+	// records and sequence numbers persist over reopening the ring
+	r, err = openRing(dir, maxSize)
+	require.NoError(t, err)
+	require.Equal(t, records, readAll(t, r))
+	require.NoError(t, r.append(&record{Event: "StopPodSandbox"}))
+	reopened := readAll(t, r)
+	require.Equal(t, uint64(count+1), reopened[len(reopened)-1].Seq)
+	require.NoError(t, r.close())
+
+	// a truncated record is skipped and not appended to
+	segments, err := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix))
+	require.NoError(t, err)
+	last := segments[len(segments)-1]
+	f, err := os.OpenFile(last, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":1002,"event":"Remo`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	r, err = openRing(dir, maxSize)
+	require.NoError(t, err)
+	require.NoError(t, r.append(&record{Event: "RemovePodSandbox"}))
+	records = readAll(t, r)
+	require.Equal(t, uint64(count+2), records[len(records)-1].Seq)
+	require.Equal(t, "RemovePodSandbox", records[len(records)-1].Event)
+	require.Equal(t, "StopPodSandbox", records[len(records)-2].Event)
+	require.NoError(t, r.close())
+
+	// shrinking the ring drops the oldest records
+	r, err = openRing(dir, maxSize/4)
+	require.NoError(t, err)
+	shrunk := readAll(t, r)
+	require.Less(t, len(shrunk), len(records))
+	require.Equal(t, records[len(records)-1], shrunk[len(shrunk)-1])
+	require.NoError(t, r.close())
+
+	_, err = openRing(t.TempDir(), 1)
+	require.Error(t, err)
+}
+
+func TestParseConfig(t *testing.T) {
+	mask, redact, err := parseConfig([]byte(""))
+	require.NoError(t, err)
+	require.Equal(t, stubMask(), mask)
+	require.Equal(t, defaultRedaction, redact)
+
+	mask, redact, err = parseConfig([]byte(`
+events:
+  - RunPodSandbox
+  - CreateContainer
+redact:
+  annotations:
+    - secret.example.com/*
+  args: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, stubMask(api.Event_RUN_POD_SANDBOX, api.Event_CREATE_CONTAINER), mask)
+	require.Equal(t, &redaction{Annotations: []string{"secret.example.com/*"}, Args: true}, redact)
+
+	_, _, err = parseConfig([]byte("events: [ NoSuchEvent ]"))
+	require.Error(t, err)
+}
+
+func stubMask(events ...api.Event) api.EventMask {
+	mask := api.EventMask(0)
+	for _, e := range events {
+		mask.Set(e)
+	}
+	return mask
+}
+
+func TestRedaction(t *testing.T) {
+	pod := &api.PodSandbox{
+		Name: "pod0",
+		Annotations: map[string]string{
+			"secret.example.com/token": "s3cr3t",
+			"example.com/public":       "public",
+		},
+		Labels: map[string]string{
+			"team": "blue",
+		},
+	}
+	ctr := &api.Container{
+		Name: "ctr0",
+		Args: []string{"server", "--password=s3cr3t"},
+		Env:  []string{"PASSWORD=s3cr3t", "HOME=/root", "EMPTY"},
+		Annotations: map[string]string{
+			"secret.example.com/token": "s3cr3t",
+		},
+	}
+
+	p := newPlugin(nil)
+
+	// by default, only the values of environment variables are redacted
+	c := p.redactContainer(ctr)
+	require.Equal(t, []string{"PASSWORD=" + redacted, "HOME=" + redacted, "EMPTY=" + redacted}, c.Env)
+	require.Equal(t, ctr.Args, c.Args)
+	require.Equal(t, "PASSWORD=s3cr3t", ctr.Env[0], "original container should not be modified")
+
+	require.NoError(t, p.setConfig([]byte(`
+redact:
+  annotations: [ secret.example.com/* ]
+  labels: [ team ]
+  env: [ PASSWORD ]
+  args: true
+`)))
+
+	rp := p.redactPod(pod)
+	require.Equal(t, redacted, rp.Annotations["secret.example.com/token"])
+	require.Equal(t, "public", rp.Annotations["example.com/public"])
+	require.Equal(t, redacted, rp.Labels["team"])
+	require.Equal(t, "s3cr3t", pod.Annotations["secret.example.com/token"])
+
+	c = p.redactContainer(ctr)
+	require.Equal(t, []string{"PASSWORD=" + redacted, "HOME=/root", "EMPTY"}, c.Env)
+	require.Equal(t, []string{redacted, redacted}, c.Args)
+	require.Equal(t, redacted, c.Annotations["secret.example.com/token"])
+
+	require.Nil(t, p.redactPod(nil))
+	require.Nil(t, p.redactContainer(nil))
+}
+
+func TestServer(t *testing.T) {
+	r, err := openRing(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+	defer r.close()
+
+	// unix socket paths are limited in length, avoid long test directories
+	dir, err := os.MkdirTemp("", "nri-recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "recorder.sock")
+
+	srv, err := startServer(socket, r)
+	require.NoError(t, err)
+	defer srv.stop()
+
+	p := newPlugin(r)
+	ctx := context.Background()
+	pods := []*api.PodSandbox{
+		{Id: "pod0", Name: "pod0", Namespace: "default"},
+		{Id: "pod1", Name: "pod1", Namespace: "kube-system"},
+	}
+	ctrs := []*api.Container{
+		{Id: "ctr0", PodSandboxId: "pod0", Name: "ctr0", Env: []string{"TOKEN=s3cr3t"}},
+		{Id: "ctr1", PodSandboxId: "pod1", Name: "ctr1"},
+	}
+
+	_, err = p.Synchronize(ctx, nil, nil)
+	require.NoError(t, err)
+	for i := range pods {
+		require.NoError(t, p.RunPodSandbox(ctx, pods[i]))
+		_, _, err := p.CreateContainer(ctx, pods[i], ctrs[i])
+		require.NoError(t, err)
+		require.NoError(t, p.StartContainer(ctx, pods[i], ctrs[i]))
+	}
+	_, err = p.UpdateContainer(ctx, pods[0], ctrs[0], &api.LinuxResources{RdtClass: api.String("gold")})
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	query := func(query string) ([]*record, int) {
+		rpl, err := client.Get("http://recorder/events" + query)
+		require.NoError(t, err)
+		defer rpl.Body.Close()
+		if rpl.StatusCode != http.StatusOK {
+			return nil, rpl.StatusCode
+		}
+
+		var records []*record
+		scanner := bufio.NewScanner(rpl.Body)
+		for scanner.Scan() {
+			rec := &record{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), rec))
+			records = append(records, rec)
+		}
+		require.NoError(t, scanner.Err())
+		return records, rpl.StatusCode
+	}
+	events := func(records []*record) []string {
+		var names []string
+		for _, rec := range records {
+			names = append(names, rec.Event)
+		}
+		return names
+	}
+
+	records, status := query("")
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, records, 8)
+	require.Equal(t, "Synchronize", records[0].Event)
+
+	records, _ = query("?container=ctr0")
+	require.Equal(t, []string{"CreateContainer", "StartContainer", "UpdateContainer"}, events(records))
+	ctr := &api.Container{}
+	require.NoError(t, protojson.Unmarshal(records[0].Data.Container, ctr))
+	require.Equal(t, []string{"TOKEN=" + redacted}, ctr.Env)
+	res := &api.LinuxResources{}
+	require.NoError(t, protojson.Unmarshal(records[2].Data.Resources, res))
+	require.Equal(t, "gold", res.GetRdtClass().GetValue())
+
+	records, _ = query("?namespace=kube-system&event=CreateContainer")
+	require.Len(t, records, 1)
+	require.Equal(t, "ctr1", records[0].ContainerID)
+
+	records, _ = query("?pod=pod0&limit=2")
+	require.Equal(t, []string{"StartContainer", "UpdateContainer"}, events(records))
+
+	records, _ = query(fmt.Sprintf("?pod=pod0&since=%d", records[0].Seq))
+	require.Equal(t, []string{"UpdateContainer"}, events(records))
+
+	_, status = query("?limit=-1")
+	require.Equal(t, http.StatusBadRequest, status)
+}
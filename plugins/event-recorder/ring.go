@@ -0,0 +1,316 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// number of segments the ring is split into
+	ringSegments = 8
+	// suffix of segment files
+	segmentSuffix = ".jsonl"
+)
+
+// ring is a size-bounded, persistent ring buffer of records. Records are
+// stored as JSON, one record per line, in a sequence of segment files in a
+// directory. Records are appended to the newest segment, and a new segment
+// is started once the next record doesn't fit its share of the size limit. The oldest
+// segments are removed to keep the ring within the limit. Since whole
+// segments are removed at a time, the ring keeps between (n-1)/n and all
+// of the records which fit the limit, n being the number of segments.
+type ring struct {
+	sync.Mutex
+	dir      string
+	maxSize  int64
+	segSize  int64
+	segments []*segment
+	file     *os.File
+	seq      uint64
+}
+
+// segment is a single segment file of the ring.
+type segment struct {
+	idx  uint64
+	size int64
+}
+
+// openRing opens the ring in the given directory, creating it if necessary.
+// Records already in the ring are kept, as long as they fit the size limit.
+func openRing(dir string, maxSize int64) (*ring, error) {
+	if maxSize < ringSegments {
+		return nil, fmt.Errorf("invalid event ring size %d, must be at least %d", maxSize, ringSegments)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event ring directory: %w", err)
+	}
+
+	r := &ring{
+		dir:     dir,
+		maxSize: maxSize,
+		segSize: maxSize / ringSegments,
+	}
+
+	if err := r.scan(); err != nil {
+		return nil, err
+	}
+	if err := r.trim(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// scan the directory for existing segments, and the last sequence number.
+func (r *ring) scan() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read event ring directory: %w", err)
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		idx, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat event ring segment %s: %w", name, err)
+		}
+		r.segments = append(r.segments, &segment{idx: idx, size: info.Size()})
+	}
+
+	sort.Slice(r.segments, func(i, j int) bool {
+		return r.segments[i].idx < r.segments[j].idx
+	})
+
+	// the last record is in the newest segment with any records in it
+	for i := len(r.segments) - 1; i >= 0 && r.seq == 0; i-- {
+		err := r.readSegment(r.segments[i], func(rec *record) bool {
+			r.seq = rec.Seq
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// append a record to the ring, assigning it the next sequence number.
+func (r *ring) append(rec *record) error {
+	r.Lock()
+	defer r.Unlock()
+
+	rec.Seq = r.seq + 1
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event record: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := r.rotate(int64(len(data))); err != nil {
+		return err
+	}
+
+	if _, err := r.file.Write(data); err != nil {
+		return fmt.Errorf("failed to write event record: %w", err)
+	}
+
+	r.seq = rec.Seq
+	r.segments[len(r.segments)-1].size += int64(len(data))
+
+	return nil
+}
+
+// rotate to a new segment if the given amount of data doesn't fit the last
+// one, removing old segments to make space. Records larger than a segment
+// get a segment of their own.
+func (r *ring) rotate(size int64) error {
+	var last *segment
+	if len(r.segments) > 0 {
+		last = r.segments[len(r.segments)-1]
+	}
+
+	if last != nil && (last.size == 0 || last.size+size <= r.segSize) {
+		if r.file != nil {
+			return nil
+		}
+		// reopen the last segment, unless it ends in a truncated record
+		complete, err := r.isComplete(last)
+		if err != nil {
+			return err
+		}
+		if complete {
+			f, err := os.OpenFile(r.path(last), os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to open event ring segment: %w", err)
+			}
+			r.file = f
+			return nil
+		}
+	}
+
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+
+	next := &segment{}
+	if last != nil {
+		next.idx = last.idx + 1
+	}
+	f, err := os.OpenFile(r.path(next), os.O_CREATE|os.O_EXCL|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create event ring segment: %w", err)
+	}
+
+	r.file = f
+	r.segments = append(r.segments, next)
+
+	return r.trim()
+}
+
+// trim the oldest segments, keeping at most ringSegments of them, within
+// the size limit. The newest segment is always kept.
+func (r *ring) trim() error {
+	total := int64(0)
+	for _, s := range r.segments {
+		total += s.size
+	}
+
+	for len(r.segments) > 1 && (len(r.segments) > ringSegments || total > r.maxSize) {
+		total -= r.segments[0].size
+		if err := os.Remove(r.path(r.segments[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove event ring segment: %w", err)
+		}
+		r.segments = r.segments[1:]
+	}
+	return nil
+}
+
+// read the records in the ring, oldest first, until fn returns false.
+func (r *ring) read(fn func(*record) bool) error {
+	r.Lock()
+	segments := make([]*segment, len(r.segments))
+	copy(segments, r.segments)
+	r.Unlock()
+
+	done := false
+	for _, s := range segments {
+		err := r.readSegment(s, func(rec *record) bool {
+			if !fn(rec) {
+				done = true
+			}
+			return !done
+		})
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+
+	return nil
+}
+
+// isComplete checks if a segment is empty or ends with a complete record.
+func (r *ring) isComplete(s *segment) (bool, error) {
+	if s.size == 0 {
+		return true, nil
+	}
+
+	f, err := os.Open(r.path(s))
+	if err != nil {
+		return false, fmt.Errorf("failed to open event ring segment: %w", err)
+	}
+	defer f.Close()
+
+	buf := make([]byte, 1)
+	if _, err := f.ReadAt(buf, s.size-1); err != nil {
+		return false, fmt.Errorf("failed to read event ring segment: %w", err)
+	}
+
+	return buf[0] == '\n', nil
+}
+
+// readSegment reads the records of a segment, until fn returns false.
+// Segments removed meanwhile and truncated records are skipped.
+func (r *ring) readSegment(s *segment, fn func(*record) bool) error {
+	f, err := os.Open(r.path(s))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to open event ring segment: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read event ring segment: %w", err)
+		}
+		rec := &record{}
+		if err := json.Unmarshal(line, rec); err != nil {
+			continue
+		}
+		if !fn(rec) {
+			return nil
+		}
+	}
+}
+
+// close the ring.
+func (r *ring) close() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
+
+func (r *ring) path(s *segment) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%016d%s", s.idx, segmentSuffix))
+}
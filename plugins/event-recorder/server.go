@@ -0,0 +1,190 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// server serves recorded events over HTTP on a unix socket.
+type server struct {
+	ring   *ring
+	path   string
+	server *http.Server
+}
+
+// filter selects the records to serve.
+type filter struct {
+	event     string
+	namespace string
+	pod       string
+	container string
+	since     uint64
+	limit     int
+}
+
+// startServer starts serving the records of the ring on the given socket.
+func startServer(path string, r *ring) (*server, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for socket %s: %w", path, err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on socket %s: %w", path, err)
+	}
+	// recorded events are only as private as the socket is
+	if err := os.Chmod(path, 0o600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to set permissions of socket %s: %w", path, err)
+	}
+
+	s := &server{
+		ring: r,
+		path: path,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", s.serveEvents)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("failed to serve recorded events: %v", err)
+		}
+	}()
+
+	log.Infof("serving recorded events on %s", path)
+
+	return s, nil
+}
+
+// stop serving records.
+func (s *server) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.server.Shutdown(ctx)
+	os.Remove(s.path)
+}
+
+// serveEvents serves the records matching the query, oldest first, as JSON,
+// one record per line.
+func (s *server) serveEvents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseFilter(req.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	records := s.collect(f)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			log.Warnf("failed to serve recorded events: %v", err)
+			return
+		}
+	}
+}
+
+// collect the records matching the filter, keeping the last limit ones.
+func (s *server) collect(f *filter) []*record {
+	var records []*record
+
+	err := s.ring.read(func(rec *record) bool {
+		if !f.matches(rec) {
+			return true
+		}
+		records = append(records, rec)
+		if f.limit > 0 && len(records) > f.limit {
+			records = records[1:]
+		}
+		return true
+	})
+	if err != nil {
+		log.Warnf("failed to read recorded events: %v", err)
+	}
+
+	return records
+}
+
+// parseFilter parses a filter from query parameters.
+func parseFilter(query url.Values) (*filter, error) {
+	f := &filter{
+		event:     query.Get("event"),
+		namespace: query.Get("namespace"),
+		pod:       query.Get("pod"),
+		container: query.Get("container"),
+	}
+
+	if v := query.Get("since"); v != "" {
+		since, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since %q: %w", v, err)
+		}
+		f.since = since
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit %q", v)
+		}
+		f.limit = limit
+	}
+
+	return f, nil
+}
+
+// matches checks if a record matches the filter. Containers are matched by
+// name or ID.
+func (f *filter) matches(rec *record) bool {
+	switch {
+	case rec.Seq <= f.since:
+		return false
+	case f.event != "" && rec.Event != f.event:
+		return false
+	case f.namespace != "" && rec.Namespace != f.namespace:
+		return false
+	case f.pod != "" && rec.Pod != f.pod:
+		return false
+	case f.container != "" && rec.Container != f.container && rec.ContainerID != f.container:
+		return false
+	}
+	return true
+}
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -135,8 +136,6 @@ func dump(args ...interface{}) {
 
 func main() {
 	var (
-		pluginName   string
-		pluginIdx    string
 		disableWatch bool
 		opts         []stub.Option
 		mgr          *hooks.Manager
@@ -144,21 +143,13 @@ func main() {
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
 	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
 	flag.BoolVar(&disableWatch, "disableWatch", false, "disable watching hook directories for new hooks")
-	flag.Parse()
-
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Errorf("failed to set up plugin: %v", err)
+		os.Exit(1)
 	}
 
 	p := &plugin{}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -201,27 +202,23 @@ func dump(args ...interface{}) {
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		events     string
-		opts       []stub.Option
-		err        error
+		events string
+		opts   []stub.Option
+		err    error
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
 	flag.StringVar(&events, "events", "all", "comma-separated list of events to subscribe for")
 	flag.StringVar(&cfg.LogFile, "log-file", "", "logfile name, if logging to a file")
 	flag.StringVar(&cfg.AddAnnotation, "add-annotation", "", "add this annotation to containers")
 	flag.StringVar(&cfg.SetAnnotation, "set-annotation", "", "set this annotation on containers")
 	flag.StringVar(&cfg.AddEnv, "add-env", "", "add this environment variable for containers")
 	flag.StringVar(&cfg.SetEnv, "set-env", "", "set this environment variable for containers")
-	flag.Parse()
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
 
 	if cfg.LogFile != "" {
 		f, err := os.OpenFile(cfg.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
@@ -231,13 +228,6 @@ func main() {
 		log.SetOutput(f)
 	}
 
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
-	}
-
 	p := &plugin{}
 	if p.mask, err = api.ParseEventMask(events); err != nil {
 		log.Fatalf("failed to parse events: %v", err)
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -270,27 +271,16 @@ func dump(args ...interface{}) {
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		opts       []stub.Option
-		err        error
+		opts []stub.Option
+		err  error
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
 	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
-	flag.Parse()
-
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
 	}
 
 	p := &plugin{}
@@ -18,12 +18,12 @@ package main
 
 import (
 	"context"
-	"flag"
 	"os"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
@@ -83,30 +83,19 @@ func (p *plugin) onClose() {
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		err        error
+		opts []stub.Option
+		err  error
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "network-logger", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
-	flag.Parse()
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
 
 	p := &plugin{}
-	opts := []stub.Option{
-		stub.WithOnClose(p.onClose),
-	}
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
-	}
+	opts = append(opts, stub.WithOnClose(p.onClose))
 
 	if p.stub, err = stub.New(p, opts...); err != nil {
 		log.Fatalf("failed to create plugin stub: %v", err)
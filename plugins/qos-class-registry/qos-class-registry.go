@@ -0,0 +1,423 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// Prefix of the key used for QoS class annotations.
+	qosClassKey = "qos-class.nri.io"
+)
+
+var (
+	log     = logrus.StandardLogger()
+	verbose bool
+)
+
+// our configuration
+type config struct {
+	// Classes are the named QoS classes containers can be assigned to.
+	Classes map[string]*class `json:"classes"`
+}
+
+// a QoS class, a named bundle of container resources
+type class struct {
+	CPUShares         uint64 `json:"cpuShares,omitempty"`
+	CPUQuota          int64  `json:"cpuQuota,omitempty"`
+	CPUPeriod         int64  `json:"cpuPeriod,omitempty"`
+	MemoryLimit       int64  `json:"memoryLimit,omitempty"`
+	MemoryReservation int64  `json:"memoryReservation,omitempty"`
+	IOWeight          uint64 `json:"ioWeight,omitempty"`
+	BlockIOClass      string `json:"blockioClass,omitempty"`
+	RDTClass          string `json:"rdtClass,omitempty"`
+}
+
+// our QoS class registry plugin
+type plugin struct {
+	sync.Mutex
+	stub    stub.Stub
+	classes map[string]*class
+	// class assignment of known containers
+	assigned map[string]string
+}
+
+// resourceSetter is implemented by both container adjustments and updates.
+type resourceSetter interface {
+	SetLinuxCPUShares(uint64)
+	SetLinuxCPUQuota(int64)
+	SetLinuxCPUPeriod(int64)
+	SetLinuxMemoryLimit(int64)
+	SetLinuxMemoryReservation(int64)
+	AddLinuxUnified(string, string)
+	SetLinuxBlockIOClass(string)
+	SetLinuxRDTClass(string)
+}
+
+var (
+	_ = stub.ConfigureInterface(&plugin{})
+	_ = stub.SynchronizeInterface(&plugin{})
+	_ = stub.CreateContainerInterface(&plugin{})
+	_ = stub.UpdateContainerInterface(&plugin{})
+	_ = stub.RemoveContainerInterface(&plugin{})
+
+	_ resourceSetter = &api.ContainerAdjustment{}
+	_ resourceSetter = &api.ContainerUpdate{}
+)
+
+func newPlugin() *plugin {
+	return &plugin{
+		classes:  map[string]*class{},
+		assigned: map[string]string{},
+	}
+}
+
+// Configure handles plugin configuration.
+func (p *plugin) Configure(_ context.Context, config, runtime, version string) (stub.EventMask, error) {
+	log.Infof("got configuration data %q from runtime %s %s", config, runtime, version)
+	if config == "" {
+		return 0, nil
+	}
+
+	if err := p.setConfig([]byte(config)); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// Synchronize assigns existing containers to their annotated QoS classes.
+func (p *plugin) Synchronize(_ context.Context, pods []*api.PodSandbox, containers []*api.Container) ([]*api.ContainerUpdate, error) {
+	podMap := map[string]*api.PodSandbox{}
+	for _, pod := range pods {
+		podMap[pod.Id] = pod
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	var updates []*api.ContainerUpdate
+	for _, ctr := range containers {
+		pod := podMap[ctr.PodSandboxId]
+		name, c, err := p.classOf(pod, ctr)
+		if err != nil {
+			log.Warnf("%s: %v", containerName(pod, ctr), err)
+			continue
+		}
+		if c == nil {
+			continue
+		}
+
+		p.assigned[ctr.Id] = name
+		updates = append(updates, c.update(ctr.Id))
+		log.Infof("%s: assigned to QoS class %q", containerName(pod, ctr), name)
+	}
+
+	return updates, nil
+}
+
+// CreateContainer applies the annotated QoS class to a container.
+func (p *plugin) CreateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	if verbose {
+		dump("CreateContainer", "pod", pod, "container", ctr)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	name, c, err := p.classOf(pod, ctr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c == nil {
+		log.Debugf("%s: no QoS class annotated...", containerName(pod, ctr))
+		return nil, nil, nil
+	}
+
+	adjust := &api.ContainerAdjustment{}
+	c.apply(adjust)
+	adjust.AddAnnotation(qosClassKey+"/assigned", name)
+
+	p.assigned[ctr.Id] = name
+	log.Infof("%s: assigned to QoS class %q", containerName(pod, ctr), name)
+
+	if verbose {
+		dump(containerName(pod, ctr), "ContainerAdjustment", adjust)
+	}
+
+	return adjust, nil, nil
+}
+
+// UpdateContainer reasserts the QoS class of a container being updated.
+func (p *plugin) UpdateContainer(_ context.Context, pod *api.PodSandbox, ctr *api.Container, _ *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	name, ok := p.assigned[ctr.Id]
+	if !ok {
+		return nil, nil
+	}
+
+	c, ok := p.classes[name]
+	if !ok {
+		log.Warnf("%s: QoS class %q is gone", containerName(pod, ctr), name)
+		return nil, nil
+	}
+
+	log.Infof("%s: reasserting QoS class %q", containerName(pod, ctr), name)
+
+	return []*api.ContainerUpdate{c.update(ctr.Id)}, nil
+}
+
+// RemoveContainer forgets the QoS class of a removed container.
+func (p *plugin) RemoveContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.assigned, ctr.Id)
+	return nil
+}
+
+// reload takes a new configuration into use, updating any affected containers.
+func (p *plugin) reload(data []byte) error {
+	if err := p.setConfig(data); err != nil {
+		return err
+	}
+
+	p.Lock()
+	var updates []*api.ContainerUpdate
+	for id, name := range p.assigned {
+		if c, ok := p.classes[name]; ok {
+			updates = append(updates, c.update(id))
+		}
+	}
+	p.Unlock()
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	failed, err := p.stub.UpdateContainers(updates)
+	if err != nil {
+		return fmt.Errorf("failed to update containers: %w", err)
+	}
+	for _, u := range failed {
+		log.Warnf("failed to update container %s", u.ContainerId)
+	}
+
+	return nil
+}
+
+// setConfig parses and takes into use the given configuration.
+func (p *plugin) setConfig(data []byte) error {
+	classes, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.classes = classes
+
+	return nil
+}
+
+// classOf returns the QoS class annotated for a container.
+func (p *plugin) classOf(pod *api.PodSandbox, ctr *api.Container) (string, *class, error) {
+	name, ok := getAnnotation(pod.GetAnnotations(), qosClassKey, ctr.Name)
+	if !ok {
+		return "", nil, nil
+	}
+
+	c, ok := p.classes[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown QoS class %q", name)
+	}
+
+	return name, c, nil
+}
+
+func parseConfig(data []byte) (map[string]*class, error) {
+	cfg := config{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	for name, c := range cfg.Classes {
+		if c == nil {
+			return nil, fmt.Errorf("invalid configuration: empty QoS class %q", name)
+		}
+		if c.IOWeight > 10000 {
+			return nil, fmt.Errorf("invalid configuration: QoS class %q: io weight %d not in [1, 10000]",
+				name, c.IOWeight)
+		}
+	}
+
+	if cfg.Classes == nil {
+		cfg.Classes = map[string]*class{}
+	}
+
+	return cfg.Classes, nil
+}
+
+func getAnnotation(annotations map[string]string, mainKey, ctr string) (string, bool) {
+	for _, key := range []string{
+		mainKey + "/container." + ctr,
+		mainKey + "/pod",
+		mainKey,
+	} {
+		if value, ok := annotations[key]; ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// Apply the resources of a QoS class to a container adjustment or update.
+func (c *class) apply(r resourceSetter) {
+	if c.CPUShares != 0 {
+		r.SetLinuxCPUShares(c.CPUShares)
+	}
+	if c.CPUQuota != 0 {
+		r.SetLinuxCPUQuota(c.CPUQuota)
+	}
+	if c.CPUPeriod != 0 {
+		r.SetLinuxCPUPeriod(c.CPUPeriod)
+	}
+	if c.MemoryLimit != 0 {
+		r.SetLinuxMemoryLimit(c.MemoryLimit)
+	}
+	if c.MemoryReservation != 0 {
+		r.SetLinuxMemoryReservation(c.MemoryReservation)
+	}
+	if c.IOWeight != 0 {
+		r.AddLinuxUnified("io.weight", fmt.Sprintf("default %d", c.IOWeight))
+	}
+	if c.BlockIOClass != "" {
+		r.SetLinuxBlockIOClass(c.BlockIOClass)
+	}
+	if c.RDTClass != "" {
+		r.SetLinuxRDTClass(c.RDTClass)
+	}
+}
+
+// Create an update applying the resources of a QoS class to a container.
+func (c *class) update(id string) *api.ContainerUpdate {
+	u := &api.ContainerUpdate{}
+	u.SetContainerId(id)
+	c.apply(u)
+	return u
+}
+
+// Construct a container name for log messages.
+func containerName(pod *api.PodSandbox, container *api.Container) string {
+	if pod != nil {
+		return pod.Name + "/" + container.Name
+	}
+	return container.Name
+}
+
+// Dump one or more objects, with an optional global prefix and per-object tags.
+func dump(args ...interface{}) {
+	var (
+		prefix string
+		idx    int
+	)
+
+	if len(args)&0x1 == 1 {
+		prefix = args[0].(string)
+		idx++
+	}
+
+	for ; idx < len(args)-1; idx += 2 {
+		tag, obj := args[idx], args[idx+1]
+		msg, err := yaml.Marshal(obj)
+		if err != nil {
+			log.Infof("%s: %s: failed to dump object: %v", prefix, tag, err)
+			continue
+		}
+
+		log.Infof("%s: %s: %s", prefix, tag, string(msg))
+	}
+}
+
+func main() {
+	var (
+		opts []stub.Option
+		err  error
+	)
+
+	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
+
+	p := newPlugin()
+
+	data, err := options.ReadConfig()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if data != nil {
+		if err := p.setConfig(data); err != nil {
+			log.Fatalf("failed to load configuration file %q: %v", options.ConfigFile, err)
+		}
+	}
+
+	if p.stub, err = stub.New(p, opts...); err != nil {
+		log.Fatalf("failed to create plugin stub: %v", err)
+	}
+
+	if configFile := options.ConfigFile; configFile != "" {
+		go func() {
+			sigC := make(chan os.Signal, 1)
+			signal.Notify(sigC, syscall.SIGHUP)
+			for range sigC {
+				log.Infof("reloading configuration file %q...", configFile)
+				data, err := os.ReadFile(configFile)
+				if err == nil {
+					err = p.reload(data)
+				}
+				if err != nil {
+					log.Errorf("failed to reload configuration file %q: %v", configFile, err)
+				}
+			}
+		}()
+	}
+
+	err = p.stub.Run(context.Background())
+	if err != nil {
+		log.Errorf("plugin exited with error %v", err)
+		os.Exit(1)
+	}
+}
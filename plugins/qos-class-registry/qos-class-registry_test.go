@@ -0,0 +1,211 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const testConfig = `
+classes:
+  gold:
+    cpuShares: 2048
+    memoryLimit: 4294967296
+    ioWeight: 500
+    blockioClass: fast
+    rdtClass: gold
+  bronze:
+    cpuShares: 256
+    cpuQuota: 50000
+    cpuPeriod: 100000
+`
+
+func TestParseConfig(t *testing.T) {
+	type testCase struct {
+		name    string
+		config  string
+		classes map[string]*class
+		fail    bool
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name:    "empty configuration",
+			config:  "",
+			classes: map[string]*class{},
+		},
+		{
+			name:   "valid configuration",
+			config: testConfig,
+			classes: map[string]*class{
+				"gold": {
+					CPUShares:    2048,
+					MemoryLimit:  4294967296,
+					IOWeight:     500,
+					BlockIOClass: "fast",
+					RDTClass:     "gold",
+				},
+				"bronze": {
+					CPUShares: 256,
+					CPUQuota:  50000,
+					CPUPeriod: 100000,
+				},
+			},
+		},
+		{
+			name: "invalid io weight",
+			config: `
+classes:
+  heavy:
+    ioWeight: 20000
+`,
+			fail: true,
+		},
+		{
+			name: "empty class",
+			config: `
+classes:
+  empty:
+`,
+			fail: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			classes, err := parseConfig([]byte(tc.config))
+			if tc.fail {
+				require.NotNil(t, err, "expected configuration error")
+				return
+			}
+			require.Nil(t, err, "configuration error")
+			require.Equal(t, tc.classes, classes, "parsed classes")
+		})
+	}
+}
+
+func TestCreateContainer(t *testing.T) {
+	type testCase struct {
+		name        string
+		annotations map[string]string
+		expected    *api.ContainerAdjustment
+		fail        bool
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "no annotated class",
+		},
+		{
+			name: "container class",
+			annotations: map[string]string{
+				"qos-class.nri.io/container.ctr0": "gold",
+				"qos-class.nri.io/pod":            "bronze",
+			},
+			expected: &api.ContainerAdjustment{
+				Annotations: map[string]string{
+					"qos-class.nri.io/assigned": "gold",
+				},
+				Linux: &api.LinuxContainerAdjustment{
+					Resources: &api.LinuxResources{
+						Cpu: &api.LinuxCPU{
+							Shares: api.UInt64(2048),
+						},
+						Memory: &api.LinuxMemory{
+							Limit: api.Int64(4294967296),
+						},
+						Unified: map[string]string{
+							"io.weight": "default 500",
+						},
+						BlockioClass: api.String("fast"),
+						RdtClass:     api.String("gold"),
+					},
+				},
+			},
+		},
+		{
+			name: "pod class",
+			annotations: map[string]string{
+				"qos-class.nri.io/container.ctr1": "gold",
+				"qos-class.nri.io/pod":            "bronze",
+			},
+			expected: &api.ContainerAdjustment{
+				Annotations: map[string]string{
+					"qos-class.nri.io/assigned": "bronze",
+				},
+				Linux: &api.LinuxContainerAdjustment{
+					Resources: &api.LinuxResources{
+						Cpu: &api.LinuxCPU{
+							Shares: api.UInt64(256),
+							Quota:  api.Int64(50000),
+							Period: api.UInt64(100000),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown class",
+			annotations: map[string]string{
+				"qos-class.nri.io": "platinum",
+			},
+			fail: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newPlugin()
+			require.Nil(t, p.setConfig([]byte(testConfig)), "configuration error")
+
+			pod := &api.PodSandbox{
+				Id:          "pod0",
+				Name:        "pod0",
+				Annotations: tc.annotations,
+			}
+			ctr := &api.Container{
+				Id:           "ctr0-id",
+				PodSandboxId: "pod0",
+				Name:         "ctr0",
+			}
+
+			adjust, _, err := p.CreateContainer(context.Background(), pod, ctr)
+			if tc.fail {
+				require.NotNil(t, err, "expected container creation error")
+				return
+			}
+			require.Nil(t, err, "container creation error")
+			require.True(t, proto.Equal(tc.expected, adjust), "container adjustment %v", adjust)
+
+			if tc.expected != nil {
+				updates, err := p.UpdateContainer(context.Background(), pod, ctr, nil)
+				require.Nil(t, err, "container update error")
+				require.Len(t, updates, 1, "container updates")
+				require.Equal(t, ctr.Id, updates[0].ContainerId, "updated container")
+				require.True(t, proto.Equal(tc.expected.Linux.Resources, updates[0].Linux.Resources),
+					"updated resources %v", updates[0].Linux.Resources)
+			}
+
+			require.Nil(t, p.RemoveContainer(context.Background(), pod, ctr), "container removal error")
+			updates, err := p.UpdateContainer(context.Background(), pod, ctr, nil)
+			require.Nil(t, err, "container update error")
+			require.Empty(t, updates, "updates of removed container")
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/log"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// seccompKey is the annotation key prefix for seccomp profiles.
+	seccompKey = "seccomp.nri.io"
+	// localhostPrefix is the optional prefix of profile file references.
+	localhostPrefix = "localhost/"
+	// defaultProfileRoot is the default directory of profile files.
+	defaultProfileRoot = "/etc/nri/seccomp"
+)
+
+func main() {
+	var (
+		verbose bool
+		opts    []stub.Option
+		err     error
+	)
+
+	l := logrus.StandardLogger()
+
+	p := &plugin{}
+
+	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
+	flag.StringVar(&p.profileRoot, "profile-root", defaultProfileRoot,
+		"directory of seccomp profile files referenced by annotations")
+	flag.BoolVar(&p.override, "override", false,
+		"also inject profiles into containers requesting an unconfined or localhost profile")
+	options := cli.Parse()
+	if opts, err = options.Setup(l); err != nil {
+		l.Fatalf("failed to set up plugin: %v", err)
+	}
+	ctx := log.WithLogger(context.Background(), l.WithField("name", options.Name).WithField("idx", options.Idx))
+	log.G(ctx).WithField("verbose", verbose).Info("starting plugin")
+
+	if verbose {
+		l.SetLevel(logrus.DebugLevel)
+	}
+
+	p.l = log.G(ctx)
+	if p.stub, err = stub.New(p, opts...); err != nil {
+		log.G(ctx).Fatalf("failed to create plugin stub: %v", err)
+	}
+
+	if err := p.stub.Run(context.Background()); err != nil {
+		log.G(ctx).Errorf("plugin exited with error %v", err)
+		os.Exit(1)
+	}
+}
+
+type plugin struct {
+	stub        stub.Stub
+	l           *logrus.Entry
+	profileRoot string
+	override    bool
+}
+
+func (p *plugin) CreateContainer(
+	ctx context.Context,
+	pod *api.PodSandbox,
+	container *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	l := p.l.WithField("pod", pod.GetName()).WithField("container", container.GetName())
+	ctx = log.WithLogger(ctx, l)
+	log.G(ctx).Debug("create container")
+
+	val, ok := getAnnotation(pod.GetAnnotations(), container.GetName())
+	if !ok {
+		log.G(ctx).Debug("no seccomp annotation found")
+		return nil, nil, nil
+	}
+
+	if !p.injectable(ctx, container.GetLinux().GetSeccompProfile()) {
+		return nil, nil, nil
+	}
+
+	profile, err := p.loadProfile(val)
+	if err != nil {
+		log.G(ctx).WithError(err).Debug("failed to load seccomp profile")
+		return nil, nil, err
+	}
+
+	log.G(ctx).WithField("defaultAction", profile.DefaultAction).
+		WithField("syscalls", len(profile.Syscalls)).Debug("inject seccomp profile")
+
+	adjust := &api.ContainerAdjustment{}
+	adjust.SetLinuxSeccompPolicy(api.FromOCILinuxSeccomp(profile))
+
+	return adjust, nil, nil
+}
+
+// getAnnotation returns the seccomp annotation of the container.
+func getAnnotation(annotations map[string]string, container string) (string, bool) {
+	val, ok := annotations[seccompKey+"/container."+container]
+	return val, ok
+}
+
+// injectable checks if a profile can be injected into a container with the
+// given requested security profile. Containers using the runtime default
+// profile get the injected one. Containers requesting an unconfined or a
+// localhost profile keep it unless overriding is enabled.
+func (p *plugin) injectable(ctx context.Context, requested *api.SecurityProfile) bool {
+	switch typ := requested.GetProfileType(); typ {
+	case api.SecurityProfile_RUNTIME_DEFAULT:
+		return true
+	default:
+		if p.override {
+			log.G(ctx).WithField("profileType", typ).Info("overriding requested seccomp profile")
+			return true
+		}
+		log.G(ctx).WithField("profileType", typ).Info("keeping requested seccomp profile")
+		return false
+	}
+}
+
+// loadProfile loads the seccomp profile given by an annotation, either
+// inline JSON or a reference to a profile file. File references, optionally
+// prefixed with localhost/, are resolved within the profile root.
+func (p *plugin) loadProfile(val string) (*rspec.LinuxSeccomp, error) {
+	var data []byte
+
+	val = strings.TrimSpace(val)
+	if strings.HasPrefix(val, "{") {
+		data = []byte(val)
+	} else {
+		path, err := p.profilePath(val)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read seccomp profile: %w", err)
+		}
+	}
+
+	return parseProfile(data)
+}
+
+// profilePath returns the path of a referenced profile file. References
+// must stay within the profile root, so pod annotations can't be used to
+// read arbitrary files on the host.
+func (p *plugin) profilePath(ref string) (string, error) {
+	ref = strings.TrimPrefix(ref, localhostPrefix)
+	if ref == "" {
+		return "", fmt.Errorf("empty seccomp profile reference")
+	}
+	if !filepath.IsLocal(ref) {
+		return "", fmt.Errorf("seccomp profile %q outside of profile root", ref)
+	}
+	return filepath.Join(p.profileRoot, ref), nil
+}
+
+// parseProfile parses a seccomp profile in OCI Spec JSON format.
+func parseProfile(data []byte) (*rspec.LinuxSeccomp, error) {
+	profile := &rspec.LinuxSeccomp{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile: %w", err)
+	}
+	if profile.DefaultAction == "" {
+		return nil, fmt.Errorf("invalid seccomp profile, no default action")
+	}
+	for _, sc := range profile.Syscalls {
+		if len(sc.Names) == 0 || sc.Action == "" {
+			return nil, fmt.Errorf("invalid seccomp profile, syscall rule without names or action")
+		}
+	}
+	return profile, nil
+}
@@ -0,0 +1,150 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+const testProfile = `{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "architectures": ["SCMP_ARCH_X86_64"],
+  "syscalls": [
+    {"names": ["read", "write", "exit_group"], "action": "SCMP_ACT_ALLOW"}
+  ]
+}`
+
+func newTestPlugin(t *testing.T) *plugin {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "profiles"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "profiles", "audit.json"), []byte(testProfile), 0o644))
+
+	return &plugin{
+		l:           logrus.NewEntry(logrus.StandardLogger()),
+		profileRoot: root,
+	}
+}
+
+func TestCreateContainer(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		requested   *api.SecurityProfile
+		override    bool
+		injected    bool
+		errStr      string
+	}{
+		"no-annotations": {},
+		"other-container": {
+			annotations: map[string]string{"seccomp.nri.io/container.bar": testProfile},
+		},
+		"inline": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": testProfile},
+			injected:    true,
+		},
+		"file": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "profiles/audit.json"},
+			injected:    true,
+		},
+		"localhost-file": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "localhost/profiles/audit.json"},
+			injected:    true,
+		},
+		"missing-file": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "profiles/missing.json"},
+			errStr:      "failed to read seccomp profile",
+		},
+		"file-outside-root": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "../../etc/passwd"},
+			errStr:      "outside of profile root",
+		},
+		"absolute-file": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "/etc/passwd"},
+			errStr:      "outside of profile root",
+		},
+		"invalid-json": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": "{"},
+			errStr:      "failed to parse seccomp profile",
+		},
+		"no-default-action": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": `{"syscalls": []}`},
+			errStr:      "no default action",
+		},
+		"runtime-default": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": testProfile},
+			requested:   &api.SecurityProfile{ProfileType: api.SecurityProfile_RUNTIME_DEFAULT},
+			injected:    true,
+		},
+		"unconfined": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": testProfile},
+			requested:   &api.SecurityProfile{ProfileType: api.SecurityProfile_UNCONFINED},
+		},
+		"localhost": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": testProfile},
+			requested: &api.SecurityProfile{
+				ProfileType:  api.SecurityProfile_LOCALHOST,
+				LocalhostRef: "profiles/other.json",
+			},
+		},
+		"unconfined-override": {
+			annotations: map[string]string{"seccomp.nri.io/container.foo": testProfile},
+			requested:   &api.SecurityProfile{ProfileType: api.SecurityProfile_UNCONFINED},
+			override:    true,
+			injected:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newTestPlugin(t)
+			p.override = tc.override
+
+			pod := &api.PodSandbox{Name: "pod", Annotations: tc.annotations}
+			ctr := &api.Container{Name: "foo"}
+			if tc.requested != nil {
+				ctr.Linux = &api.LinuxContainer{SeccompProfile: tc.requested}
+			}
+
+			adjust, _, err := p.CreateContainer(context.Background(), pod, ctr)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			require.NoError(t, err)
+
+			if !tc.injected {
+				assert.Nil(t, adjust.GetLinux().GetSeccompPolicy())
+				return
+			}
+
+			policy := adjust.GetLinux().GetSeccompPolicy()
+			require.NotNil(t, policy)
+			assert.Equal(t, "SCMP_ACT_ERRNO", policy.DefaultAction)
+			assert.Equal(t, []string{"SCMP_ARCH_X86_64"}, policy.Architectures)
+			require.Len(t, policy.Syscalls, 1)
+			assert.Equal(t, []string{"read", "write", "exit_group"}, policy.Syscalls[0].Names)
+			assert.Equal(t, "SCMP_ACT_ALLOW", policy.Syscalls[0].Action)
+		})
+	}
+}
@@ -28,11 +28,13 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 )
 
 const (
 	ulimitKey    = "ulimits.nri.containerd.io"
+	rlimitKey    = "rlimits.nri.io"
 	rlimitPrefix = "RLIMIT_"
 )
 
@@ -59,37 +61,26 @@ var (
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		verbose    bool
-		opts       []stub.Option
+		verbose bool
+		opts    []stub.Option
+		err     error
 	)
 
 	l := logrus.StandardLogger()
-	l.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
 	flag.BoolVar(&verbose, "verbose", false, "enable (more) verbose logging")
-	flag.Parse()
-	ctx := log.WithLogger(context.Background(), l.WithField("name", pluginName).WithField("idx", pluginIdx))
+	options := cli.Parse()
+	if opts, err = options.Setup(l); err != nil {
+		l.Fatalf("failed to set up plugin: %v", err)
+	}
+	ctx := log.WithLogger(context.Background(), l.WithField("name", options.Name).WithField("idx", options.Idx))
 	log.G(ctx).WithField("verbose", verbose).Info("starting plugin")
 
 	if verbose {
 		l.SetLevel(logrus.DebugLevel)
 	}
 
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
-	}
-
 	p := &plugin{l: log.G(ctx)}
-	var err error
 	if p.stub, err = stub.New(p, opts...); err != nil {
 		log.G(ctx).Fatalf("failed to create plugin stub: %v", err)
 	}
@@ -138,10 +129,9 @@ type ulimit struct {
 }
 
 func parseUlimits(ctx context.Context, container string, annotations map[string]string) ([]ulimit, error) {
-	key := ulimitKey + "/container." + container
-	val, ok := annotations[key]
+	val, ok := getAnnotation(annotations, container)
 	if !ok {
-		log.G(ctx).Debugf("no annotations found with key %q", key)
+		log.G(ctx).Debugf("no ulimit annotations found for container %q", container)
 		return nil, nil
 	}
 	ulimits := make([]ulimit, 0)
@@ -160,6 +150,20 @@ func parseUlimits(ctx context.Context, container string, annotations map[string]
 	return ulimits, nil
 }
 
+// getAnnotation returns the ulimit annotation of the container, preferring
+// the original ulimits.nri.containerd.io key over the rlimits.nri.io one.
+func getAnnotation(annotations map[string]string, container string) (string, bool) {
+	for _, key := range []string{
+		ulimitKey + "/container." + container,
+		rlimitKey + "/container." + container,
+	} {
+		if val, ok := annotations[key]; ok {
+			return val, true
+		}
+	}
+	return "", false
+}
+
 func adjustUlimits(ctx context.Context, ulimits []ulimit) (*api.ContainerAdjustment, error) {
 	adjust := &api.ContainerAdjustment{}
 	for _, u := range ulimits {
@@ -46,6 +46,39 @@ func TestParseAnnotations(t *testing.T) {
 - type: RLIMIT_NOFILE
   soft: 123
   hard: 456
+`},
+			expected: []ulimit{{
+				Type: "RLIMIT_NOFILE",
+				Hard: 456,
+				Soft: 123,
+			}},
+		},
+		"rlimits-key": {
+			container: "foo",
+			annotations: map[string]string{
+				"rlimits.nri.io/container.foo": `
+- type: nproc
+  soft: 64
+  hard: 128
+`},
+			expected: []ulimit{{
+				Type: "RLIMIT_NPROC",
+				Hard: 128,
+				Soft: 64,
+			}},
+		},
+		"ulimits-key-preferred": {
+			container: "foo",
+			annotations: map[string]string{
+				"ulimits.nri.containerd.io/container.foo": `
+- type: RLIMIT_NOFILE
+  soft: 123
+  hard: 456
+`,
+				"rlimits.nri.io/container.foo": `
+- type: RLIMIT_NOFILE
+  soft: 1
+  hard: 2
 `},
 			expected: []ulimit{{
 				Type: "RLIMIT_NOFILE",
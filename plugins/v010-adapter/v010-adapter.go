@@ -18,13 +18,13 @@ package main
 
 import (
 	"context"
-	"flag"
 	"os"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/containerd/nri"
 	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin/cli"
 	"github.com/containerd/nri/pkg/stub"
 	nriv1 "github.com/containerd/nri/types/v1"
 	oci "github.com/opencontainers/runtime-spec/specs-go"
@@ -195,30 +195,19 @@ func namespacesToSlice(namespaces []*api.LinuxNamespace) []oci.LinuxNamespace {
 
 func main() {
 	var (
-		pluginName string
-		pluginIdx  string
-		err        error
+		opts []stub.Option
+		err  error
 	)
 
 	log = logrus.StandardLogger()
-	log.SetFormatter(&logrus.TextFormatter{
-		PadLevelText: true,
-	})
 
-	flag.StringVar(&pluginName, "name", "", "plugin name to register to NRI")
-	flag.StringVar(&pluginIdx, "idx", "", "plugin index to register to NRI")
-	flag.Parse()
+	options := cli.Parse()
+	if opts, err = options.Setup(log); err != nil {
+		log.Fatalf("failed to set up plugin: %v", err)
+	}
 
 	p := &plugin{}
-	opts := []stub.Option{
-		stub.WithOnClose(p.onClose),
-	}
-	if pluginName != "" {
-		opts = append(opts, stub.WithPluginName(pluginName))
-	}
-	if pluginIdx != "" {
-		opts = append(opts, stub.WithPluginIdx(pluginIdx))
-	}
+	opts = append(opts, stub.WithOnClose(p.onClose))
 
 	if p.stub, err = stub.New(p, opts...); err != nil {
 		log.Fatalf("failed to create plugin stub: %v", err)
@@ -50,6 +50,20 @@ func (p *plugin) Shutdown(ctx context.Context, req *api.Empty) (*api.Empty, erro
 	return nil, nil
 }
 
+func (p *plugin) SetLeadership(ctx context.Context, req *api.SetLeadershipRequest) (*api.Empty, error) {
+	log(ctx, "Got set leadership request")
+	return nil, nil
+}
+
+func (p *plugin) Ping(ctx context.Context, req *api.Empty) (*api.Empty, error) {
+	return &api.Empty{}, nil
+}
+
+func (p *plugin) ReconfigurePlugin(ctx context.Context, req *api.ReconfigurePluginRequest) (*api.Empty, error) {
+	log(ctx, "Got reconfigure plugin request")
+	return &api.Empty{}, nil
+}
+
 func (p *plugin) StateChange(ctx context.Context, req *api.StateChangeEvent) (*api.Empty, error) {
 	log(ctx, "Got state change request with event: "+req.GetEvent().String())
 
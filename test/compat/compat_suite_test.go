@@ -0,0 +1,237 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compat_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nri "github.com/containerd/nri/pkg/adaptation"
+	"github.com/containerd/nri/pkg/api"
+)
+
+const (
+	// pluginDirEnvVar is the directory with plugin binaries, one per version.
+	pluginDirEnvVar = "NRI_COMPAT_PLUGINS"
+	// versionsEnvVar optionally limits the versions tested.
+	versionsEnvVar = "NRI_COMPAT_VERSIONS"
+
+	// these must match the ones used by the plugin
+	versionAnnotation = "compat.nri.io/version"
+	versionEnv        = "NRI_COMPAT_VERSION"
+
+	pluginName = "10-compat"
+)
+
+func TestCompat(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cross-Version Compatibility Suite")
+}
+
+// compatPlugins returns the plugin binaries to test, by version.
+func compatPlugins() map[string]string {
+	dir := os.Getenv(pluginDirEnvVar)
+	if dir == "" {
+		return nil
+	}
+
+	plugins := map[string]string{}
+	if versions := strings.Fields(os.Getenv(versionsEnvVar)); len(versions) > 0 {
+		for _, v := range versions {
+			plugins[v] = filepath.Join(dir, v)
+		}
+		return plugins
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		panic("failed to read compatibility test plugins: " + err.Error())
+	}
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			plugins[e.Name()] = filepath.Join(dir, e.Name())
+		}
+	}
+	return plugins
+}
+
+var _ = Describe("Plugins built with released versions", func() {
+	plugins := compatPlugins()
+	if len(plugins) == 0 {
+		It("should be tested if available", func() {
+			Skip("no compatibility test plugins, set " + pluginDirEnvVar + " or run test/compat/run.sh")
+		})
+		return
+	}
+
+	versions := make([]string, 0, len(plugins))
+	for v := range plugins {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+
+	for _, v := range versions {
+		version, binary := v, plugins[v]
+
+		When("built with NRI "+version, func() {
+			var (
+				ctx     = context.Background()
+				runtime *nri.Adaptation
+				events  string
+				pod     *api.PodSandbox
+				ctr     *api.Container
+			)
+
+			existingPod := &api.PodSandbox{Id: "pod0", Name: "pod0", Uid: "uid0", Namespace: "default"}
+			existingCtr := &api.Container{Id: "ctr0", PodSandboxId: "pod0", Name: "ctr0",
+				State: api.ContainerState_CONTAINER_RUNNING}
+
+			BeforeEach(func() {
+				pod = &api.PodSandbox{Id: "pod1", Name: "pod1", Uid: "uid1", Namespace: "default"}
+				ctr = &api.Container{Id: "ctr1", PodSandboxId: "pod1", Name: "ctr1"}
+
+				dir := GinkgoT().TempDir()
+				pluginPath := filepath.Join(dir, "plugins")
+				configPath := filepath.Join(dir, "conf.d")
+				events = filepath.Join(dir, "events")
+
+				Expect(os.MkdirAll(pluginPath, 0o755)).To(Succeed())
+				Expect(os.MkdirAll(configPath, 0o755)).To(Succeed())
+
+				data, err := os.ReadFile(binary)
+				Expect(err).To(BeNil(), "plugin binary for %s", version)
+				Expect(os.WriteFile(filepath.Join(pluginPath, pluginName), data, 0o755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(configPath, pluginName+".conf"), []byte(events), 0o644)).To(Succeed())
+
+				runtime, err = nri.New("compat-runtime", "0.0.1",
+					func(ctx context.Context, cb nri.SyncCB) error {
+						_, err := cb(ctx, []*api.PodSandbox{existingPod}, []*api.Container{existingCtr})
+						return err
+					},
+					func(context.Context, []*nri.ContainerUpdate) ([]*nri.ContainerUpdate, error) {
+						return nil, nil
+					},
+					nri.WithPluginPath(pluginPath),
+					nri.WithPluginConfigPath(configPath),
+					nri.WithSocketPath(filepath.Join(dir, "nri.sock")),
+				)
+				Expect(err).To(BeNil())
+				Expect(runtime.Start()).To(Succeed())
+				DeferCleanup(runtime.Stop)
+			})
+
+			recorded := func() []string {
+				data, err := os.ReadFile(events)
+				if os.IsNotExist(err) {
+					return nil
+				}
+				Expect(err).To(BeNil())
+				var lines []string
+				for _, l := range strings.Split(string(data), "\n") {
+					if l = strings.TrimSpace(l); l != "" {
+						lines = append(lines, l)
+					}
+				}
+				return lines
+			}
+
+			It("should register and synchronize", func() {
+				var names []string
+				for _, s := range runtime.PluginStatus() {
+					names = append(names, s.Name)
+				}
+				Expect(names).To(ContainElement(pluginName))
+
+				Eventually(recorded).WithTimeout(5 * time.Second).Should(Equal([]string{
+					"Configure",
+					"Synchronize pod0",
+					"Synchronize ctr0",
+				}))
+			})
+
+			It("should receive pod and container lifecycle events", func() {
+				Expect(runtime.RunPodSandbox(ctx, &nri.StateChangeEvent{Pod: pod})).To(Succeed())
+
+				rpl, err := runtime.CreateContainer(ctx, &nri.CreateContainerRequest{
+					Pod:       pod,
+					Container: ctr,
+				})
+				Expect(err).To(BeNil())
+				Expect(rpl.GetAdjust().GetAnnotations()).To(HaveKeyWithValue(versionAnnotation, version))
+				var env []string
+				for _, kv := range rpl.GetAdjust().GetEnv() {
+					env = append(env, kv.Key+"="+kv.Value)
+				}
+				Expect(env).To(ContainElement(versionEnv + "=" + version))
+
+				ctr.State = api.ContainerState_CONTAINER_CREATED
+				Expect(runtime.PostCreateContainer(ctx, &nri.StateChangeEvent{Pod: pod, Container: ctr})).To(Succeed())
+				Expect(runtime.StartContainer(ctx, &nri.StateChangeEvent{Pod: pod, Container: ctr})).To(Succeed())
+				ctr.State = api.ContainerState_CONTAINER_RUNNING
+				Expect(runtime.PostStartContainer(ctx, &nri.StateChangeEvent{Pod: pod, Container: ctr})).To(Succeed())
+
+				_, err = runtime.UpdateContainer(ctx, &nri.UpdateContainerRequest{
+					Pod:       pod,
+					Container: ctr,
+					LinuxResources: &api.LinuxResources{
+						Memory: &api.LinuxMemory{Limit: api.Int64(64 * 1024 * 1024)},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(runtime.PostUpdateContainer(ctx, &nri.StateChangeEvent{Pod: pod, Container: ctr})).To(Succeed())
+
+				_, err = runtime.StopContainer(ctx, &nri.StopContainerRequest{Pod: pod, Container: ctr})
+				Expect(err).To(BeNil())
+				ctr.State = api.ContainerState_CONTAINER_STOPPED
+				Expect(runtime.RemoveContainer(ctx, &nri.StateChangeEvent{Pod: pod, Container: ctr})).To(Succeed())
+				Expect(runtime.StopPodSandbox(ctx, &nri.StateChangeEvent{Pod: pod})).To(Succeed())
+				Expect(runtime.RemovePodSandbox(ctx, &nri.StateChangeEvent{Pod: pod})).To(Succeed())
+
+				Eventually(recorded).WithTimeout(5 * time.Second).Should(Equal([]string{
+					"Configure",
+					"Synchronize pod0",
+					"Synchronize ctr0",
+					"RunPodSandbox pod1",
+					"CreateContainer ctr1",
+					"PostCreateContainer ctr1",
+					"StartContainer ctr1",
+					"PostStartContainer ctr1",
+					"UpdateContainer ctr1",
+					"PostUpdateContainer ctr1",
+					"StopContainer ctr1",
+					"RemoveContainer ctr1",
+					"StopPodSandbox pod1",
+					"RemovePodSandbox pod1",
+				}))
+
+				var names []string
+				for _, s := range runtime.PluginStatus() {
+					names = append(names, s.Name)
+				}
+				Expect(names).To(ContainElement(pluginName), "plugin disconnected")
+			})
+		})
+	}
+})
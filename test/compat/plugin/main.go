@@ -0,0 +1,167 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// The compatibility test plugin is built against released versions of NRI
+// and run against the current runtime adaptation. It only uses parts of the
+// stub and the API which are available in all versions tested. The plugin
+// logs the events it receives to the file given as its configuration, and
+// adjusts created containers, so the tests can verify both directions of
+// the protocol.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/stub"
+)
+
+const (
+	// VersionAnnotation is set to the NRI version the plugin was built with.
+	VersionAnnotation = "compat.nri.io/version"
+	// VersionEnv is set to the NRI version the plugin was built with.
+	VersionEnv = "NRI_COMPAT_VERSION"
+)
+
+var (
+	// version of NRI the plugin was built with, set by the build script
+	version = "unknown"
+)
+
+type plugin struct {
+	sync.Mutex
+	log *os.File
+}
+
+func (p *plugin) Configure(_ context.Context, config, _, _ string) (api.EventMask, error) {
+	path := strings.TrimSpace(config)
+	if path == "" {
+		return 0, fmt.Errorf("no event log configured")
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open event log: %w", err)
+	}
+	p.log = f
+
+	p.record("Configure", "")
+	return 0, nil
+}
+
+func (p *plugin) Synchronize(_ context.Context, pods []*api.PodSandbox, ctrs []*api.Container) ([]*api.ContainerUpdate, error) {
+	for _, pod := range pods {
+		p.record("Synchronize", pod.GetName())
+	}
+	for _, ctr := range ctrs {
+		p.record("Synchronize", ctr.GetName())
+	}
+	return nil, nil
+}
+
+func (p *plugin) Shutdown(_ context.Context) {
+	p.record("Shutdown", "")
+}
+
+func (p *plugin) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.record("RunPodSandbox", pod.GetName())
+	return nil
+}
+
+func (p *plugin) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.record("StopPodSandbox", pod.GetName())
+	return nil
+}
+
+func (p *plugin) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	p.record("RemovePodSandbox", pod.GetName())
+	return nil
+}
+
+func (p *plugin) CreateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) (*api.ContainerAdjustment, []*api.ContainerUpdate, error) {
+	p.record("CreateContainer", ctr.GetName())
+
+	adjust := &api.ContainerAdjustment{}
+	adjust.AddAnnotation(VersionAnnotation, version)
+	adjust.AddEnv(VersionEnv, version)
+
+	return adjust, nil, nil
+}
+
+func (p *plugin) PostCreateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.record("PostCreateContainer", ctr.GetName())
+	return nil
+}
+
+func (p *plugin) StartContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.record("StartContainer", ctr.GetName())
+	return nil
+}
+
+func (p *plugin) PostStartContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.record("PostStartContainer", ctr.GetName())
+	return nil
+}
+
+func (p *plugin) UpdateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container, _ *api.LinuxResources) ([]*api.ContainerUpdate, error) {
+	p.record("UpdateContainer", ctr.GetName())
+	return nil, nil
+}
+
+func (p *plugin) PostUpdateContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.record("PostUpdateContainer", ctr.GetName())
+	return nil
+}
+
+func (p *plugin) StopContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) ([]*api.ContainerUpdate, error) {
+	p.record("StopContainer", ctr.GetName())
+	return nil, nil
+}
+
+func (p *plugin) RemoveContainer(_ context.Context, _ *api.PodSandbox, ctr *api.Container) error {
+	p.record("RemoveContainer", ctr.GetName())
+	return nil
+}
+
+// record an event in the event log, one event per line.
+func (p *plugin) record(event, name string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.log == nil {
+		return
+	}
+	fmt.Fprintf(p.log, "%s %s\n", event, name)
+}
+
+func main() {
+	p := &plugin{}
+
+	s, err := stub.New(p, stub.WithOnClose(func() { os.Exit(0) }))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create plugin stub: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := s.Run(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "plugin exited with error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+#!/usr/bin/env bash
+
+#   Copyright The containerd Authors.
+
+#   Licensed under the Apache License, Version 2.0 (the "License");
+#   you may not use this file except in compliance with the License.
+#   You may obtain a copy of the License at
+
+#       http://www.apache.org/licenses/LICENSE-2.0
+
+#   Unless required by applicable law or agreed to in writing, software
+#   distributed under the License is distributed on an "AS IS" BASIS,
+#   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#   See the License for the specific language governing permissions and
+#   limitations under the License.
+
+
+#
+# Runs cross-version compatibility tests.
+#
+# The compatibility test plugin in plugin/ is built against each released
+# version of NRI to test, then every plugin binary is run against the
+# current runtime adaptation by the tests in this directory. Binaries are
+# cached in the plugin directory and only built if they are missing, so
+# previously built or vendored binaries can be reused.
+#
+# Environment variables:
+#   COMPAT_VERSIONS:   whitespace-separated list of NRI versions to test
+#   COMPAT_PLUGIN_DIR: directory to build or look up plugin binaries in
+#   COMPAT_NO_BUILD:   only use existing plugin binaries, if set to 1
+#
+set -eu -o pipefail
+
+COMPAT_DIR=$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)
+TOP_DIR=$(cd "$COMPAT_DIR/../.." && pwd)
+
+COMPAT_VERSIONS=${COMPAT_VERSIONS:-"v0.6.1 v0.8.0 v0.9.0 v0.11.0"}
+COMPAT_PLUGIN_DIR=${COMPAT_PLUGIN_DIR:-"$TOP_DIR/build/compat"}
+COMPAT_NO_BUILD=${COMPAT_NO_BUILD:-0}
+
+GO_CMD=${GO_CMD:-go}
+
+info() {
+    echo "[compat] $*"
+}
+
+fail() {
+    echo "[compat] FAIL: $*" 1>&2
+    exit 1
+}
+
+# build_plugin <version>
+#   Build the compatibility test plugin against the given NRI version.
+build_plugin() {
+    local version=$1 out="$COMPAT_PLUGIN_DIR/$1" tmp
+    if [ -x "$out" ]; then
+        info "using existing plugin for $version"
+        return 0
+    fi
+    if [ "$COMPAT_NO_BUILD" = 1 ]; then
+        fail "no plugin for $version in $COMPAT_PLUGIN_DIR"
+    fi
+
+    info "building plugin for $version..."
+    tmp=$(mktemp -d)
+    cp "$COMPAT_DIR/plugin/main.go" "$tmp"
+    (
+        cd "$tmp" &&
+        $GO_CMD mod init nri-compat-plugin >/dev/null 2>&1 &&
+        $GO_CMD get "github.com/containerd/nri@$version" &&
+        $GO_CMD mod tidy &&
+        $GO_CMD build -ldflags "-X main.version=$version" -o "$out" .
+    ) || { rm -rf "$tmp"; fail "failed to build plugin for $version"; }
+    rm -rf "$tmp"
+}
+
+command -v "$GO_CMD" >/dev/null || fail "required command $GO_CMD not found"
+
+mkdir -p "$COMPAT_PLUGIN_DIR"
+
+for v in $COMPAT_VERSIONS; do
+    build_plugin "$v"
+done
+
+info "running tests against plugins for $COMPAT_VERSIONS..."
+cd "$TOP_DIR"
+NRI_COMPAT_PLUGINS="$COMPAT_PLUGIN_DIR" NRI_COMPAT_VERSIONS="$COMPAT_VERSIONS" \
+    $GO_CMD test -count=1 -v ./test/compat/
+
+info "all versions passed"
@@ -0,0 +1,221 @@
+#!/usr/bin/env bash
+
+#   Copyright The containerd Authors.
+
+#   Licensed under the Apache License, Version 2.0 (the "License");
+#   you may not use this file except in compliance with the License.
+#   You may obtain a copy of the License at
+
+#       http://www.apache.org/licenses/LICENSE-2.0
+
+#   Unless required by applicable law or agreed to in writing, software
+#   distributed under the License is distributed on an "AS IS" BASIS,
+#   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#   See the License for the specific language governing permissions and
+#   limitations under the License.
+
+
+#
+# Runs end-to-end tests against a real containerd with NRI enabled.
+#
+# A privileged node container is started with containerd in it, sample
+# plugins from plugins/ are installed into it as pre-launched plugins, and
+# then pod/container lifecycle scenarios are run using crictl. Scenarios
+# verify that adjustments requested by plugins show up in the resulting
+# OCI Spec of the containers.
+#
+# Environment variables:
+#   E2E_NODE_IMAGE: node image with containerd and crictl in it
+#   E2E_NODE_NAME:  name of the node container
+#   E2E_TEST_IMAGE: image to use for test containers
+#   E2E_KEEP_NODE:  don't remove the node container when done, if set to 1
+#   E2E_SCENARIOS:  whitespace-separated list of scenarios to run
+#
+set -eu -o pipefail
+
+E2E_DIR=$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)
+TOP_DIR=$(cd "$E2E_DIR/../.." && pwd)
+BIN_DIR="$TOP_DIR/build/bin"
+
+E2E_NODE_IMAGE=${E2E_NODE_IMAGE:-kindest/node:v1.29.2}
+E2E_NODE_NAME=${E2E_NODE_NAME:-nri-e2e}
+E2E_TEST_IMAGE=${E2E_TEST_IMAGE:-docker.io/library/busybox:latest}
+E2E_KEEP_NODE=${E2E_KEEP_NODE:-0}
+E2E_SCENARIOS=${E2E_SCENARIOS:-"device-injection mount-injection ulimit-adjustment"}
+
+PLUGINS="10-device-injector 20-ulimit-adjuster"
+
+info() {
+    echo "[e2e] $*"
+}
+
+fail() {
+    echo "[e2e] FAIL: $*" 1>&2
+    exit 1
+}
+
+node() {
+    docker exec -i "$E2E_NODE_NAME" "$@"
+}
+
+crictl() {
+    node crictl "$@"
+}
+
+cleanup() {
+    if [ "$E2E_KEEP_NODE" = 1 ]; then
+        info "keeping node container $E2E_NODE_NAME"
+        return
+    fi
+    docker rm -f "$E2E_NODE_NAME" >/dev/null 2>&1 || :
+}
+
+build_plugins() {
+    local p
+    for p in $PLUGINS; do
+        make -C "$TOP_DIR" "$BIN_DIR/${p#*-}"
+    done
+}
+
+start_node() {
+    info "starting node container $E2E_NODE_NAME ($E2E_NODE_IMAGE)..."
+    docker rm -f "$E2E_NODE_NAME" >/dev/null 2>&1 || :
+    docker run -d --privileged --name "$E2E_NODE_NAME" \
+        --tmpfs /tmp --tmpfs /run \
+        --volume /var --volume /lib/modules:/lib/modules:ro \
+        "$E2E_NODE_IMAGE" >/dev/null
+    wait_for "systemd" node systemctl is-system-running --wait
+}
+
+enable_nri() {
+    local p
+    info "installing plugins and enabling NRI..."
+    node mkdir -p /opt/nri/plugins /etc/nri/conf.d
+    for p in $PLUGINS; do
+        docker cp "$BIN_DIR/${p#*-}" "$E2E_NODE_NAME:/opt/nri/plugins/$p"
+    done
+    node sh -c 'cat >> /etc/containerd/config.toml' <<EOT
+
+[plugins."io.containerd.nri.v1.nri"]
+  disable = false
+  plugin_path = "/opt/nri/plugins"
+  plugin_config_path = "/etc/nri/conf.d"
+  socket_path = "/var/run/nri/nri.sock"
+EOT
+    node systemctl restart containerd
+    wait_for "containerd" crictl info
+    wait_for "NRI socket" node test -S /var/run/nri/nri.sock
+    info "pulling test image $E2E_TEST_IMAGE..."
+    crictl pull "$E2E_TEST_IMAGE" >/dev/null
+}
+
+wait_for() {
+    local what=$1 i
+    shift
+    for i in $(seq 1 60); do
+        if "$@" >/dev/null 2>&1; then
+            return 0
+        fi
+        sleep 1
+    done
+    fail "timeout waiting for $what"
+}
+
+# create_container <name> <annotations-json>
+#   Create a pod and a container in it with the given annotations set on
+#   both. Prints the ID of the created container.
+create_container() {
+    local name=$1 annotations=$2 pod
+    node sh -c "cat > /tmp/$name-pod.json" <<EOT
+{
+  "metadata": { "name": "$name", "namespace": "default", "uid": "$name-uid" },
+  "annotations": $annotations,
+  "log_directory": "/tmp",
+  "linux": {}
+}
+EOT
+    node sh -c "cat > /tmp/$name-ctr.json" <<EOT
+{
+  "metadata": { "name": "$name" },
+  "image": { "image": "$E2E_TEST_IMAGE" },
+  "command": [ "sleep", "3600" ],
+  "annotations": $annotations,
+  "log_path": "$name.log",
+  "linux": {}
+}
+EOT
+    pod=$(crictl runp "/tmp/$name-pod.json")
+    crictl create "$pod" "/tmp/$name-ctr.json" "/tmp/$name-pod.json"
+}
+
+# remove_container <name>
+#   Remove the pod, and the container in it, created for a scenario.
+remove_container() {
+    local name=$1 pod
+    pod=$(crictl pods -q --name "^$name\$")
+    if [ -n "$pod" ]; then
+        crictl stopp "$pod" >/dev/null
+        crictl rmp "$pod" >/dev/null
+    fi
+}
+
+# check_spec <container-id> <jq-filter> <description>
+#   Check that the OCI Spec of the container satisfies a jq filter.
+check_spec() {
+    local id=$1 filter=$2 what=$3
+    if ! crictl inspect -o json "$id" | jq -e ".info.runtimeSpec | $filter" >/dev/null; then
+        crictl inspect -o json "$id" | jq '.info.runtimeSpec' 1>&2 || :
+        fail "$what"
+    fi
+    info "  ok: $what"
+}
+
+scenario_device_injection() {
+    local id
+    id=$(create_container device-injection '{
+    "devices.nri.io": "- path: /dev/nri-null\n  type: c\n  major: 1\n  minor: 3\n"
+  }')
+    check_spec "$id" '.linux.devices | any(.path == "/dev/nri-null" and .major == 1 and .minor == 3)' \
+        "device /dev/nri-null injected"
+    check_spec "$id" '.linux.resources.devices | any(.allow and .type == "c" and .major == 1 and .minor == 3)' \
+        "device /dev/nri-null allowed in device cgroup"
+    remove_container device-injection
+}
+
+scenario_mount_injection() {
+    local id
+    node mkdir -p /tmp/nri-e2e-mount
+    id=$(create_container mount-injection '{
+    "mounts.nri.io": "- source: /tmp/nri-e2e-mount\n  destination: /mnt/nri\n  type: bind\n  options:\n  - bind\n  - ro\n"
+  }')
+    check_spec "$id" '.mounts | any(.destination == "/mnt/nri" and .source == "/tmp/nri-e2e-mount")' \
+        "mount /mnt/nri injected"
+    remove_container mount-injection
+}
+
+scenario_ulimit_adjustment() {
+    local id
+    id=$(create_container ulimit-adjustment '{
+    "ulimits.nri.containerd.io/container.ulimit-adjustment": "- type: nofile\n  hard: 4096\n  soft: 1024\n"
+  }')
+    check_spec "$id" '.process.rlimits | any(.type == "RLIMIT_NOFILE" and .hard == 4096 and .soft == 1024)' \
+        "RLIMIT_NOFILE adjusted"
+    remove_container ulimit-adjustment
+}
+
+for cmd in docker jq make; do
+    command -v "$cmd" >/dev/null || fail "required command $cmd not found"
+done
+
+trap cleanup EXIT
+
+build_plugins
+start_node
+enable_nri
+
+for s in $E2E_SCENARIOS; do
+    info "running scenario $s..."
+    "scenario_${s//-/_}"
+done
+
+info "all scenarios passed"